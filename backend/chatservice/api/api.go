@@ -2,13 +2,17 @@ package api
 
 import (
 	"context"
+	"errors"
 	"log"
 	"log/slog"
 	"net/http"
+	"time"
 
+	"sortedstartup/chatservice/auth"
 	db "sortedstartup/chatservice/dao"
 	pb "sortedstartup/chatservice/proto"
 	"sortedstartup/chatservice/queue"
+	"sortedstartup/chatservice/secrets"
 	"sortedstartup/chatservice/service"
 	settings "sortedstartup/chatservice/settings"
 
@@ -22,8 +26,8 @@ type SettingServiceAPI struct {
 	service *service.SettingService
 }
 
-func NewSettingService(queue queue.Queue, daoFactory db.DAOFactory) *SettingServiceAPI {
-	settingService := service.NewSettingService(queue, daoFactory)
+func NewSettingService(queue queue.Queue, daoFactory db.DAOFactory, keyring *secrets.Keyring) *SettingServiceAPI {
+	settingService := service.NewSettingService(queue, daoFactory, keyring)
 	return &SettingServiceAPI{service: settingService}
 }
 
@@ -31,6 +35,11 @@ func (s *SettingServiceAPI) Init() {
 	s.service.Init()
 }
 
+// RotateKeys re-encrypts the stored secrets with the current master key
+func (s *SettingServiceAPI) RotateKeys() error {
+	return s.service.RotateKeys()
+}
+
 func (s *SettingServiceAPI) GetSetting(ctx context.Context, req *pb.GetSettingRequest) (*pb.GetSettingResponse, error) {
 	settings, err := s.service.GetSetting(ctx)
 	if err != nil {
@@ -55,12 +64,11 @@ func (s *SettingServiceAPI) SetSetting(ctx context.Context, req *pb.SetSettingRe
 
 type ChatServiceAPI struct {
 	pb.UnimplementedSortedChatServer
-	service *service.ChatService
+	service       *service.ChatService
+	authenticator *auth.Authenticator
 }
 
-const HARDCODED_USER_ID = "0"
-
-func NewChatService(mux *http.ServeMux, queue queue.Queue, settingsManager *settings.SettingsManager, daoFactory db.DAOFactory) *ChatServiceAPI {
+func NewChatService(mux *http.ServeMux, queue queue.Queue, settingsManager *settings.SettingsManager, daoFactory db.DAOFactory, authenticator *auth.Authenticator) *ChatServiceAPI {
 	settingsManager.LoadSettingsFromDB()
 
 	chatService, err := service.NewChatService(queue, settingsManager, daoFactory)
@@ -69,57 +77,157 @@ func NewChatService(mux *http.ServeMux, queue queue.Queue, settingsManager *sett
 	}
 
 	s := &ChatServiceAPI{
-		service: chatService,
+		service:       chatService,
+		authenticator: authenticator,
 	}
 
 	s.registerRoutes(mux)
 	chatService.EmbeddingSubscriber()
+	chatService.SummarizationSubscriber()
 
 	return s
 }
 
 func (s *ChatServiceAPI) Chat(req *pb.ChatRequest, stream grpc.ServerStreamingServer[pb.ChatResponse]) error {
-	return s.service.Chat(stream.Context(), HARDCODED_USER_ID, req, func(response *pb.ChatResponse) error {
+	userID, err := currentUserID(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	err = s.service.Chat(stream.Context(), userID, req, func(response *pb.ChatResponse) error {
+		return stream.Send(response)
+	})
+	return chatError(err)
+}
+
+// chatError maps the errors of the RPCs that generate replies or work on branches to gRPC status codes
+func chatError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrBudgetExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, service.ErrGenerationInProgress), errors.Is(err, service.ErrSummaryInProgress), errors.Is(err, service.ErrNothingToSummarize),
+		errors.Is(err, service.ErrContextTooLarge):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrInvalidAlternative), errors.Is(err, service.ErrInvalidBranch), errors.Is(err, service.ErrInvalidPage),
+		errors.Is(err, service.ErrInvalidGenerationOptions):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrChatNotFound), errors.Is(err, service.ErrMessageNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return workspaceError(err)
+}
+
+func (s *ChatServiceAPI) RegenerateMessage(req *pb.RegenerateMessageRequest, stream grpc.ServerStreamingServer[pb.ChatResponse]) error {
+	userID, err := currentUserID(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	err = s.service.RegenerateMessage(stream.Context(), userID, req.GetChatId(), req.GetMessageId(), req.GetModel(), req.GetOptions(), func(response *pb.ChatResponse) error {
+		return stream.Send(response)
+	})
+	return chatError(err)
+}
+
+func (s *ChatServiceAPI) EditMessage(req *pb.EditMessageRequest, stream grpc.ServerStreamingServer[pb.ChatResponse]) error {
+	userID, err := currentUserID(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	err = s.service.EditMessage(stream.Context(), userID, req.GetChatId(), req.GetMessageId(), req.GetNewText(), req.GetModel(), req.GetOptions(), func(response *pb.ChatResponse) error {
 		return stream.Send(response)
 	})
+	return chatError(err)
+}
+
+func (s *ChatServiceAPI) ResumeChat(req *pb.ResumeChatRequest, stream grpc.ServerStreamingServer[pb.ChatResponse]) error {
+	userID, err := currentUserID(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	err = s.service.ResumeChat(stream.Context(), userID, req.GetChatId(), int(req.GetFromOffset()), func(response *pb.ChatResponse) error {
+		return stream.Send(response)
+	})
+	switch {
+	case errors.Is(err, service.ErrNoGeneration):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidResumeOffset):
+		return status.Error(codes.OutOfRange, err.Error())
+	}
+	return err
+}
+
+func (s *ChatServiceAPI) CancelChat(ctx context.Context, req *pb.CancelChatRequest) (*pb.CancelChatResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.service.CancelChat(ctx, userID, req.GetChatId())
+	if errors.Is(err, service.ErrNoGeneration) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &pb.CancelChatResponse{Message: "Generation cancelled"}, nil
 }
 
 func (s *ChatServiceAPI) GenerateChatName(ctx context.Context, req *pb.GenerateChatNameRequest) (*pb.GenerateChatNameResponse, error) {
-	chatName, err := s.service.GenerateChatName(ctx, HARDCODED_USER_ID, req.GetChatId(), req.GetMessage(), req.GetModel())
+	userID, err := currentUserID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	chatName, err := s.service.GenerateChatName(ctx, userID, req.GetChatId(), req.GetMessage(), req.GetModel())
+	if err != nil {
+		return nil, chatError(err)
+	}
+
 	return &pb.GenerateChatNameResponse{
 		ChatName: chatName,
 	}, nil
 }
 
 func (s *ChatServiceAPI) GetHistory(ctx context.Context, req *pb.GetHistoryRequest) (*pb.GetHistoryResponse, error) {
-	history, err := s.service.GetHistory(ctx, HARDCODED_USER_ID, req.ChatId)
+	userID, err := currentUserID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.GetHistoryResponse{
-		History: history,
-	}, nil
+	resp, err := s.service.GetHistory(ctx, userID, req)
+	if err != nil {
+		return nil, chatError(err)
+	}
+	return resp, nil
 }
 
 func (s *ChatServiceAPI) GetChatList(ctx context.Context, req *pb.GetChatListRequest) (*pb.GetChatListResponse, error) {
-	chats, err := s.service.GetChatList(ctx, HARDCODED_USER_ID, req.GetProjectId())
+	userID, err := currentUserID(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return &pb.GetChatListResponse{Chats: chats}, nil
+
+	chats, next, err := s.service.GetChatList(ctx, userID, req)
+	if err != nil {
+		return nil, chatError(err)
+	}
+	return &pb.GetChatListResponse{Chats: toPBChatList(chats), NextPageToken: next}, nil
 }
 
 func (s *ChatServiceAPI) CreateChat(ctx context.Context, req *pb.CreateChatRequest) (*pb.CreateChatResponse, error) {
-	chatId, err := s.service.CreateChat(ctx, HARDCODED_USER_ID, req.Name, req.GetProjectId())
+	userID, err := currentUserID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	chatId, err := s.service.CreateChat(ctx, userID, req.Name, req.GetProjectId())
+	if err != nil {
+		return nil, workspaceError(err)
+	}
+
 	return &pb.CreateChatResponse{
 		Message: "Chat created successfully",
 		ChatId:  chatId,
@@ -127,7 +235,7 @@ func (s *ChatServiceAPI) CreateChat(ctx context.Context, req *pb.CreateChatReque
 }
 
 func (s *ChatServiceAPI) ListModel(ctx context.Context, req *pb.ListModelsRequest) (*pb.ListModelsResponse, error) {
-	models, err := s.service.ListModel(ctx)
+	models, err := s.service.ListModel(ctx, req.GetIncludeDisabled())
 	if err != nil {
 		return nil, err
 	}
@@ -135,8 +243,85 @@ func (s *ChatServiceAPI) ListModel(ctx context.Context, req *pb.ListModelsReques
 	return &pb.ListModelsResponse{Models: models}, nil
 }
 
+// modelError maps the model catalog errors of the service to gRPC status codes
+func modelError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidModel):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrModelNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrModelExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	default:
+		return err
+	}
+}
+
+func (s *ChatServiceAPI) CreateModel(ctx context.Context, req *pb.CreateModelRequest) (*pb.CreateModelResponse, error) {
+	model, err := s.service.CreateModel(ctx, req.GetModel())
+	if err != nil {
+		return nil, modelError(err)
+	}
+
+	return &pb.CreateModelResponse{Model: model}, nil
+}
+
+func (s *ChatServiceAPI) UpdateModel(ctx context.Context, req *pb.UpdateModelRequest) (*pb.UpdateModelResponse, error) {
+	model, err := s.service.UpdateModel(ctx, req.GetModel())
+	if err != nil {
+		return nil, modelError(err)
+	}
+
+	return &pb.UpdateModelResponse{Model: model}, nil
+}
+
+func (s *ChatServiceAPI) DeleteModel(ctx context.Context, req *pb.DeleteModelRequest) (*pb.DeleteModelResponse, error) {
+	err := s.service.DeleteModel(ctx, req.GetId())
+	if err != nil {
+		return nil, modelError(err)
+	}
+
+	return &pb.DeleteModelResponse{
+		Message: "Model deleted successfully",
+	}, nil
+}
+
+func (s *ChatServiceAPI) SyncModelsFromProvider(ctx context.Context, req *pb.SyncModelsFromProviderRequest) (*pb.SyncModelsFromProviderResponse, error) {
+	if req.GetProvider() == "" {
+		return nil, status.Error(codes.InvalidArgument, "provider is required")
+	}
+
+	added, total, err := s.service.SyncModelsFromProvider(ctx, req.GetProvider())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SyncModelsFromProviderResponse{
+		Added: added,
+		Total: int32(total),
+	}, nil
+}
+
+func (s *ChatServiceAPI) GetUsage(ctx context.Context, req *pb.GetUsageRequest) (*pb.GetUsageResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := s.service.GetUsage(ctx, userID, req)
+	if errors.Is(err, service.ErrInvalidUsageRequest) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return usage, err
+}
+
 func (s *ChatServiceAPI) SearchChat(ctx context.Context, req *pb.ChatSearchRequest) (*pb.ChatSearchResponse, error) {
-	results, err := s.service.SearchChat(ctx, HARDCODED_USER_ID, req.Query)
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.service.SearchChat(ctx, userID, req.Query)
 	if err != nil {
 		return nil, err
 	}
@@ -148,11 +333,16 @@ func (s *ChatServiceAPI) SearchChat(ctx context.Context, req *pb.ChatSearchReque
 }
 
 func (s *ChatServiceAPI) CreateProject(ctx context.Context, req *pb.CreateProjectRequest) (*pb.CreateProjectResponse, error) {
-	projectID, err := s.service.CreateProject(ctx, HARDCODED_USER_ID, req.Name, req.Description, req.AdditionalData)
+	userID, err := currentUserID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	projectID, err := s.service.CreateProject(ctx, userID, req.Name, req.Description, req.AdditionalData, req.GetSystemPrompt(), req.GetWorkspaceId())
+	if err != nil {
+		return nil, workspaceError(err)
+	}
+
 	return &pb.CreateProjectResponse{
 		Message:   "Project created successfully",
 		ProjectId: projectID,
@@ -160,7 +350,12 @@ func (s *ChatServiceAPI) CreateProject(ctx context.Context, req *pb.CreateProjec
 }
 
 func (s *ChatServiceAPI) GetProjects(ctx context.Context, req *pb.GetProjectsRequest) (*pb.GetProjectsResponse, error) {
-	projects, err := s.service.GetProjects(ctx, HARDCODED_USER_ID)
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := s.service.GetProjects(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -168,12 +363,15 @@ func (s *ChatServiceAPI) GetProjects(ctx context.Context, req *pb.GetProjectsReq
 	var pbProjects []*pb.Project
 	for _, p := range projects {
 		pbProjects = append(pbProjects, &pb.Project{
-			Id:             p.ID,
-			Name:           p.Name,
-			Description:    p.Description,
-			AdditionalData: p.AdditionalData,
-			CreatedAt:      p.CreatedAt,
-			UpdatedAt:      p.UpdatedAt,
+			Id:                p.ID,
+			Name:              p.Name,
+			Description:       p.Description,
+			AdditionalData:    p.AdditionalData,
+			SystemPrompt:      p.SystemPrompt,
+			GenerationOptions: service.StoredGenerationOptions(p.GenerationOptions),
+			CreatedAt:         p.CreatedAt,
+			UpdatedAt:         p.UpdatedAt,
+			WorkspaceId:       p.WorkspaceID,
 		})
 	}
 
@@ -181,7 +379,12 @@ func (s *ChatServiceAPI) GetProjects(ctx context.Context, req *pb.GetProjectsReq
 }
 
 func (s *ChatServiceAPI) ListDocuments(ctx context.Context, req *pb.ListDocumentsRequest) (*pb.ListDocumentsResponse, error) {
-	docs, err := s.service.ListDocuments(ctx, HARDCODED_USER_ID, req.GetProjectId())
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := s.service.ListDocuments(ctx, userID, req.GetProjectId())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to fetch documents: %v", err)
 	}
@@ -204,19 +407,50 @@ func (s *ChatServiceAPI) ListDocuments(ctx context.Context, req *pb.ListDocument
 	}, nil
 }
 
+// GetDocumentLink signs a download link for the document, the Authorization header is the only other way in
+func (s *ChatServiceAPI) GetDocumentLink(ctx context.Context, req *pb.GetDocumentLinkRequest) (*pb.GetDocumentLinkResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := s.service.GetDocument(ctx, userID, req.GetDocsId())
+	if errors.Is(err, service.ErrDocumentNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, err
+	}
+
+	link, expiresAt, err := s.authenticator.SignLink(userID, "/documents/"+doc.DocsID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sign the link: %v", err)
+	}
+	return &pb.GetDocumentLinkResponse{Url: link, ExpiresAt: expiresAt.UTC().Format(time.RFC3339)}, nil
+}
+
 func (s *ChatServiceAPI) SubmitGenerateEmbeddingsJob(ctx context.Context, req *pb.GenerateEmbeddingRequest) (*pb.GenerateEmbeddingResponse, error) {
-	err := s.service.SubmitGenerateEmbeddingsJob(ctx, HARDCODED_USER_ID, req.GetProjectId())
+	userID, err := currentUserID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	err = s.service.SubmitGenerateEmbeddingsJob(ctx, userID, req.GetProjectId())
+	if err != nil {
+		return nil, workspaceError(err)
+	}
+
 	return &pb.GenerateEmbeddingResponse{
 		Message: "Embedding job submitted successfully",
 	}, nil
 }
 
 func (s *ChatServiceAPI) BranchAChat(ctx context.Context, req *pb.BranchAChatRequest) (*pb.BranchAChatResponse, error) {
-	newChatId, err := s.service.BranchAChat(ctx, HARDCODED_USER_ID, req.SourceChatId, req.BranchFromMessageId, req.BranchName)
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newChatId, err := s.service.BranchAChat(ctx, userID, req.SourceChatId, req.BranchFromMessageId, req.BranchName)
 	if err != nil {
 		return &pb.BranchAChatResponse{
 			Message: err.Error(),
@@ -230,24 +464,63 @@ func (s *ChatServiceAPI) BranchAChat(ctx context.Context, req *pb.BranchAChatReq
 }
 
 func (s *ChatServiceAPI) ListChatBranch(ctx context.Context, req *pb.ListChatBranchRequest) (*pb.ListChatBranchResponse, error) {
-	branches, err := s.service.ListChatBranch(ctx, HARDCODED_USER_ID, req.GetChatId())
+	userID, err := currentUserID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var pbChats []*pb.ChatInfo
-	for i := range branches {
-		pbChats = append(pbChats, &pb.ChatInfo{
-			ChatId: branches[i].Id,
-			Name:   branches[i].Name,
-		})
+	branches, err := s.service.ListChatBranch(ctx, userID, req.GetChatId())
+	if err != nil {
+		return nil, err
 	}
 
 	return &pb.ListChatBranchResponse{
-		BranchChatList: pbChats,
+		BranchChatList: toPBChatInfos(branches),
 	}, nil
 }
 
+func (s *ChatServiceAPI) GetBranchTree(ctx context.Context, req *pb.GetBranchTreeRequest) (*pb.GetBranchTreeResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := s.service.GetBranchTree(ctx, userID, req.GetChatId())
+	if err != nil {
+		return nil, chatError(err)
+	}
+
+	return &pb.GetBranchTreeResponse{Root: root}, nil
+}
+
+func (s *ChatServiceAPI) DiffBranches(ctx context.Context, req *pb.DiffBranchesRequest) (*pb.DiffBranchesResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := s.service.DiffBranches(ctx, userID, req.GetChatA(), req.GetChatB())
+	if err != nil {
+		return nil, chatError(err)
+	}
+
+	return diff, nil
+}
+
+func (s *ChatServiceAPI) SummarizeBranch(ctx context.Context, req *pb.SummarizeBranchRequest) (*pb.SummarizeBranchResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.service.SummarizeBranch(ctx, userID, req.GetChatId(), req.GetModel())
+	if err != nil {
+		return nil, chatError(err)
+	}
+
+	return summary, nil
+}
+
 func (s *ChatServiceAPI) Init(config *db.Config) {
 	switch config.Database.Type {
 	case db.DatabaseTypeSQLite:
@@ -0,0 +1,217 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"sortedstartup/chatservice/auth"
+	db "sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+	"sortedstartup/chatservice/service"
+
+	"google.golang.org/grpc/codes"
+	grpc_reflection_v1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	grpc_reflection_v1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+)
+
+// currentUserID returns the user put into the context by the auth interceptors or middleware
+func currentUserID(ctx context.Context) (string, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "not authenticated")
+	}
+	return userID, nil
+}
+
+type AuthServiceAPI struct {
+	pb.UnimplementedAuthServiceServer
+	service *service.AuthService
+}
+
+// NewAuthService also lets the login and registration RPCs through the authenticator without a token
+func NewAuthService(daoFactory db.DAOFactory, authenticator *auth.Authenticator) *AuthServiceAPI {
+	authService, err := service.NewAuthService(daoFactory, authenticator)
+	if err != nil {
+		log.Fatalf("Failed to initialize AuthService: %v", err)
+	}
+
+	authenticator.AllowUnauthenticated(pb.AuthService_Register_FullMethodName, pb.AuthService_Login_FullMethodName)
+	authenticator.SetAPITokenVerifier(authService)
+	authenticator.SetAdminChecker(authService)
+	for scope, methods := range methodScopes {
+		authenticator.RequireScope(scope, methods...)
+	}
+	authenticator.RequireAdmin(adminMethods...)
+
+	return &AuthServiceAPI{service: authService}
+}
+
+// methodScopes is the API token scope each method needs, everything else (settings, the model
+// catalog, API token management) needs auth.ScopeAdmin. The scope only limits the token, adminMethods
+// also need the user to be an instance admin.
+var methodScopes = map[auth.Scope][]string{
+	auth.ScopeReadOnly: {
+		pb.SortedChat_GetHistory_FullMethodName,
+		pb.SortedChat_ResumeChat_FullMethodName,
+		pb.SortedChat_GetChatList_FullMethodName,
+		pb.SortedChat_ListModel_FullMethodName,
+		pb.SortedChat_GetUsage_FullMethodName,
+		pb.SortedChat_SearchChat_FullMethodName,
+		pb.SortedChat_GetProjects_FullMethodName,
+		pb.SortedChat_ListDocuments_FullMethodName,
+		pb.SortedChat_GetDocumentLink_FullMethodName,
+		pb.SortedChat_ListChatBranch_FullMethodName,
+		pb.SortedChat_GetBranchTree_FullMethodName,
+		pb.SortedChat_DiffBranches_FullMethodName,
+		pb.SortedChat_GetChatSummary_FullMethodName,
+		pb.SortedChat_ListPromptTemplates_FullMethodName,
+		pb.SortedChat_ListTrash_FullMethodName,
+		pb.SortedChat_ListWorkspaces_FullMethodName,
+		pb.SortedChat_ListWorkspaceMembers_FullMethodName,
+		pb.AuthService_GetCurrentUser_FullMethodName,
+		grpc_reflection_v1.ServerReflection_ServerReflectionInfo_FullMethodName,
+		grpc_reflection_v1alpha.ServerReflection_ServerReflectionInfo_FullMethodName,
+	},
+	auth.ScopeChat: {
+		pb.SortedChat_Chat_FullMethodName,
+		pb.SortedChat_CancelChat_FullMethodName,
+		pb.SortedChat_RegenerateMessage_FullMethodName,
+		pb.SortedChat_EditMessage_FullMethodName,
+		pb.SortedChat_SummarizeBranch_FullMethodName,
+		pb.SortedChat_RenameChat_FullMethodName,
+		pb.SortedChat_DeleteChat_FullMethodName,
+		pb.SortedChat_RestoreChat_FullMethodName,
+		pb.SortedChat_ArchiveChat_FullMethodName,
+		pb.SortedChat_PinChat_FullMethodName,
+		pb.SortedChat_MoveChat_FullMethodName,
+		pb.SortedChat_RegenerateChatSummary_FullMethodName,
+		pb.SortedChat_SetSystemPrompt_FullMethodName,
+		pb.SortedChat_CreatePromptTemplate_FullMethodName,
+		pb.SortedChat_SetGenerationOptions_FullMethodName,
+		pb.SortedChat_GenerateChatName_FullMethodName,
+		pb.SortedChat_CreateChat_FullMethodName,
+		pb.SortedChat_CreateProject_FullMethodName,
+		pb.SortedChat_SubmitGenerateEmbeddingsJob_FullMethodName,
+		pb.SortedChat_BranchAChat_FullMethodName,
+		pb.SortedChat_CreateWorkspace_FullMethodName,
+	},
+}
+
+// adminMethods manage the whole instance, only instance admins can call them
+var adminMethods = []string{
+	pb.SettingService_GetSetting_FullMethodName,
+	pb.SettingService_SetSetting_FullMethodName,
+	pb.SortedChat_CreateModel_FullMethodName,
+	pb.SortedChat_UpdateModel_FullMethodName,
+	pb.SortedChat_DeleteModel_FullMethodName,
+	pb.SortedChat_SyncModelsFromProvider_FullMethodName,
+}
+
+// authError maps the auth errors of the service to gRPC status codes
+func authError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidUser):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrUserExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, service.ErrSignupDisabled):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrInvalidCredentials):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, service.ErrInvalidApiTokenInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrAdminTokenDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrApiTokenNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return err
+	}
+}
+
+func (s *AuthServiceAPI) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	user, err := s.service.Register(ctx, req.GetUsername(), req.GetPassword())
+	if err != nil {
+		return nil, authError(err)
+	}
+
+	return &pb.RegisterResponse{User: user}, nil
+}
+
+func (s *AuthServiceAPI) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	token, expiresAt, user, err := s.service.Login(ctx, req.GetUsername(), req.GetPassword())
+	if err != nil {
+		return nil, authError(err)
+	}
+
+	return &pb.LoginResponse{
+		Token:     token,
+		ExpiresAt: expiresAt.Unix(),
+		User:      user,
+	}, nil
+}
+
+func (s *AuthServiceAPI) GetCurrentUser(ctx context.Context, req *pb.GetCurrentUserRequest) (*pb.GetCurrentUserResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.service.GetCurrentUser(ctx, userID)
+	if err != nil {
+		return nil, authError(err)
+	}
+
+	return &pb.GetCurrentUserResponse{
+		User:        user,
+		AuthEnabled: s.service.AuthEnabled(),
+	}, nil
+}
+
+func (s *AuthServiceAPI) CreateApiToken(ctx context.Context, req *pb.CreateApiTokenRequest) (*pb.CreateApiTokenResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, apiToken, err := s.service.CreateApiToken(ctx, userID, req)
+	if err != nil {
+		return nil, authError(err)
+	}
+
+	return &pb.CreateApiTokenResponse{
+		Token:    token,
+		ApiToken: apiToken,
+	}, nil
+}
+
+func (s *AuthServiceAPI) ListApiTokens(ctx context.Context, req *pb.ListApiTokensRequest) (*pb.ListApiTokensResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.service.ListApiTokens(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListApiTokensResponse{ApiTokens: tokens}, nil
+}
+
+func (s *AuthServiceAPI) RevokeApiToken(ctx context.Context, req *pb.RevokeApiTokenRequest) (*pb.RevokeApiTokenResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.RevokeApiToken(ctx, userID, req.GetId()); err != nil {
+		return nil, authError(err)
+	}
+
+	return &pb.RevokeApiTokenResponse{
+		Message: "API token revoked",
+	}, nil
+}
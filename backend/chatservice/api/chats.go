@@ -0,0 +1,204 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+	"sortedstartup/chatservice/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// lifecycleError maps the errors of the chat lifecycle RPCs to gRPC status codes
+func lifecycleError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidChatUpdate):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrChatNotInTrash), errors.Is(err, service.ErrParentChatInTrash):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return chatError(err)
+}
+
+func formatNullTime(t sql.NullTime) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.UTC().Format(time.RFC3339)
+}
+
+func toPBChatInfos(chats []dao.ChatInfoRow) []*pb.ChatInfo {
+	var pbChats []*pb.ChatInfo
+	for i := range chats {
+		pbChats = append(pbChats, &pb.ChatInfo{
+			ChatId:            chats[i].Id,
+			Name:              chats[i].Name,
+			ParentChatId:      chats[i].ParentChatID,
+			IsMainBranch:      chats[i].IsMainBranch,
+			Pinned:            chats[i].Pinned,
+			Archived:          chats[i].Archived,
+			CreatedAt:         formatNullTime(chats[i].CreatedAt),
+			UpdatedAt:         formatNullTime(chats[i].UpdatedAt),
+			LastMessageAt:     formatNullTime(chats[i].LastMessageAt),
+			SystemPrompt:      chats[i].SystemPrompt,
+			GenerationOptions: service.StoredGenerationOptions(chats[i].GenerationOptions),
+		})
+	}
+	return pbChats
+}
+
+func toPBChatList(chats []dao.ChatListRow) []*pb.ChatInfo {
+	infos := make([]dao.ChatInfoRow, 0, len(chats))
+	for i := range chats {
+		infos = append(infos, chats[i].ChatInfoRow)
+	}
+	return toPBChatInfos(infos)
+}
+
+func (s *ChatServiceAPI) RenameChat(ctx context.Context, req *pb.RenameChatRequest) (*pb.RenameChatResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.RenameChat(ctx, userID, req.GetChatId(), req.GetName()); err != nil {
+		return nil, lifecycleError(err)
+	}
+
+	return &pb.RenameChatResponse{Message: "Chat renamed"}, nil
+}
+
+func (s *ChatServiceAPI) DeleteChat(ctx context.Context, req *pb.DeleteChatRequest) (*pb.DeleteChatResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.DeleteChat(ctx, userID, req.GetChatId(), req.GetPermanent()); err != nil {
+		return nil, lifecycleError(err)
+	}
+
+	if req.GetPermanent() {
+		return &pb.DeleteChatResponse{Message: "Chat deleted"}, nil
+	}
+	return &pb.DeleteChatResponse{Message: "Chat moved to the trash"}, nil
+}
+
+func (s *ChatServiceAPI) RestoreChat(ctx context.Context, req *pb.RestoreChatRequest) (*pb.RestoreChatResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.RestoreChat(ctx, userID, req.GetChatId()); err != nil {
+		return nil, lifecycleError(err)
+	}
+
+	return &pb.RestoreChatResponse{Message: "Chat restored"}, nil
+}
+
+func (s *ChatServiceAPI) ListTrash(ctx context.Context, req *pb.ListTrashRequest) (*pb.ListTrashResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chats, err := s.service.ListTrash(ctx, userID, req.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListTrashResponse{Chats: toPBChatInfos(chats)}, nil
+}
+
+func (s *ChatServiceAPI) ArchiveChat(ctx context.Context, req *pb.ArchiveChatRequest) (*pb.ArchiveChatResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.ArchiveChat(ctx, userID, req.GetChatId(), req.GetArchived()); err != nil {
+		return nil, lifecycleError(err)
+	}
+
+	if req.GetArchived() {
+		return &pb.ArchiveChatResponse{Message: "Chat archived"}, nil
+	}
+	return &pb.ArchiveChatResponse{Message: "Chat unarchived"}, nil
+}
+
+func (s *ChatServiceAPI) PinChat(ctx context.Context, req *pb.PinChatRequest) (*pb.PinChatResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.PinChat(ctx, userID, req.GetChatId(), req.GetPinned()); err != nil {
+		return nil, lifecycleError(err)
+	}
+
+	if req.GetPinned() {
+		return &pb.PinChatResponse{Message: "Chat pinned"}, nil
+	}
+	return &pb.PinChatResponse{Message: "Chat unpinned"}, nil
+}
+
+func (s *ChatServiceAPI) MoveChat(ctx context.Context, req *pb.MoveChatRequest) (*pb.MoveChatResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.MoveChat(ctx, userID, req.GetChatId(), req.GetProjectId()); err != nil {
+		return nil, lifecycleError(err)
+	}
+
+	return &pb.MoveChatResponse{Message: "Chat moved"}, nil
+}
+
+func toPBChatSummary(summary *dao.ChatSummaryRow) *pb.ChatSummary {
+	if summary == nil {
+		return nil
+	}
+	return &pb.ChatSummary{
+		ChatId:        summary.ChatID,
+		Summary:       summary.Summary,
+		UpToMessageId: summary.UpToMessageID,
+		Model:         summary.Model,
+		Cost:          summary.Cost,
+		UpdatedAt:     summary.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func (s *ChatServiceAPI) GetChatSummary(ctx context.Context, req *pb.GetChatSummaryRequest) (*pb.GetChatSummaryResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.service.GetChatSummary(ctx, userID, req.GetChatId())
+	if err != nil {
+		return nil, chatError(err)
+	}
+
+	return &pb.GetChatSummaryResponse{Summary: toPBChatSummary(summary)}, nil
+}
+
+func (s *ChatServiceAPI) RegenerateChatSummary(ctx context.Context, req *pb.RegenerateChatSummaryRequest) (*pb.RegenerateChatSummaryResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.service.RegenerateChatSummary(ctx, userID, req.GetChatId(), req.GetModel())
+	if err != nil {
+		return nil, chatError(err)
+	}
+
+	return &pb.RegenerateChatSummaryResponse{Summary: toPBChatSummary(summary)}, nil
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"context"
+
+	pb "sortedstartup/chatservice/proto"
+)
+
+func (s *ChatServiceAPI) SetGenerationOptions(ctx context.Context, req *pb.SetGenerationOptionsRequest) (*pb.SetGenerationOptionsResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := s.service.SetGenerationOptions(ctx, userID, req)
+	if err != nil {
+		return nil, lifecycleError(err)
+	}
+
+	return &pb.SetGenerationOptionsResponse{Options: options}, nil
+}
@@ -1,11 +1,16 @@
 package api
 
 import (
+	"errors"
 	"fmt"
+	"mime"
 	"net/http"
-	"os"
 	"path/filepath"
 	"strings"
+	"time"
+
+	"sortedstartup/chatservice/auth"
+	"sortedstartup/chatservice/service"
 )
 
 const (
@@ -15,8 +20,8 @@ const (
 
 // registerRoutes binds HTTP routes to the Server
 func (s *ChatServiceAPI) registerRoutes(mux *http.ServeMux) {
-	mux.HandleFunc("/upload", s.handleUpload)
-	mux.HandleFunc("/documents/", s.handleDownload)
+	mux.Handle("/upload", s.authenticator.Middleware(auth.ScopeChat, http.HandlerFunc(s.handleUpload)))
+	mux.Handle("/documents/", s.authenticator.Middleware(auth.ScopeReadOnly, http.HandlerFunc(s.handleDownload)))
 }
 
 func (s *ChatServiceAPI) handleUpload(w http.ResponseWriter, r *http.Request) {
@@ -38,9 +43,20 @@ func (s *ChatServiceAPI) handleUpload(w http.ResponseWriter, r *http.Request) {
 	}
 	defer file.Close()
 
-	// Use service layer to handle file upload with hardcoded user ID
-	objectID, err := s.service.UploadFile(r.Context(), HARDCODED_USER_ID, projectID, file, header, MaxFileSize, MaxProjectUploadSize)
-	if err != nil {
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	objectID, err := s.service.UploadFile(r.Context(), userID, projectID, file, header, MaxFileSize, MaxProjectUploadSize)
+	if errors.Is(err, service.ErrProjectNotFound) {
+		http.Error(w, "Project not found", http.StatusNotFound)
+		return
+	} else if errors.Is(err, service.ErrPermissionDenied) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	} else if err != nil {
 		http.Error(w, "Failed to upload file: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -51,20 +67,49 @@ func (s *ChatServiceAPI) handleUpload(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *ChatServiceAPI) handleDownload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
 	docsId := strings.TrimPrefix(r.URL.Path, "/documents/")
 	if docsId == "" {
 		http.Error(w, "Missing document ID", http.StatusBadRequest)
 		return
 	}
 
-	// TODO: This should also validate user access to the document
-	// and use service layer for access control
+	userID, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
 
-	filePath := filepath.Join("filestore", "objects", docsId)
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		http.Error(w, "File not found on disk", http.StatusNotFound)
+	doc, content, err := s.service.OpenDocument(r.Context(), userID, docsId)
+	if errors.Is(err, service.ErrDocumentNotFound) {
+		http.Error(w, "Document not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, "Failed to open document", http.StatusInternalServerError)
 		return
 	}
+	defer content.Close()
 
-	http.ServeFile(w, r, filePath)
+	contentType, disposition := documentContentType(doc.FileName)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": doc.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	// ServeContent answers Range and If-Range requests
+	http.ServeContent(w, r, doc.FileName, time.Time{}, content)
+}
+
+// documentContentType picks the content type from the file name. Documents are previewed inline, except
+// types the browser would run as part of our origin (HTML, SVG, XML), those are always downloaded.
+func documentContentType(fileName string) (string, string) {
+	contentType := mime.TypeByExtension(filepath.Ext(fileName))
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "" || mediaType == "text/html" || strings.Contains(mediaType, "xml") || strings.Contains(mediaType, "javascript") {
+		return "application/octet-stream", "attachment"
+	}
+	return contentType, "inline"
 }
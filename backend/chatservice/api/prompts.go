@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	pb "sortedstartup/chatservice/proto"
+	"sortedstartup/chatservice/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// promptError maps the errors of the system prompt and prompt template RPCs to gRPC status codes
+func promptError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidSystemPrompt), errors.Is(err, service.ErrInvalidPromptTemplate):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrPromptTemplateNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return lifecycleError(err)
+}
+
+func (s *ChatServiceAPI) SetSystemPrompt(ctx context.Context, req *pb.SetSystemPromptRequest) (*pb.SetSystemPromptResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt, err := s.service.SetSystemPrompt(ctx, userID, req)
+	if err != nil {
+		return nil, promptError(err)
+	}
+
+	return &pb.SetSystemPromptResponse{SystemPrompt: prompt}, nil
+}
+
+func (s *ChatServiceAPI) CreatePromptTemplate(ctx context.Context, req *pb.CreatePromptTemplateRequest) (*pb.CreatePromptTemplateResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := s.service.CreatePromptTemplate(ctx, userID, req.GetProjectId(), req.GetName(), req.GetDescription(), req.GetContent())
+	if err != nil {
+		return nil, promptError(err)
+	}
+
+	return &pb.CreatePromptTemplateResponse{Template: template}, nil
+}
+
+func (s *ChatServiceAPI) ListPromptTemplates(ctx context.Context, req *pb.ListPromptTemplatesRequest) (*pb.ListPromptTemplatesResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := s.service.ListPromptTemplates(ctx, userID, req.GetProjectId())
+	if err != nil {
+		return nil, promptError(err)
+	}
+
+	return &pb.ListPromptTemplatesResponse{Templates: templates}, nil
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	pb "sortedstartup/chatservice/proto"
+	"sortedstartup/chatservice/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// workspaceError maps the workspace and project access errors of the service to gRPC status codes
+func workspaceError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidWorkspace):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrWorkspaceNotFound), errors.Is(err, service.ErrProjectNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrWorkspaceMemberExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, service.ErrLastWorkspaceOwner):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
+
+func (s *ChatServiceAPI) CreateWorkspace(ctx context.Context, req *pb.CreateWorkspaceRequest) (*pb.CreateWorkspaceResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.service.CreateWorkspace(ctx, userID, req.GetName())
+	if err != nil {
+		return nil, workspaceError(err)
+	}
+
+	return &pb.CreateWorkspaceResponse{Workspace: workspace}, nil
+}
+
+func (s *ChatServiceAPI) ListWorkspaces(ctx context.Context, req *pb.ListWorkspacesRequest) (*pb.ListWorkspacesResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces, err := s.service.ListWorkspaces(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListWorkspacesResponse{Workspaces: workspaces}, nil
+}
+
+func (s *ChatServiceAPI) ListWorkspaceMembers(ctx context.Context, req *pb.ListWorkspaceMembersRequest) (*pb.ListWorkspaceMembersResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.service.ListWorkspaceMembers(ctx, userID, req.GetWorkspaceId())
+	if err != nil {
+		return nil, workspaceError(err)
+	}
+
+	return &pb.ListWorkspaceMembersResponse{Members: members}, nil
+}
+
+func (s *ChatServiceAPI) AddWorkspaceMember(ctx context.Context, req *pb.AddWorkspaceMemberRequest) (*pb.AddWorkspaceMemberResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.service.AddWorkspaceMember(ctx, userID, req.GetWorkspaceId(), req.GetUsername(), req.GetRole())
+	if err != nil {
+		return nil, workspaceError(err)
+	}
+
+	return &pb.AddWorkspaceMemberResponse{Member: member}, nil
+}
+
+func (s *ChatServiceAPI) UpdateWorkspaceMember(ctx context.Context, req *pb.UpdateWorkspaceMemberRequest) (*pb.UpdateWorkspaceMemberResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.UpdateWorkspaceMember(ctx, userID, req.GetWorkspaceId(), req.GetUserId(), req.GetRole()); err != nil {
+		return nil, workspaceError(err)
+	}
+
+	return &pb.UpdateWorkspaceMemberResponse{Message: "Workspace member updated"}, nil
+}
+
+func (s *ChatServiceAPI) RemoveWorkspaceMember(ctx context.Context, req *pb.RemoveWorkspaceMemberRequest) (*pb.RemoveWorkspaceMemberResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.RemoveWorkspaceMember(ctx, userID, req.GetWorkspaceId(), req.GetUserId()); err != nil {
+		return nil, workspaceError(err)
+	}
+
+	return &pb.RemoveWorkspaceMemberResponse{Message: "Workspace member removed"}, nil
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Scope limits what an API token can call, login sessions have ScopeAdmin
+type Scope string
+
+const (
+	ScopeReadOnly Scope = "read-only"
+	ScopeChat     Scope = "chat"
+	ScopeAdmin    Scope = "admin"
+)
+
+var scopeRank = map[Scope]int{
+	ScopeReadOnly: 1,
+	ScopeChat:     2,
+	ScopeAdmin:    3,
+}
+
+// Allows reports whether a caller with this scope may call something that requires `required`
+func (s Scope) Allows(required Scope) bool {
+	rank, ok := scopeRank[s]
+	return ok && rank >= scopeRank[required]
+}
+
+func (s Scope) Valid() bool {
+	_, ok := scopeRank[s]
+	return ok
+}
+
+/*
+API tokens look like sct_<43 base64url characters> (32 random bytes). They carry enough entropy
+that a plain SHA-256 is enough to store them, unlike passwords they can't be guessed.
+*/
+
+const (
+	APITokenPrefix = "sct_"
+	apiTokenSize   = 32
+	// apiTokenDisplayLength characters of the token are stored in clear to tell tokens apart
+	apiTokenDisplayLength = len(APITokenPrefix) + 6
+)
+
+// APITokenVerifier resolves an API token to its user and scope
+type APITokenVerifier interface {
+	VerifyAPIToken(ctx context.Context, token string) (string, Scope, error)
+}
+
+// GenerateAPIToken returns a new token, its hash for storage and a short prefix for display
+func GenerateAPIToken() (token string, hash string, displayPrefix string, err error) {
+	raw := make([]byte, apiTokenSize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", "", fmt.Errorf("failed to generate API token: %w", err)
+	}
+	token = APITokenPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashAPIToken(token), token[:apiTokenDisplayLength], nil
+}
+
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, APITokenPrefix)
+}
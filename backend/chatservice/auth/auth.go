@@ -0,0 +1,348 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// EnabledEnv turns on authentication, without it every request runs as LocalUserID
+	EnabledEnv = "AUTH_ENABLED"
+	// TokenSecretEnv holds the HS256 signing secret (at least 32 bytes)
+	TokenSecretEnv = "AUTH_TOKEN_SECRET"
+	// TokenTTLEnv is a Go duration, e.g. 24h
+	TokenTTLEnv = "AUTH_TOKEN_TTL"
+	// AllowSignupEnv lets anyone register, otherwise only the first account can be registered
+	AllowSignupEnv = "AUTH_ALLOW_SIGNUP"
+
+	DefaultTokenTTL = 7 * 24 * time.Hour
+
+	// LinkTokenParam carries the token of a signed link (e.g. a document download) that can't set headers. Session
+	// and API tokens are only taken from the Authorization header, URLs end up in logs and browser history.
+	LinkTokenParam = "signature"
+	// LinkTokenTTL is how long a signed link can be opened
+	LinkTokenTTL = 5 * time.Minute
+)
+
+type Config struct {
+	Enabled     bool
+	TokenSecret []byte
+	TokenTTL    time.Duration
+	AllowSignup bool
+}
+
+// LoadConfig reads the auth configuration from the environment
+func LoadConfig() (Config, error) {
+	cfg := Config{
+		TokenSecret: []byte(os.Getenv(TokenSecretEnv)),
+		TokenTTL:    DefaultTokenTTL,
+	}
+
+	var err error
+	if cfg.Enabled, err = envBool(EnabledEnv); err != nil {
+		return Config{}, err
+	}
+	if cfg.AllowSignup, err = envBool(AllowSignupEnv); err != nil {
+		return Config{}, err
+	}
+	if ttl := os.Getenv(TokenTTLEnv); ttl != "" {
+		if cfg.TokenTTL, err = time.ParseDuration(ttl); err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %w", TokenTTLEnv, err)
+		}
+	}
+	return cfg, nil
+}
+
+func envBool(name string) (bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return b, nil
+}
+
+// Authenticator resolves the user of gRPC and HTTP requests and puts its id into the request context
+type Authenticator struct {
+	enabled       bool
+	allowSignup   bool
+	tokens        *TokenIssuer
+	apiTokens     APITokenVerifier
+	publicMethods map[string]bool
+	// methodScopes is the scope an API token needs for a method, methods not listed need ScopeAdmin
+	methodScopes map[string]Scope
+	admins       AdminChecker
+	// adminMethods can only be called by instance admins, whatever the scope of their token
+	adminMethods map[string]bool
+}
+
+// AdminChecker reports whether a user is an instance admin
+type AdminChecker interface {
+	IsAdmin(ctx context.Context, userID string) (bool, error)
+}
+
+func NewAuthenticator(cfg Config) (*Authenticator, error) {
+	secret := cfg.TokenSecret
+	if len(secret) == 0 {
+		secret = make([]byte, minSecretSize)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate token secret: %w", err)
+		}
+		if cfg.Enabled {
+			slog.Warn("No token secret configured, sessions will not survive a restart", "env", TokenSecretEnv)
+		}
+	}
+
+	tokens, err := NewTokenIssuer(secret, cfg.TokenTTL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", TokenSecretEnv, err)
+	}
+
+	return &Authenticator{
+		enabled:       cfg.Enabled,
+		allowSignup:   cfg.AllowSignup,
+		tokens:        tokens,
+		publicMethods: make(map[string]bool),
+		methodScopes:  make(map[string]Scope),
+		adminMethods:  make(map[string]bool),
+	}, nil
+}
+
+// NewLocalAuthenticator runs every request as LocalUserID, used by the single user desktop app
+func NewLocalAuthenticator() *Authenticator {
+	a, err := NewAuthenticator(Config{TokenTTL: DefaultTokenTTL})
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func (a *Authenticator) Enabled() bool {
+	return a.enabled
+}
+
+func (a *Authenticator) AllowSignup() bool {
+	return a.allowSignup
+}
+
+func (a *Authenticator) Tokens() *TokenIssuer {
+	return a.tokens
+}
+
+// AllowUnauthenticated lets the gRPC methods (e.g. "/sortedchat.AuthService/Login") through without a token
+func (a *Authenticator) AllowUnauthenticated(fullMethods ...string) {
+	for _, m := range fullMethods {
+		a.publicMethods[m] = true
+	}
+}
+
+// SetAPITokenVerifier enables personal access tokens (tokens starting with APITokenPrefix)
+func (a *Authenticator) SetAPITokenVerifier(v APITokenVerifier) {
+	a.apiTokens = v
+}
+
+// RequireScope sets the scope an API token needs to call the gRPC methods
+func (a *Authenticator) RequireScope(scope Scope, fullMethods ...string) {
+	for _, m := range fullMethods {
+		a.methodScopes[m] = scope
+	}
+}
+
+// SetAdminChecker looks up the role of the users calling the methods passed to RequireAdmin
+func (a *Authenticator) SetAdminChecker(c AdminChecker) {
+	a.admins = c
+}
+
+// RequireAdmin restricts the gRPC methods to instance admins, on top of the scope they require
+func (a *Authenticator) RequireAdmin(fullMethods ...string) {
+	for _, m := range fullMethods {
+		a.adminMethods[m] = true
+	}
+}
+
+// requireAdmin checks that the user of ctx is an instance admin, the local user always is
+func (a *Authenticator) requireAdmin(ctx context.Context, fullMethod string) error {
+	if !a.enabled {
+		return nil
+	}
+	isAdmin := false
+	if a.admins != nil {
+		userID, _ := UserIDFromContext(ctx)
+		var err error
+		if isAdmin, err = a.admins.IsAdmin(ctx, userID); err != nil {
+			return status.Errorf(codes.Internal, "failed to check the role of the user: %v", err)
+		}
+	}
+	if !isAdmin {
+		return status.Errorf(codes.PermissionDenied, "%s requires an instance admin", fullMethod)
+	}
+	return nil
+}
+
+func (a *Authenticator) requiredScope(fullMethod string) Scope {
+	if scope, ok := a.methodScopes[fullMethod]; ok {
+		return scope
+	}
+	return ScopeAdmin
+}
+
+// authenticate returns the context and scope for a request with the given bearer token
+func (a *Authenticator) authenticate(ctx context.Context, token string) (context.Context, Scope, error) {
+	if !a.enabled {
+		return WithUserID(ctx, LocalUserID), ScopeAdmin, nil
+	}
+	if token == "" {
+		return ctx, "", errors.New("missing bearer token")
+	}
+
+	if IsAPIToken(token) {
+		if a.apiTokens == nil {
+			return ctx, "", ErrInvalidToken
+		}
+		userID, scope, err := a.apiTokens.VerifyAPIToken(ctx, token)
+		if err != nil {
+			return ctx, "", err
+		}
+		return WithUserID(ctx, userID), scope, nil
+	}
+
+	userID, err := a.tokens.Verify(token)
+	if err != nil {
+		return ctx, "", err
+	}
+	return WithUserID(ctx, userID), ScopeAdmin, nil
+}
+
+func (a *Authenticator) authenticateRPC(ctx context.Context, fullMethod string) (context.Context, error) {
+	authCtx, scope, err := a.authenticate(ctx, tokenFromMetadata(ctx))
+	if a.publicMethods[fullMethod] {
+		if err != nil {
+			return ctx, nil
+		}
+		return authCtx, nil
+	}
+	if err != nil {
+		return ctx, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if required := a.requiredScope(fullMethod); !scope.Allows(required) {
+		return ctx, status.Errorf(codes.PermissionDenied, "token scope %s can't call %s, it requires %s", scope, fullMethod, required)
+	}
+	if a.adminMethods[fullMethod] {
+		if err := a.requireAdmin(authCtx, fullMethod); err != nil {
+			return ctx, err
+		}
+	}
+	return authCtx, nil
+}
+
+func (a *Authenticator) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authenticateRPC(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *Authenticator) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticateRPC(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// SignLink returns path with a link token for the user that opens it for LinkTokenTTL, read-only
+func (a *Authenticator) SignLink(userID string, path string) (string, time.Time, error) {
+	token, expiresAt, err := a.tokens.IssueLink(userID, path, LinkTokenTTL)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return path + "?" + LinkTokenParam + "=" + url.QueryEscape(token), expiresAt, nil
+}
+
+// authenticateLink returns the context and scope for a request with a link token, it only opens the path it was
+// issued for and only reads
+func (a *Authenticator) authenticateLink(ctx context.Context, token string, path string) (context.Context, Scope, error) {
+	if !a.enabled {
+		return WithUserID(ctx, LocalUserID), ScopeAdmin, nil
+	}
+	userID, err := a.tokens.VerifyLink(token, path)
+	if err != nil {
+		return ctx, "", err
+	}
+	return WithUserID(ctx, userID), ScopeReadOnly, nil
+}
+
+// Middleware authenticates plain HTTP routes such as /upload and /documents/, API tokens need the given scope.
+// Without an Authorization header a signed link from SignLink is accepted.
+func (a *Authenticator) Middleware(required Scope, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := bearerToken(r.Header.Get("Authorization"))
+
+		var ctx context.Context
+		var scope Scope
+		var err error
+		if link := r.URL.Query().Get(LinkTokenParam); token == "" && link != "" {
+			ctx, scope, err = a.authenticateLink(r.Context(), link, r.URL.Path)
+		} else {
+			ctx, scope, err = a.authenticate(r.Context(), token)
+		}
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if !scope.Allows(required) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func tokenFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, value := range md.Get("authorization") {
+		if token := bearerToken(value); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+func bearerToken(header string) string {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
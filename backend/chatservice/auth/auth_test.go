@@ -0,0 +1,256 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var testSecret = []byte(strings.Repeat("s", minSecretSize))
+
+func TestPassword(t *testing.T) {
+	hash, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatalf("HashPassword failed: %v", err)
+	}
+	if strings.Contains(hash, "correct horse") {
+		t.Fatalf("Expected the password not to be stored, got '%s'", hash)
+	}
+
+	if ok, err := CheckPassword(hash, "correct horse"); err != nil || !ok {
+		t.Errorf("Expected password to match, got %v (%v)", ok, err)
+	}
+	if ok, _ := CheckPassword(hash, "wrong horse"); ok {
+		t.Errorf("Expected wrong password not to match")
+	}
+
+	if _, err := HashPassword("short"); !errors.Is(err, ErrPasswordTooShort) {
+		t.Errorf("Expected ErrPasswordTooShort, got %v", err)
+	}
+}
+
+func TestToken(t *testing.T) {
+	issuer, err := NewTokenIssuer(testSecret, time.Hour)
+	if err != nil {
+		t.Fatalf("NewTokenIssuer failed: %v", err)
+	}
+
+	token, _, err := issuer.Issue("user-1")
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	if userID, err := issuer.Verify(token); err != nil || userID != "user-1" {
+		t.Errorf("Expected 'user-1', got '%s' (%v)", userID, err)
+	}
+
+	other, _ := NewTokenIssuer([]byte(strings.Repeat("o", minSecretSize)), time.Hour)
+	if _, err := other.Verify(token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected token signed with another secret to be rejected, got %v", err)
+	}
+
+	issuer.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if _, err := issuer.Verify(token); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("Expected ErrTokenExpired, got %v", err)
+	}
+
+	if _, err := NewTokenIssuer([]byte("short"), time.Hour); err == nil {
+		t.Errorf("Expected short secret to be rejected")
+	}
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	a, err := NewAuthenticator(Config{Enabled: true, TokenSecret: testSecret, TokenTTL: time.Hour})
+	if err != nil {
+		t.Fatalf("NewAuthenticator failed: %v", err)
+	}
+	a.AllowUnauthenticated("/test.Service/Public")
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		userID, _ := UserIDFromContext(ctx)
+		return userID, nil
+	}
+	call := func(ctx context.Context, method string) (any, error) {
+		return a.UnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	}
+
+	if _, err := call(context.Background(), "/test.Service/Private"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated without a token, got %v", err)
+	}
+	if userID, err := call(context.Background(), "/test.Service/Public"); err != nil || userID != "" {
+		t.Errorf("Expected public method without a user, got '%v' (%v)", userID, err)
+	}
+
+	token, _, _ := a.Tokens().Issue("user-1")
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	if userID, err := call(ctx, "/test.Service/Private"); err != nil || userID != "user-1" {
+		t.Errorf("Expected 'user-1', got '%v' (%v)", userID, err)
+	}
+
+	local := NewLocalAuthenticator()
+	userID, err := local.UnaryInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Private"}, handler)
+	if err != nil || userID != LocalUserID {
+		t.Errorf("Expected local user when auth is disabled, got '%v' (%v)", userID, err)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	a, _ := NewAuthenticator(Config{Enabled: true, TokenSecret: testSecret, TokenTTL: time.Hour})
+	handler := a.Middleware(ScopeReadOnly, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, _ := UserIDFromContext(r.Context())
+		w.Write([]byte(userID))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/documents/x", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without a token, got %d", rec.Code)
+	}
+
+	token, _, _ := a.Tokens().Issue("user-1")
+	req := httptest.NewRequest(http.MethodGet, "/documents/x", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Body.String() != "user-1" {
+		t.Errorf("Expected 200 for 'user-1', got %d '%s'", rec.Code, rec.Body.String())
+	}
+
+	// bearer tokens aren't taken from the URL, only links signed for the path they open
+	for _, target := range []string{"/documents/x?access_token=" + token, "/documents/x?" + LinkTokenParam + "=" + token} {
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("Expected 401 for a session token in %s, got %d", target, rec.Code)
+		}
+	}
+
+	link, expiresAt, err := a.SignLink("user-1", "/documents/x")
+	if err != nil || time.Until(expiresAt) > LinkTokenTTL {
+		t.Fatalf("SignLink failed: %v, expires at %v", err, expiresAt)
+	}
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, link, nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "user-1" {
+		t.Errorf("Expected the signed link to open /documents/x, got %d '%s'", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, strings.Replace(link, "/documents/x", "/documents/y", 1), nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected the signed link not to open another document, got %d", rec.Code)
+	}
+
+	// link tokens are no session tokens and only read
+	linkToken := strings.TrimPrefix(link, "/documents/x?"+LinkTokenParam+"=")
+	if _, err := a.Tokens().Verify(linkToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected a link token to be refused as a session token, got %v", err)
+	}
+	upload := a.Middleware(ScopeChat, handler)
+	rec = httptest.NewRecorder()
+	upload.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, link, nil))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for a signed link on a route that writes, got %d", rec.Code)
+	}
+}
+
+type fakeAPITokens map[string]Scope
+
+func (f fakeAPITokens) VerifyAPIToken(ctx context.Context, token string) (string, Scope, error) {
+	scope, ok := f[token]
+	if !ok {
+		return "", "", ErrInvalidToken
+	}
+	return "user-1", scope, nil
+}
+
+func TestAPITokenScopes(t *testing.T) {
+	readOnly, hash, prefix, err := GenerateAPIToken()
+	if err != nil {
+		t.Fatalf("GenerateAPIToken failed: %v", err)
+	}
+	if !IsAPIToken(readOnly) || hash != HashAPIToken(readOnly) || !strings.HasPrefix(readOnly, prefix) {
+		t.Fatalf("Unexpected token '%s', hash '%s', prefix '%s'", readOnly, hash, prefix)
+	}
+
+	a, _ := NewAuthenticator(Config{Enabled: true, TokenSecret: testSecret, TokenTTL: time.Hour})
+	a.SetAPITokenVerifier(fakeAPITokens{readOnly: ScopeReadOnly})
+	a.RequireScope(ScopeReadOnly, "/test.Service/Read")
+
+	handler := func(ctx context.Context, req any) (any, error) { return nil, nil }
+	call := func(token string, method string) error {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+		_, err := a.UnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	if err := call(readOnly, "/test.Service/Read"); err != nil {
+		t.Errorf("Expected read-only token to call a read-only method, got %v", err)
+	}
+	if err := call(readOnly, "/test.Service/Write"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for a method that needs admin, got %v", err)
+	}
+	if err := call(APITokenPrefix+"unknown", "/test.Service/Read"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated for an unknown token, got %v", err)
+	}
+
+	session, _, _ := a.Tokens().Issue("user-1")
+	if err := call(session, "/test.Service/Write"); err != nil {
+		t.Errorf("Expected login session to call everything, got %v", err)
+	}
+}
+
+type fakeAdmins map[string]bool
+
+func (f fakeAdmins) IsAdmin(ctx context.Context, userID string) (bool, error) {
+	return f[userID], nil
+}
+
+func TestAdminMethods(t *testing.T) {
+	a, _ := NewAuthenticator(Config{Enabled: true, TokenSecret: testSecret, TokenTTL: time.Hour})
+	a.RequireAdmin("/test.Service/Settings")
+
+	handler := func(ctx context.Context, req any) (any, error) { return nil, nil }
+	call := func(a *Authenticator, userID string, method string) error {
+		token, _, _ := a.Tokens().Issue(userID)
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+		_, err := a.UnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	if err := call(a, "admin", "/test.Service/Settings"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied without an admin checker, got %v", err)
+	}
+
+	a.SetAdminChecker(fakeAdmins{"admin": true})
+	if err := call(a, "admin", "/test.Service/Settings"); err != nil {
+		t.Errorf("Expected the admin to call an admin method, got %v", err)
+	}
+	if err := call(a, "user-1", "/test.Service/Settings"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for a user who isn't an admin, got %v", err)
+	}
+	if err := call(a, "user-1", "/test.Service/Write"); err != nil {
+		t.Errorf("Expected a user who isn't an admin to call other methods, got %v", err)
+	}
+
+	// an admin scoped API token doesn't make its user an admin
+	apiToken, _, _, _ := GenerateAPIToken()
+	a.SetAPITokenVerifier(fakeAPITokens{apiToken: ScopeAdmin})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+apiToken))
+	if _, err := a.UnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Settings"}, handler); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for the admin token of a user who isn't an admin, got %v", err)
+	}
+
+	local := NewLocalAuthenticator()
+	local.RequireAdmin("/test.Service/Settings")
+	if err := call(local, LocalUserID, "/test.Service/Settings"); err != nil {
+		t.Errorf("Expected the local user to call admin methods, got %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+package auth
+
+import "context"
+
+// LocalUserID owns all data in single user mode, it is also the user_id default of the user scoped tables
+const LocalUserID = "0"
+
+type userIDKey struct{}
+
+// WithUserID returns a context carrying the authenticated user id
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserIDFromContext returns the user id set by the interceptors or the HTTP middleware
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(userIDKey{}).(string)
+	return userID, ok && userID != ""
+}
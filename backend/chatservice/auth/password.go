@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+Passwords are stored as PBKDF2-SHA256 hashes:
+
+	pbkdf2-sha256$<iterations>$<base64 salt>$<base64 hash>
+
+The iteration count is part of the stored value so it can be raised later
+without invalidating existing hashes.
+*/
+
+const (
+	passwordHashScheme = "pbkdf2-sha256"
+	passwordIterations = 600_000
+	passwordSaltSize   = 16
+	passwordKeySize    = 32
+
+	MinPasswordLength = 8
+)
+
+var ErrPasswordTooShort = fmt.Errorf("password must be at least %d characters", MinPasswordLength)
+
+// HashPassword returns the encoded hash of the password with a random salt
+func HashPassword(password string) (string, error) {
+	if len(password) < MinPasswordLength {
+		return "", ErrPasswordTooShort
+	}
+
+	salt := make([]byte, passwordSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key, err := pbkdf2.Key(sha256.New, password, salt, passwordIterations, passwordKeySize)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	return fmt.Sprintf("%s$%d$%s$%s", passwordHashScheme, passwordIterations,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// CheckPassword reports whether the password matches a hash produced by HashPassword
+func CheckPassword(encoded string, password string) (bool, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 4 || parts[0] != passwordHashScheme {
+		return false, errors.New("unsupported password hash")
+	}
+
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return false, errors.New("malformed password hash")
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false, fmt.Errorf("malformed password salt: %w", err)
+	}
+	expected, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false, fmt.Errorf("malformed password hash: %w", err)
+	}
+
+	key, err := pbkdf2.Key(sha256.New, password, salt, iterations, len(expected))
+	if err != nil {
+		return false, fmt.Errorf("failed to hash password: %w", err)
+	}
+	return subtle.ConstantTimeCompare(key, expected) == 1, nil
+}
@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenExpired = errors.New("token expired")
+)
+
+// minSecretSize is the minimum HS256 key size, shorter secrets are easy to brute force offline
+const minSecretSize = 32
+
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+type claims struct {
+	Subject   string `json:"sub"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+	// Path binds a link token to the URL path it was issued for, session tokens have none
+	Path string `json:"path,omitempty"`
+}
+
+// TokenIssuer issues and verifies HS256 signed JWTs carrying the user id
+type TokenIssuer struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+func NewTokenIssuer(secret []byte, ttl time.Duration) (*TokenIssuer, error) {
+	if len(secret) < minSecretSize {
+		return nil, fmt.Errorf("token secret must be at least %d bytes, got %d", minSecretSize, len(secret))
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("token ttl must be positive")
+	}
+	return &TokenIssuer{secret: secret, ttl: ttl, now: time.Now}, nil
+}
+
+// Issue returns a signed token for the user and its expiry
+func (t *TokenIssuer) Issue(userID string) (string, time.Time, error) {
+	return t.issue(userID, "", t.ttl)
+}
+
+// Verify checks the signature and expiry of the token and returns the user id, link tokens are refused
+func (t *TokenIssuer) Verify(token string) (string, error) {
+	c, err := t.verify(token)
+	if err != nil {
+		return "", err
+	}
+	if c.Path != "" {
+		return "", ErrInvalidToken
+	}
+	return c.Subject, nil
+}
+
+// IssueLink returns a token that only opens the given URL path, for links that can't carry an Authorization
+// header. It ends up in logs and browser history, so ttl should be minutes.
+func (t *TokenIssuer) IssueLink(userID string, path string, ttl time.Duration) (string, time.Time, error) {
+	if path == "" {
+		return "", time.Time{}, fmt.Errorf("link tokens need a path")
+	}
+	return t.issue(userID, path, ttl)
+}
+
+// VerifyLink checks a token from IssueLink against the path it is used for and returns the user id
+func (t *TokenIssuer) VerifyLink(token string, path string) (string, error) {
+	c, err := t.verify(token)
+	if err != nil {
+		return "", err
+	}
+	if c.Path == "" || c.Path != path {
+		return "", ErrInvalidToken
+	}
+	return c.Subject, nil
+}
+
+func (t *TokenIssuer) issue(userID string, path string, ttl time.Duration) (string, time.Time, error) {
+	now := t.now()
+	expiresAt := now.Add(ttl)
+
+	payload, err := json.Marshal(claims{Subject: userID, IssuedAt: now.Unix(), ExpiresAt: expiresAt.Unix(), Path: path})
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to encode token claims: %w", err)
+	}
+
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + t.sign(unsigned), expiresAt, nil
+}
+
+func (t *TokenIssuer) verify(token string) (claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != jwtHeader {
+		return claims{}, ErrInvalidToken
+	}
+
+	expected := t.sign(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(parts[2]), []byte(expected)) {
+		return claims{}, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims{}, ErrInvalidToken
+	}
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil || c.Subject == "" {
+		return claims{}, ErrInvalidToken
+	}
+	if t.now().Unix() >= c.ExpiresAt {
+		return claims{}, ErrTokenExpired
+	}
+	return c, nil
+}
+
+func (t *TokenIssuer) sign(unsigned string) string {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
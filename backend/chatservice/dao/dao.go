@@ -1,35 +1,97 @@
 package dao
 
 import (
+	"database/sql"
+	"time"
+
 	proto "sortedstartup/chatservice/proto"
 )
 
 type DAO interface {
 	// Chat CRUD
 	CreateChat(userID string, chatId string, name string, projectID string) error
-	GetChatName(userID string, chatId string) (string, error)
-	SaveChatName(userID string, chatId string, name string) error
+	// SaveChatName names a chat for whoever may write to it, sql.ErrNoRows if the chat does not exist
+	SaveChatName(chatId string, name string) error
 	AddChatMessage(userID string, chatId string, role string, content string) error
-	AddChatMessageWithTokens(userID string, chatId string, role string, content string, model string, inputTokens int, outputTokens int) (int64, error)
+	// AddChatMessageWithTokens stores a reply with its usage and the generation options (JSON) it was generated with,
+	// interrupted marks a partial reply. Assistant replies are recorded in the usage ledger too
+	AddChatMessageWithTokens(userID string, chatId string, role string, content string, model string, inputTokens int, outputTokens int, cost float64, generationOptions string, interrupted bool) (int64, error)
+	// AddUsage records a completion that isn't stored as a message, like naming a chat, in the usage ledger
+	AddUsage(userID string, chatId string, model string, inputTokens int, outputTokens int, cost float64) error
+	// GetChatMessages returns the messages of a chat, alternatives include the messages they inherit from their parents
 	GetChatMessages(userID string, chatId string) ([]ChatMessageRow, error)
+	// GetChatMessagePage returns the messages of GetChatMessages after page.After, oldest first unless descending
+	GetChatMessagePage(userID string, chatId string, page MessagePage) ([]ChatMessageRow, error)
+	// GetChatRole returns owner for chats of the user or in the user's projects outside of workspaces and the user's
+	// workspace role for chats in workspace projects, even the creator's. sql.ErrNoRows if the user can't access the chat
+	GetChatRole(userID string, chatId string) (string, error)
+	// ChatExists reports whether any user has a chat with the id, whether or not the caller can access it
+	ChatExists(chatId string) (bool, error)
 
-	// GetChatList retrieves all chats for a user
-	GetChatList(userID string, projectID string) ([]*proto.ChatInfo, error)
+	// GetChatList retrieves a page of the chats of a user (projectID empty) or project, pinned chats first, trashed
+	// chats are left out
+	GetChatList(userID string, q ChatListQuery) ([]ChatListRow, error)
 
 	// Model operations
-	GetModels() ([]proto.ModelListInfo, error)
+	GetModels(includeDisabled bool) ([]ModelRow, error)
+	GetModel(modelID string) (*ModelRow, error)
+	CreateModel(model ModelRow) error
+	// UpdateModel and DeleteModel return sql.ErrNoRows if the model does not exist
+	UpdateModel(model ModelRow) error
+	DeleteModel(modelID string) error
+
+	// Usage operations
+	// GetUsage aggregates tokens and cost the user spent in [from, to), optionally within one project
+	GetUsage(userID string, groupBy UsageGroupBy, from time.Time, to time.Time, projectID string) ([]UsageRow, error)
+	// GetSpend sums the cost spent since `since`, an empty userID or projectID matches all. Spend is recorded in a
+	// ledger, purging a chat keeps it and moving one leaves it with the project it was spent in
+	GetSpend(since time.Time, userID string, projectID string) (float64, error)
+
+	// User operations
+	CreateUser(user UserRow) error
+	// GetUserByUsername and GetUserByID return sql.ErrNoRows if the user does not exist
+	GetUserByUsername(username string) (*UserRow, error)
+	GetUserByID(userID string) (*UserRow, error)
+	CountUsers() (int, error)
+
+	// API token operations
+	CreateApiToken(token ApiTokenRow) error
+	// GetApiTokenByHash returns sql.ErrNoRows if no token has the hash
+	GetApiTokenByHash(tokenHash string) (*ApiTokenRow, error)
+	ListApiTokens(userID string) ([]ApiTokenRow, error)
+	// DeleteApiToken returns sql.ErrNoRows if the user has no token with the id
+	DeleteApiToken(userID string, tokenID string) error
+	TouchApiToken(tokenID string, usedAt time.Time) error
+
+	// Workspace operations
+	// CreateWorkspace creates the workspace with ownerID as its first owner
+	CreateWorkspace(workspace WorkspaceRow, ownerID string) error
+	GetWorkspaces(userID string) ([]WorkspaceRow, error)
+	// GetWorkspaceRole returns sql.ErrNoRows if the user is not a member
+	GetWorkspaceRole(userID string, workspaceID string) (string, error)
+	GetWorkspaceMembers(workspaceID string) ([]WorkspaceMemberRow, error)
+	AddWorkspaceMember(workspaceID string, userID string, role string) error
+	// UpdateWorkspaceMember and RemoveWorkspaceMember return sql.ErrNoRows if the user is not a member
+	UpdateWorkspaceMember(workspaceID string, userID string, role string) error
+	RemoveWorkspaceMember(workspaceID string, userID string) error
 
 	// Search operations
 	SearchChatMessages(userID string, query string) ([]proto.SearchResult, error)
 
 	//Project Operations
-	CreateProject(userID string, id string, name string, description string, additionalData string) (string, error)
+	// CreateProject creates a project of the user, shared with the workspace's members when workspaceID is set
+	CreateProject(userID string, id string, name string, description string, additionalData string, systemPrompt string, workspaceID string) (string, error)
 	GetProjects(userID string) ([]ProjectRow, error)
+	// GetProjectRole returns owner for the user's own projects outside of workspaces and the user's workspace role
+	// for workspace projects, the creator's too. sql.ErrNoRows if the user can't access the project
+	GetProjectRole(userID string, projectID string) (string, error)
 	FileSave(userID string, project_id string, docs_id string, file_name string, fileSize int64) error
 	UpdateEmbeddingStatus(docs_id string, status int32) error
 	FetchErrorDocs(userID string, project_id string) ([]string, error)
 	FilesList(userID string, project_id string) ([]DocumentListRow, error)
 	GetFileMetadata(docsId string) (*DocumentListRow, error)
+	// GetDocument returns a document of one of the user's projects, sql.ErrNoRows if there is none
+	GetDocument(userID string, docsID string) (*DocumentListRow, error)
 	TotalUsedSize(userID string, projectID string) (int64, error)
 
 	// SaveRAGChunk saves a chunk to rag_chunks table
@@ -37,12 +99,85 @@ type DAO interface {
 	SaveRAGChunkEmbedding(chunkID string, embedding []float64) error
 	GetTopSimilarRAGChunks(userID string, embedding string, projectID string) ([]RAGChunkRow, error)
 
-	IsMainBranch(userID string, source_chat_id string) (bool, error)
+	// Message alternatives (regenerated and edited messages)
+	// GetChat returns sql.ErrNoRows if the chat does not exist or the user can't read it
+	GetChat(userID string, chatId string) (*ChatRow, error)
+	// CreateAlternative inserts the alternative and makes it the active chat of chat.AlternativeOf
+	CreateAlternative(userID string, chat ChatRow) error
+	// SetActiveChat shows activeChatId for the listed chat, an empty activeChatId shows the chat itself
+	SetActiveChat(chatId string, activeChatId string) error
+	// GetAlternativeCounts returns the number of alternatives of a listed chat with messages by parent_message_id
+	GetAlternativeCounts(chatId string) (map[string]int, error)
+
+	// Branches
+	// BranchChat creates new_chat_id branched from source_chat_id after parent_message_id, the branch reads the messages
+	// up to there from its parents and only stores its own. Returns sql.ErrNoRows if the user can't read source_chat_id.
 	BranchChat(userID string, source_chat_id string, parent_message_id string, new_chat_id string, branch_name string) error
-	GetChatBranches(userID string, chatId string, isMain bool) ([]ChatInfoRow, error)
+	// GetChatBranches returns the chat a branch was branched from and the branches of the chat
+	GetChatBranches(userID string, chatId string) ([]ChatInfoRow, error)
+	// GetBranchTree returns the chats of the branch tree chatId belongs to, from its main branch down, without alternatives
+	GetBranchTree(userID string, chatId string) ([]BranchRow, error)
+
+	// Chat lifecycle, trashing, restoring, purging and moving a chat apply to everything branched from it too.
+	// They return sql.ErrNoRows if nothing changed.
+	RenameChat(chatId string, name string) error
+	TrashChat(chatId string) error
+	// RestoreChat takes the chat out of the trash along with the branches trashed with it
+	RestoreChat(chatId string) error
+	// PurgeChat deletes the chat, its branches, their messages and summaries for good
+	PurgeChat(chatId string) error
+	SetChatArchived(chatId string, archived bool) error
+	SetChatPinned(chatId string, pinned bool) error
+	// MoveChat moves the chat to another project, an empty projectID moves it out of its project
+	MoveChat(chatId string, projectID string) error
+	// GetTrash returns the chats trashed on their own, not along with the chat they were branched from,
+	// of a project or the user's chats without project
+	GetTrash(userID string, projectID string) ([]ChatInfoRow, error)
+
+	// Summaries
+	// GetChatSummary returns the rolling summary of a listed chat, sql.ErrNoRows if it has none
+	GetChatSummary(chatId string) (*ChatSummaryRow, error)
+	// SaveChatSummary replaces the summary of the chat and records what writing it cost the user in the usage ledger
+	SaveChatSummary(userID string, summary ChatSummaryRow) error
+
+	// System prompts and prompt templates
+	// SetChatSystemPrompt and SetProjectSystemPrompt return sql.ErrNoRows if nothing changed
+	SetChatSystemPrompt(chatId string, prompt string) error
+	SetProjectSystemPrompt(projectID string, prompt string) error
+	// GetSystemPrompt returns the system prompt of a listed chat, the one of its project if it has none of its own
+	GetSystemPrompt(chatId string) (string, error)
+	CreatePromptTemplate(template PromptTemplateRow) error
+	// GetPromptTemplate returns sql.ErrNoRows if the user can't read the template
+	GetPromptTemplate(userID string, templateID string) (*PromptTemplateRow, error)
+	// GetPromptTemplates returns the user's own templates and, if projectID is set, the project's
+	GetPromptTemplates(userID string, projectID string) ([]PromptTemplateRow, error)
+
+	// Generation options, stored as JSON
+	// SetChatGenerationOptions and SetProjectGenerationOptions return sql.ErrNoRows if nothing changed
+	SetChatGenerationOptions(chatId string, options string) error
+	SetProjectGenerationOptions(projectID string, options string) error
+	// GetGenerationDefaults returns the generation options of a listed chat and of its project
+	GetGenerationDefaults(chatId string) (chatOptions string, projectOptions string, err error)
 }
 
 type SettingsDAO interface {
 	GetSettingValue(settingName string) (string, error)
 	SetSettingValue(settingName string, settingValue string) error
 }
+
+// nullIfEmpty stores an empty optional reference as NULL
+func nullIfEmpty(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// expectRowsAffected turns an UPDATE, DELETE or INSERT ... SELECT that matched nothing into sql.ErrNoRows
+func expectRowsAffected(result sql.Result) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	proto "sortedstartup/chatservice/proto"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
@@ -72,21 +73,12 @@ func (p *PostgresDAO) CreateChat(userID string, chatId string, name string, proj
 	}
 }
 
-func (p *PostgresDAO) GetChatName(userID string, chatId string) (string, error) {
-	var name string
-	err := p.db.Get(&name, "SELECT name FROM chat_list WHERE chat_id = $1 AND user_id = $2", chatId, userID)
-	if err != nil {
-		return "", fmt.Errorf("failed to get chat name: %w", err)
-	}
-	return name, nil
-}
-
-func (p *PostgresDAO) SaveChatName(userID string, chatId string, name string) error {
-	_, err := p.db.Exec("UPDATE chat_list SET name = $1 WHERE chat_id = $2 AND user_id = $3", name, chatId, userID)
+func (p *PostgresDAO) SaveChatName(chatId string, name string) error {
+	result, err := p.db.Exec("UPDATE chat_list SET name = $1 WHERE chat_id = $2", name, chatId)
 	if err != nil {
 		return fmt.Errorf("failed to save chat name: %w", err)
 	}
-	return nil
+	return expectRowsAffected(result)
 }
 
 // AddChatMessage adds a message to a chat
@@ -95,72 +87,362 @@ func (p *PostgresDAO) AddChatMessage(userID string, chatId string, role string,
 	return err
 }
 
-// GetChatMessages retrieves all messages for a given chat
+// GetChatMessages retrieves all messages for a given chat, chats of shared projects include the messages of all members.
+// Branches and alternatives walk up their parents, each parent contributes its messages up to the branch point.
 func (p *PostgresDAO) GetChatMessages(userID string, chatId string) ([]ChatMessageRow, error) {
 	var messages []ChatMessageRow
-	err := p.db.Select(&messages, "SELECT role, content, id FROM chat_messages WHERE chat_id = $1 AND user_id = $2 ORDER BY id", chatId, userID)
+	err := p.db.Select(&messages, `
+		`+chatPathCTE("$1", "BIGINT")+`
+		SELECT m.role, m.content, m.id, COALESCE(m.model, '') AS model, COALESCE(m.error, FALSE) AS interrupted,
+			COALESCE(m.generation_options, '') AS generation_options
+		FROM chat_messages m JOIN chain ON m.chat_id = chain.chat_id
+		WHERE (chain.upto IS NULL OR m.id <= chain.upto)
+		  AND ((m.user_id = $2 AND m.chat_id IN (SELECT chat_id FROM chat_list WHERE project_id IS NULL)) OR m.chat_id IN (SELECT chat_id FROM chat_list WHERE project_id IN (`+projectAccessQuery("$2", false)+`)))
+		ORDER BY m.id
+	`, chatId, userID)
 	return messages, err
 }
 
-// GetChatList retrieves all chats for a user
-func (p *PostgresDAO) GetChatList(userID string, projectID string) ([]*proto.ChatInfo, error) {
-	var chats []ChatInfoRow
-	var err error
-
-	if projectID == "" || projectID == "null" {
-		err = p.db.Select(&chats, "SELECT chat_id, name FROM chat_list WHERE project_id IS NULL AND user_id = $1", userID)
-	} else {
-		err = p.db.Select(&chats, "SELECT chat_id, name FROM chat_list WHERE project_id = $1 AND user_id = $2", projectID, userID)
+// GetChatMessagePage is GetChatMessages for one page of the messages, in id order
+func (p *PostgresDAO) GetChatMessagePage(userID string, chatId string, page MessagePage) ([]ChatMessageRow, error) {
+	query := `
+		` + chatPathCTE("$1", "BIGINT") + `
+		SELECT m.role, m.content, m.id, COALESCE(m.model, '') AS model, COALESCE(m.error, FALSE) AS interrupted,
+			COALESCE(m.generation_options, '') AS generation_options
+		FROM chat_messages m JOIN chain ON m.chat_id = chain.chat_id
+		WHERE (chain.upto IS NULL OR m.id <= chain.upto)
+		  AND ((m.user_id = $2 AND m.chat_id IN (SELECT chat_id FROM chat_list WHERE project_id IS NULL)) OR m.chat_id IN (SELECT chat_id FROM chat_list WHERE project_id IN (` + projectAccessQuery("$2", false) + `)))`
+	args := []interface{}{chatId, userID}
+
+	cmp, dir := ">", "ASC"
+	if page.Descending {
+		cmp, dir = "<", "DESC"
+	}
+	if page.After > 0 {
+		args = append(args, page.After)
+		query += " AND m.id " + cmp + " $" + strconv.Itoa(len(args))
 	}
+	query += " ORDER BY m.id " + dir
+	if page.Limit > 0 {
+		args = append(args, page.Limit)
+		query += " LIMIT $" + strconv.Itoa(len(args))
+	}
+
+	var messages []ChatMessageRow
+	err := p.db.Select(&messages, query, args...)
+	return messages, err
+}
+
+func (p *PostgresDAO) GetChatRole(userID string, chatId string) (string, error) {
+	var role string
+	err := p.db.Get(&role, `
+		SELECT COALESCE(m.role, '`+WorkspaceRoleOwner+`')
+		FROM chat_list c
+		LEFT JOIN project p ON p.id = c.project_id
+		LEFT JOIN workspace_members m ON m.workspace_id = p.workspace_id AND m.user_id = $1
+		WHERE c.chat_id = $2 AND ((p.workspace_id IS NULL AND (c.user_id = $1 OR p.user_id = $1)) OR m.user_id IS NOT NULL)
+	`, userID, chatId)
+	return role, err
+}
+
+func (p *PostgresDAO) ChatExists(chatId string) (bool, error) {
+	var exists bool
+	err := p.db.Get(&exists, "SELECT EXISTS (SELECT 1 FROM chat_list WHERE chat_id = $1)", chatId)
+	return exists, err
+}
 
+// GetChatList retrieves a page of the chats of a user or project
+func (p *PostgresDAO) GetChatList(userID string, q ChatListQuery) ([]ChatListRow, error) {
+	query, args, err := chatListQuery(func(n int) string { return "$" + strconv.Itoa(n) }, "TIMESTAMP", userID, q)
 	if err != nil {
 		return nil, err
 	}
 
-	var result []*proto.ChatInfo
-	for _, c := range chats {
-		result = append(result, &proto.ChatInfo{
-			ChatId: c.Id,
-			Name:   c.Name,
-		})
-	}
-	return result, nil
+	var chats []ChatListRow
+	err = p.db.Select(&chats, query, args...)
+	return chats, err
 }
 
-func (p *PostgresDAO) AddChatMessageWithTokens(userID string, chatId string, role string, content string, model string, inputTokens int, outputTokens int) (int64, error) {
+func (p *PostgresDAO) AddChatMessageWithTokens(userID string, chatId string, role string, content string, model string, inputTokens int, outputTokens int, cost float64, generationOptions string, interrupted bool) (int64, error) {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
 	// PostgreSQL doesn't have LastInsertId(), so we use RETURNING
 	var messageId int64
-	err := p.db.Get(&messageId, `
-		INSERT INTO chat_messages (chat_id, role, content, model, input_token_count, output_token_count, user_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	err = tx.Get(&messageId, `
+		INSERT INTO chat_messages (chat_id, role, content, model, input_token_count, output_token_count, cost, user_id, error, generation_options)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id`,
-		chatId, role, content, model, inputTokens, outputTokens, userID)
+		chatId, role, content, model, inputTokens, outputTokens, cost, userID, interrupted, nullIfEmpty(generationOptions))
 	if err != nil {
 		return 0, err
 	}
 
-	return messageId, nil
+	if role == "assistant" {
+		_, err = tx.Exec(`
+			INSERT INTO usage_ledger (user_id, chat_id, project_id, message_id, model, input_token_count, output_token_count, cost)
+			VALUES ($1, $2, (SELECT project_id FROM chat_list WHERE chat_id = $2), $3, $4, $5, $6, $7)`,
+			userID, chatId, messageId, model, inputTokens, outputTokens, cost)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return messageId, tx.Commit()
 }
 
-// GetModels retrieves all available models
-func (p *PostgresDAO) GetModels() ([]proto.ModelListInfo, error) {
-	var models []struct {
-		ID   string `db:"id"`
-		Name string `db:"name"`
+// AddUsage records a completion without a message in the usage ledger, see DAO.AddUsage
+func (p *PostgresDAO) AddUsage(userID string, chatId string, model string, inputTokens int, outputTokens int, cost float64) error {
+	_, err := p.db.Exec(`
+		INSERT INTO usage_ledger (user_id, chat_id, project_id, model, input_token_count, output_token_count, cost)
+		VALUES ($1, $2, (SELECT project_id FROM chat_list WHERE chat_id = $2), $3, $4, $5, $6)`,
+		userID, chatId, model, inputTokens, outputTokens, cost)
+	return err
+}
+
+// GetModels retrieves the model catalog, disabled models are skipped unless includeDisabled is set
+func (p *PostgresDAO) GetModels(includeDisabled bool) ([]ModelRow, error) {
+	query := "SELECT " + modelColumns + " FROM model_metadata"
+	if !includeDisabled {
+		query += " WHERE enabled"
 	}
-	err := p.db.Select(&models, "SELECT id, name FROM model_metadata")
+	query += " ORDER BY provider, name"
+
+	var models []ModelRow
+	err := p.db.Select(&models, query)
 	if err != nil {
 		return nil, err
 	}
+	return models, nil
+}
 
-	var result []proto.ModelListInfo
-	for _, m := range models {
-		result = append(result, proto.ModelListInfo{
-			Id:    m.ID,
-			Label: m.Name,
-		})
+// GetModel retrieves the metadata of a single model
+func (p *PostgresDAO) GetModel(modelID string) (*ModelRow, error) {
+	var model ModelRow
+	err := p.db.Get(&model, "SELECT "+modelColumns+" FROM model_metadata WHERE id = $1", modelID)
+	if err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
+
+// CreateModel adds a model to the catalog
+func (p *PostgresDAO) CreateModel(model ModelRow) error {
+	_, err := p.db.Exec(`
+		INSERT INTO model_metadata (id, name, url, provider, input_token_cost, output_token_cost,
+			supports_vision, supports_tools, context_window, supports_reasoning, max_output_tokens, provider_model_id, enabled)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		model.ID, model.Name, model.URL, model.Provider, model.InputTokenCost, model.OutputTokenCost,
+		model.SupportsVision, model.SupportsTools, model.ContextWindow, model.SupportsReasoning, model.MaxOutputTokens, model.ProviderModelID, model.Enabled)
+	return err
+}
+
+// UpdateModel replaces all fields of the model with the given id
+func (p *PostgresDAO) UpdateModel(model ModelRow) error {
+	result, err := p.db.Exec(`
+		UPDATE model_metadata
+		SET name = $1, url = $2, provider = $3, input_token_cost = $4, output_token_cost = $5,
+			supports_vision = $6, supports_tools = $7, context_window = $8, supports_reasoning = $9, max_output_tokens = $10,
+			provider_model_id = $11, enabled = $12
+		WHERE id = $13`,
+		model.Name, model.URL, model.Provider, model.InputTokenCost, model.OutputTokenCost,
+		model.SupportsVision, model.SupportsTools, model.ContextWindow, model.SupportsReasoning, model.MaxOutputTokens, model.ProviderModelID, model.Enabled, model.ID)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+// DeleteModel removes the model from the catalog, messages keep the model id they were generated with
+func (p *PostgresDAO) DeleteModel(modelID string) error {
+	result, err := p.db.Exec("DELETE FROM model_metadata WHERE id = $1", modelID)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+// GetUsage aggregates the tokens and cost in the usage ledger, see DAO.GetUsage
+func (p *PostgresDAO) GetUsage(userID string, groupBy UsageGroupBy, from time.Time, to time.Time, projectID string) ([]UsageRow, error) {
+	query, err := usageQuery(groupBy, "to_char(ul.created_at, 'YYYY-MM-DD')", func(i int) string { return fmt.Sprintf("$%d", i) }, projectID != "")
+	if err != nil {
+		return nil, err
+	}
+
+	// created_at is a TIMESTAMP without time zone filled by CURRENT_TIMESTAMP
+	args := []interface{}{userID, from.UTC(), to.UTC()}
+	if projectID != "" {
+		args = append(args, projectID)
+	}
+
+	var rows []UsageRow
+	if err := p.db.Select(&rows, query, args...); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// GetSpend sums the cost in the usage ledger, see DAO.GetSpend
+func (p *PostgresDAO) GetSpend(since time.Time, userID string, projectID string) (float64, error) {
+	args := []interface{}{since.UTC()}
+	if userID != "" {
+		args = append(args, userID)
+	}
+	if projectID != "" {
+		args = append(args, projectID)
+	}
+
+	var spent float64
+	err := p.db.Get(&spent, spendQuery(func(i int) string { return fmt.Sprintf("$%d", i) }, userID, projectID), args...)
+	return spent, err
+}
+
+// CreateUser adds an account, the id is chosen by the caller
+func (p *PostgresDAO) CreateUser(user UserRow) error {
+	_, err := p.db.Exec("INSERT INTO users (id, username, password_hash, is_admin) VALUES ($1, $2, $3, $4)", user.ID, user.Username, user.PasswordHash, user.IsAdmin)
+	return err
+}
+
+func (p *PostgresDAO) GetUserByUsername(username string) (*UserRow, error) {
+	var user UserRow
+	err := p.db.Get(&user, "SELECT id, username, password_hash, is_admin, created_at FROM users WHERE username = $1", username)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (p *PostgresDAO) GetUserByID(userID string) (*UserRow, error) {
+	var user UserRow
+	err := p.db.Get(&user, "SELECT id, username, password_hash, is_admin, created_at FROM users WHERE id = $1", userID)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (p *PostgresDAO) CountUsers() (int, error) {
+	var count int
+	err := p.db.Get(&count, "SELECT COUNT(*) FROM users")
+	return count, err
+}
+
+func (p *PostgresDAO) CreateApiToken(token ApiTokenRow) error {
+	_, err := p.db.Exec(`
+		INSERT INTO api_tokens (id, user_id, name, token_hash, token_prefix, scope, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, token.ID, token.UserID, token.Name, token.TokenHash, token.TokenPrefix, token.Scope, token.ExpiresAt)
+	return err
+}
+
+func (p *PostgresDAO) GetApiTokenByHash(tokenHash string) (*ApiTokenRow, error) {
+	var token ApiTokenRow
+	err := p.db.Get(&token, "SELECT "+apiTokenColumns+" FROM api_tokens WHERE token_hash = $1", tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (p *PostgresDAO) ListApiTokens(userID string) ([]ApiTokenRow, error) {
+	var tokens []ApiTokenRow
+	err := p.db.Select(&tokens, "SELECT "+apiTokenColumns+" FROM api_tokens WHERE user_id = $1 ORDER BY created_at DESC", userID)
+	return tokens, err
+}
+
+func (p *PostgresDAO) DeleteApiToken(userID string, tokenID string) error {
+	result, err := p.db.Exec("DELETE FROM api_tokens WHERE id = $1 AND user_id = $2", tokenID, userID)
+	if err != nil {
+		return err
 	}
-	return result, nil
+	return expectRowsAffected(result)
+}
+
+func (p *PostgresDAO) TouchApiToken(tokenID string, usedAt time.Time) error {
+	_, err := p.db.Exec("UPDATE api_tokens SET last_used_at = $1 WHERE id = $2", usedAt, tokenID)
+	return err
+}
+
+func (p *PostgresDAO) GetProjectRole(userID string, projectID string) (string, error) {
+	var role string
+	err := p.db.Get(&role, `
+		SELECT COALESCE(m.role, '`+WorkspaceRoleOwner+`')
+		FROM project p
+		LEFT JOIN workspace_members m ON m.workspace_id = p.workspace_id AND m.user_id = $1
+		WHERE p.id = $2 AND ((p.workspace_id IS NULL AND p.user_id = $1) OR m.user_id IS NOT NULL)
+	`, userID, projectID)
+	return role, err
+}
+
+func (p *PostgresDAO) CreateWorkspace(workspace WorkspaceRow, ownerID string) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("INSERT INTO workspaces (id, name, created_by) VALUES ($1, $2, $3)", workspace.ID, workspace.Name, ownerID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, $3)", workspace.ID, ownerID, WorkspaceRoleOwner)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (p *PostgresDAO) GetWorkspaces(userID string) ([]WorkspaceRow, error) {
+	var workspaces []WorkspaceRow
+	err := p.db.Select(&workspaces, `
+		SELECT w.id, w.name, w.created_by, w.created_at, m.role
+		FROM workspaces w
+		JOIN workspace_members m ON m.workspace_id = w.id
+		WHERE m.user_id = $1
+		ORDER BY w.name
+	`, userID)
+	return workspaces, err
+}
+
+func (p *PostgresDAO) GetWorkspaceRole(userID string, workspaceID string) (string, error) {
+	var role string
+	err := p.db.Get(&role, "SELECT role FROM workspace_members WHERE workspace_id = $1 AND user_id = $2", workspaceID, userID)
+	return role, err
+}
+
+func (p *PostgresDAO) GetWorkspaceMembers(workspaceID string) ([]WorkspaceMemberRow, error) {
+	var members []WorkspaceMemberRow
+	err := p.db.Select(&members, `
+		SELECT m.workspace_id, m.user_id, COALESCE(u.username, '') AS username, m.role, m.created_at
+		FROM workspace_members m
+		LEFT JOIN users u ON u.id = m.user_id
+		WHERE m.workspace_id = $1
+		ORDER BY m.created_at
+	`, workspaceID)
+	return members, err
+}
+
+func (p *PostgresDAO) AddWorkspaceMember(workspaceID string, userID string, role string) error {
+	_, err := p.db.Exec("INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, $3)", workspaceID, userID, role)
+	return err
+}
+
+func (p *PostgresDAO) UpdateWorkspaceMember(workspaceID string, userID string, role string) error {
+	result, err := p.db.Exec("UPDATE workspace_members SET role = $1 WHERE workspace_id = $2 AND user_id = $3", role, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (p *PostgresDAO) RemoveWorkspaceMember(workspaceID string, userID string) error {
+	result, err := p.db.Exec("DELETE FROM workspace_members WHERE workspace_id = $1 AND user_id = $2", workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
 }
 
 // SearchChatMessages performs full text search across chat messages
@@ -193,6 +475,8 @@ func (p *PostgresDAO) SearchChatMessages(userID string, query string) ([]proto.S
 		JOIN chat_list cl ON cm.chat_id = cl.chat_id
 		WHERE cm.user_id = $2
 		AND cl.user_id = $2
+		AND (cl.project_id IS NULL OR cl.project_id IN (` + projectAccessQuery("$2", false) + `))
+		AND cl.deleted_at IS NULL
 		AND cm.content_tsvector @@ to_tsquery('english', $1)
 		GROUP BY cm.chat_id, cl.name
 		ORDER BY max(ts_rank_cd(cm.content_tsvector, to_tsquery('english', $1))) DESC, cm.chat_id
@@ -225,21 +509,24 @@ func (p *PostgresDAO) SearchChatMessages(userID string, query string) ([]proto.S
 }
 
 // Project CRUD
-func (p *PostgresDAO) CreateProject(userID string, id string, name string, description string, additionalData string) (string, error) {
+func (p *PostgresDAO) CreateProject(userID string, id string, name string, description string, additionalData string, systemPrompt string, workspaceID string) (string, error) {
 	_, err := p.db.Exec(`
-		INSERT INTO project (id, name, description, additional_data, user_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
-	`, id, name, description, additionalData, userID)
+		INSERT INTO project (id, name, description, additional_data, system_prompt, user_id, workspace_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, id, name, description, additionalData, systemPrompt, userID, nullIfEmpty(workspaceID))
 	if err != nil {
 		return "", err
 	}
 	return id, nil
 }
 
-// GetProjects retrieves all projects for a user
+// GetProjects retrieves the user's own projects and the projects of the user's workspaces
 func (p *PostgresDAO) GetProjects(userID string) ([]ProjectRow, error) {
 	var projects []ProjectRow
-	err := p.db.Select(&projects, `SELECT id, name, description, additional_data, created_at, updated_at FROM project WHERE user_id = $1`, userID)
+	err := p.db.Select(&projects, `
+		SELECT id, COALESCE(workspace_id, '') AS workspace_id, name, description, additional_data, system_prompt, generation_options, created_at, updated_at
+		FROM project WHERE id IN (`+projectAccessQuery("$1", false)+`)
+	`, userID)
 	return projects, err
 }
 
@@ -257,7 +544,7 @@ func (p *PostgresDAO) UpdateEmbeddingStatus(docs_id string, status int32) error
 
 func (p *PostgresDAO) FetchErrorDocs(userID string, project_id string) ([]string, error) {
 	var docs_list []string
-	err := p.db.Select(&docs_list, "SELECT docs_id FROM project_docs WHERE project_id = $1 AND embedding_status = $2 AND user_id = $3",
+	err := p.db.Select(&docs_list, "SELECT docs_id FROM project_docs WHERE project_id = $1 AND embedding_status = $2 AND project_id IN ("+projectAccessQuery("$3", true)+")",
 		project_id, int32(proto.Embedding_Status_STATUS_ERROR), userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch error docs: %w", err)
@@ -270,7 +557,7 @@ func (p *PostgresDAO) TotalUsedSize(userID string, projectID string) (int64, err
 	err := p.db.Get(&total, `
 		SELECT COALESCE(SUM(file_size), 0)
 		FROM project_docs
-		WHERE project_id = $1 AND user_id = $2
+		WHERE project_id = $1 AND project_id IN (`+projectAccessQuery("$2", false)+`)
 	`, projectID, userID)
 	return total, err
 }
@@ -280,7 +567,7 @@ func (p *PostgresDAO) FilesList(userID string, project_id string) ([]DocumentLis
 	err := p.db.Select(&files, `
 		SELECT id, project_id, docs_id, file_name, created_at, updated_at, embedding_status
 		FROM project_docs
-		WHERE project_id = $1 AND user_id = $2
+		WHERE project_id = $1 AND project_id IN (`+projectAccessQuery("$2", false)+`)
 	`, project_id, userID)
 	return files, err
 }
@@ -294,6 +581,19 @@ func (p *PostgresDAO) GetFileMetadata(docsId string) (*DocumentListRow, error) {
 	return &doc, nil
 }
 
+func (p *PostgresDAO) GetDocument(userID string, docsID string) (*DocumentListRow, error) {
+	var doc DocumentListRow
+	err := p.db.Get(&doc, `
+		SELECT d.id, d.project_id, d.docs_id, d.file_name, d.file_size, d.created_at, d.updated_at, d.embedding_status, d.user_id
+		FROM project_docs d
+		WHERE d.docs_id = $1 AND d.project_id IN (`+projectAccessQuery("$2", false)+`)
+	`, docsID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
 // SaveRAGChunk saves a chunk to rag_chunks table
 func (p *PostgresDAO) SaveRAGChunk(userID string, chunkID, projectID, docsID string, startByte, endByte int) error {
 	_, err := p.db.Exec(`
@@ -349,8 +649,8 @@ func (p *PostgresDAO) GetTopSimilarRAGChunks(userID string, queryEmbedding strin
 	query := `
 		SELECT id, project_id, docs_id, start_byte, end_byte
     FROM rag_chunks 
-    WHERE user_id = $2 
-      AND project_id = $3
+    WHERE project_id = $3
+      AND project_id IN (` + projectAccessQuery("$2", false) + `)
       AND embedding IS NOT NULL
     ORDER BY embedding <=> $1  -- Cosine distance (smaller = more similar)
     LIMIT 10`
@@ -376,65 +676,218 @@ func (p *PostgresDAO) GetTopSimilarRAGChunks(userID string, queryEmbedding strin
 	return chunks, nil
 }
 
-func (p *PostgresDAO) IsMainBranch(userID string, source_chat_id string) (bool, error) {
-	var isMainBranch bool
-	err := p.db.Get(&isMainBranch, `SELECT is_main_branch FROM chat_list WHERE chat_id = $1 AND user_id = $2`, source_chat_id, userID)
-	return isMainBranch, err
+func (p *PostgresDAO) GetChat(userID string, chatId string) (*ChatRow, error) {
+	var chat ChatRow
+	err := p.db.Get(&chat, "SELECT "+chatColumns+" FROM chat_list WHERE chat_id = $1 AND ((project_id IS NULL AND user_id = $2) OR project_id IN ("+projectAccessQuery("$2", false)+"))", chatId, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &chat, nil
 }
 
-func (p *PostgresDAO) BranchChat(userID string, source_chat_id string, parent_message_id string, new_chat_id string, branch_name string) error {
+func (p *PostgresDAO) CreateAlternative(userID string, chat ChatRow) error {
 	tx, err := p.db.Beginx()
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	_, err = tx.Exec(`
+		INSERT INTO chat_list (chat_id, name, project_id, parent_chat_id, parent_message_id, is_main_branch, alternative_of, user_id)
+		VALUES ($1, $2, $3, $4, $5, FALSE, $6, $7)`,
+		chat.ChatID, chat.Name, nullIfEmpty(chat.ProjectID), chat.ParentChatID, chat.ParentMessageID, chat.AlternativeOf, userID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("UPDATE chat_list SET active_chat_id = $1 WHERE chat_id = $2", chat.ChatID, chat.AlternativeOf)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (p *PostgresDAO) SetActiveChat(chatId string, activeChatId string) error {
+	result, err := p.db.Exec("UPDATE chat_list SET active_chat_id = $1 WHERE chat_id = $2", nullIfEmpty(activeChatId), chatId)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (p *PostgresDAO) GetAlternativeCounts(chatId string) (map[string]int, error) {
+	var rows []struct {
+		ParentMessageID string `db:"parent_message_id"`
+		Count           int    `db:"count"`
+	}
+	err := p.db.Select(&rows, `
+		SELECT parent_message_id, COUNT(*) AS count
+		FROM chat_list c
+		WHERE alternative_of = $1 AND EXISTS (SELECT 1 FROM chat_messages m WHERE m.chat_id = c.chat_id)
+		GROUP BY parent_message_id
+	`, chatId)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.ParentMessageID] = row.Count
+	}
+	return counts, nil
+}
+
+func (p *PostgresDAO) BranchChat(userID string, source_chat_id string, parent_message_id string, new_chat_id string, branch_name string) error {
 	// Use CTE to find project_id from source chat and insert the new branch chat
-	_, err = tx.Exec(`WITH source_chat AS (
-						SELECT project_id 
+	result, err := p.db.Exec(`WITH source_chat AS (
+						SELECT project_id, system_prompt
 						FROM chat_list 
-						WHERE chat_id = $1 AND user_id = $2
+						WHERE chat_id = $1 AND ((project_id IS NULL AND user_id = $2) OR project_id IN (`+projectAccessQuery("$2", false)+`))
 					)
-					INSERT INTO chat_list (chat_id, name, project_id, parent_chat_id, parent_message_id, is_main_branch, user_id)
-					SELECT $3, $4, COALESCE(source_chat.project_id, NULL), $1, $5, FALSE, $2
+					INSERT INTO chat_list (chat_id, name, project_id, parent_chat_id, parent_message_id, is_main_branch, user_id, system_prompt)
+					SELECT $3, $4, COALESCE(source_chat.project_id, NULL), $1, $5, FALSE, $2, source_chat.system_prompt
 					FROM source_chat`, source_chat_id, userID, new_chat_id, branch_name, parent_message_id)
 	if err != nil {
 		return err
 	}
+	return expectRowsAffected(result)
+}
 
-	// Copy messages up to branch point
-	_, err = tx.Exec(`INSERT INTO chat_messages (chat_id, role, content, model, error, input_token_count, output_token_count, created_at, user_id)
-					  SELECT $1, role, content, model, error, input_token_count, output_token_count, created_at, $2
-					  FROM chat_messages 
-					  WHERE chat_id = $3 AND id <= $4 AND user_id = $5
-					  ORDER BY id`, new_chat_id, userID, source_chat_id, parent_message_id, userID)
+func (p *PostgresDAO) GetChatBranches(userID string, chatId string) ([]ChatInfoRow, error) {
+	// branches of the chat's alternatives are branches of the chat
+	var chats []ChatInfoRow
+	err := p.db.Select(&chats, `
+		SELECT `+chatInfoColumns+`
+		FROM chat_list c
+		WHERE c.alternative_of IS NULL AND c.deleted_at IS NULL AND ((c.project_id IS NULL AND c.user_id = $1) OR c.project_id IN (`+projectAccessQuery("$1", false)+`))
+		  AND (c.chat_id = (SELECT COALESCE(p.alternative_of, p.chat_id) FROM chat_list b JOIN chat_list p ON p.chat_id = b.parent_chat_id WHERE b.chat_id = $2 LIMIT 1)
+		    OR c.parent_chat_id = $2
+		    OR c.parent_chat_id IN (SELECT chat_id FROM chat_list WHERE alternative_of = $2))
+		ORDER BY c.id
+	`, userID, chatId)
+	return chats, err
+}
+
+func (p *PostgresDAO) GetBranchTree(userID string, chatId string) ([]BranchRow, error) {
+	// walk up to the main branch, then down through every chat branched from it, alternatives included as
+	// branches of alternatives belong to the tree
+	var branches []BranchRow
+	err := p.db.Select(&branches, `
+		WITH RECURSIVE up(chat_id, parent_chat_id) AS (
+			SELECT chat_id, parent_chat_id FROM chat_list WHERE chat_id = $2
+			UNION
+			SELECT p.chat_id, p.parent_chat_id FROM up JOIN chat_list p ON p.chat_id = up.parent_chat_id
+		),
+		tree(chat_id) AS (
+			SELECT chat_id FROM up WHERE parent_chat_id IS NULL OR parent_chat_id = ''
+			UNION
+			SELECT c.chat_id FROM tree JOIN chat_list c ON c.parent_chat_id = tree.chat_id
+		)
+		SELECT `+chatInfoColumns+`, COALESCE(c.parent_message_id, '') AS parent_message_id,
+			(SELECT COUNT(*) FROM chat_messages m WHERE m.chat_id = c.chat_id) AS message_count
+		FROM chat_list c
+		WHERE c.chat_id IN (SELECT chat_id FROM tree) AND c.alternative_of IS NULL AND c.deleted_at IS NULL AND ((c.project_id IS NULL AND c.user_id = $1) OR c.project_id IN (`+projectAccessQuery("$1", false)+`))
+		ORDER BY c.id
+	`, userID, chatId)
+	return branches, err
+}
+
+func (p *PostgresDAO) RenameChat(chatId string, name string) error {
+	result, err := p.db.Exec("UPDATE chat_list SET name = $1, updated_at = CURRENT_TIMESTAMP WHERE chat_id = $2 AND alternative_of IS NULL", name, chatId)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (p *PostgresDAO) TrashChat(chatId string) error {
+	result, err := p.db.Exec(chatSubtreeCTE("$1")+`
+		UPDATE chat_list SET deleted_at = CURRENT_TIMESTAMP
+		WHERE chat_id IN (SELECT chat_id FROM subtree) AND deleted_at IS NULL`, chatId)
 	if err != nil {
 		return err
 	}
+	return expectRowsAffected(result)
+}
 
+func (p *PostgresDAO) RestoreChat(chatId string) error {
+	// branches trashed on their own before stay in the trash
+	result, err := p.db.Exec(chatSubtreeCTE("$1")+`
+		UPDATE chat_list SET deleted_at = NULL
+		WHERE chat_id IN (SELECT chat_id FROM subtree)
+		  AND deleted_at = (SELECT deleted_at FROM chat_list WHERE chat_id = $1)`, chatId)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (p *PostgresDAO) PurgeChat(chatId string) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// deleting the messages removes them from the search index too
+	_, err = tx.Exec(chatSubtreeCTE("$1")+`
+		DELETE FROM chat_messages WHERE chat_id IN (SELECT chat_id FROM subtree)`, chatId)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(chatSubtreeCTE("$1")+`
+		DELETE FROM chat_summaries WHERE chat_id IN (SELECT chat_id FROM subtree)`, chatId)
+	if err != nil {
+		return err
+	}
+	result, err := tx.Exec(chatSubtreeCTE("$1")+`
+		DELETE FROM chat_list WHERE chat_id IN (SELECT chat_id FROM subtree)`, chatId)
+	if err != nil {
+		return err
+	}
+	if err := expectRowsAffected(result); err != nil {
+		return err
+	}
 	return tx.Commit()
 }
 
-func (p *PostgresDAO) GetChatBranches(userID string, chatId string, isMain bool) ([]ChatInfoRow, error) {
-	var chats []ChatInfoRow
-	var err error
+func (p *PostgresDAO) SetChatArchived(chatId string, archived bool) error {
+	result, err := p.db.Exec("UPDATE chat_list SET archived = $1, updated_at = CURRENT_TIMESTAMP WHERE chat_id = $2 AND alternative_of IS NULL", archived, chatId)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
 
-	if isMain {
-		err = p.db.Select(&chats, `SELECT chat_id, name FROM chat_list WHERE parent_chat_id = $1`, chatId)
-	} else {
-		err = p.db.Select(&chats, `
-			SELECT c1.chat_id, c1.name 
-			FROM chat_list c1
-			JOIN chat_list c2 ON c1.chat_id = c2.parent_chat_id
-			WHERE c2.chat_id = $1
-		`, chatId)
+func (p *PostgresDAO) SetChatPinned(chatId string, pinned bool) error {
+	result, err := p.db.Exec("UPDATE chat_list SET pinned = $1, updated_at = CURRENT_TIMESTAMP WHERE chat_id = $2 AND alternative_of IS NULL", pinned, chatId)
+	if err != nil {
+		return err
 	}
+	return expectRowsAffected(result)
+}
 
+func (p *PostgresDAO) MoveChat(chatId string, projectID string) error {
+	result, err := p.db.Exec(chatSubtreeCTE("$1")+`
+		UPDATE chat_list SET project_id = $2, updated_at = CURRENT_TIMESTAMP WHERE chat_id IN (SELECT chat_id FROM subtree)`, chatId, nullIfEmpty(projectID))
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return expectRowsAffected(result)
+}
 
-	return chats, nil
+func (p *PostgresDAO) GetTrash(userID string, projectID string) ([]ChatInfoRow, error) {
+	// a chat trashed along with its parent has the same deleted_at, it comes back when the parent is restored
+	trashed := `c.deleted_at IS NOT NULL AND c.alternative_of IS NULL
+		AND NOT EXISTS (SELECT 1 FROM chat_list p WHERE p.chat_id = c.parent_chat_id AND p.deleted_at = c.deleted_at)`
+
+	var chats []ChatInfoRow
+	var err error
+	if projectID == "" {
+		err = p.db.Select(&chats, "SELECT "+chatInfoColumns+" FROM chat_list c WHERE c.project_id IS NULL AND c.user_id = $1 AND "+trashed+" ORDER BY c.deleted_at DESC", userID)
+	} else {
+		err = p.db.Select(&chats, "SELECT "+chatInfoColumns+" FROM chat_list c WHERE c.project_id = $1 AND c.project_id IN ("+projectAccessQuery("$2", false)+") AND "+trashed+" ORDER BY c.deleted_at DESC", projectID, userID)
+	}
+	return chats, err
 }
 
 // Helper function to convert float64 slice to pgvector string format
@@ -571,3 +1024,121 @@ func (p *PostgresSettingsDAO) SetSettingValue(settingName string, settingValue s
 	}
 	return nil
 }
+
+func (p *PostgresDAO) GetChatSummary(chatId string) (*ChatSummaryRow, error) {
+	var summary ChatSummaryRow
+	err := p.db.Get(&summary, `
+		SELECT chat_id, summary, up_to_message_id, model, input_token_count, output_token_count, cost, updated_at
+		FROM chat_summaries WHERE chat_id = $1`, chatId)
+	if err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+func (p *PostgresDAO) SaveChatSummary(userID string, summary ChatSummaryRow) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO chat_summaries (chat_id, summary, up_to_message_id, model, input_token_count, output_token_count, cost, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP)
+		ON CONFLICT(chat_id) DO UPDATE SET summary = EXCLUDED.summary, up_to_message_id = EXCLUDED.up_to_message_id,
+			model = EXCLUDED.model, input_token_count = EXCLUDED.input_token_count,
+			output_token_count = EXCLUDED.output_token_count, cost = EXCLUDED.cost, updated_at = CURRENT_TIMESTAMP`,
+		summary.ChatID, summary.Summary, summary.UpToMessageID, summary.Model, summary.InputTokens, summary.OutputTokens, summary.Cost)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO usage_ledger (user_id, chat_id, project_id, model, input_token_count, output_token_count, cost)
+		VALUES ($1, $2, (SELECT project_id FROM chat_list WHERE chat_id = $2), $3, $4, $5, $6)`,
+		userID, summary.ChatID, summary.Model, summary.InputTokens, summary.OutputTokens, summary.Cost)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (p *PostgresDAO) SetChatSystemPrompt(chatId string, prompt string) error {
+	result, err := p.db.Exec("UPDATE chat_list SET system_prompt = $1, updated_at = CURRENT_TIMESTAMP WHERE chat_id = $2 AND alternative_of IS NULL", prompt, chatId)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (p *PostgresDAO) SetProjectSystemPrompt(projectID string, prompt string) error {
+	result, err := p.db.Exec("UPDATE project SET system_prompt = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2", prompt, projectID)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (p *PostgresDAO) GetSystemPrompt(chatId string) (string, error) {
+	var prompt string
+	err := p.db.Get(&prompt, `
+		SELECT COALESCE(NULLIF(c.system_prompt, ''), p.system_prompt, '')
+		FROM chat_list c LEFT JOIN project p ON p.id = c.project_id
+		WHERE c.chat_id = $1`, chatId)
+	return prompt, err
+}
+
+func (p *PostgresDAO) CreatePromptTemplate(template PromptTemplateRow) error {
+	_, err := p.db.Exec(`
+		INSERT INTO prompt_templates (id, user_id, project_id, name, description, content, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		template.ID, template.UserID, nullIfEmpty(template.ProjectID), template.Name, template.Description, template.Content, template.CreatedAt)
+	return err
+}
+
+func (p *PostgresDAO) GetPromptTemplate(userID string, templateID string) (*PromptTemplateRow, error) {
+	var template PromptTemplateRow
+	err := p.db.Get(&template, "SELECT "+promptTemplateColumns+` FROM prompt_templates
+		WHERE id = $1 AND ((project_id IS NULL AND user_id = $2) OR project_id IN (`+projectAccessQuery("$2", false)+`))`, templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (p *PostgresDAO) GetPromptTemplates(userID string, projectID string) ([]PromptTemplateRow, error) {
+	var templates []PromptTemplateRow
+	err := p.db.Select(&templates, "SELECT "+promptTemplateColumns+` FROM prompt_templates
+		WHERE (project_id IS NULL AND user_id = $1) OR project_id = $2
+		ORDER BY name, created_at`, userID, projectID)
+	return templates, err
+}
+
+func (p *PostgresDAO) SetChatGenerationOptions(chatId string, options string) error {
+	result, err := p.db.Exec("UPDATE chat_list SET generation_options = $1, updated_at = CURRENT_TIMESTAMP WHERE chat_id = $2 AND alternative_of IS NULL", options, chatId)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (p *PostgresDAO) SetProjectGenerationOptions(projectID string, options string) error {
+	result, err := p.db.Exec("UPDATE project SET generation_options = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2", options, projectID)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (p *PostgresDAO) GetGenerationDefaults(chatId string) (string, string, error) {
+	var defaults struct {
+		Chat    string `db:"chat_options"`
+		Project string `db:"project_options"`
+	}
+	err := p.db.Get(&defaults, `
+		SELECT c.generation_options AS chat_options, COALESCE(p.generation_options, '') AS project_options
+		FROM chat_list c LEFT JOIN project p ON p.id = c.project_id
+		WHERE c.chat_id = $1`, chatId)
+	return defaults.Chat, defaults.Project, err
+}
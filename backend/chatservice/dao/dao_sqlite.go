@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"log"
 	proto "sortedstartup/chatservice/proto"
+	"strconv"
+	"time"
 
 	// sqlite_vec "github.com/asg017/sqlite-vec-go-bindings/cgo"
 
@@ -41,21 +43,12 @@ func (s *SQLiteDAO) CreateChat(userID string, chatId string, name string, projec
 	}
 }
 
-func (s *SQLiteDAO) GetChatName(userID string, chatId string) (string, error) {
-	var name string
-	err := s.db.Get(&name, "SELECT name FROM chat_list WHERE chat_id = ? AND user_id = ?", chatId, userID)
+func (s *SQLiteDAO) SaveChatName(chatId string, name string) error {
+	result, err := s.db.Exec("UPDATE chat_list SET name = ? WHERE chat_id = ?", name, chatId)
 	if err != nil {
-		return "", fmt.Errorf("failed to get chat name: %w", err)
+		return fmt.Errorf("failed to save chat name: %w", err)
 	}
-	return name, nil
-}
-
-func (s *SQLiteDAO) SaveChatName(userID string, chatId string, name string) error {
-	_, err := s.db.Exec("UPDATE chat_list SET name = ? WHERE chat_id = ? AND user_id = ?", name, chatId, userID)
-	if err != nil {
-		return fmt.Errorf("failed to get chat name: %w", err)
-	}
-	return nil
+	return expectRowsAffected(result)
 }
 
 // AddChatMessage adds a message to a chat
@@ -64,76 +57,370 @@ func (s *SQLiteDAO) AddChatMessage(userID string, chatId string, role string, co
 	return err
 }
 
-// GetChatMessages retrieves all messages for a given chat
+// GetChatMessages retrieves all messages for a given chat, chats of shared projects include the messages of all members.
+// Branches and alternatives walk up their parents, each parent contributes its messages up to the branch point.
 func (s *SQLiteDAO) GetChatMessages(userID string, chatId string) ([]ChatMessageRow, error) {
-	// todo : do we need to order by time?
 	var messages []ChatMessageRow
-	err := s.db.Select(&messages, "SELECT role, content, id FROM chat_messages WHERE chat_id = ? AND user_id = ?", chatId, userID)
+	err := s.db.Select(&messages, `
+		`+chatPathCTE("?", "INTEGER")+`
+		SELECT m.role, m.content, m.id, COALESCE(m.model, '') AS model, COALESCE(m.error, 0) AS interrupted,
+			COALESCE(m.generation_options, '') AS generation_options
+		FROM chat_messages m JOIN chain ON m.chat_id = chain.chat_id
+		WHERE (chain.upto IS NULL OR m.id <= chain.upto)
+		  AND ((m.user_id = ? AND m.chat_id IN (SELECT chat_id FROM chat_list WHERE project_id IS NULL)) OR m.chat_id IN (SELECT chat_id FROM chat_list WHERE project_id IN (`+projectAccessQuery("?", false)+`)))
+		ORDER BY m.id
+	`, chatId, chatId, userID, userID, userID)
 	return messages, err
 }
 
-// GetChatList retrieves all chats for a user
-func (s *SQLiteDAO) GetChatList(userID string, projectID string) ([]*proto.ChatInfo, error) {
-	var chats []ChatInfoRow
-	var err error
+// GetChatMessagePage is GetChatMessages for one page of the messages, in id order
+func (s *SQLiteDAO) GetChatMessagePage(userID string, chatId string, page MessagePage) ([]ChatMessageRow, error) {
+	query := `
+		` + chatPathCTE("?", "INTEGER") + `
+		SELECT m.role, m.content, m.id, COALESCE(m.model, '') AS model, COALESCE(m.error, 0) AS interrupted,
+			COALESCE(m.generation_options, '') AS generation_options
+		FROM chat_messages m JOIN chain ON m.chat_id = chain.chat_id
+		WHERE (chain.upto IS NULL OR m.id <= chain.upto)
+		  AND ((m.user_id = ? AND m.chat_id IN (SELECT chat_id FROM chat_list WHERE project_id IS NULL)) OR m.chat_id IN (SELECT chat_id FROM chat_list WHERE project_id IN (` + projectAccessQuery("?", false) + `)))`
+	args := []interface{}{chatId, chatId, userID, userID, userID}
+
+	cmp, dir := ">", "ASC"
+	if page.Descending {
+		cmp, dir = "<", "DESC"
+	}
+	if page.After > 0 {
+		query += " AND m.id " + cmp + " ?"
+		args = append(args, page.After)
+	}
+	query += " ORDER BY m.id " + dir
+	if page.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, page.Limit)
+	}
 
-	if projectID == "" || projectID == "null" {
-		err = s.db.Select(&chats, "SELECT chat_id, name FROM chat_list WHERE project_id IS NULL AND user_id = ?", userID)
-	} else {
-		err = s.db.Select(&chats, "SELECT chat_id, name FROM chat_list WHERE project_id = ? AND user_id = ?", projectID, userID)
+	var messages []ChatMessageRow
+	err := s.db.Select(&messages, query, args...)
+	return messages, err
+}
+
+func (s *SQLiteDAO) GetChatRole(userID string, chatId string) (string, error) {
+	var role string
+	err := s.db.Get(&role, `
+		SELECT COALESCE(m.role, '`+WorkspaceRoleOwner+`')
+		FROM chat_list c
+		LEFT JOIN project p ON p.id = c.project_id
+		LEFT JOIN workspace_members m ON m.workspace_id = p.workspace_id AND m.user_id = ?
+		WHERE c.chat_id = ? AND ((p.workspace_id IS NULL AND (c.user_id = ? OR p.user_id = ?)) OR m.user_id IS NOT NULL)
+	`, userID, chatId, userID, userID)
+	return role, err
+}
+
+func (s *SQLiteDAO) ChatExists(chatId string) (bool, error) {
+	var exists bool
+	err := s.db.Get(&exists, "SELECT EXISTS (SELECT 1 FROM chat_list WHERE chat_id = ?)", chatId)
+	return exists, err
+}
+
+// GetChatList retrieves a page of the chats of a user or project
+func (s *SQLiteDAO) GetChatList(userID string, q ChatListQuery) ([]ChatListRow, error) {
+	// numbered parameters, the query refers to the user id more than once
+	query, args, err := chatListQuery(func(n int) string { return "?" + strconv.Itoa(n) }, "TEXT", userID, q)
+	if err != nil {
+		return nil, err
+	}
+
+	var chats []ChatListRow
+	err = s.db.Select(&chats, query, args...)
+	return chats, err
+}
+
+func (s *SQLiteDAO) AddChatMessageWithTokens(userID string, chatId string, role string, content string, model string, inputTokens int, outputTokens int, cost float64, generationOptions string, interrupted bool) (int64, error) {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return 0, err
 	}
+	defer tx.Rollback()
 
+	result, err := tx.Exec(`
+		INSERT INTO chat_messages (chat_id, role, content, model, input_token_count, output_token_count, cost, user_id, error, generation_options)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		chatId, role, content, model, inputTokens, outputTokens, cost, userID, interrupted, nullIfEmpty(generationOptions))
+	if err != nil {
+		return 0, err
+	}
+	messageId, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	if role == "assistant" {
+		_, err = tx.Exec(`
+			INSERT INTO usage_ledger (user_id, chat_id, project_id, message_id, model, input_token_count, output_token_count, cost)
+			VALUES (?, ?, (SELECT project_id FROM chat_list WHERE chat_id = ?), ?, ?, ?, ?, ?)`,
+			userID, chatId, chatId, messageId, model, inputTokens, outputTokens, cost)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return messageId, tx.Commit()
+}
+
+// AddUsage records a completion without a message in the usage ledger, see DAO.AddUsage
+func (s *SQLiteDAO) AddUsage(userID string, chatId string, model string, inputTokens int, outputTokens int, cost float64) error {
+	_, err := s.db.Exec(`
+		INSERT INTO usage_ledger (user_id, chat_id, project_id, model, input_token_count, output_token_count, cost)
+		VALUES (?, ?, (SELECT project_id FROM chat_list WHERE chat_id = ?), ?, ?, ?, ?)`,
+		userID, chatId, chatId, model, inputTokens, outputTokens, cost)
+	return err
+}
+
+// GetModels retrieves the model catalog, disabled models are skipped unless includeDisabled is set
+func (s *SQLiteDAO) GetModels(includeDisabled bool) ([]ModelRow, error) {
+	query := "SELECT " + modelColumns + " FROM model_metadata"
+	if !includeDisabled {
+		query += " WHERE enabled"
+	}
+	query += " ORDER BY provider, name"
+
+	var models []ModelRow
+	err := s.db.Select(&models, query)
 	if err != nil {
 		return nil, err
 	}
+	return models, nil
+}
 
-	var result []*proto.ChatInfo
-	for _, c := range chats {
-		result = append(result, &proto.ChatInfo{
-			ChatId: c.Id,
-			Name:   c.Name,
-		})
+// GetModel retrieves the metadata of a single model
+func (s *SQLiteDAO) GetModel(modelID string) (*ModelRow, error) {
+	var model ModelRow
+	err := s.db.Get(&model, "SELECT "+modelColumns+" FROM model_metadata WHERE id = ?", modelID)
+	if err != nil {
+		return nil, err
 	}
-	return result, nil
+	return &model, nil
 }
 
-func (s *SQLiteDAO) AddChatMessageWithTokens(userID string, chatId string, role string, content string, model string, inputTokens int, outputTokens int) (int64, error) {
+// CreateModel adds a model to the catalog
+func (s *SQLiteDAO) CreateModel(model ModelRow) error {
+	_, err := s.db.Exec(`
+		INSERT INTO model_metadata (id, name, url, provider, input_token_cost, output_token_cost,
+			supports_vision, supports_tools, context_window, supports_reasoning, max_output_tokens, provider_model_id, enabled)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		model.ID, model.Name, model.URL, model.Provider, model.InputTokenCost, model.OutputTokenCost,
+		model.SupportsVision, model.SupportsTools, model.ContextWindow, model.SupportsReasoning, model.MaxOutputTokens, model.ProviderModelID, model.Enabled)
+	return err
+}
+
+// UpdateModel replaces all fields of the model with the given id
+func (s *SQLiteDAO) UpdateModel(model ModelRow) error {
 	result, err := s.db.Exec(`
-		INSERT INTO chat_messages (chat_id, role, content, model, input_token_count, output_token_count, user_id)
-		VALUES (?, ?, ?, ?, ?, ?, ?)`,
-		chatId, role, content, model, inputTokens, outputTokens, userID)
+		UPDATE model_metadata
+		SET name = ?, url = ?, provider = ?, input_token_cost = ?, output_token_cost = ?,
+			supports_vision = ?, supports_tools = ?, context_window = ?, supports_reasoning = ?, max_output_tokens = ?,
+			provider_model_id = ?, enabled = ?
+		WHERE id = ?`,
+		model.Name, model.URL, model.Provider, model.InputTokenCost, model.OutputTokenCost,
+		model.SupportsVision, model.SupportsTools, model.ContextWindow, model.SupportsReasoning, model.MaxOutputTokens, model.ProviderModelID, model.Enabled, model.ID)
 	if err != nil {
-		return 0, err
+		return err
 	}
+	return expectRowsAffected(result)
+}
 
-	messageId, err := result.LastInsertId()
-	return messageId, err
+// DeleteModel removes the model from the catalog, messages keep the model id they were generated with
+func (s *SQLiteDAO) DeleteModel(modelID string) error {
+	result, err := s.db.Exec("DELETE FROM model_metadata WHERE id = ?", modelID)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
 }
 
-// GetModels retrieves all available models
-func (s *SQLiteDAO) GetModels() ([]proto.ModelListInfo, error) {
-	var models []struct {
-		ID   string `db:"id"`
-		Name string `db:"name"`
+// GetUsage aggregates the tokens and cost in the usage ledger, see DAO.GetUsage
+func (s *SQLiteDAO) GetUsage(userID string, groupBy UsageGroupBy, from time.Time, to time.Time, projectID string) ([]UsageRow, error) {
+	query, err := usageQuery(groupBy, "date(ul.created_at)", func(int) string { return "?" }, projectID != "")
+	if err != nil {
+		return nil, err
+	}
+
+	// created_at is stored as UTC text by CURRENT_TIMESTAMP, compare in the same format
+	const layout = "2006-01-02 15:04:05"
+	args := []interface{}{userID, from.UTC().Format(layout), to.UTC().Format(layout)}
+	if projectID != "" {
+		args = append(args, projectID)
 	}
-	err := s.db.Select(&models, "SELECT id, name FROM model_metadata")
+
+	var rows []UsageRow
+	if err := s.db.Select(&rows, query, args...); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// GetSpend sums the cost in the usage ledger, see DAO.GetSpend
+func (s *SQLiteDAO) GetSpend(since time.Time, userID string, projectID string) (float64, error) {
+	args := []interface{}{since.UTC().Format("2006-01-02 15:04:05")}
+	if userID != "" {
+		args = append(args, userID)
+	}
+	if projectID != "" {
+		args = append(args, projectID)
+	}
+
+	var spent float64
+	err := s.db.Get(&spent, spendQuery(func(int) string { return "?" }, userID, projectID), args...)
+	return spent, err
+}
+
+// CreateUser adds an account, the id is chosen by the caller
+func (s *SQLiteDAO) CreateUser(user UserRow) error {
+	_, err := s.db.Exec("INSERT INTO users (id, username, password_hash, is_admin) VALUES (?, ?, ?, ?)", user.ID, user.Username, user.PasswordHash, user.IsAdmin)
+	return err
+}
+
+func (s *SQLiteDAO) GetUserByUsername(username string) (*UserRow, error) {
+	var user UserRow
+	err := s.db.Get(&user, "SELECT id, username, password_hash, is_admin, created_at FROM users WHERE username = ?", username)
 	if err != nil {
 		return nil, err
 	}
+	return &user, nil
+}
 
-	var result []proto.ModelListInfo
-	for _, m := range models {
-		result = append(result, proto.ModelListInfo{
-			Id:    m.ID,
-			Label: m.Name,
-		})
+func (s *SQLiteDAO) GetUserByID(userID string) (*UserRow, error) {
+	var user UserRow
+	err := s.db.Get(&user, "SELECT id, username, password_hash, is_admin, created_at FROM users WHERE id = ?", userID)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (s *SQLiteDAO) CountUsers() (int, error) {
+	var count int
+	err := s.db.Get(&count, "SELECT COUNT(*) FROM users")
+	return count, err
+}
+
+func (s *SQLiteDAO) CreateApiToken(token ApiTokenRow) error {
+	_, err := s.db.Exec(`
+		INSERT INTO api_tokens (id, user_id, name, token_hash, token_prefix, scope, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, token.ID, token.UserID, token.Name, token.TokenHash, token.TokenPrefix, token.Scope, token.ExpiresAt)
+	return err
+}
+
+func (s *SQLiteDAO) GetApiTokenByHash(tokenHash string) (*ApiTokenRow, error) {
+	var token ApiTokenRow
+	err := s.db.Get(&token, "SELECT "+apiTokenColumns+" FROM api_tokens WHERE token_hash = ?", tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (s *SQLiteDAO) ListApiTokens(userID string) ([]ApiTokenRow, error) {
+	var tokens []ApiTokenRow
+	err := s.db.Select(&tokens, "SELECT "+apiTokenColumns+" FROM api_tokens WHERE user_id = ? ORDER BY created_at DESC", userID)
+	return tokens, err
+}
+
+func (s *SQLiteDAO) DeleteApiToken(userID string, tokenID string) error {
+	result, err := s.db.Exec("DELETE FROM api_tokens WHERE id = ? AND user_id = ?", tokenID, userID)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (s *SQLiteDAO) TouchApiToken(tokenID string, usedAt time.Time) error {
+	_, err := s.db.Exec("UPDATE api_tokens SET last_used_at = ? WHERE id = ?", usedAt, tokenID)
+	return err
+}
+
+func (s *SQLiteDAO) GetProjectRole(userID string, projectID string) (string, error) {
+	var role string
+	err := s.db.Get(&role, `
+		SELECT COALESCE(m.role, '`+WorkspaceRoleOwner+`')
+		FROM project p
+		LEFT JOIN workspace_members m ON m.workspace_id = p.workspace_id AND m.user_id = ?
+		WHERE p.id = ? AND ((p.workspace_id IS NULL AND p.user_id = ?) OR m.user_id IS NOT NULL)
+	`, userID, projectID, userID)
+	return role, err
+}
+
+func (s *SQLiteDAO) CreateWorkspace(workspace WorkspaceRow, ownerID string) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("INSERT INTO workspaces (id, name, created_by) VALUES (?, ?, ?)", workspace.ID, workspace.Name, ownerID)
+	if err != nil {
+		return err
 	}
-	return result, nil
+	_, err = tx.Exec("INSERT INTO workspace_members (workspace_id, user_id, role) VALUES (?, ?, ?)", workspace.ID, ownerID, WorkspaceRoleOwner)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *SQLiteDAO) GetWorkspaces(userID string) ([]WorkspaceRow, error) {
+	var workspaces []WorkspaceRow
+	err := s.db.Select(&workspaces, `
+		SELECT w.id, w.name, w.created_by, w.created_at, m.role
+		FROM workspaces w
+		JOIN workspace_members m ON m.workspace_id = w.id
+		WHERE m.user_id = ?
+		ORDER BY w.name
+	`, userID)
+	return workspaces, err
+}
+
+func (s *SQLiteDAO) GetWorkspaceRole(userID string, workspaceID string) (string, error) {
+	var role string
+	err := s.db.Get(&role, "SELECT role FROM workspace_members WHERE workspace_id = ? AND user_id = ?", workspaceID, userID)
+	return role, err
+}
+
+func (s *SQLiteDAO) GetWorkspaceMembers(workspaceID string) ([]WorkspaceMemberRow, error) {
+	var members []WorkspaceMemberRow
+	err := s.db.Select(&members, `
+		SELECT m.workspace_id, m.user_id, COALESCE(u.username, '') AS username, m.role, m.created_at
+		FROM workspace_members m
+		LEFT JOIN users u ON u.id = m.user_id
+		WHERE m.workspace_id = ?
+		ORDER BY m.created_at
+	`, workspaceID)
+	return members, err
+}
+
+func (s *SQLiteDAO) AddWorkspaceMember(workspaceID string, userID string, role string) error {
+	_, err := s.db.Exec("INSERT INTO workspace_members (workspace_id, user_id, role) VALUES (?, ?, ?)", workspaceID, userID, role)
+	return err
+}
+
+func (s *SQLiteDAO) UpdateWorkspaceMember(workspaceID string, userID string, role string) error {
+	result, err := s.db.Exec("UPDATE workspace_members SET role = ? WHERE workspace_id = ? AND user_id = ?", role, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (s *SQLiteDAO) RemoveWorkspaceMember(workspaceID string, userID string) error {
+	result, err := s.db.Exec("DELETE FROM workspace_members WHERE workspace_id = ? AND user_id = ?", workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
 }
 
 // SearchChatMessages searches chat messages using FTS
 func (s *SQLiteDAO) SearchChatMessages(userID string, query string) ([]proto.SearchResult, error) {
-	const searchSQL = `
+	searchSQL := `
         SELECT
             cm.chat_id as chat_id,
             cl.name AS chat_name,
@@ -151,7 +438,8 @@ func (s *SQLiteDAO) SearchChatMessages(userID string, query string) ([]proto.Sea
         JOIN
             chat_list AS cl ON cm.chat_id = cl.chat_id
         WHERE
-            fts.chat_messages_fts MATCH ? AND cm.user_id = ? AND cl.user_id = ?
+            fts.chat_messages_fts MATCH ? AND cm.user_id = ? AND cl.user_id = ? AND cl.deleted_at IS NULL
+            AND (cl.project_id IS NULL OR cl.project_id IN (` + projectAccessQuery("?", false) + `))
         GROUP BY
             cm.chat_id, cl.name
         ORDER BY
@@ -164,7 +452,7 @@ func (s *SQLiteDAO) SearchChatMessages(userID string, query string) ([]proto.Sea
 		MatchedText string `db:"aggregated_snippets"`
 	}
 
-	err := s.db.Select(&rows, searchSQL, query, userID, userID)
+	err := s.db.Select(&rows, searchSQL, query, userID, userID, userID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -182,21 +470,24 @@ func (s *SQLiteDAO) SearchChatMessages(userID string, query string) ([]proto.Sea
 }
 
 // Project CRUD
-func (s *SQLiteDAO) CreateProject(userID string, id string, name string, description string, additionalData string) (string, error) {
+func (s *SQLiteDAO) CreateProject(userID string, id string, name string, description string, additionalData string, systemPrompt string, workspaceID string) (string, error) {
 	_, err := s.db.Exec(`
-		INSERT INTO project (id, name, description, additional_data, user_id, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
-	`, id, name, description, additionalData, userID)
+		INSERT INTO project (id, name, description, additional_data, system_prompt, user_id, workspace_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, id, name, description, additionalData, systemPrompt, userID, nullIfEmpty(workspaceID))
 	if err != nil {
 		return "", err
 	}
 	return id, nil
 }
 
-// GetProjectList retrieves all projects for a user
+// GetProjectList retrieves the user's own projects and the projects of the user's workspaces
 func (s *SQLiteDAO) GetProjects(userID string) ([]ProjectRow, error) {
 	var projects []ProjectRow
-	err := s.db.Select(&projects, `SELECT id, name, description, additional_data, created_at, updated_at FROM project WHERE user_id = ?`, userID)
+	err := s.db.Select(&projects, `
+		SELECT id, COALESCE(workspace_id, '') AS workspace_id, name, description, additional_data, system_prompt, generation_options, created_at, updated_at
+		FROM project WHERE id IN (`+projectAccessQuery("?", false)+`)
+	`, userID, userID)
 	return projects, err
 }
 
@@ -213,7 +504,7 @@ func (s *SQLiteDAO) UpdateEmbeddingStatus(docs_id string, status int32) error {
 
 func (s *SQLiteDAO) FetchErrorDocs(userID string, project_id string) ([]string, error) {
 	var docs_list []string
-	err := s.db.Select(&docs_list, "SELECT docs_id FROM project_docs WHERE project_id = ? AND embedding_status = ? AND user_id = ?", project_id, int32(proto.Embedding_Status_STATUS_ERROR), userID)
+	err := s.db.Select(&docs_list, "SELECT docs_id FROM project_docs WHERE project_id = ? AND embedding_status = ? AND project_id IN ("+projectAccessQuery("?", true)+")", project_id, int32(proto.Embedding_Status_STATUS_ERROR), userID, userID)
 	if err != nil {
 		fmt.Print("fetchErrorDocs dao", err)
 		return nil, fmt.Errorf("failed to check embedding status: %w", err)
@@ -227,8 +518,8 @@ func (s *SQLiteDAO) TotalUsedSize(userID string, projectID string) (int64, error
 	err := s.db.Get(&total, `
 		SELECT COALESCE(SUM(file_size), 0)
 		FROM project_docs
-		WHERE project_id = ? AND user_id = ?
-	`, projectID, userID)
+		WHERE project_id = ? AND project_id IN (`+projectAccessQuery("?", false)+`)
+	`, projectID, userID, userID)
 	return total, err
 }
 
@@ -237,8 +528,8 @@ func (s *SQLiteDAO) FilesList(userID string, project_id string) ([]DocumentListR
 	err := s.db.Select(&files, `
 		SELECT id, project_id, docs_id, file_name, created_at, updated_at,embedding_status
 		FROM project_docs
-		WHERE project_id = ? AND user_id = ?
-	`, project_id, userID)
+		WHERE project_id = ? AND project_id IN (`+projectAccessQuery("?", false)+`)
+	`, project_id, userID, userID)
 	return files, err
 }
 
@@ -251,6 +542,19 @@ func (s *SQLiteDAO) GetFileMetadata(docsId string) (*DocumentListRow, error) {
 	return &doc, nil
 }
 
+func (s *SQLiteDAO) GetDocument(userID string, docsID string) (*DocumentListRow, error) {
+	var doc DocumentListRow
+	err := s.db.Get(&doc, `
+		SELECT d.id, d.project_id, d.docs_id, d.file_name, d.file_size, d.created_at, d.updated_at, d.embedding_status, d.user_id
+		FROM project_docs d
+		WHERE d.docs_id = ? AND d.project_id IN (`+projectAccessQuery("?", false)+`)
+	`, docsID, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
 // SaveRAGChunk saves a chunk to rag_chunks table
 func (s *SQLiteDAO) SaveRAGChunk(userID string, chunkID, projectID, docsID string, startByte, endByte int) error {
 	_, err := s.db.Exec(`
@@ -275,7 +579,7 @@ func (s *SQLiteDAO) GetTopSimilarRAGChunks(userID string, embedding string, proj
 	err := s.db.Select(&chunks, `
         SELECT id,project_id,docs_id,start_byte,end_byte
         FROM rag_chunks
-        WHERE project_id = ? AND user_id = ?
+        WHERE project_id = ? AND project_id IN (`+projectAccessQuery("?", false)+`)
         AND id IN (
             SELECT id
             FROM rag_chunks_vec
@@ -283,59 +587,222 @@ func (s *SQLiteDAO) GetTopSimilarRAGChunks(userID string, embedding string, proj
             ORDER BY distance
             LIMIT 2
         )
-    `, projectID, userID, embedding)
+    `, projectID, userID, userID, embedding)
 	return chunks, err
 }
 
-func (s *SQLiteDAO) IsMainBranch(userID string, source_chat_id string) (bool, error) {
-	var isMainBranch bool
-	err := s.db.Get(&isMainBranch, `SELECT is_main_branch FROM chat_list WHERE chat_id = ? AND user_id = ?`, source_chat_id, userID)
-	return isMainBranch, err
+func (s *SQLiteDAO) GetChat(userID string, chatId string) (*ChatRow, error) {
+	var chat ChatRow
+	err := s.db.Get(&chat, "SELECT "+chatColumns+" FROM chat_list WHERE chat_id = ? AND ((project_id IS NULL AND user_id = ?) OR project_id IN ("+projectAccessQuery("?", false)+"))", chatId, userID, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &chat, nil
+}
+
+func (s *SQLiteDAO) CreateAlternative(userID string, chat ChatRow) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO chat_list (chat_id, name, project_id, parent_chat_id, parent_message_id, is_main_branch, alternative_of, user_id)
+		VALUES (?, ?, ?, ?, ?, FALSE, ?, ?)`,
+		chat.ChatID, chat.Name, nullIfEmpty(chat.ProjectID), chat.ParentChatID, chat.ParentMessageID, chat.AlternativeOf, userID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("UPDATE chat_list SET active_chat_id = ? WHERE chat_id = ?", chat.ChatID, chat.AlternativeOf)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *SQLiteDAO) SetActiveChat(chatId string, activeChatId string) error {
+	result, err := s.db.Exec("UPDATE chat_list SET active_chat_id = ? WHERE chat_id = ?", nullIfEmpty(activeChatId), chatId)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (s *SQLiteDAO) GetAlternativeCounts(chatId string) (map[string]int, error) {
+	var rows []struct {
+		ParentMessageID string `db:"parent_message_id"`
+		Count           int    `db:"count"`
+	}
+	err := s.db.Select(&rows, `
+		SELECT parent_message_id, COUNT(*) AS count
+		FROM chat_list c
+		WHERE alternative_of = ? AND EXISTS (SELECT 1 FROM chat_messages m WHERE m.chat_id = c.chat_id)
+		GROUP BY parent_message_id
+	`, chatId)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.ParentMessageID] = row.Count
+	}
+	return counts, nil
 }
 
 func (s *SQLiteDAO) BranchChat(userID string, source_chat_id string, parent_message_id string, new_chat_id string, branch_name string) error {
 	// Use CTE to find project_id from source chat and insert the new branch chat
-	_, err := s.db.Exec(`WITH source_chat AS (
-							SELECT project_id 
+	result, err := s.db.Exec(`WITH source_chat AS (
+							SELECT project_id, system_prompt
 							FROM chat_list 
-							WHERE chat_id = ? AND user_id = ?
+							WHERE chat_id = ? AND ((project_id IS NULL AND user_id = ?) OR project_id IN (`+projectAccessQuery("?", false)+`))
 						)
-						INSERT INTO chat_list (chat_id, name, project_id, parent_chat_id, parent_message_id, is_main_branch, user_id)
-						SELECT ?, ?, COALESCE(source_chat.project_id, NULL), ?, ?, FALSE, ?
-						FROM source_chat`, source_chat_id, userID, new_chat_id, branch_name, source_chat_id, parent_message_id, userID)
+						INSERT INTO chat_list (chat_id, name, project_id, parent_chat_id, parent_message_id, is_main_branch, user_id, system_prompt)
+						SELECT ?, ?, COALESCE(source_chat.project_id, NULL), ?, ?, FALSE, ?, source_chat.system_prompt
+						FROM source_chat`, source_chat_id, userID, userID, userID, new_chat_id, branch_name, source_chat_id, parent_message_id, userID)
 	if err != nil {
 		return err
 	}
-
-	//copy messages up to branch point
-	_, err = s.db.Exec(`INSERT INTO chat_messages (chat_id, role, content, model, error, input_token_count, output_token_count, created_at, user_id)
-						SELECT ?, role, content, model, error, input_token_count, output_token_count, created_at, ?
-						FROM chat_messages 
-						WHERE chat_id = ? AND id <= ? AND user_id = ?
-						ORDER BY id;`, new_chat_id, userID, source_chat_id, parent_message_id, userID)
-	return err
+	return expectRowsAffected(result)
 }
 
-func (s *SQLiteDAO) GetChatBranches(userID string, chatId string, isMain bool) ([]ChatInfoRow, error) {
+func (s *SQLiteDAO) GetChatBranches(userID string, chatId string) ([]ChatInfoRow, error) {
+	// branches of the chat's alternatives are branches of the chat
 	var chats []ChatInfoRow
-	var err error
+	err := s.db.Select(&chats, `
+		SELECT `+chatInfoColumns+`
+		FROM chat_list c
+		WHERE c.alternative_of IS NULL AND c.deleted_at IS NULL AND ((c.project_id IS NULL AND c.user_id = ?) OR c.project_id IN (`+projectAccessQuery("?", false)+`))
+		  AND (c.chat_id = (SELECT COALESCE(p.alternative_of, p.chat_id) FROM chat_list b JOIN chat_list p ON p.chat_id = b.parent_chat_id WHERE b.chat_id = ? LIMIT 1)
+		    OR c.parent_chat_id = ?
+		    OR c.parent_chat_id IN (SELECT chat_id FROM chat_list WHERE alternative_of = ?))
+		ORDER BY c.id
+	`, userID, userID, userID, chatId, chatId, chatId)
+	return chats, err
+}
 
-	if isMain {
-		err = s.db.Select(&chats, `SELECT chat_id, name FROM chat_list WHERE parent_chat_id = ?`, chatId)
-	} else {
-		err = s.db.Select(&chats, `
-			SELECT c1.chat_id, c1.name 
-			FROM chat_list c1
-			JOIN chat_list c2 ON c1.chat_id = c2.parent_chat_id
-			WHERE c2.chat_id = ?
-		`, chatId)
+func (s *SQLiteDAO) GetBranchTree(userID string, chatId string) ([]BranchRow, error) {
+	// walk up to the main branch, then down through every chat branched from it, alternatives included as
+	// branches of alternatives belong to the tree
+	var branches []BranchRow
+	err := s.db.Select(&branches, `
+		WITH RECURSIVE up(chat_id, parent_chat_id) AS (
+			SELECT chat_id, parent_chat_id FROM chat_list WHERE chat_id = ?
+			UNION
+			SELECT p.chat_id, p.parent_chat_id FROM up JOIN chat_list p ON p.chat_id = up.parent_chat_id
+		),
+		tree(chat_id) AS (
+			SELECT chat_id FROM up WHERE parent_chat_id IS NULL OR parent_chat_id = ''
+			UNION
+			SELECT c.chat_id FROM tree JOIN chat_list c ON c.parent_chat_id = tree.chat_id
+		)
+		SELECT `+chatInfoColumns+`, COALESCE(c.parent_message_id, '') AS parent_message_id,
+			(SELECT COUNT(*) FROM chat_messages m WHERE m.chat_id = c.chat_id) AS message_count
+		FROM chat_list c
+		WHERE c.chat_id IN (SELECT chat_id FROM tree) AND c.alternative_of IS NULL AND c.deleted_at IS NULL AND ((c.project_id IS NULL AND c.user_id = ?) OR c.project_id IN (`+projectAccessQuery("?", false)+`))
+		ORDER BY c.id
+	`, chatId, userID, userID, userID)
+	return branches, err
+}
+
+func (s *SQLiteDAO) RenameChat(chatId string, name string) error {
+	result, err := s.db.Exec("UPDATE chat_list SET name = ?, updated_at = CURRENT_TIMESTAMP WHERE chat_id = ? AND alternative_of IS NULL", name, chatId)
+	if err != nil {
+		return err
 	}
+	return expectRowsAffected(result)
+}
 
+func (s *SQLiteDAO) TrashChat(chatId string) error {
+	result, err := s.db.Exec(chatSubtreeCTE("?")+`
+		UPDATE chat_list SET deleted_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+		WHERE chat_id IN (SELECT chat_id FROM subtree) AND deleted_at IS NULL`, chatId)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (s *SQLiteDAO) RestoreChat(chatId string) error {
+	// branches trashed on their own before stay in the trash
+	result, err := s.db.Exec(chatSubtreeCTE("?")+`
+		UPDATE chat_list SET deleted_at = NULL
+		WHERE chat_id IN (SELECT chat_id FROM subtree)
+		  AND deleted_at = (SELECT deleted_at FROM chat_list WHERE chat_id = ?)`, chatId, chatId)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (s *SQLiteDAO) PurgeChat(chatId string) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// deleting the messages removes them from the search index too
+	_, err = tx.Exec(chatSubtreeCTE("?")+`
+		DELETE FROM chat_messages WHERE chat_id IN (SELECT chat_id FROM subtree)`, chatId)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(chatSubtreeCTE("?")+`
+		DELETE FROM chat_summaries WHERE chat_id IN (SELECT chat_id FROM subtree)`, chatId)
+	if err != nil {
+		return err
+	}
+	result, err := tx.Exec(chatSubtreeCTE("?")+`
+		DELETE FROM chat_list WHERE chat_id IN (SELECT chat_id FROM subtree)`, chatId)
+	if err != nil {
+		return err
+	}
+	if err := expectRowsAffected(result); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *SQLiteDAO) SetChatArchived(chatId string, archived bool) error {
+	result, err := s.db.Exec("UPDATE chat_list SET archived = ?, updated_at = CURRENT_TIMESTAMP WHERE chat_id = ? AND alternative_of IS NULL", archived, chatId)
+	if err != nil {
+		return err
 	}
+	return expectRowsAffected(result)
+}
+
+func (s *SQLiteDAO) SetChatPinned(chatId string, pinned bool) error {
+	result, err := s.db.Exec("UPDATE chat_list SET pinned = ?, updated_at = CURRENT_TIMESTAMP WHERE chat_id = ? AND alternative_of IS NULL", pinned, chatId)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (s *SQLiteDAO) MoveChat(chatId string, projectID string) error {
+	result, err := s.db.Exec(chatSubtreeCTE("?")+`
+		UPDATE chat_list SET project_id = ?, updated_at = CURRENT_TIMESTAMP WHERE chat_id IN (SELECT chat_id FROM subtree)`, chatId, nullIfEmpty(projectID))
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (s *SQLiteDAO) GetTrash(userID string, projectID string) ([]ChatInfoRow, error) {
+	// a chat trashed along with its parent has the same deleted_at, it comes back when the parent is restored
+	trashed := `c.deleted_at IS NOT NULL AND c.alternative_of IS NULL
+		AND NOT EXISTS (SELECT 1 FROM chat_list p WHERE p.chat_id = c.parent_chat_id AND p.deleted_at = c.deleted_at)`
 
-	return chats, nil
+	var chats []ChatInfoRow
+	var err error
+	if projectID == "" {
+		err = s.db.Select(&chats, "SELECT "+chatInfoColumns+" FROM chat_list c WHERE c.project_id IS NULL AND c.user_id = ? AND "+trashed+" ORDER BY c.deleted_at DESC", userID)
+	} else {
+		err = s.db.Select(&chats, "SELECT "+chatInfoColumns+" FROM chat_list c WHERE c.project_id = ? AND c.project_id IN ("+projectAccessQuery("?", false)+") AND "+trashed+" ORDER BY c.deleted_at DESC", projectID, userID, userID)
+	}
+	return chats, err
 }
 
 type SQLiteSettingsDAO struct {
@@ -376,3 +843,121 @@ func (s *SQLiteSettingsDAO) SetSettingValue(settingName string, settingValue str
 	}
 	return nil
 }
+
+func (s *SQLiteDAO) GetChatSummary(chatId string) (*ChatSummaryRow, error) {
+	var summary ChatSummaryRow
+	err := s.db.Get(&summary, `
+		SELECT chat_id, summary, up_to_message_id, model, input_token_count, output_token_count, cost, updated_at
+		FROM chat_summaries WHERE chat_id = ?`, chatId)
+	if err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+func (s *SQLiteDAO) SaveChatSummary(userID string, summary ChatSummaryRow) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO chat_summaries (chat_id, summary, up_to_message_id, model, input_token_count, output_token_count, cost, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(chat_id) DO UPDATE SET summary = EXCLUDED.summary, up_to_message_id = EXCLUDED.up_to_message_id,
+			model = EXCLUDED.model, input_token_count = EXCLUDED.input_token_count,
+			output_token_count = EXCLUDED.output_token_count, cost = EXCLUDED.cost, updated_at = CURRENT_TIMESTAMP`,
+		summary.ChatID, summary.Summary, summary.UpToMessageID, summary.Model, summary.InputTokens, summary.OutputTokens, summary.Cost)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO usage_ledger (user_id, chat_id, project_id, model, input_token_count, output_token_count, cost)
+		VALUES (?, ?, (SELECT project_id FROM chat_list WHERE chat_id = ?), ?, ?, ?, ?)`,
+		userID, summary.ChatID, summary.ChatID, summary.Model, summary.InputTokens, summary.OutputTokens, summary.Cost)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *SQLiteDAO) SetChatSystemPrompt(chatId string, prompt string) error {
+	result, err := s.db.Exec("UPDATE chat_list SET system_prompt = ?, updated_at = CURRENT_TIMESTAMP WHERE chat_id = ? AND alternative_of IS NULL", prompt, chatId)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (s *SQLiteDAO) SetProjectSystemPrompt(projectID string, prompt string) error {
+	result, err := s.db.Exec("UPDATE project SET system_prompt = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?", prompt, projectID)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (s *SQLiteDAO) GetSystemPrompt(chatId string) (string, error) {
+	var prompt string
+	err := s.db.Get(&prompt, `
+		SELECT COALESCE(NULLIF(c.system_prompt, ''), p.system_prompt, '')
+		FROM chat_list c LEFT JOIN project p ON p.id = c.project_id
+		WHERE c.chat_id = ?`, chatId)
+	return prompt, err
+}
+
+func (s *SQLiteDAO) CreatePromptTemplate(template PromptTemplateRow) error {
+	_, err := s.db.Exec(`
+		INSERT INTO prompt_templates (id, user_id, project_id, name, description, content, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		template.ID, template.UserID, nullIfEmpty(template.ProjectID), template.Name, template.Description, template.Content, template.CreatedAt)
+	return err
+}
+
+func (s *SQLiteDAO) GetPromptTemplate(userID string, templateID string) (*PromptTemplateRow, error) {
+	var template PromptTemplateRow
+	err := s.db.Get(&template, "SELECT "+promptTemplateColumns+` FROM prompt_templates
+		WHERE id = ? AND ((project_id IS NULL AND user_id = ?) OR project_id IN (`+projectAccessQuery("?", false)+`))`, templateID, userID, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (s *SQLiteDAO) GetPromptTemplates(userID string, projectID string) ([]PromptTemplateRow, error) {
+	var templates []PromptTemplateRow
+	err := s.db.Select(&templates, "SELECT "+promptTemplateColumns+` FROM prompt_templates
+		WHERE (project_id IS NULL AND user_id = ?) OR project_id = ?
+		ORDER BY name, created_at`, userID, projectID)
+	return templates, err
+}
+
+func (s *SQLiteDAO) SetChatGenerationOptions(chatId string, options string) error {
+	result, err := s.db.Exec("UPDATE chat_list SET generation_options = ?, updated_at = CURRENT_TIMESTAMP WHERE chat_id = ? AND alternative_of IS NULL", options, chatId)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (s *SQLiteDAO) SetProjectGenerationOptions(projectID string, options string) error {
+	result, err := s.db.Exec("UPDATE project SET generation_options = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?", options, projectID)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result)
+}
+
+func (s *SQLiteDAO) GetGenerationDefaults(chatId string) (string, string, error) {
+	var defaults struct {
+		Chat    string `db:"chat_options"`
+		Project string `db:"project_options"`
+	}
+	err := s.db.Get(&defaults, `
+		SELECT c.generation_options AS chat_options, COALESCE(p.generation_options, '') AS project_options
+		FROM chat_list c LEFT JOIN project p ON p.id = c.project_id
+		WHERE c.chat_id = ?`, chatId)
+	return defaults.Chat, defaults.Project, err
+}
@@ -0,0 +1,487 @@
+//go:build sqlite_fts5
+
+package dao
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// sqliteTestURL returns the URL of a database file that is removed with the test
+func sqliteTestURL(t *testing.T) string {
+	t.Helper()
+	return "file:" + filepath.Join(t.TempDir(), "chat.sqlite")
+}
+
+func newSQLiteTestDAO(t *testing.T, url string) *SQLiteDAO {
+	t.Helper()
+	if err := MigrateSQLite(url); err != nil {
+		t.Fatalf("MigrateSQLite failed: %v", err)
+	}
+	d, err := NewSQLiteDAO(url)
+	if err != nil {
+		t.Fatalf("NewSQLiteDAO failed: %v", err)
+	}
+	t.Cleanup(func() { d.db.Close() })
+	return d
+}
+
+// migrateSQLiteTo runs the migrations up to and including version
+func migrateSQLiteTo(t *testing.T, url string, version uint) {
+	t.Helper()
+	sqlDB, err := sql.Open("sqlite3", url)
+	if err != nil {
+		t.Fatalf("sql.Open failed: %v", err)
+	}
+	defer sqlDB.Close()
+
+	files, err := iofs.New(sqliteMigrationFiles, "db/sqlite/scripts/migrations")
+	if err != nil {
+		t.Fatalf("iofs.New failed: %v", err)
+	}
+	instance, err := sqlite.WithInstance(sqlDB, &sqlite.Config{MigrationsTable: MIGRATION_TABLE})
+	if err != nil {
+		t.Fatalf("sqlite.WithInstance failed: %v", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", files, "DUMMY", instance)
+	if err != nil {
+		t.Fatalf("migrate.NewWithInstance failed: %v", err)
+	}
+	if err := m.Migrate(version); err != nil {
+		t.Fatalf("Migrate(%d) failed: %v", version, err)
+	}
+}
+
+// addMessages adds user and assistant messages to the chat in turns and returns their ids
+func addMessages(t *testing.T, d *SQLiteDAO, userID string, chatId string, contents ...string) []string {
+	t.Helper()
+	var ids []string
+	for i, content := range contents {
+		role := "user"
+		if i%2 == 1 {
+			role = "assistant"
+		}
+		id, err := d.AddChatMessageWithTokens(userID, chatId, role, content, "gpt-4o", 10, 20, 0.5, "", false)
+		if err != nil {
+			t.Fatalf("AddChatMessageWithTokens failed: %v", err)
+		}
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+	return ids
+}
+
+func messageContents(messages []ChatMessageRow) []string {
+	contents := make([]string, len(messages))
+	for i, m := range messages {
+		contents[i] = m.Content
+	}
+	return contents
+}
+
+func TestSQLiteBranchPaths(t *testing.T) {
+	d := newSQLiteTestDAO(t, sqliteTestURL(t))
+
+	if err := d.CreateChat("alice", "main", "Main", ""); err != nil {
+		t.Fatalf("CreateChat failed: %v", err)
+	}
+	main := addMessages(t, d, "alice", "main", "m1", "m2", "m3", "m4")
+	if err := d.BranchChat("alice", "main", main[1], "branch", "Branch"); err != nil {
+		t.Fatalf("BranchChat failed: %v", err)
+	}
+	branch := addMessages(t, d, "alice", "branch", "b1", "b2")
+	if err := d.BranchChat("alice", "branch", branch[0], "nested", "Nested"); err != nil {
+		t.Fatalf("BranchChat failed: %v", err)
+	}
+	addMessages(t, d, "alice", "nested", "n1")
+
+	// each parent contributes its messages up to the branch point, the nearest branch point wins
+	for chatId, want := range map[string][]string{
+		"main":   {"m1", "m2", "m3", "m4"},
+		"branch": {"m1", "m2", "b1", "b2"},
+		"nested": {"m1", "m2", "b1", "n1"},
+	} {
+		messages, err := d.GetChatMessages("alice", chatId)
+		if err != nil {
+			t.Fatalf("GetChatMessages failed: %v", err)
+		}
+		if got := messageContents(messages); !slices.Equal(got, want) {
+			t.Errorf("Expected %v for %s, got %v", want, chatId, got)
+		}
+	}
+
+	page, err := d.GetChatMessagePage("alice", "nested", MessagePage{Descending: true, Limit: 2})
+	if err != nil {
+		t.Fatalf("GetChatMessagePage failed: %v", err)
+	}
+	if got := messageContents(page); !slices.Equal(got, []string{"n1", "b1"}) {
+		t.Errorf("Expected the newest two messages of the path, got %v", got)
+	}
+	after, _ := strconv.ParseInt(page[1].Id, 10, 64)
+	page, err = d.GetChatMessagePage("alice", "nested", MessagePage{After: after, Descending: true, Limit: 2})
+	if err != nil {
+		t.Fatalf("GetChatMessagePage failed: %v", err)
+	}
+	if got := messageContents(page); !slices.Equal(got, []string{"m2", "m1"}) {
+		t.Errorf("Expected the parents' messages on the next page, got %v", got)
+	}
+
+	tree, err := d.GetBranchTree("alice", "nested")
+	if err != nil {
+		t.Fatalf("GetBranchTree failed: %v", err)
+	}
+	if len(tree) != 3 || tree[0].Id != "main" {
+		t.Errorf("Expected the tree of main with its two branches, got %+v", tree)
+	}
+
+	// trashing, restoring and purging take the whole subtree along
+	if err := d.TrashChat("branch"); err != nil {
+		t.Fatalf("TrashChat failed: %v", err)
+	}
+	if chat, _ := d.GetChat("alice", "nested"); chat == nil || !chat.Deleted {
+		t.Errorf("Expected nested to be trashed with its parent, got %+v", chat)
+	}
+	if err := d.RestoreChat("branch"); err != nil {
+		t.Fatalf("RestoreChat failed: %v", err)
+	}
+	if chat, _ := d.GetChat("alice", "nested"); chat == nil || chat.Deleted {
+		t.Errorf("Expected nested to be restored with its parent, got %+v", chat)
+	}
+	if err := d.PurgeChat("branch"); err != nil {
+		t.Fatalf("PurgeChat failed: %v", err)
+	}
+	if _, err := d.GetChat("alice", "nested"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected nested to be purged with its parent, got %v", err)
+	}
+	if messages, _ := d.GetChatMessages("alice", "main"); len(messages) != 4 {
+		t.Errorf("Expected main to keep its messages, got %v", messageContents(messages))
+	}
+}
+
+func TestSQLiteChatListPages(t *testing.T) {
+	d := newSQLiteTestDAO(t, sqliteTestURL(t))
+
+	for _, name := range []string{"echo", "Alpha", "delta", "charlie", "bravo"} {
+		if err := d.CreateChat("alice", name, name, ""); err != nil {
+			t.Fatalf("CreateChat failed: %v", err)
+		}
+	}
+	if err := d.SetChatPinned("delta", true); err != nil {
+		t.Fatalf("SetChatPinned failed: %v", err)
+	}
+	if err := d.SetChatArchived("echo", true); err != nil {
+		t.Fatalf("SetChatArchived failed: %v", err)
+	}
+
+	pages := func(q ChatListQuery) [][]string {
+		var pages [][]string
+		for {
+			rows, err := d.GetChatList("alice", q)
+			if err != nil {
+				t.Fatalf("GetChatList failed: %v", err)
+			}
+			if len(rows) == 0 {
+				return pages
+			}
+			var names []string
+			for _, row := range rows {
+				names = append(names, row.Name)
+			}
+			pages = append(pages, names)
+			q.After = rows[len(rows)-1].Cursor()
+		}
+	}
+
+	// pinned chats come first whatever the order, the name sort ignores case
+	got := pages(ChatListQuery{Sort: ChatSortName, Limit: 2})
+	want := [][]string{{"delta", "Alpha"}, {"bravo", "charlie"}}
+	if len(got) != len(want) || !slices.Equal(got[0], want[0]) || !slices.Equal(got[1], want[1]) {
+		t.Errorf("Expected pages %v, got %v", want, got)
+	}
+
+	got = pages(ChatListQuery{Sort: ChatSortName, Descending: true, IncludeArchived: true, Limit: 3})
+	want = [][]string{{"delta", "echo", "charlie"}, {"bravo", "Alpha"}}
+	if len(got) != len(want) || !slices.Equal(got[0], want[0]) || !slices.Equal(got[1], want[1]) {
+		t.Errorf("Expected pages %v, got %v", want, got)
+	}
+
+	// chats created in the same second are told apart by their id
+	got = pages(ChatListQuery{Sort: ChatSortCreated, Limit: 1})
+	if len(got) != 4 || got[0][0] != "delta" || got[1][0] != "Alpha" || got[3][0] != "bravo" {
+		t.Errorf("Expected delta and then the others in the order they were created, got %v", got)
+	}
+}
+
+func TestSQLiteBranchCopyOnWriteMigration(t *testing.T) {
+	url := sqliteTestURL(t)
+	migrateSQLiteTo(t, url, 16)
+
+	// before migration 17 a branch started with copies of its parent's messages up to the branch point
+	db, err := sql.Open("sqlite3", url)
+	if err != nil {
+		t.Fatalf("sql.Open failed: %v", err)
+	}
+	for _, stmt := range []string{
+		`INSERT INTO chat_list (chat_id, name, user_id) VALUES ('main', 'Main', 'alice')`,
+		`INSERT INTO chat_messages (id, chat_id, role, content, user_id) VALUES
+			(1, 'main', 'user', 'm1', 'alice'), (2, 'main', 'assistant', 'm2', 'alice'),
+			(3, 'main', 'user', 'm3', 'alice'), (4, 'main', 'assistant', 'm4', 'alice')`,
+		`INSERT INTO chat_list (chat_id, name, user_id, parent_chat_id, parent_message_id, is_main_branch)
+			VALUES ('branch', 'Branch', 'alice', 'main', '2', FALSE)`,
+		`INSERT INTO chat_messages (id, chat_id, role, content, user_id) VALUES
+			(5, 'branch', 'user', 'm1', 'alice'), (6, 'branch', 'assistant', 'm2', 'alice'),
+			(7, 'branch', 'user', 'b1', 'alice'), (8, 'branch', 'assistant', 'b2', 'alice')`,
+		// an edit of the branch's second message, started at the copy of the first
+		`INSERT INTO chat_list (chat_id, name, user_id, parent_chat_id, parent_message_id, is_main_branch, alternative_of)
+			VALUES ('edit', 'Branch', 'alice', 'branch', '5', FALSE, 'branch')`,
+		`INSERT INTO chat_messages (id, chat_id, role, content, user_id) VALUES (9, 'edit', 'assistant', 'e2', 'alice')`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+	}
+	db.Close()
+
+	d := newSQLiteTestDAO(t, url)
+	for chatId, want := range map[string][]string{
+		"main":   {"m1", "m2", "m3", "m4"},
+		"branch": {"m1", "m2", "b1", "b2"},
+		"edit":   {"m1", "e2"},
+	} {
+		messages, err := d.GetChatMessages("alice", chatId)
+		if err != nil {
+			t.Fatalf("GetChatMessages failed: %v", err)
+		}
+		if got := messageContents(messages); !slices.Equal(got, want) {
+			t.Errorf("Expected %v for %s, got %v", want, chatId, got)
+		}
+	}
+
+	var copies int
+	if err := d.db.Get(&copies, "SELECT COUNT(*) FROM chat_messages WHERE chat_id = 'branch'"); err != nil || copies != 2 {
+		t.Errorf("Expected the copies to be deleted, %d messages left (%v)", copies, err)
+	}
+	if chat, err := d.GetChat("alice", "edit"); err != nil || chat.ParentMessageID != "1" {
+		t.Errorf("Expected the edit to start at the original message, got %+v (%v)", chat, err)
+	}
+}
+
+func TestSQLiteProjectAccess(t *testing.T) {
+	d := newSQLiteTestDAO(t, sqliteTestURL(t))
+
+	if err := d.CreateWorkspace(WorkspaceRow{ID: "w1", Name: "Team"}, "alice"); err != nil {
+		t.Fatalf("CreateWorkspace failed: %v", err)
+	}
+	if err := d.AddWorkspaceMember("w1", "bob", WorkspaceRoleViewer); err != nil {
+		t.Fatalf("AddWorkspaceMember failed: %v", err)
+	}
+	if _, err := d.CreateProject("alice", "shared", "Shared", "", "", "", "w1"); err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	if _, err := d.CreateProject("alice", "private", "Private", "", "", "", ""); err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	for chatId, projectID := range map[string]string{"shared-chat": "shared", "private-chat": "private", "own-chat": ""} {
+		if err := d.CreateChat("alice", chatId, chatId, projectID); err != nil {
+			t.Fatalf("CreateChat failed: %v", err)
+		}
+		addMessages(t, d, "alice", chatId, "question", "answer")
+	}
+
+	roles := []struct {
+		userID    string
+		projectID string
+		want      string
+	}{
+		{"alice", "shared", WorkspaceRoleOwner},
+		{"alice", "private", WorkspaceRoleOwner},
+		{"bob", "shared", WorkspaceRoleViewer},
+		{"bob", "private", ""},
+		{"carol", "shared", ""},
+	}
+	for _, tt := range roles {
+		role, err := d.GetProjectRole(tt.userID, tt.projectID)
+		if tt.want == "" && !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("Expected %s to have no access to %s, got %q (%v)", tt.userID, tt.projectID, role, err)
+		} else if tt.want != "" && role != tt.want {
+			t.Errorf("Expected %s to be %s of %s, got %q (%v)", tt.userID, tt.want, tt.projectID, role, err)
+		}
+	}
+
+	// the members of the workspace read the chats of its projects and their messages, nothing else
+	access := []struct {
+		userID string
+		chatId string
+		want   bool
+	}{
+		{"bob", "shared-chat", true},
+		{"bob", "private-chat", false},
+		{"bob", "own-chat", false},
+		{"carol", "shared-chat", false},
+	}
+	for _, tt := range access {
+		_, err := d.GetChat(tt.userID, tt.chatId)
+		if tt.want != (err == nil) {
+			t.Errorf("Expected access of %s to %s to be %v, got %v", tt.userID, tt.chatId, tt.want, err)
+		}
+		messages, _ := d.GetChatMessages(tt.userID, tt.chatId)
+		if tt.want != (len(messages) == 2) {
+			t.Errorf("Expected access of %s to the messages of %s to be %v, got %v", tt.userID, tt.chatId, tt.want, messageContents(messages))
+		}
+		role, _ := d.GetChatRole(tt.userID, tt.chatId)
+		if tt.want != (role != "") {
+			t.Errorf("Expected a role of %s in %s only with access, got %q", tt.userID, tt.chatId, role)
+		}
+	}
+
+	if rows, err := d.GetChatList("bob", ChatListQuery{ProjectID: "shared", Sort: ChatSortName}); err != nil || len(rows) != 1 {
+		t.Errorf("Expected bob to list the shared chat, got %d chats (%v)", len(rows), err)
+	}
+	if rows, err := d.GetChatList("carol", ChatListQuery{ProjectID: "shared", Sort: ChatSortName}); err != nil || len(rows) != 0 {
+		t.Errorf("Expected carol to list no chats, got %d chats (%v)", len(rows), err)
+	}
+	if err := d.BranchChat("carol", "shared-chat", "1", "stolen", "Stolen"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected carol not to branch the shared chat, got %v", err)
+	}
+
+	// chat ids are global, one taken by another user is no new chat
+	if exists, err := d.ChatExists("private-chat"); err != nil || !exists {
+		t.Errorf("Expected private-chat to exist, got %v (%v)", exists, err)
+	}
+	if exists, err := d.ChatExists("new-chat"); err != nil || exists {
+		t.Errorf("Expected new-chat not to exist, got %v (%v)", exists, err)
+	}
+
+	// any writer names a chat, not only its creator
+	if err := d.SaveChatName("shared-chat", "Named"); err != nil {
+		t.Errorf("Expected the shared chat to be named, got %v", err)
+	}
+	if chat, err := d.GetChat("bob", "shared-chat"); err != nil || chat.Name != "Named" {
+		t.Errorf("Expected the shared chat to be named Named, got %+v (%v)", chat, err)
+	}
+	if err := d.SaveChatName("new-chat", "Named"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected naming a missing chat to fail, got %v", err)
+	}
+
+	// in workspace projects the membership decides, creating a chat there grants nothing once demoted or removed
+	if err := d.CreateChat("bob", "bob-chat", "bob-chat", "shared"); err != nil {
+		t.Fatalf("CreateChat failed: %v", err)
+	}
+	addMessages(t, d, "bob", "bob-chat", "question", "answer")
+	if role, err := d.GetChatRole("bob", "bob-chat"); err != nil || role != WorkspaceRoleViewer {
+		t.Errorf("Expected bob to be viewer of their chat in the shared project, got %q (%v)", role, err)
+	}
+	if err := d.RemoveWorkspaceMember("w1", "bob"); err != nil {
+		t.Fatalf("RemoveWorkspaceMember failed: %v", err)
+	}
+	if _, err := d.GetChat("bob", "bob-chat"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected bob to lose access to their chat, got %v", err)
+	}
+	if _, err := d.GetChatRole("bob", "bob-chat"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected bob to lose their role in their chat, got %v", err)
+	}
+	if messages, _ := d.GetChatMessages("bob", "bob-chat"); len(messages) != 0 {
+		t.Errorf("Expected bob to lose access to the messages of their chat, got %v", messageContents(messages))
+	}
+	if err := d.UpdateWorkspaceMember("w1", "alice", WorkspaceRoleEditor); err != nil {
+		t.Fatalf("UpdateWorkspaceMember failed: %v", err)
+	}
+	if role, err := d.GetProjectRole("alice", "shared"); err != nil || role != WorkspaceRoleEditor {
+		t.Errorf("Expected the demoted creator of the shared project to be editor, got %q (%v)", role, err)
+	}
+	if role, err := d.GetChatRole("alice", "shared-chat"); err != nil || role != WorkspaceRoleEditor {
+		t.Errorf("Expected the demoted creator of the shared chat to be editor, got %q (%v)", role, err)
+	}
+}
+
+func TestSQLiteUsageLedger(t *testing.T) {
+	url := sqliteTestURL(t)
+	migrateSQLiteTo(t, url, 24)
+
+	// replies and summaries stored before migrations 25 and 26 are backfilled
+	db, err := sql.Open("sqlite3", url)
+	if err != nil {
+		t.Fatalf("sql.Open failed: %v", err)
+	}
+	for _, stmt := range []string{
+		`INSERT INTO chat_list (chat_id, name, user_id, project_id) VALUES ('chat', 'Chat', 'alice', 'p1')`,
+		`INSERT INTO chat_messages (chat_id, role, content, model, input_token_count, output_token_count, cost, user_id) VALUES
+			('chat', 'user', 'question', '', 0, 0, 0, 'alice'), ('chat', 'assistant', 'answer', 'gpt-4o', 10, 20, 0.5, 'alice')`,
+		`INSERT INTO chat_summaries (chat_id, summary, up_to_message_id, model, input_token_count, output_token_count, cost)
+			VALUES ('chat', 'summary', '1', 'gpt-4o-mini', 100, 10, 0.25)`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+	}
+	db.Close()
+
+	d := newSQLiteTestDAO(t, url)
+	for _, id := range []string{"p1", "p2"} {
+		if _, err := d.CreateProject("alice", id, id, "", "", "", ""); err != nil {
+			t.Fatalf("CreateProject failed: %v", err)
+		}
+	}
+	since := time.Now().Add(-time.Hour)
+	until := time.Now().Add(time.Hour)
+
+	spent := func(projectID string) float64 {
+		t.Helper()
+		spent, err := d.GetSpend(since, "alice", projectID)
+		if err != nil {
+			t.Fatalf("GetSpend failed: %v", err)
+		}
+		return spent
+	}
+	if got := spent("p1"); got != 0.75 {
+		t.Errorf("Expected the old reply and summary backfilled to p1, got %v", got)
+	}
+
+	// moving the chat leaves the spend so far with p1, every summarization and chat name is spent, purging the chat keeps it all
+	addMessages(t, d, "alice", "chat", "question", "answer")
+	if err := d.MoveChat("chat", "p2"); err != nil {
+		t.Fatalf("MoveChat failed: %v", err)
+	}
+	addMessages(t, d, "alice", "chat", "question", "answer")
+	if err := d.AddUsage("alice", "chat", "gpt-4o-mini", 5, 5, 0.25); err != nil {
+		t.Fatalf("AddUsage failed: %v", err)
+	}
+	for range 2 {
+		summary := ChatSummaryRow{ChatID: "chat", Summary: "summary", UpToMessageID: "1", Model: "gpt-4o-mini", Cost: 0.25}
+		if err := d.SaveChatSummary("alice", summary); err != nil {
+			t.Fatalf("SaveChatSummary failed: %v", err)
+		}
+	}
+	if got, want := []float64{spent("p1"), spent("p2")}, []float64{1.25, 1.25}; !slices.Equal(got, want) {
+		t.Errorf("Expected %v spent in p1 and p2, got %v", want, got)
+	}
+
+	if err := d.PurgeChat("chat"); err != nil {
+		t.Fatalf("PurgeChat failed: %v", err)
+	}
+	if got := spent(""); got != 2.5 {
+		t.Errorf("Expected the spend kept after PurgeChat, got %v", got)
+	}
+	rows, err := d.GetUsage("alice", UsageByChat, since, until, "")
+	if err != nil {
+		t.Fatalf("GetUsage failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Key != "chat" || rows[0].Label != "" || rows[0].Cost != 2.5 || rows[0].MessageCount != 3 {
+		t.Errorf("Expected the purged chat to keep its usage, got %+v", rows)
+	}
+	rows, err = d.GetUsage("alice", UsageByProject, since, until, "")
+	if err != nil {
+		t.Fatalf("GetUsage failed: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Key != "p1" || rows[0].Cost != 1.25 || rows[1].Key != "p2" || rows[1].Cost != 1.25 {
+		t.Errorf("Expected the usage split between p1 and p2, got %+v", rows)
+	}
+}
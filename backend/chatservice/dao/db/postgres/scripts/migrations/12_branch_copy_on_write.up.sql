@@ -0,0 +1,20 @@
+-- Branches no longer copy the messages before the branch point, they read them from their parent chats like
+-- alternatives do. The copies existing branches were created with are their first messages, one for each
+-- message the parent had up to the branch point (branches could only be created from main chats until now).
+CREATE TABLE branch_copies AS
+SELECT m.id AS copy_id, s.id AS original_id
+FROM chat_list b
+JOIN chat_messages m ON m.chat_id = b.chat_id
+JOIN chat_messages s ON s.chat_id = b.parent_chat_id AND s.id <= CAST(b.parent_message_id AS BIGINT)
+WHERE b.alternative_of IS NULL AND b.parent_chat_id IS NOT NULL
+  AND (SELECT COUNT(*) FROM chat_messages e WHERE e.chat_id = m.chat_id AND e.id < m.id)
+    = (SELECT COUNT(*) FROM chat_messages e WHERE e.chat_id = s.chat_id AND e.id < s.id);
+
+-- Alternatives and branches started at a copy now start at the message it was copied from
+UPDATE chat_list
+SET parent_message_id = (SELECT CAST(original_id AS TEXT) FROM branch_copies WHERE CAST(copy_id AS TEXT) = chat_list.parent_message_id)
+WHERE parent_message_id IN (SELECT CAST(copy_id AS TEXT) FROM branch_copies);
+
+DELETE FROM chat_messages WHERE id IN (SELECT copy_id FROM branch_copies);
+
+DROP TABLE branch_copies;
@@ -0,0 +1,31 @@
+-- Timestamps for sorting and paging the chat list
+ALTER TABLE chat_list ADD COLUMN IF NOT EXISTS created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE chat_list ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE chat_list ADD COLUMN IF NOT EXISTS last_message_at TIMESTAMP;
+
+-- Existing chats: the first and last message sent in them, alternatives count for the chat they belong to
+UPDATE chat_list SET
+    created_at = COALESCE((SELECT MIN(m.created_at) FROM chat_messages m WHERE m.chat_id = chat_list.chat_id), created_at),
+    last_message_at = (SELECT MAX(m.created_at) FROM chat_messages m
+        WHERE m.chat_id = chat_list.chat_id
+           OR m.chat_id IN (SELECT a.chat_id FROM chat_list a WHERE a.alternative_of = chat_list.chat_id));
+UPDATE chat_list SET updated_at = COALESCE(last_message_at, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_chat_list_last_message_at ON chat_list(last_message_at);
+
+-- A new message updates the chat it is sent in and, for alternatives, the listed chat
+CREATE OR REPLACE FUNCTION update_chat_last_message_at()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE chat_list SET last_message_at = NEW.created_at, updated_at = NEW.created_at
+    WHERE chat_id = NEW.chat_id
+       OR chat_id = (SELECT alternative_of FROM chat_list WHERE chat_id = NEW.chat_id LIMIT 1);
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS chat_messages_last_message_at ON chat_messages;
+CREATE TRIGGER chat_messages_last_message_at
+    AFTER INSERT ON chat_messages
+    FOR EACH ROW
+    EXECUTE FUNCTION update_chat_last_message_at();
@@ -0,0 +1,12 @@
+-- Rolling summary of the older messages of a chat, sent instead of them. The summary covers the chat's messages
+-- up to and including up_to_message_id, it is kept per listed chat.
+CREATE TABLE IF NOT EXISTS chat_summaries (
+    chat_id TEXT PRIMARY KEY,
+    summary TEXT NOT NULL,
+    up_to_message_id TEXT NOT NULL,
+    model TEXT NOT NULL,
+    input_token_count INTEGER NOT NULL DEFAULT 0,
+    output_token_count INTEGER NOT NULL DEFAULT 0,
+    cost DOUBLE PRECISION NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+-- The model name sent to the provider when it differs from the catalog id, empty means the catalog id
+ALTER TABLE model_metadata ADD COLUMN provider_model_id TEXT NOT NULL DEFAULT '';
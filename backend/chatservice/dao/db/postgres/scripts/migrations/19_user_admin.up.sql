@@ -0,0 +1,3 @@
+-- Instance admins manage the settings and the model catalog, the first account registered becomes one
+ALTER TABLE users ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE users SET is_admin = TRUE WHERE id = (SELECT id FROM users ORDER BY created_at, id LIMIT 1);
@@ -0,0 +1,24 @@
+-- Usage ledger: one row per billed completion, kept when the chat is purged and not moved with it, so budgets and
+-- usage reports don't change after the fact. project_id is the chat's project when the completion was made.
+CREATE TABLE IF NOT EXISTS usage_ledger (
+    id BIGSERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    chat_id TEXT NOT NULL,
+    project_id TEXT,
+    message_id BIGINT,
+    model TEXT,
+    input_token_count INTEGER NOT NULL DEFAULT 0,
+    output_token_count INTEGER NOT NULL DEFAULT 0,
+    cost DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_usage_ledger_user_created ON usage_ledger(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_usage_ledger_project_created ON usage_ledger(project_id, created_at);
+
+INSERT INTO usage_ledger (user_id, chat_id, project_id, message_id, model, input_token_count, output_token_count, cost, created_at)
+SELECT cm.user_id, cm.chat_id, cl.project_id, cm.id, cm.model,
+       COALESCE(cm.input_token_count, 0), COALESCE(cm.output_token_count, 0), cm.cost, cm.created_at
+  FROM chat_messages cm
+  LEFT JOIN chat_list cl ON cl.chat_id = cm.chat_id
+ WHERE cm.role = 'assistant';
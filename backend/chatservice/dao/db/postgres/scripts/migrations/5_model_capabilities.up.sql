@@ -0,0 +1,6 @@
+-- Migration: 5_model_capabilities.up.sql
+-- Capabilities and enabled flag for the model catalog
+ALTER TABLE model_metadata ADD COLUMN supports_vision BOOLEAN DEFAULT FALSE NOT NULL;
+ALTER TABLE model_metadata ADD COLUMN supports_tools BOOLEAN DEFAULT FALSE NOT NULL;
+ALTER TABLE model_metadata ADD COLUMN context_window INTEGER DEFAULT 0 NOT NULL;
+ALTER TABLE model_metadata ADD COLUMN enabled BOOLEAN DEFAULT TRUE NOT NULL;
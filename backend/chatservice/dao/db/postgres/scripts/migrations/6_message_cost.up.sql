@@ -0,0 +1,14 @@
+-- Migration: 6_message_cost.up.sql
+-- Cost of each completion, computed from the model's token prices when the message is saved
+ALTER TABLE chat_messages ADD COLUMN cost DOUBLE PRECISION DEFAULT 0 NOT NULL;
+
+-- Backfill existing messages with the current prices (USD per 1M tokens)
+UPDATE chat_messages cm
+   SET cost = (
+       COALESCE(cm.input_token_count, 0) * COALESCE(mm.input_token_cost, 0) +
+       COALESCE(cm.output_token_count, 0) * COALESCE(mm.output_token_cost, 0)
+   ) / 1000000.0
+  FROM model_metadata mm
+ WHERE mm.id = cm.model;
+
+CREATE INDEX IF NOT EXISTS idx_chat_messages_user_created ON chat_messages(user_id, created_at);
@@ -0,0 +1,8 @@
+-- Migration: 7_users.up.sql
+-- Accounts used when authentication is enabled, ids match the user_id column of the user scoped tables
+CREATE TABLE IF NOT EXISTS users (
+    id TEXT PRIMARY KEY,
+    username TEXT NOT NULL UNIQUE,
+    password_hash TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,5 @@
+-- Capabilities and enabled flag for the model catalog
+ALTER TABLE model_metadata ADD COLUMN supports_vision INTEGER DEFAULT 0 NOT NULL;
+ALTER TABLE model_metadata ADD COLUMN supports_tools INTEGER DEFAULT 0 NOT NULL;
+ALTER TABLE model_metadata ADD COLUMN context_window INTEGER DEFAULT 0 NOT NULL;
+ALTER TABLE model_metadata ADD COLUMN enabled INTEGER DEFAULT 1 NOT NULL;
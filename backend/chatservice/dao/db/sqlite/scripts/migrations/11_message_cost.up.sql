@@ -0,0 +1,12 @@
+-- Cost of each completion, computed from the model's token prices when the message is saved
+ALTER TABLE chat_messages ADD COLUMN cost REAL DEFAULT 0 NOT NULL;
+
+-- Backfill existing messages with the current prices (USD per 1M tokens)
+UPDATE chat_messages
+   SET cost = (
+       COALESCE(input_token_count, 0) * COALESCE((SELECT input_token_cost FROM model_metadata WHERE id = chat_messages.model), 0) +
+       COALESCE(output_token_count, 0) * COALESCE((SELECT output_token_cost FROM model_metadata WHERE id = chat_messages.model), 0)
+   ) / 1000000.0
+ WHERE model IS NOT NULL;
+
+CREATE INDEX idx_chat_messages_user_created ON chat_messages(user_id, created_at);
@@ -0,0 +1,22 @@
+-- Workspaces share their projects (with the documents and chats in them) between members
+CREATE TABLE IF NOT EXISTS workspaces (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- role is owner (manages members), editor (chats, uploads) or viewer (read only)
+CREATE TABLE IF NOT EXISTS workspace_members (
+    workspace_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    role TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (workspace_id, user_id)
+);
+
+CREATE INDEX idx_workspace_members_user_id ON workspace_members(user_id);
+
+-- projects without a workspace stay private to their user_id
+ALTER TABLE project ADD COLUMN workspace_id TEXT;
+CREATE INDEX idx_project_workspace_id ON project(workspace_id);
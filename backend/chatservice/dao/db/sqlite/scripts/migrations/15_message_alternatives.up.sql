@@ -0,0 +1,10 @@
+-- Regenerated and edited messages are kept as alternatives: branches of a chat that start at the message
+-- before the replaced one. Unlike branches they don't copy the messages before it, they read them from
+-- their parent chats. alternative_of is the chat shown in the chat list the alternative belongs to.
+ALTER TABLE chat_list ADD COLUMN alternative_of TEXT;
+
+-- active_chat_id is the alternative currently shown for a chat, NULL shows the chat itself
+ALTER TABLE chat_list ADD COLUMN active_chat_id TEXT;
+
+CREATE INDEX idx_chat_list_chat_id ON chat_list(chat_id);
+CREATE INDEX idx_chat_list_alternative_of ON chat_list(alternative_of);
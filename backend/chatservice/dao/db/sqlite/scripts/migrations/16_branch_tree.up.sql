@@ -0,0 +1,2 @@
+-- Branches can be branched again, the branch tree of a chat is walked through parent_chat_id
+CREATE INDEX idx_chat_list_parent_chat_id ON chat_list(parent_chat_id);
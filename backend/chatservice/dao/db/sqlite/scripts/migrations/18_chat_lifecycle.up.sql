@@ -0,0 +1,5 @@
+-- Deleted chats go to the trash first: deleted_at is set on the chat and everything branched from it
+-- and cleared again on restore
+ALTER TABLE chat_list ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE chat_list ADD COLUMN archived BOOLEAN DEFAULT FALSE;
+ALTER TABLE chat_list ADD COLUMN pinned BOOLEAN DEFAULT FALSE;
@@ -0,0 +1,31 @@
+-- Timestamps for sorting and paging the chat list. SQLite can't add a column defaulting to CURRENT_TIMESTAMP,
+-- a trigger fills created_at and updated_at of new chats instead.
+ALTER TABLE chat_list ADD COLUMN created_at TIMESTAMP;
+ALTER TABLE chat_list ADD COLUMN updated_at TIMESTAMP;
+ALTER TABLE chat_list ADD COLUMN last_message_at TIMESTAMP;
+
+-- Existing chats: the first and last message sent in them, alternatives count for the chat they belong to
+UPDATE chat_list SET
+    created_at = COALESCE((SELECT MIN(m.created_at) FROM chat_messages m WHERE m.chat_id = chat_list.chat_id), CURRENT_TIMESTAMP),
+    last_message_at = (SELECT MAX(m.created_at) FROM chat_messages m
+        WHERE m.chat_id = chat_list.chat_id
+           OR m.chat_id IN (SELECT a.chat_id FROM chat_list a WHERE a.alternative_of = chat_list.chat_id));
+UPDATE chat_list SET updated_at = COALESCE(last_message_at, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_chat_list_last_message_at ON chat_list(last_message_at);
+
+CREATE TRIGGER IF NOT EXISTS chat_list_created_at
+AFTER INSERT ON chat_list
+WHEN new.created_at IS NULL
+BEGIN
+    UPDATE chat_list SET created_at = CURRENT_TIMESTAMP, updated_at = COALESCE(updated_at, CURRENT_TIMESTAMP) WHERE id = new.id;
+END;
+
+-- A new message updates the chat it is sent in and, for alternatives, the listed chat
+CREATE TRIGGER IF NOT EXISTS chat_messages_last_message_at
+AFTER INSERT ON chat_messages
+BEGIN
+    UPDATE chat_list SET last_message_at = new.created_at, updated_at = new.created_at
+    WHERE chat_id = new.chat_id
+       OR chat_id = (SELECT alternative_of FROM chat_list WHERE chat_id = new.chat_id LIMIT 1);
+END;
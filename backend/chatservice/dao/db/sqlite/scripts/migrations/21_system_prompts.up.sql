@@ -0,0 +1,18 @@
+-- System prompts sent ahead of a chat's history, a chat's own system prompt takes the place of its project's
+ALTER TABLE chat_list ADD COLUMN system_prompt TEXT NOT NULL DEFAULT '';
+ALTER TABLE project ADD COLUMN system_prompt TEXT NOT NULL DEFAULT '';
+
+-- Reusable prompts, {{ name }} stands for the variable name. Templates without a project belong to the user who
+-- created them, the others to everyone who can read the project.
+CREATE TABLE IF NOT EXISTS prompt_templates (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    project_id TEXT,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    content TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_prompt_templates_project_id ON prompt_templates(project_id);
+CREATE INDEX IF NOT EXISTS idx_prompt_templates_user_id ON prompt_templates(user_id);
@@ -0,0 +1,8 @@
+-- Generation options: what the model catalog allows, the defaults of chats and projects and the options each reply
+-- was generated with, stored as JSON. Empty (and NULL for messages) means the provider's defaults.
+ALTER TABLE model_metadata ADD COLUMN supports_reasoning INTEGER DEFAULT 0 NOT NULL;
+ALTER TABLE model_metadata ADD COLUMN max_output_tokens INTEGER DEFAULT 0 NOT NULL;
+
+ALTER TABLE chat_list ADD COLUMN generation_options TEXT NOT NULL DEFAULT '';
+ALTER TABLE project ADD COLUMN generation_options TEXT NOT NULL DEFAULT '';
+ALTER TABLE chat_messages ADD COLUMN generation_options TEXT;
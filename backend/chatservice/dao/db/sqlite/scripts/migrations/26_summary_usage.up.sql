@@ -0,0 +1,6 @@
+-- Each summarization is recorded in the usage ledger, for the summaries written before only the cost of the latest one
+-- of each chat is known. It is put on the chat's owner.
+INSERT INTO usage_ledger (user_id, chat_id, project_id, model, input_token_count, output_token_count, cost, created_at)
+SELECT cl.user_id, cs.chat_id, cl.project_id, cs.model, cs.input_token_count, cs.output_token_count, cs.cost, cs.updated_at
+  FROM chat_summaries cs
+  JOIN chat_list cl ON cl.chat_id = cs.chat_id;
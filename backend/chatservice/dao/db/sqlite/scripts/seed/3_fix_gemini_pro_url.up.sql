@@ -0,0 +1,4 @@
+-- gemini-2.5-pro was seeded with the gemini-2.5-flash endpoint
+UPDATE model_metadata
+   SET url = 'https://generativelanguage.googleapis.com/v1beta/models/gemini-2.5-pro:generateContent'
+   WHERE id = 'gemini-2.5-pro';
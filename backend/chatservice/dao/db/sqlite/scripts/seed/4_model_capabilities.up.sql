@@ -0,0 +1,9 @@
+-- Capabilities of the seeded models
+UPDATE model_metadata SET supports_vision = 1, supports_tools = 1, context_window = 1047576 WHERE id = 'gpt-4.1';
+UPDATE model_metadata SET supports_vision = 1, supports_tools = 1, context_window = 128000 WHERE id = 'gpt-4o';
+UPDATE model_metadata SET supports_vision = 0, supports_tools = 1, context_window = 200000 WHERE id = 'o3-mini';
+UPDATE model_metadata SET supports_vision = 1, supports_tools = 1, context_window = 200000 WHERE id IN ('o3', 'o4-mini');
+UPDATE model_metadata SET supports_vision = 1, supports_tools = 1, context_window = 400000 WHERE id IN ('gpt-5', 'gpt-5-mini', 'gpt-5-nano');
+UPDATE model_metadata SET supports_vision = 1, supports_tools = 1, context_window = 1048576 WHERE id IN ('gemini-2.5-flash', 'gemini-2.0-flash', 'gemini-2.5-pro');
+UPDATE model_metadata SET supports_vision = 0, supports_tools = 1, context_window = 200000 WHERE id = 'claude-3.5-haiku';
+UPDATE model_metadata SET supports_vision = 1, supports_tools = 1, context_window = 200000 WHERE id IN ('claude-3.7-sonnet', 'claude-4-sonnet');
@@ -0,0 +1,8 @@
+-- Reasoning support and output limits of the seeded models
+UPDATE model_metadata SET max_output_tokens = 32768 WHERE id = 'gpt-4.1';
+UPDATE model_metadata SET max_output_tokens = 16384 WHERE id = 'gpt-4o';
+UPDATE model_metadata SET supports_reasoning = 1, max_output_tokens = 100000 WHERE id IN ('o3-mini', 'o3', 'o4-mini');
+UPDATE model_metadata SET supports_reasoning = 1, max_output_tokens = 128000 WHERE id IN ('gpt-5', 'gpt-5-mini', 'gpt-5-nano');
+UPDATE model_metadata SET supports_reasoning = 1, max_output_tokens = 65536 WHERE id IN ('gemini-2.5-flash', 'gemini-2.5-pro');
+UPDATE model_metadata SET max_output_tokens = 8192 WHERE id IN ('gemini-2.0-flash', 'claude-3.5-haiku');
+UPDATE model_metadata SET supports_reasoning = 1, max_output_tokens = 64000 WHERE id IN ('claude-3.7-sonnet', 'claude-4-sonnet');
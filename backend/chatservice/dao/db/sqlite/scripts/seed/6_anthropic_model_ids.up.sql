@@ -0,0 +1,4 @@
+-- the seeded Claude ids are not Anthropic model names, the Messages API answers them with 404
+UPDATE model_metadata SET provider_model_id = 'claude-3-5-haiku-latest' WHERE id = 'claude-3.5-haiku';
+UPDATE model_metadata SET provider_model_id = 'claude-3-7-sonnet-latest', name = 'claude-3.7-sonnet' WHERE id = 'claude-3.7-sonnet';
+UPDATE model_metadata SET provider_model_id = 'claude-sonnet-4-0' WHERE id = 'claude-4-sonnet';
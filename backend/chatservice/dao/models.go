@@ -1,18 +1,56 @@
 package dao
 
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
 type ChatMessageRow struct {
 	Role    string `db:"role" json:"role"`
 	Content string `db:"content" json:"content"`
 	Id      string `db:"id" json:"id"`
+	Model   string `db:"model" json:"model"`
+	// Interrupted is stored in the error column, set for replies cancelled before they were complete
+	Interrupted bool `db:"interrupted" json:"interrupted"`
+	// GenerationOptions are the options a reply was generated with as JSON, empty for the provider's defaults
+	GenerationOptions string `db:"generation_options" json:"generation_options"`
 }
 
+// ChatRow is a chat_list row with its branching columns, empty strings stand for NULL
+type ChatRow struct {
+	ChatID          string `db:"chat_id"`
+	Name            string `db:"name"`
+	UserID          string `db:"user_id"`
+	ProjectID       string `db:"project_id"`
+	ParentChatID    string `db:"parent_chat_id"`
+	ParentMessageID string `db:"parent_message_id"`
+	// AlternativeOf is set for regenerated and edited versions of a chat's messages, it is the listed chat they belong to
+	AlternativeOf string `db:"alternative_of"`
+	// ActiveChatID is the alternative shown for a listed chat
+	ActiveChatID string `db:"active_chat_id"`
+	// Deleted is set for chats in the trash
+	Deleted bool `db:"deleted"`
+}
+
+const chatColumns = `chat_id, name, user_id, COALESCE(project_id, '') AS project_id, COALESCE(parent_chat_id, '') AS parent_chat_id,
+	COALESCE(parent_message_id, '') AS parent_message_id, COALESCE(alternative_of, '') AS alternative_of,
+	COALESCE(active_chat_id, '') AS active_chat_id, deleted_at IS NOT NULL AS deleted`
+
+// NoParentMessage is the parent_message_id of alternatives of a chat's first message
+const NoParentMessage = "0"
+
 type ProjectRow struct {
 	ID             string `db:"id"`
+	WorkspaceID    string `db:"workspace_id"`
 	Name           string `db:"name"`
 	Description    string `db:"description"`
 	AdditionalData string `db:"additional_data"`
-	CreatedAt      string `db:"created_at"`
-	UpdatedAt      string `db:"updated_at"`
+	SystemPrompt   string `db:"system_prompt"`
+	// GenerationOptions are the defaults of the project's chats as JSON
+	GenerationOptions string `db:"generation_options"`
+	CreatedAt         string `db:"created_at"`
+	UpdatedAt         string `db:"updated_at"`
 }
 
 type DocumentListRow struct {
@@ -39,6 +77,386 @@ type RAGChunkRow struct {
 type ChatInfoRow struct {
 	Id   string `db:"chat_id"`
 	Name string `db:"name"`
+	// ParentChatID is the chat a branch was branched from, for branches of an alternative the chat it belongs to
+	ParentChatID  string       `db:"parent_chat_id"`
+	IsMainBranch  bool         `db:"is_main_branch"`
+	Pinned        bool         `db:"pinned"`
+	Archived      bool         `db:"archived"`
+	CreatedAt     sql.NullTime `db:"created_at"`
+	UpdatedAt     sql.NullTime `db:"updated_at"`
+	LastMessageAt sql.NullTime `db:"last_message_at"`
+	SystemPrompt  string       `db:"system_prompt"`
+	// GenerationOptions are the chat's defaults as JSON, they take the place of its project's option by option
+	GenerationOptions string `db:"generation_options"`
+}
+
+// chatInfoColumns selects a ChatInfoRow from chat_list c
+const chatInfoColumns = `c.chat_id, c.name,
+	COALESCE((SELECT p.alternative_of FROM chat_list p WHERE p.chat_id = c.parent_chat_id LIMIT 1), c.parent_chat_id, '') AS parent_chat_id,
+	COALESCE(c.is_main_branch, TRUE) AS is_main_branch, COALESCE(c.pinned, FALSE) AS pinned, COALESCE(c.archived, FALSE) AS archived,
+	c.created_at, c.updated_at, c.last_message_at, c.system_prompt, c.generation_options`
+
+// ChatListSort is what GetChatList sorts by, after pinned chats
+type ChatListSort string
+
+const (
+	// ChatSortLastMessage sorts chats without messages by the time they were created
+	ChatSortLastMessage ChatListSort = "last_message"
+	ChatSortCreated     ChatListSort = "created"
+	ChatSortUpdated     ChatListSort = "updated"
+	ChatSortName        ChatListSort = "name"
+)
+
+// ChatListQuery selects one page of a chat list
+type ChatListQuery struct {
+	ProjectID       string
+	IncludeArchived bool
+	Sort            ChatListSort
+	Descending      bool
+	// After is the last chat of the previous page, nil for the first page
+	After *ChatListCursor
+	// Limit is the page size, 0 for no limit
+	Limit int
+}
+
+// ChatListCursor is the position of a chat in a sorted chat list
+type ChatListCursor struct {
+	Pinned    bool   `json:"pinned"`
+	SortValue string `json:"value"`
+	RowID     int64  `json:"id"`
+}
+
+// ChatListRow is a chat of the chat list with the values it is sorted by
+type ChatListRow struct {
+	ChatInfoRow
+	RowID     int64  `db:"id"`
+	SortValue string `db:"sort_value"`
+}
+
+// Cursor returns the position of the chat, GetChatList continues after it
+func (r ChatListRow) Cursor() *ChatListCursor {
+	return &ChatListCursor{Pinned: r.Pinned, SortValue: r.SortValue, RowID: r.RowID}
+}
+
+// chatListSortExpr returns the expression a chat list is sorted by and whether it is a timestamp
+func chatListSortExpr(sort ChatListSort) (string, bool, error) {
+	switch sort {
+	case ChatSortLastMessage:
+		return "COALESCE(c.last_message_at, c.created_at)", true, nil
+	case ChatSortCreated:
+		return "c.created_at", true, nil
+	case ChatSortUpdated:
+		return "COALESCE(c.updated_at, c.created_at)", true, nil
+	case ChatSortName:
+		return "LOWER(c.name)", false, nil
+	default:
+		return "", false, fmt.Errorf("unsupported chat list sort: %s", sort)
+	}
+}
+
+// chatListQuery builds the query behind GetChatList. Pages continue after the cursor by (pinned, sort value, id),
+// the sort value is compared as timestampType for timestamps. placeholder(n) must refer to the n-th argument
+// wherever it appears, the user id is used more than once.
+func chatListQuery(placeholder func(int) string, timestampType string, userID string, q ChatListQuery) (string, []interface{}, error) {
+	sortExpr, isTimestamp, err := chatListSortExpr(q.Sort)
+	if err != nil {
+		return "", nil, err
+	}
+	valueType := "TEXT"
+	if isTimestamp {
+		valueType = timestampType
+	}
+
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return placeholder(len(args))
+	}
+
+	user := arg(userID)
+	query := `
+		SELECT ` + chatInfoColumns + `, c.id, CAST(` + sortExpr + ` AS TEXT) AS sort_value
+		FROM chat_list c`
+	if q.ProjectID == "" {
+		query += `
+		WHERE c.project_id IS NULL AND c.user_id = ` + user
+	} else {
+		query += `
+		WHERE c.project_id = ` + arg(q.ProjectID) + ` AND c.project_id IN (` + projectAccessQuery(user, false) + `)`
+	}
+	query += ` AND c.alternative_of IS NULL AND c.deleted_at IS NULL`
+	if !q.IncludeArchived {
+		query += ` AND NOT COALESCE(c.archived, FALSE)`
+	}
+
+	cmp, dir := ">", "ASC"
+	if q.Descending {
+		cmp, dir = "<", "DESC"
+	}
+	if q.After != nil {
+		pinned := arg(q.After.Pinned)
+		value := "CAST(" + arg(q.After.SortValue) + " AS " + valueType + ")"
+		query += `
+		AND (COALESCE(c.pinned, FALSE) < ` + pinned + ` OR (COALESCE(c.pinned, FALSE) = ` + pinned + ` AND (
+			` + sortExpr + ` ` + cmp + ` ` + value + ` OR (` + sortExpr + ` = ` + value + ` AND c.id ` + cmp + ` ` + arg(q.After.RowID) + `))))`
+	}
+	query += `
+		ORDER BY COALESCE(c.pinned, FALSE) DESC, ` + sortExpr + ` ` + dir + `, c.id ` + dir
+	if q.Limit > 0 {
+		query += ` LIMIT ` + arg(q.Limit)
+	}
+	return query, args, nil
+}
+
+// MessagePage selects one page of a chat's messages by message id
+type MessagePage struct {
+	// After is the id of the last message of the previous page, 0 for the first page
+	After      int64
+	Descending bool
+	// Limit is the page size, 0 for no limit
+	Limit int
+}
+
+// BranchRow is a chat of a branch tree, ParentMessageID is the message of the parent chat it was branched at
+type BranchRow struct {
+	ChatInfoRow
+	ParentMessageID string `db:"parent_message_id"`
+	MessageCount    int    `db:"message_count"`
+}
+
+// ChatSummaryRow is the rolling summary of a chat's older messages, it covers the chat's messages up to and
+// including UpToMessageID
+type ChatSummaryRow struct {
+	ChatID        string    `db:"chat_id"`
+	Summary       string    `db:"summary"`
+	UpToMessageID string    `db:"up_to_message_id"`
+	Model         string    `db:"model"`
+	InputTokens   int       `db:"input_token_count"`
+	OutputTokens  int       `db:"output_token_count"`
+	Cost          float64   `db:"cost"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+// PromptTemplateRow is a reusable prompt, ProjectID is empty for templates of the user who created them
+type PromptTemplateRow struct {
+	ID          string    `db:"id"`
+	UserID      string    `db:"user_id"`
+	ProjectID   string    `db:"project_id"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	Content     string    `db:"content"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+const promptTemplateColumns = `id, user_id, COALESCE(project_id, '') AS project_id, name, description, content, created_at`
+
+// Workspace roles, editors and owners can write to the workspace's projects, only owners manage members
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleEditor = "editor"
+	WorkspaceRoleViewer = "viewer"
+)
+
+// WorkspaceRow is a workspace as seen by one member, Role is that member's role
+type WorkspaceRow struct {
+	ID        string `db:"id"`
+	Name      string `db:"name"`
+	CreatedBy string `db:"created_by"`
+	CreatedAt string `db:"created_at"`
+	Role      string `db:"role"`
+}
+
+type WorkspaceMemberRow struct {
+	WorkspaceID string `db:"workspace_id"`
+	UserID      string `db:"user_id"`
+	Username    string `db:"username"`
+	Role        string `db:"role"`
+	CreatedAt   string `db:"created_at"`
+}
+
+// chatPathCTE defines chain, the chats whose messages a chat shows: the chat itself and its parents (branches and
+// alternatives only store their own messages) with the id of the last message they contribute (upto, NULL for
+// all). chatParam is the placeholder of the chat id, it appears twice, intType the integer type message ids are
+// compared as.
+func chatPathCTE(chatParam string, intType string) string {
+	return `WITH RECURSIVE chain(chat_id, parent_chat_id, parent_message_id, alternative_of, upto) AS (
+			SELECT CAST(` + chatParam + ` AS TEXT), c.parent_chat_id, c.parent_message_id, c.alternative_of, CAST(NULL AS ` + intType + `)
+			FROM (SELECT 1) AS one LEFT JOIN chat_list c ON c.chat_id = ` + chatParam + `
+			UNION ALL
+			SELECT p.chat_id, p.parent_chat_id, p.parent_message_id, p.alternative_of,
+				CASE WHEN c.upto IS NULL OR CAST(c.parent_message_id AS ` + intType + `) < c.upto
+					THEN CAST(c.parent_message_id AS ` + intType + `) ELSE c.upto END
+			FROM chain c JOIN chat_list p ON p.chat_id = c.parent_chat_id
+		)`
+}
+
+// chatSubtreeCTE defines subtree, the chat and every chat branched from it at any depth, alternatives included.
+// chatParam is the placeholder of the chat id.
+func chatSubtreeCTE(chatParam string) string {
+	return `WITH RECURSIVE subtree(chat_id) AS (
+			SELECT chat_id FROM chat_list WHERE chat_id = ` + chatParam + `
+			UNION
+			SELECT c.chat_id FROM subtree JOIN chat_list c ON c.parent_chat_id = subtree.chat_id
+		)`
+}
+
+// projectAccessQuery selects the ids of the projects a user can read (or write): the user's own projects outside of
+// workspaces and the projects of workspaces the user is a member of. Access to workspace projects follows the
+// membership only, so demoted or removed creators lose their rights. userParam is the placeholder of the user id,
+// it appears twice.
+func projectAccessQuery(userParam string, write bool) string {
+	roles := ""
+	if write {
+		roles = " AND role IN ('" + WorkspaceRoleOwner + "', '" + WorkspaceRoleEditor + "')"
+	}
+	return `SELECT id FROM project WHERE (workspace_id IS NULL AND user_id = ` + userParam + `)
+		OR workspace_id IN (SELECT workspace_id FROM workspace_members WHERE user_id = ` + userParam + roles + `)`
+}
+
+type UserRow struct {
+	ID           string `db:"id"`
+	Username     string `db:"username"`
+	PasswordHash string `db:"password_hash"`
+	// IsAdmin lets the user manage the settings and the model catalog of the instance
+	IsAdmin   bool   `db:"is_admin"`
+	CreatedAt string `db:"created_at"`
+}
+
+type ApiTokenRow struct {
+	ID          string       `db:"id"`
+	UserID      string       `db:"user_id"`
+	Name        string       `db:"name"`
+	TokenHash   string       `db:"token_hash"`
+	TokenPrefix string       `db:"token_prefix"`
+	Scope       string       `db:"scope"`
+	CreatedAt   time.Time    `db:"created_at"`
+	LastUsedAt  sql.NullTime `db:"last_used_at"`
+	ExpiresAt   sql.NullTime `db:"expires_at"`
+}
+
+const apiTokenColumns = "id, user_id, name, token_hash, token_prefix, scope, created_at, last_used_at, expires_at"
+
+type ModelRow struct {
+	ID   string `db:"id"`
+	Name string `db:"name"`
+	// ProviderModelID is the model name sent to the provider, empty when it is the catalog id
+	ProviderModelID string  `db:"provider_model_id"`
+	URL             string  `db:"url"`
+	Provider        string  `db:"provider"`
+	InputTokenCost  float64 `db:"input_token_cost"`
+	OutputTokenCost float64 `db:"output_token_cost"`
+	SupportsVision  bool    `db:"supports_vision"`
+	SupportsTools   bool    `db:"supports_tools"`
+	ContextWindow   int     `db:"context_window"`
+	// SupportsReasoning allows a reasoning effort, MaxOutputTokens limits max_tokens (0 if unknown)
+	SupportsReasoning bool `db:"supports_reasoning"`
+	MaxOutputTokens   int  `db:"max_output_tokens"`
+	Enabled           bool `db:"enabled"`
+}
+
+// modelColumns selects a ModelRow, shared by the SQLite and Postgres queries
+const modelColumns = `id, name, url, COALESCE(provider, '') AS provider,
+			COALESCE(input_token_cost, 0) AS input_token_cost, COALESCE(output_token_cost, 0) AS output_token_cost,
+			supports_vision, supports_tools, context_window, supports_reasoning, max_output_tokens, provider_model_id, enabled`
+
+// APIModelID returns the model name to send to the provider
+func (m ModelRow) APIModelID() string {
+	if m.ProviderModelID != "" {
+		return m.ProviderModelID
+	}
+	return m.ID
+}
+
+// UsageGroupBy is the dimension GetUsage aggregates by
+type UsageGroupBy string
+
+const (
+	UsageByDay     UsageGroupBy = "day"
+	UsageByModel   UsageGroupBy = "model"
+	UsageByProject UsageGroupBy = "project"
+	UsageByChat    UsageGroupBy = "chat"
+)
+
+// UsageRow is one bucket of a usage report, Key is the day (YYYY-MM-DD), model id, project id or chat id
+type UsageRow struct {
+	Key          string  `db:"key"`
+	Label        string  `db:"label"`
+	InputTokens  int64   `db:"input_tokens"`
+	OutputTokens int64   `db:"output_tokens"`
+	Cost         float64 `db:"cost"`
+	MessageCount int64   `db:"message_count"`
+}
+
+// usageKeyColumns returns the key and label expressions for a usage query, day is database specific
+func usageKeyColumns(groupBy UsageGroupBy, dayExpr string) (string, string, error) {
+	switch groupBy {
+	case UsageByDay:
+		return dayExpr, dayExpr, nil
+	case UsageByModel:
+		return "COALESCE(ul.model, '')", "COALESCE(mm.name, ul.model, '')", nil
+	case UsageByProject:
+		return "COALESCE(ul.project_id, '')", "COALESCE(p.name, '')", nil
+	case UsageByChat:
+		return "ul.chat_id", "COALESCE(cl.name, '')", nil
+	default:
+		return "", "", fmt.Errorf("unsupported usage grouping: %s", groupBy)
+	}
+}
+
+/*
+usageQuery builds the aggregate query over usage_ledger, placeholders are userID, from, to and optionally projectID
+in that order. Spend stays with the project the chat was in at the time and purged chats keep counting, their
+label is empty. Summaries have no message, they add to the tokens and cost but not to message_count.
+*/
+func usageQuery(groupBy UsageGroupBy, dayExpr string, placeholder func(int) string, withProject bool) (string, error) {
+	key, label, err := usageKeyColumns(groupBy, dayExpr)
+	if err != nil {
+		return "", err
+	}
+
+	query := `
+		SELECT ` + key + ` AS key, ` + label + ` AS label,
+			COALESCE(SUM(ul.input_token_count), 0) AS input_tokens,
+			COALESCE(SUM(ul.output_token_count), 0) AS output_tokens,
+			COALESCE(SUM(ul.cost), 0) AS cost,
+			COUNT(ul.message_id) AS message_count
+		FROM usage_ledger ul
+		LEFT JOIN chat_list cl ON cl.chat_id = ul.chat_id
+		LEFT JOIN model_metadata mm ON mm.id = ul.model
+		LEFT JOIN project p ON p.id = ul.project_id
+		WHERE ul.user_id = ` + placeholder(1) + `
+			AND ul.created_at >= ` + placeholder(2) + ` AND ul.created_at < ` + placeholder(3)
+	if withProject {
+		query += ` AND ul.project_id = ` + placeholder(4)
+	}
+	query += `
+		GROUP BY ` + key + `, ` + label
+
+	if groupBy == UsageByDay {
+		query += " ORDER BY key"
+	} else {
+		query += " ORDER BY cost DESC, key"
+	}
+	return query, nil
+}
+
+// spendQuery builds the query behind GetSpend, placeholders are since followed by the non empty filters
+func spendQuery(placeholder func(int) string, userID string, projectID string) string {
+	query := `
+		SELECT COALESCE(SUM(ul.cost), 0)
+		FROM usage_ledger ul
+		WHERE ul.created_at >= ` + placeholder(1)
+
+	n := 1
+	if userID != "" {
+		n++
+		query += ` AND ul.user_id = ` + placeholder(n)
+	}
+	if projectID != "" {
+		n++
+		query += ` AND ul.project_id = ` + placeholder(n)
+	}
+	return query
 }
 
 type dbSettings struct {
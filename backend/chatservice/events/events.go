@@ -3,4 +3,6 @@ package events
 const (
 	SETTINGS_CHANGED_EVENT = "settings.changed"
 	GENERATE_EMBEDDINGS    = "generate.embedding"
+	BUDGET_WARNING_EVENT   = "budget.warning"
+	SUMMARIZE_CHAT_EVENT   = "chat.summarize"
 )
@@ -21,6 +21,213 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ReasoningEffort int32
+
+const (
+	ReasoningEffort_REASONING_EFFORT_UNSPECIFIED ReasoningEffort = 0
+	ReasoningEffort_REASONING_EFFORT_LOW         ReasoningEffort = 1
+	ReasoningEffort_REASONING_EFFORT_MEDIUM      ReasoningEffort = 2
+	ReasoningEffort_REASONING_EFFORT_HIGH        ReasoningEffort = 3
+)
+
+// Enum value maps for ReasoningEffort.
+var (
+	ReasoningEffort_name = map[int32]string{
+		0: "REASONING_EFFORT_UNSPECIFIED",
+		1: "REASONING_EFFORT_LOW",
+		2: "REASONING_EFFORT_MEDIUM",
+		3: "REASONING_EFFORT_HIGH",
+	}
+	ReasoningEffort_value = map[string]int32{
+		"REASONING_EFFORT_UNSPECIFIED": 0,
+		"REASONING_EFFORT_LOW":         1,
+		"REASONING_EFFORT_MEDIUM":      2,
+		"REASONING_EFFORT_HIGH":        3,
+	}
+)
+
+func (x ReasoningEffort) Enum() *ReasoningEffort {
+	p := new(ReasoningEffort)
+	*p = x
+	return p
+}
+
+func (x ReasoningEffort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReasoningEffort) Descriptor() protoreflect.EnumDescriptor {
+	return file_chatservice_proto_enumTypes[0].Descriptor()
+}
+
+func (ReasoningEffort) Type() protoreflect.EnumType {
+	return &file_chatservice_proto_enumTypes[0]
+}
+
+func (x ReasoningEffort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReasoningEffort.Descriptor instead.
+func (ReasoningEffort) EnumDescriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{0}
+}
+
+// SortOrder is the direction of a sorted list, the default depends on the list
+type SortOrder int32
+
+const (
+	SortOrder_SORT_ORDER_DEFAULT    SortOrder = 0
+	SortOrder_SORT_ORDER_ASCENDING  SortOrder = 1
+	SortOrder_SORT_ORDER_DESCENDING SortOrder = 2
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "SORT_ORDER_DEFAULT",
+		1: "SORT_ORDER_ASCENDING",
+		2: "SORT_ORDER_DESCENDING",
+	}
+	SortOrder_value = map[string]int32{
+		"SORT_ORDER_DEFAULT":    0,
+		"SORT_ORDER_ASCENDING":  1,
+		"SORT_ORDER_DESCENDING": 2,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_chatservice_proto_enumTypes[1].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_chatservice_proto_enumTypes[1]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{1}
+}
+
+// ChatSort is what the chat list is sorted by, pinned chats always come first
+type ChatSort int32
+
+const (
+	ChatSort_CHAT_SORT_LAST_MESSAGE ChatSort = 0 // most recent activity first by default, chats without messages by creation
+	ChatSort_CHAT_SORT_CREATED      ChatSort = 1 // newest first by default
+	ChatSort_CHAT_SORT_UPDATED      ChatSort = 2 // most recently changed first by default
+	ChatSort_CHAT_SORT_NAME         ChatSort = 3 // A to Z by default, case insensitive
+)
+
+// Enum value maps for ChatSort.
+var (
+	ChatSort_name = map[int32]string{
+		0: "CHAT_SORT_LAST_MESSAGE",
+		1: "CHAT_SORT_CREATED",
+		2: "CHAT_SORT_UPDATED",
+		3: "CHAT_SORT_NAME",
+	}
+	ChatSort_value = map[string]int32{
+		"CHAT_SORT_LAST_MESSAGE": 0,
+		"CHAT_SORT_CREATED":      1,
+		"CHAT_SORT_UPDATED":      2,
+		"CHAT_SORT_NAME":         3,
+	}
+)
+
+func (x ChatSort) Enum() *ChatSort {
+	p := new(ChatSort)
+	*p = x
+	return p
+}
+
+func (x ChatSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChatSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_chatservice_proto_enumTypes[2].Descriptor()
+}
+
+func (ChatSort) Type() protoreflect.EnumType {
+	return &file_chatservice_proto_enumTypes[2]
+}
+
+func (x ChatSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChatSort.Descriptor instead.
+func (ChatSort) EnumDescriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{2}
+}
+
+type UsageGroupBy int32
+
+const (
+	UsageGroupBy_USAGE_BY_DAY     UsageGroupBy = 0
+	UsageGroupBy_USAGE_BY_MODEL   UsageGroupBy = 1
+	UsageGroupBy_USAGE_BY_PROJECT UsageGroupBy = 2
+	UsageGroupBy_USAGE_BY_CHAT    UsageGroupBy = 3
+)
+
+// Enum value maps for UsageGroupBy.
+var (
+	UsageGroupBy_name = map[int32]string{
+		0: "USAGE_BY_DAY",
+		1: "USAGE_BY_MODEL",
+		2: "USAGE_BY_PROJECT",
+		3: "USAGE_BY_CHAT",
+	}
+	UsageGroupBy_value = map[string]int32{
+		"USAGE_BY_DAY":     0,
+		"USAGE_BY_MODEL":   1,
+		"USAGE_BY_PROJECT": 2,
+		"USAGE_BY_CHAT":    3,
+	}
+)
+
+func (x UsageGroupBy) Enum() *UsageGroupBy {
+	p := new(UsageGroupBy)
+	*p = x
+	return p
+}
+
+func (x UsageGroupBy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UsageGroupBy) Descriptor() protoreflect.EnumDescriptor {
+	return file_chatservice_proto_enumTypes[3].Descriptor()
+}
+
+func (UsageGroupBy) Type() protoreflect.EnumType {
+	return &file_chatservice_proto_enumTypes[3]
+}
+
+func (x UsageGroupBy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UsageGroupBy.Descriptor instead.
+func (UsageGroupBy) EnumDescriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{3}
+}
+
 type Embedding_Status int32
 
 const (
@@ -57,11 +264,11 @@ func (x Embedding_Status) String() string {
 }
 
 func (Embedding_Status) Descriptor() protoreflect.EnumDescriptor {
-	return file_chatservice_proto_enumTypes[0].Descriptor()
+	return file_chatservice_proto_enumTypes[4].Descriptor()
 }
 
 func (Embedding_Status) Type() protoreflect.EnumType {
-	return &file_chatservice_proto_enumTypes[0]
+	return &file_chatservice_proto_enumTypes[4]
 }
 
 func (x Embedding_Status) Number() protoreflect.EnumNumber {
@@ -70,16 +277,125 @@ func (x Embedding_Status) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Embedding_Status.Descriptor instead.
 func (Embedding_Status) EnumDescriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{0}
+	return file_chatservice_proto_rawDescGZIP(), []int{4}
+}
+
+type ApiTokenScope int32
+
+const (
+	ApiTokenScope_API_TOKEN_SCOPE_UNSPECIFIED ApiTokenScope = 0
+	ApiTokenScope_API_TOKEN_SCOPE_READ_ONLY   ApiTokenScope = 1 // history, chat list, projects, documents, models, usage, search
+	ApiTokenScope_API_TOKEN_SCOPE_CHAT        ApiTokenScope = 2 // read-only plus sending messages, creating chats/projects and uploads
+	ApiTokenScope_API_TOKEN_SCOPE_ADMIN       ApiTokenScope = 3 // everything, including settings, the model catalog and API tokens
+)
+
+// Enum value maps for ApiTokenScope.
+var (
+	ApiTokenScope_name = map[int32]string{
+		0: "API_TOKEN_SCOPE_UNSPECIFIED",
+		1: "API_TOKEN_SCOPE_READ_ONLY",
+		2: "API_TOKEN_SCOPE_CHAT",
+		3: "API_TOKEN_SCOPE_ADMIN",
+	}
+	ApiTokenScope_value = map[string]int32{
+		"API_TOKEN_SCOPE_UNSPECIFIED": 0,
+		"API_TOKEN_SCOPE_READ_ONLY":   1,
+		"API_TOKEN_SCOPE_CHAT":        2,
+		"API_TOKEN_SCOPE_ADMIN":       3,
+	}
+)
+
+func (x ApiTokenScope) Enum() *ApiTokenScope {
+	p := new(ApiTokenScope)
+	*p = x
+	return p
+}
+
+func (x ApiTokenScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ApiTokenScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_chatservice_proto_enumTypes[5].Descriptor()
+}
+
+func (ApiTokenScope) Type() protoreflect.EnumType {
+	return &file_chatservice_proto_enumTypes[5]
+}
+
+func (x ApiTokenScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ApiTokenScope.Descriptor instead.
+func (ApiTokenScope) EnumDescriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{5}
+}
+
+type WorkspaceRole int32
+
+const (
+	WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED WorkspaceRole = 0
+	WorkspaceRole_WORKSPACE_ROLE_OWNER       WorkspaceRole = 1 // manages members, plus everything an editor can do
+	WorkspaceRole_WORKSPACE_ROLE_EDITOR      WorkspaceRole = 2 // chats, uploads and new projects in the workspace
+	WorkspaceRole_WORKSPACE_ROLE_VIEWER      WorkspaceRole = 3 // reads projects, documents and chats
+)
+
+// Enum value maps for WorkspaceRole.
+var (
+	WorkspaceRole_name = map[int32]string{
+		0: "WORKSPACE_ROLE_UNSPECIFIED",
+		1: "WORKSPACE_ROLE_OWNER",
+		2: "WORKSPACE_ROLE_EDITOR",
+		3: "WORKSPACE_ROLE_VIEWER",
+	}
+	WorkspaceRole_value = map[string]int32{
+		"WORKSPACE_ROLE_UNSPECIFIED": 0,
+		"WORKSPACE_ROLE_OWNER":       1,
+		"WORKSPACE_ROLE_EDITOR":      2,
+		"WORKSPACE_ROLE_VIEWER":      3,
+	}
+)
+
+func (x WorkspaceRole) Enum() *WorkspaceRole {
+	p := new(WorkspaceRole)
+	*p = x
+	return p
+}
+
+func (x WorkspaceRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_chatservice_proto_enumTypes[6].Descriptor()
+}
+
+func (WorkspaceRole) Type() protoreflect.EnumType {
+	return &file_chatservice_proto_enumTypes[6]
+}
+
+func (x WorkspaceRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkspaceRole.Descriptor instead.
+func (WorkspaceRole) EnumDescriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{6}
 }
 
 type Settings struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	OPENAI_API_KEY string                 `protobuf:"bytes,1,opt,name=OPENAI_API_KEY,json=OPENAIAPIKEY,proto3" json:"OPENAI_API_KEY,omitempty"`
-	OPENAI_API_URL string                 `protobuf:"bytes,2,opt,name=OPENAI_API_URL,json=OPENAIAPIURL,proto3" json:"OPENAI_API_URL,omitempty"`
-	OLLAMA_URL     string                 `protobuf:"bytes,3,opt,name=OLLAMA_URL,json=OLLAMAURL,proto3" json:"OLLAMA_URL,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	OPENAI_API_KEY    string                 `protobuf:"bytes,1,opt,name=OPENAI_API_KEY,json=OPENAIAPIKEY,proto3" json:"OPENAI_API_KEY,omitempty"`          // Deprecated: use providers
+	OPENAI_API_URL    string                 `protobuf:"bytes,2,opt,name=OPENAI_API_URL,json=OPENAIAPIURL,proto3" json:"OPENAI_API_URL,omitempty"`          // Deprecated: use providers
+	OLLAMA_URL        string                 `protobuf:"bytes,3,opt,name=OLLAMA_URL,json=OLLAMAURL,proto3" json:"OLLAMA_URL,omitempty"`                     // embeddings endpoint
+	ANTHROPIC_API_KEY string                 `protobuf:"bytes,4,opt,name=ANTHROPIC_API_KEY,json=ANTHROPICAPIKEY,proto3" json:"ANTHROPIC_API_KEY,omitempty"` // Deprecated: use providers
+	GEMINI_API_KEY    string                 `protobuf:"bytes,5,opt,name=GEMINI_API_KEY,json=GEMINIAPIKEY,proto3" json:"GEMINI_API_KEY,omitempty"`          // Deprecated: use providers
+	Providers         []*ProviderConfig      `protobuf:"bytes,6,rep,name=providers,proto3" json:"providers,omitempty"`
+	Budgets           []*Budget              `protobuf:"bytes,7,rep,name=budgets,proto3" json:"budgets,omitempty"`
+	Summarization     *SummarizationSettings `protobuf:"bytes,8,opt,name=summarization,proto3" json:"summarization,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *Settings) Reset() {
@@ -116,44 +432,4848 @@ func (x *Settings) GetOPENAI_API_KEY() string {
 	if x != nil {
 		return x.OPENAI_API_KEY
 	}
-	return ""
+	return ""
+}
+
+func (x *Settings) GetOPENAI_API_URL() string {
+	if x != nil {
+		return x.OPENAI_API_URL
+	}
+	return ""
+}
+
+func (x *Settings) GetOLLAMA_URL() string {
+	if x != nil {
+		return x.OLLAMA_URL
+	}
+	return ""
+}
+
+func (x *Settings) GetANTHROPIC_API_KEY() string {
+	if x != nil {
+		return x.ANTHROPIC_API_KEY
+	}
+	return ""
+}
+
+func (x *Settings) GetGEMINI_API_KEY() string {
+	if x != nil {
+		return x.GEMINI_API_KEY
+	}
+	return ""
+}
+
+func (x *Settings) GetProviders() []*ProviderConfig {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+func (x *Settings) GetBudgets() []*Budget {
+	if x != nil {
+		return x.Budgets
+	}
+	return nil
+}
+
+func (x *Settings) GetSummarization() *SummarizationSettings {
+	if x != nil {
+		return x.Summarization
+	}
+	return nil
+}
+
+// SummarizationSettings control the rolling summary of long chats, older messages are sent as a summary
+type SummarizationSettings struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Model              string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`                                                        // cheap model writing the summaries, empty disables summarization
+	ThresholdTokens    int32                  `protobuf:"varint,2,opt,name=threshold_tokens,json=thresholdTokens,proto3" json:"threshold_tokens,omitempty"`            // history size that starts a summary, 0 for 8000
+	KeepRecentMessages int32                  `protobuf:"varint,3,opt,name=keep_recent_messages,json=keepRecentMessages,proto3" json:"keep_recent_messages,omitempty"` // newest messages always sent as they are, 0 for 10
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SummarizationSettings) Reset() {
+	*x = SummarizationSettings{}
+	mi := &file_chatservice_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SummarizationSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SummarizationSettings) ProtoMessage() {}
+
+func (x *SummarizationSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SummarizationSettings.ProtoReflect.Descriptor instead.
+func (*SummarizationSettings) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SummarizationSettings) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *SummarizationSettings) GetThresholdTokens() int32 {
+	if x != nil {
+		return x.ThresholdTokens
+	}
+	return 0
+}
+
+func (x *SummarizationSettings) GetKeepRecentMessages() int32 {
+	if x != nil {
+		return x.KeepRecentMessages
+	}
+	return 0
+}
+
+// Budget caps the cost of completions over a day or a calendar month (UTC)
+type Budget struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Scope            string                 `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`                                                   // global, project or user
+	ScopeId          string                 `protobuf:"bytes,2,opt,name=scope_id,json=scopeId,proto3" json:"scope_id,omitempty"`                                // project or user id, empty applies the budget to each project/user separately
+	Period           string                 `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`                                                 // daily or monthly
+	Limit            float64                `protobuf:"fixed64,4,opt,name=limit,proto3" json:"limit,omitempty"`                                                 // USD
+	SoftLimitPercent float64                `protobuf:"fixed64,5,opt,name=soft_limit_percent,json=softLimitPercent,proto3" json:"soft_limit_percent,omitempty"` // publishes a budget.warning event once usage crosses this share of the limit, 0 disables
+	DowngradeModel   string                 `protobuf:"bytes,6,opt,name=downgrade_model,json=downgradeModel,proto3" json:"downgrade_model,omitempty"`           // when exceeded, requests use this model instead of being refused
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Budget) Reset() {
+	*x = Budget{}
+	mi := &file_chatservice_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Budget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Budget) ProtoMessage() {}
+
+func (x *Budget) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Budget.ProtoReflect.Descriptor instead.
+func (*Budget) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Budget) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *Budget) GetScopeId() string {
+	if x != nil {
+		return x.ScopeId
+	}
+	return ""
+}
+
+func (x *Budget) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *Budget) GetLimit() float64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *Budget) GetSoftLimitPercent() float64 {
+	if x != nil {
+		return x.SoftLimitPercent
+	}
+	return 0
+}
+
+func (x *Budget) GetDowngradeModel() string {
+	if x != nil {
+		return x.DowngradeModel
+	}
+	return ""
+}
+
+type ProviderConfig struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // matched against the provider of a model
+	Type           string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // openai, anthropic, gemini, ollama
+	BaseUrl        string                 `protobuf:"bytes,3,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	ApiKey         string                 `protobuf:"bytes,4,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	Headers        map[string]string      `protobuf:"bytes,5,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // extra headers sent with every request
+	TimeoutSeconds int32                  `protobuf:"varint,6,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ProviderConfig) Reset() {
+	*x = ProviderConfig{}
+	mi := &file_chatservice_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProviderConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderConfig) ProtoMessage() {}
+
+func (x *ProviderConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderConfig.ProtoReflect.Descriptor instead.
+func (*ProviderConfig) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProviderConfig) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *ProviderConfig) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+type GetSettingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSettingRequest) Reset() {
+	*x = GetSettingRequest{}
+	mi := &file_chatservice_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSettingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSettingRequest) ProtoMessage() {}
+
+func (x *GetSettingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSettingRequest.ProtoReflect.Descriptor instead.
+func (*GetSettingRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{4}
+}
+
+type GetSettingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *Settings              `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSettingResponse) Reset() {
+	*x = GetSettingResponse{}
+	mi := &file_chatservice_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSettingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSettingResponse) ProtoMessage() {}
+
+func (x *GetSettingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSettingResponse.ProtoReflect.Descriptor instead.
+func (*GetSettingResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetSettingResponse) GetSettings() *Settings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SetSettingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *Settings              `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSettingRequest) Reset() {
+	*x = SetSettingRequest{}
+	mi := &file_chatservice_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSettingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSettingRequest) ProtoMessage() {}
+
+func (x *SetSettingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSettingRequest.ProtoReflect.Descriptor instead.
+func (*SetSettingRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetSettingRequest) GetSettings() *Settings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SetSettingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSettingResponse) Reset() {
+	*x = SetSettingResponse{}
+	mi := &file_chatservice_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSettingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSettingResponse) ProtoMessage() {}
+
+func (x *SetSettingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSettingResponse.ProtoReflect.Descriptor instead.
+func (*SetSettingResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SetSettingResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type CreateChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateChatRequest) Reset() {
+	*x = CreateChatRequest{}
+	mi := &file_chatservice_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateChatRequest) ProtoMessage() {}
+
+func (x *CreateChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateChatRequest.ProtoReflect.Descriptor instead.
+func (*CreateChatRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateChatRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateChatRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type CreateChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	ChatId        string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateChatResponse) Reset() {
+	*x = CreateChatResponse{}
+	mi := &file_chatservice_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateChatResponse) ProtoMessage() {}
+
+func (x *CreateChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateChatResponse.ProtoReflect.Descriptor instead.
+func (*CreateChatResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateChatResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateChatResponse) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+type ChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	ChatId        string                 `protobuf:"bytes,2,opt,name=chatId,proto3" json:"chatId,omitempty"`
+	Model         string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,4,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Options       *GenerationOptions     `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatRequest) Reset() {
+	*x = ChatRequest{}
+	mi := &file_chatservice_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatRequest) ProtoMessage() {}
+
+func (x *ChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatRequest.ProtoReflect.Descriptor instead.
+func (*ChatRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ChatRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ChatRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ChatRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ChatRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ChatRequest) GetOptions() *GenerationOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// GenerationOptions tune how a reply is generated. Options left unset come from the chat's defaults, then from
+// its project's, and otherwise the provider's defaults apply.
+type GenerationOptions struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Temperature     *float64               `protobuf:"fixed64,1,opt,name=temperature,proto3,oneof" json:"temperature,omitempty"`                                                         // 0 to 2, at most 1 for Anthropic models
+	TopP            *float64               `protobuf:"fixed64,2,opt,name=top_p,json=topP,proto3,oneof" json:"top_p,omitempty"`                                                           // above 0, at most 1
+	MaxTokens       int32                  `protobuf:"varint,3,opt,name=max_tokens,json=maxTokens,proto3" json:"max_tokens,omitempty"`                                                   // at most the model's max_output_tokens
+	Stop            []string               `protobuf:"bytes,4,rep,name=stop,proto3" json:"stop,omitempty"`                                                                               // at most 4 stop sequences
+	Seed            *int64                 `protobuf:"varint,5,opt,name=seed,proto3,oneof" json:"seed,omitempty"`                                                                        // not supported by Anthropic models
+	ReasoningEffort ReasoningEffort        `protobuf:"varint,6,opt,name=reasoning_effort,json=reasoningEffort,proto3,enum=sortedchat.ReasoningEffort" json:"reasoning_effort,omitempty"` // models with supports_reasoning only
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GenerationOptions) Reset() {
+	*x = GenerationOptions{}
+	mi := &file_chatservice_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerationOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerationOptions) ProtoMessage() {}
+
+func (x *GenerationOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerationOptions.ProtoReflect.Descriptor instead.
+func (*GenerationOptions) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GenerationOptions) GetTemperature() float64 {
+	if x != nil && x.Temperature != nil {
+		return *x.Temperature
+	}
+	return 0
+}
+
+func (x *GenerationOptions) GetTopP() float64 {
+	if x != nil && x.TopP != nil {
+		return *x.TopP
+	}
+	return 0
+}
+
+func (x *GenerationOptions) GetMaxTokens() int32 {
+	if x != nil {
+		return x.MaxTokens
+	}
+	return 0
+}
+
+func (x *GenerationOptions) GetStop() []string {
+	if x != nil {
+		return x.Stop
+	}
+	return nil
+}
+
+func (x *GenerationOptions) GetSeed() int64 {
+	if x != nil && x.Seed != nil {
+		return *x.Seed
+	}
+	return 0
+}
+
+func (x *GenerationOptions) GetReasoningEffort() ReasoningEffort {
+	if x != nil {
+		return x.ReasoningEffort
+	}
+	return ReasoningEffort_REASONING_EFFORT_UNSPECIFIED
+}
+
+type ChatResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*ChatResponse_Text
+	//	*ChatResponse_Summary
+	//	*ChatResponse_Truncation
+	Response      isChatResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatResponse) Reset() {
+	*x = ChatResponse{}
+	mi := &file_chatservice_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatResponse) ProtoMessage() {}
+
+func (x *ChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatResponse.ProtoReflect.Descriptor instead.
+func (*ChatResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ChatResponse) GetResponse() isChatResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ChatResponse) GetText() string {
+	if x != nil {
+		if x, ok := x.Response.(*ChatResponse_Text); ok {
+			return x.Text
+		}
+	}
+	return ""
+}
+
+func (x *ChatResponse) GetSummary() *MessageSummary {
+	if x != nil {
+		if x, ok := x.Response.(*ChatResponse_Summary); ok {
+			return x.Summary
+		}
+	}
+	return nil
+}
+
+func (x *ChatResponse) GetTruncation() *ContextTruncation {
+	if x != nil {
+		if x, ok := x.Response.(*ChatResponse_Truncation); ok {
+			return x.Truncation
+		}
+	}
+	return nil
+}
+
+type isChatResponse_Response interface {
+	isChatResponse_Response()
+}
+
+type ChatResponse_Text struct {
+	Text string `protobuf:"bytes,1,opt,name=text,proto3,oneof"`
+}
+
+type ChatResponse_Summary struct {
+	Summary *MessageSummary `protobuf:"bytes,2,opt,name=summary,proto3,oneof"`
+}
+
+type ChatResponse_Truncation struct {
+	Truncation *ContextTruncation `protobuf:"bytes,3,opt,name=truncation,proto3,oneof"`
+}
+
+func (*ChatResponse_Text) isChatResponse_Response() {}
+
+func (*ChatResponse_Summary) isChatResponse_Response() {}
+
+func (*ChatResponse_Truncation) isChatResponse_Response() {}
+
+// ContextTruncation is sent before the reply when the history didn't fit into the model's context window
+type ContextTruncation struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DroppedMessages int32                  `protobuf:"varint,1,opt,name=dropped_messages,json=droppedMessages,proto3" json:"dropped_messages,omitempty"` // oldest messages left out of the request
+	TrimmedMessages int32                  `protobuf:"varint,2,opt,name=trimmed_messages,json=trimmedMessages,proto3" json:"trimmed_messages,omitempty"` // messages shortened in the middle to fit
+	FirstMessageId  string                 `protobuf:"bytes,3,opt,name=first_message_id,json=firstMessageId,proto3" json:"first_message_id,omitempty"`   // oldest message that was sent, empty if it is the new one
+	PromptTokens    int32                  `protobuf:"varint,4,opt,name=prompt_tokens,json=promptTokens,proto3" json:"prompt_tokens,omitempty"`          // estimated with a local tokenizer
+	ContextWindow   int32                  `protobuf:"varint,5,opt,name=context_window,json=contextWindow,proto3" json:"context_window,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ContextTruncation) Reset() {
+	*x = ContextTruncation{}
+	mi := &file_chatservice_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContextTruncation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContextTruncation) ProtoMessage() {}
+
+func (x *ContextTruncation) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContextTruncation.ProtoReflect.Descriptor instead.
+func (*ContextTruncation) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ContextTruncation) GetDroppedMessages() int32 {
+	if x != nil {
+		return x.DroppedMessages
+	}
+	return 0
+}
+
+func (x *ContextTruncation) GetTrimmedMessages() int32 {
+	if x != nil {
+		return x.TrimmedMessages
+	}
+	return 0
+}
+
+func (x *ContextTruncation) GetFirstMessageId() string {
+	if x != nil {
+		return x.FirstMessageId
+	}
+	return ""
+}
+
+func (x *ContextTruncation) GetPromptTokens() int32 {
+	if x != nil {
+		return x.PromptTokens
+	}
+	return 0
+}
+
+func (x *ContextTruncation) GetContextWindow() int32 {
+	if x != nil {
+		return x.ContextWindow
+	}
+	return 0
+}
+
+type MessageSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Interrupted   bool                   `protobuf:"varint,2,opt,name=interrupted,proto3" json:"interrupted,omitempty"` // the reply was cancelled before it was complete
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageSummary) Reset() {
+	*x = MessageSummary{}
+	mi := &file_chatservice_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageSummary) ProtoMessage() {}
+
+func (x *MessageSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageSummary.ProtoReflect.Descriptor instead.
+func (*MessageSummary) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MessageSummary) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *MessageSummary) GetInterrupted() bool {
+	if x != nil {
+		return x.Interrupted
+	}
+	return false
+}
+
+type CancelChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelChatRequest) Reset() {
+	*x = CancelChatRequest{}
+	mi := &file_chatservice_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelChatRequest) ProtoMessage() {}
+
+func (x *CancelChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelChatRequest.ProtoReflect.Descriptor instead.
+func (*CancelChatRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CancelChatRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+type CancelChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelChatResponse) Reset() {
+	*x = CancelChatResponse{}
+	mi := &file_chatservice_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelChatResponse) ProtoMessage() {}
+
+func (x *CancelChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelChatResponse.ProtoReflect.Descriptor instead.
+func (*CancelChatResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CancelChatResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type RegenerateMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"` // assistant message to generate again
+	Model         string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`                          // defaults to the model of the replaced message
+	Options       *GenerationOptions     `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegenerateMessageRequest) Reset() {
+	*x = RegenerateMessageRequest{}
+	mi := &file_chatservice_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateMessageRequest) ProtoMessage() {}
+
+func (x *RegenerateMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateMessageRequest.ProtoReflect.Descriptor instead.
+func (*RegenerateMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RegenerateMessageRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *RegenerateMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *RegenerateMessageRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *RegenerateMessageRequest) GetOptions() *GenerationOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type EditMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"` // user message to replace
+	NewText       string                 `protobuf:"bytes,3,opt,name=new_text,json=newText,proto3" json:"new_text,omitempty"`
+	Model         string                 `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"` // defaults to the model of the reply to the replaced message
+	Options       *GenerationOptions     `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMessageRequest) Reset() {
+	*x = EditMessageRequest{}
+	mi := &file_chatservice_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMessageRequest) ProtoMessage() {}
+
+func (x *EditMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMessageRequest.ProtoReflect.Descriptor instead.
+func (*EditMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *EditMessageRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetNewText() string {
+	if x != nil {
+		return x.NewText
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetOptions() *GenerationOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type ResumeChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	FromOffset    int32                  `protobuf:"varint,2,opt,name=from_offset,json=fromOffset,proto3" json:"from_offset,omitempty"` // number of ChatResponse messages already received, 0 replays everything
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeChatRequest) Reset() {
+	*x = ResumeChatRequest{}
+	mi := &file_chatservice_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeChatRequest) ProtoMessage() {}
+
+func (x *ResumeChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeChatRequest.ProtoReflect.Descriptor instead.
+func (*ResumeChatRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ResumeChatRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ResumeChatRequest) GetFromOffset() int32 {
+	if x != nil {
+		return x.FromOffset
+	}
+	return 0
+}
+
+type GetHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chatId,proto3" json:"chatId,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`     // 0 returns the whole history, at most 200 messages per page otherwise
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`   // next_page_token of the previous page
+	Order         SortOrder              `protobuf:"varint,4,opt,name=order,proto3,enum=sortedchat.SortOrder" json:"order,omitempty"` // oldest message first by default, descending pages go back from the newest
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryRequest) Reset() {
+	*x = GetHistoryRequest{}
+	mi := &file_chatservice_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryRequest) ProtoMessage() {}
+
+func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetHistoryRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *GetHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetHistoryRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_DEFAULT
+}
+
+type GetHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	History       []*ChatMessage         `protobuf:"bytes,1,rep,name=history,proto3" json:"history,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryResponse) Reset() {
+	*x = GetHistoryResponse{}
+	mi := &file_chatservice_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryResponse) ProtoMessage() {}
+
+func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetHistoryResponse) GetHistory() []*ChatMessage {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *GetHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ChatMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	MessageId     string                 `protobuf:"bytes,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Interrupted   bool                   `protobuf:"varint,4,opt,name=interrupted,proto3" json:"interrupted,omitempty"`
+	Alternatives  int32                  `protobuf:"varint,5,opt,name=alternatives,proto3" json:"alternatives,omitempty"` // versions of this turn, including this one
+	Options       *GenerationOptions     `protobuf:"bytes,6,opt,name=options,proto3" json:"options,omitempty"`            // replies only, the options they were generated with
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatMessage) Reset() {
+	*x = ChatMessage{}
+	mi := &file_chatservice_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatMessage) ProtoMessage() {}
+
+func (x *ChatMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatMessage.ProtoReflect.Descriptor instead.
+func (*ChatMessage) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ChatMessage) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetInterrupted() bool {
+	if x != nil {
+		return x.Interrupted
+	}
+	return false
+}
+
+func (x *ChatMessage) GetAlternatives() int32 {
+	if x != nil {
+		return x.Alternatives
+	}
+	return 0
+}
+
+func (x *ChatMessage) GetOptions() *GenerationOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type GetChatListRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId       string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	IncludeArchived bool                   `protobuf:"varint,2,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	PageSize        int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // 0 returns every chat, at most 200 chats per page otherwise
+	PageToken       string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page, only valid with the same sort and order
+	Sort            ChatSort               `protobuf:"varint,5,opt,name=sort,proto3,enum=sortedchat.ChatSort" json:"sort,omitempty"`
+	Order           SortOrder              `protobuf:"varint,6,opt,name=order,proto3,enum=sortedchat.SortOrder" json:"order,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetChatListRequest) Reset() {
+	*x = GetChatListRequest{}
+	mi := &file_chatservice_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChatListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChatListRequest) ProtoMessage() {}
+
+func (x *GetChatListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChatListRequest.ProtoReflect.Descriptor instead.
+func (*GetChatListRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetChatListRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *GetChatListRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+func (x *GetChatListRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetChatListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetChatListRequest) GetSort() ChatSort {
+	if x != nil {
+		return x.Sort
+	}
+	return ChatSort_CHAT_SORT_LAST_MESSAGE
+}
+
+func (x *GetChatListRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_DEFAULT
+}
+
+type GetChatListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chats         []*ChatInfo            `protobuf:"bytes,1,rep,name=chats,proto3" json:"chats,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChatListResponse) Reset() {
+	*x = GetChatListResponse{}
+	mi := &file_chatservice_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChatListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChatListResponse) ProtoMessage() {}
+
+func (x *GetChatListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChatListResponse.ProtoReflect.Descriptor instead.
+func (*GetChatListResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetChatListResponse) GetChats() []*ChatInfo {
+	if x != nil {
+		return x.Chats
+	}
+	return nil
+}
+
+func (x *GetChatListResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ChatInfo struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ChatId string                 `protobuf:"bytes,1,opt,name=chatId,proto3" json:"chatId,omitempty"`
+	Name   string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// chat this branch was branched from, empty for main branches
+	ParentChatId      string             `protobuf:"bytes,3,opt,name=parent_chat_id,json=parentChatId,proto3" json:"parent_chat_id,omitempty"`
+	IsMainBranch      bool               `protobuf:"varint,4,opt,name=is_main_branch,json=isMainBranch,proto3" json:"is_main_branch,omitempty"`
+	Pinned            bool               `protobuf:"varint,5,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	Archived          bool               `protobuf:"varint,6,opt,name=archived,proto3" json:"archived,omitempty"`
+	CreatedAt         string             `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                          // RFC 3339 in UTC
+	UpdatedAt         string             `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                          // RFC 3339 in UTC, renaming, moving, pinning, archiving and new messages
+	LastMessageAt     string             `protobuf:"bytes,9,opt,name=last_message_at,json=lastMessageAt,proto3" json:"last_message_at,omitempty"`            // RFC 3339 in UTC, empty for chats without messages
+	SystemPrompt      string             `protobuf:"bytes,10,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`                // the chat's own, empty when it uses its project's
+	GenerationOptions *GenerationOptions `protobuf:"bytes,11,opt,name=generation_options,json=generationOptions,proto3" json:"generation_options,omitempty"` // the chat's defaults
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ChatInfo) Reset() {
+	*x = ChatInfo{}
+	mi := &file_chatservice_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatInfo) ProtoMessage() {}
+
+func (x *ChatInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatInfo.ProtoReflect.Descriptor instead.
+func (*ChatInfo) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ChatInfo) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ChatInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ChatInfo) GetParentChatId() string {
+	if x != nil {
+		return x.ParentChatId
+	}
+	return ""
+}
+
+func (x *ChatInfo) GetIsMainBranch() bool {
+	if x != nil {
+		return x.IsMainBranch
+	}
+	return false
+}
+
+func (x *ChatInfo) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *ChatInfo) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *ChatInfo) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *ChatInfo) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *ChatInfo) GetLastMessageAt() string {
+	if x != nil {
+		return x.LastMessageAt
+	}
+	return ""
+}
+
+func (x *ChatInfo) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *ChatInfo) GetGenerationOptions() *GenerationOptions {
+	if x != nil {
+		return x.GenerationOptions
+	}
+	return nil
+}
+
+type ModelListInfo struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Label             string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Provider          string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	Url               string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	InputTokenCost    float32                `protobuf:"fixed32,5,opt,name=input_token_cost,json=inputTokenCost,proto3" json:"input_token_cost,omitempty"`    // USD per 1M input tokens
+	OutputTokenCost   float32                `protobuf:"fixed32,6,opt,name=output_token_cost,json=outputTokenCost,proto3" json:"output_token_cost,omitempty"` // USD per 1M output tokens
+	SupportsVision    bool                   `protobuf:"varint,7,opt,name=supports_vision,json=supportsVision,proto3" json:"supports_vision,omitempty"`
+	SupportsTools     bool                   `protobuf:"varint,8,opt,name=supports_tools,json=supportsTools,proto3" json:"supports_tools,omitempty"`
+	ContextWindow     int32                  `protobuf:"varint,9,opt,name=context_window,json=contextWindow,proto3" json:"context_window,omitempty"`              // in tokens, 0 if unknown
+	Enabled           *bool                  `protobuf:"varint,10,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`                                        // disabled models are hidden from the model picker, unset means enabled
+	SupportsReasoning bool                   `protobuf:"varint,11,opt,name=supports_reasoning,json=supportsReasoning,proto3" json:"supports_reasoning,omitempty"` // accepts a reasoning effort
+	MaxOutputTokens   int32                  `protobuf:"varint,12,opt,name=max_output_tokens,json=maxOutputTokens,proto3" json:"max_output_tokens,omitempty"`     // 0 if unknown
+	ProviderModelId   string                 `protobuf:"bytes,13,opt,name=provider_model_id,json=providerModelId,proto3" json:"provider_model_id,omitempty"`      // model name sent to the provider, empty when it is the id
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ModelListInfo) Reset() {
+	*x = ModelListInfo{}
+	mi := &file_chatservice_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelListInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelListInfo) ProtoMessage() {}
+
+func (x *ModelListInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelListInfo.ProtoReflect.Descriptor instead.
+func (*ModelListInfo) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ModelListInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ModelListInfo) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *ModelListInfo) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ModelListInfo) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ModelListInfo) GetInputTokenCost() float32 {
+	if x != nil {
+		return x.InputTokenCost
+	}
+	return 0
+}
+
+func (x *ModelListInfo) GetOutputTokenCost() float32 {
+	if x != nil {
+		return x.OutputTokenCost
+	}
+	return 0
+}
+
+func (x *ModelListInfo) GetSupportsVision() bool {
+	if x != nil {
+		return x.SupportsVision
+	}
+	return false
+}
+
+func (x *ModelListInfo) GetSupportsTools() bool {
+	if x != nil {
+		return x.SupportsTools
+	}
+	return false
+}
+
+func (x *ModelListInfo) GetContextWindow() int32 {
+	if x != nil {
+		return x.ContextWindow
+	}
+	return 0
+}
+
+func (x *ModelListInfo) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *ModelListInfo) GetSupportsReasoning() bool {
+	if x != nil {
+		return x.SupportsReasoning
+	}
+	return false
+}
+
+func (x *ModelListInfo) GetMaxOutputTokens() int32 {
+	if x != nil {
+		return x.MaxOutputTokens
+	}
+	return 0
+}
+
+func (x *ModelListInfo) GetProviderModelId() string {
+	if x != nil {
+		return x.ProviderModelId
+	}
+	return ""
+}
+
+type ListModelsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IncludeDisabled bool                   `protobuf:"varint,1,opt,name=include_disabled,json=includeDisabled,proto3" json:"include_disabled,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListModelsRequest) Reset() {
+	*x = ListModelsRequest{}
+	mi := &file_chatservice_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModelsRequest) ProtoMessage() {}
+
+func (x *ListModelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModelsRequest.ProtoReflect.Descriptor instead.
+func (*ListModelsRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListModelsRequest) GetIncludeDisabled() bool {
+	if x != nil {
+		return x.IncludeDisabled
+	}
+	return false
+}
+
+type ListModelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Models        []*ModelListInfo       `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModelsResponse) Reset() {
+	*x = ListModelsResponse{}
+	mi := &file_chatservice_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModelsResponse) ProtoMessage() {}
+
+func (x *ListModelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModelsResponse.ProtoReflect.Descriptor instead.
+func (*ListModelsResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListModelsResponse) GetModels() []*ModelListInfo {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+type CreateModelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         *ModelListInfo         `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateModelRequest) Reset() {
+	*x = CreateModelRequest{}
+	mi := &file_chatservice_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateModelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateModelRequest) ProtoMessage() {}
+
+func (x *CreateModelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateModelRequest.ProtoReflect.Descriptor instead.
+func (*CreateModelRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateModelRequest) GetModel() *ModelListInfo {
+	if x != nil {
+		return x.Model
+	}
+	return nil
+}
+
+type CreateModelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         *ModelListInfo         `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateModelResponse) Reset() {
+	*x = CreateModelResponse{}
+	mi := &file_chatservice_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateModelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateModelResponse) ProtoMessage() {}
+
+func (x *CreateModelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateModelResponse.ProtoReflect.Descriptor instead.
+func (*CreateModelResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateModelResponse) GetModel() *ModelListInfo {
+	if x != nil {
+		return x.Model
+	}
+	return nil
+}
+
+// UpdateModelRequest replaces every field of the model with the same id
+type UpdateModelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         *ModelListInfo         `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateModelRequest) Reset() {
+	*x = UpdateModelRequest{}
+	mi := &file_chatservice_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateModelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateModelRequest) ProtoMessage() {}
+
+func (x *UpdateModelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateModelRequest.ProtoReflect.Descriptor instead.
+func (*UpdateModelRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UpdateModelRequest) GetModel() *ModelListInfo {
+	if x != nil {
+		return x.Model
+	}
+	return nil
+}
+
+type UpdateModelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         *ModelListInfo         `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateModelResponse) Reset() {
+	*x = UpdateModelResponse{}
+	mi := &file_chatservice_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateModelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateModelResponse) ProtoMessage() {}
+
+func (x *UpdateModelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateModelResponse.ProtoReflect.Descriptor instead.
+func (*UpdateModelResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UpdateModelResponse) GetModel() *ModelListInfo {
+	if x != nil {
+		return x.Model
+	}
+	return nil
+}
+
+type DeleteModelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteModelRequest) Reset() {
+	*x = DeleteModelRequest{}
+	mi := &file_chatservice_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteModelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteModelRequest) ProtoMessage() {}
+
+func (x *DeleteModelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteModelRequest.ProtoReflect.Descriptor instead.
+func (*DeleteModelRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeleteModelRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteModelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteModelResponse) Reset() {
+	*x = DeleteModelResponse{}
+	mi := &file_chatservice_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteModelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteModelResponse) ProtoMessage() {}
+
+func (x *DeleteModelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteModelResponse.ProtoReflect.Descriptor instead.
+func (*DeleteModelResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeleteModelResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// SyncModelsFromProviderRequest adds the models listed by the provider's API to the catalog.
+// New models are added disabled, models already in the catalog are left untouched.
+type SyncModelsFromProviderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"` // name of the provider in the settings
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncModelsFromProviderRequest) Reset() {
+	*x = SyncModelsFromProviderRequest{}
+	mi := &file_chatservice_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncModelsFromProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncModelsFromProviderRequest) ProtoMessage() {}
+
+func (x *SyncModelsFromProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncModelsFromProviderRequest.ProtoReflect.Descriptor instead.
+func (*SyncModelsFromProviderRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SyncModelsFromProviderRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+type SyncModelsFromProviderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Added         []*ModelListInfo       `protobuf:"bytes,1,rep,name=added,proto3" json:"added,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // number of models reported by the provider
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncModelsFromProviderResponse) Reset() {
+	*x = SyncModelsFromProviderResponse{}
+	mi := &file_chatservice_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncModelsFromProviderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncModelsFromProviderResponse) ProtoMessage() {}
+
+func (x *SyncModelsFromProviderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncModelsFromProviderResponse.ProtoReflect.Descriptor instead.
+func (*SyncModelsFromProviderResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SyncModelsFromProviderResponse) GetAdded() []*ModelListInfo {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *SyncModelsFromProviderResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"` // YYYY-MM-DD in UTC, inclusive, defaults to 30 days before `to`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`     // YYYY-MM-DD in UTC, inclusive, defaults to today
+	GroupBy       UsageGroupBy           `protobuf:"varint,3,opt,name=group_by,json=groupBy,proto3,enum=sortedchat.UsageGroupBy" json:"group_by,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,4,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"` // optional, only usage of chats in this project
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageRequest) Reset() {
+	*x = GetUsageRequest{}
+	mi := &file_chatservice_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageRequest) ProtoMessage() {}
+
+func (x *GetUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetUsageRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetUsageRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *GetUsageRequest) GetGroupBy() UsageGroupBy {
+	if x != nil {
+		return x.GroupBy
+	}
+	return UsageGroupBy_USAGE_BY_DAY
+}
+
+func (x *GetUsageRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type UsageBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`     // day, model id, project id or chat id depending on group_by
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"` // model, project or chat name
+	InputTokens   int64                  `protobuf:"varint,3,opt,name=input_tokens,json=inputTokens,proto3" json:"input_tokens,omitempty"`
+	OutputTokens  int64                  `protobuf:"varint,4,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	Cost          float64                `protobuf:"fixed64,5,opt,name=cost,proto3" json:"cost,omitempty"`                                    // USD
+	MessageCount  int64                  `protobuf:"varint,6,opt,name=message_count,json=messageCount,proto3" json:"message_count,omitempty"` // number of assistant replies, summaries only add to the tokens and cost
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsageBucket) Reset() {
+	*x = UsageBucket{}
+	mi := &file_chatservice_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageBucket) ProtoMessage() {}
+
+func (x *UsageBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageBucket.ProtoReflect.Descriptor instead.
+func (*UsageBucket) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UsageBucket) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *UsageBucket) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *UsageBucket) GetInputTokens() int64 {
+	if x != nil {
+		return x.InputTokens
+	}
+	return 0
+}
+
+func (x *UsageBucket) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+func (x *UsageBucket) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *UsageBucket) GetMessageCount() int64 {
+	if x != nil {
+		return x.MessageCount
+	}
+	return 0
+}
+
+type GetUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Buckets       []*UsageBucket         `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	Total         *UsageBucket           `protobuf:"bytes,2,opt,name=total,proto3" json:"total,omitempty"`
+	From          string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageResponse) Reset() {
+	*x = GetUsageResponse{}
+	mi := &file_chatservice_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageResponse) ProtoMessage() {}
+
+func (x *GetUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetUsageResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetUsageResponse) GetBuckets() []*UsageBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *GetUsageResponse) GetTotal() *UsageBucket {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *GetUsageResponse) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetUsageResponse) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type ChatSearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatSearchRequest) Reset() {
+	*x = ChatSearchRequest{}
+	mi := &file_chatservice_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatSearchRequest) ProtoMessage() {}
+
+func (x *ChatSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatSearchRequest.ProtoReflect.Descriptor instead.
+func (*ChatSearchRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ChatSearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+type SearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatName      string                 `protobuf:"bytes,1,opt,name=chat_name,json=chatName,proto3" json:"chat_name,omitempty"`
+	ChatId        string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MatchedText   string                 `protobuf:"bytes,3,opt,name=matched_text,json=matchedText,proto3" json:"matched_text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_chatservice_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SearchResult) GetChatName() string {
+	if x != nil {
+		return x.ChatName
+	}
+	return ""
+}
+
+func (x *SearchResult) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *SearchResult) GetMatchedText() string {
+	if x != nil {
+		return x.MatchedText
+	}
+	return ""
+}
+
+type ChatSearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Results       []*SearchResult        `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatSearchResponse) Reset() {
+	*x = ChatSearchResponse{}
+	mi := &file_chatservice_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatSearchResponse) ProtoMessage() {}
+
+func (x *ChatSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatSearchResponse.ProtoReflect.Descriptor instead.
+func (*ChatSearchResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ChatSearchResponse) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ChatSearchResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type CreateProjectRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description    string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	AdditionalData string                 `protobuf:"bytes,3,opt,name=additional_data,json=additionalData,proto3" json:"additional_data,omitempty"`
+	WorkspaceId    string                 `protobuf:"bytes,4,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`    // optional, shares the project with the workspace's members
+	SystemPrompt   string                 `protobuf:"bytes,5,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"` // optional, sent ahead of the history of the project's chats
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateProjectRequest) Reset() {
+	*x = CreateProjectRequest{}
+	mi := &file_chatservice_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectRequest) ProtoMessage() {}
+
+func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectRequest.ProtoReflect.Descriptor instead.
+func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CreateProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetAdditionalData() string {
+	if x != nil {
+		return x.AdditionalData
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+type CreateProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectResponse) Reset() {
+	*x = CreateProjectResponse{}
+	mi := &file_chatservice_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectResponse) ProtoMessage() {}
+
+func (x *CreateProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectResponse.ProtoReflect.Descriptor instead.
+func (*CreateProjectResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CreateProjectResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateProjectResponse) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type GetProjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectsRequest) Reset() {
+	*x = GetProjectsRequest{}
+	mi := &file_chatservice_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectsRequest) ProtoMessage() {}
+
+func (x *GetProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectsRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{45}
+}
+
+type GetProjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Projects      []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectsResponse) Reset() {
+	*x = GetProjectsResponse{}
+	mi := &file_chatservice_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectsResponse) ProtoMessage() {}
+
+func (x *GetProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectsResponse.ProtoReflect.Descriptor instead.
+func (*GetProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetProjectsResponse) GetProjects() []*Project {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+type Project struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description       string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	AdditionalData    string                 `protobuf:"bytes,4,opt,name=additional_data,json=additionalData,proto3" json:"additional_data,omitempty"`
+	CreatedAt         string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	WorkspaceId       string                 `protobuf:"bytes,7,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"` // empty for private projects
+	SystemPrompt      string                 `protobuf:"bytes,8,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	GenerationOptions *GenerationOptions     `protobuf:"bytes,9,opt,name=generation_options,json=generationOptions,proto3" json:"generation_options,omitempty"` // defaults of the project's chats
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Project) Reset() {
+	*x = Project{}
+	mi := &file_chatservice_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Project) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Project) ProtoMessage() {}
+
+func (x *Project) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Project.ProtoReflect.Descriptor instead.
+func (*Project) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *Project) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Project) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Project) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Project) GetAdditionalData() string {
+	if x != nil {
+		return x.AdditionalData
+	}
+	return ""
+}
+
+func (x *Project) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Project) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Project) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *Project) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *Project) GetGenerationOptions() *GenerationOptions {
+	if x != nil {
+		return x.GenerationOptions
+	}
+	return nil
+}
+
+type ListDocumentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDocumentsRequest) Reset() {
+	*x = ListDocumentsRequest{}
+	mi := &file_chatservice_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDocumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDocumentsRequest) ProtoMessage() {}
+
+func (x *ListDocumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDocumentsRequest.ProtoReflect.Descriptor instead.
+func (*ListDocumentsRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListDocumentsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type ListDocumentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Documents     []*Document            `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDocumentsResponse) Reset() {
+	*x = ListDocumentsResponse{}
+	mi := &file_chatservice_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDocumentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDocumentsResponse) ProtoMessage() {}
+
+func (x *ListDocumentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDocumentsResponse.ProtoReflect.Descriptor instead.
+func (*ListDocumentsResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListDocumentsResponse) GetDocuments() []*Document {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+type GetDocumentLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocsId        string                 `protobuf:"bytes,1,opt,name=docs_id,json=docsId,proto3" json:"docs_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDocumentLinkRequest) Reset() {
+	*x = GetDocumentLinkRequest{}
+	mi := &file_chatservice_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDocumentLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDocumentLinkRequest) ProtoMessage() {}
+
+func (x *GetDocumentLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDocumentLinkRequest.ProtoReflect.Descriptor instead.
+func (*GetDocumentLinkRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetDocumentLinkRequest) GetDocsId() string {
+	if x != nil {
+		return x.DocsId
+	}
+	return ""
+}
+
+type GetDocumentLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`                              // path and query of the download on the HTTP server, opens only this document
+	ExpiresAt     string                 `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // RFC 3339 in UTC
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDocumentLinkResponse) Reset() {
+	*x = GetDocumentLinkResponse{}
+	mi := &file_chatservice_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDocumentLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDocumentLinkResponse) ProtoMessage() {}
+
+func (x *GetDocumentLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDocumentLinkResponse.ProtoReflect.Descriptor instead.
+func (*GetDocumentLinkResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetDocumentLinkResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *GetDocumentLinkResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type Document struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId       string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	DocsId          string                 `protobuf:"bytes,3,opt,name=docs_id,json=docsId,proto3" json:"docs_id,omitempty"`
+	FileName        string                 `protobuf:"bytes,4,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	CreatedAt       string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	EmbeddingStatus Embedding_Status       `protobuf:"varint,7,opt,name=embedding_status,json=embeddingStatus,proto3,enum=sortedchat.Embedding_Status" json:"embedding_status,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Document) Reset() {
+	*x = Document{}
+	mi := &file_chatservice_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Document) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Document) ProtoMessage() {}
+
+func (x *Document) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Document.ProtoReflect.Descriptor instead.
+func (*Document) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *Document) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Document) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *Document) GetDocsId() string {
+	if x != nil {
+		return x.DocsId
+	}
+	return ""
+}
+
+func (x *Document) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *Document) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Document) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Document) GetEmbeddingStatus() Embedding_Status {
+	if x != nil {
+		return x.EmbeddingStatus
+	}
+	return Embedding_Status_STATUS_QUEUED
+}
+
+type GenerateEmbeddingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateEmbeddingRequest) Reset() {
+	*x = GenerateEmbeddingRequest{}
+	mi := &file_chatservice_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateEmbeddingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateEmbeddingRequest) ProtoMessage() {}
+
+func (x *GenerateEmbeddingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateEmbeddingRequest.ProtoReflect.Descriptor instead.
+func (*GenerateEmbeddingRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GenerateEmbeddingRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type GenerateEmbeddingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateEmbeddingResponse) Reset() {
+	*x = GenerateEmbeddingResponse{}
+	mi := &file_chatservice_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateEmbeddingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateEmbeddingResponse) ProtoMessage() {}
+
+func (x *GenerateEmbeddingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateEmbeddingResponse.ProtoReflect.Descriptor instead.
+func (*GenerateEmbeddingResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GenerateEmbeddingResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GenerateChatNameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"` //first message in new chat
+	Model         string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateChatNameRequest) Reset() {
+	*x = GenerateChatNameRequest{}
+	mi := &file_chatservice_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateChatNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateChatNameRequest) ProtoMessage() {}
+
+func (x *GenerateChatNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateChatNameRequest.ProtoReflect.Descriptor instead.
+func (*GenerateChatNameRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GenerateChatNameRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *GenerateChatNameRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GenerateChatNameRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+type GenerateChatNameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatName      string                 `protobuf:"bytes,1,opt,name=chat_name,json=chatName,proto3" json:"chat_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateChatNameResponse) Reset() {
+	*x = GenerateChatNameResponse{}
+	mi := &file_chatservice_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateChatNameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateChatNameResponse) ProtoMessage() {}
+
+func (x *GenerateChatNameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateChatNameResponse.ProtoReflect.Descriptor instead.
+func (*GenerateChatNameResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GenerateChatNameResponse) GetChatName() string {
+	if x != nil {
+		return x.ChatName
+	}
+	return ""
+}
+
+type BranchAChatRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	SourceChatId        string                 `protobuf:"bytes,1,opt,name=source_chat_id,json=sourceChatId,proto3" json:"source_chat_id,omitempty"`                        // Chat to branch from
+	BranchFromMessageId string                 `protobuf:"bytes,2,opt,name=branch_from_message_id,json=branchFromMessageId,proto3" json:"branch_from_message_id,omitempty"` // Message ID where branch starts
+	BranchName          string                 `protobuf:"bytes,3,opt,name=branch_name,json=branchName,proto3" json:"branch_name,omitempty"`                                // Optional name for new branch
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *BranchAChatRequest) Reset() {
+	*x = BranchAChatRequest{}
+	mi := &file_chatservice_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BranchAChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BranchAChatRequest) ProtoMessage() {}
+
+func (x *BranchAChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BranchAChatRequest.ProtoReflect.Descriptor instead.
+func (*BranchAChatRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *BranchAChatRequest) GetSourceChatId() string {
+	if x != nil {
+		return x.SourceChatId
+	}
+	return ""
+}
+
+func (x *BranchAChatRequest) GetBranchFromMessageId() string {
+	if x != nil {
+		return x.BranchFromMessageId
+	}
+	return ""
+}
+
+func (x *BranchAChatRequest) GetBranchName() string {
+	if x != nil {
+		return x.BranchName
+	}
+	return ""
+}
+
+type BranchAChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`                        // Success/error message
+	NewChatId     string                 `protobuf:"bytes,2,opt,name=new_chat_id,json=newChatId,proto3" json:"new_chat_id,omitempty"` // ID of newly created branch
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BranchAChatResponse) Reset() {
+	*x = BranchAChatResponse{}
+	mi := &file_chatservice_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BranchAChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BranchAChatResponse) ProtoMessage() {}
+
+func (x *BranchAChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BranchAChatResponse.ProtoReflect.Descriptor instead.
+func (*BranchAChatResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *BranchAChatResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BranchAChatResponse) GetNewChatId() string {
+	if x != nil {
+		return x.NewChatId
+	}
+	return ""
+}
+
+type ListChatBranchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChatBranchRequest) Reset() {
+	*x = ListChatBranchRequest{}
+	mi := &file_chatservice_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChatBranchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChatBranchRequest) ProtoMessage() {}
+
+func (x *ListChatBranchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChatBranchRequest.ProtoReflect.Descriptor instead.
+func (*ListChatBranchRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ListChatBranchRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+type ListChatBranchResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BranchChatList []*ChatInfo            `protobuf:"bytes,1,rep,name=branch_chat_list,json=branchChatList,proto3" json:"branch_chat_list,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListChatBranchResponse) Reset() {
+	*x = ListChatBranchResponse{}
+	mi := &file_chatservice_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChatBranchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChatBranchResponse) ProtoMessage() {}
+
+func (x *ListChatBranchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChatBranchResponse.ProtoReflect.Descriptor instead.
+func (*ListChatBranchResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListChatBranchResponse) GetBranchChatList() []*ChatInfo {
+	if x != nil {
+		return x.BranchChatList
+	}
+	return nil
+}
+
+type GetBranchTreeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// any chat of the tree
+	ChatId        string `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBranchTreeRequest) Reset() {
+	*x = GetBranchTreeRequest{}
+	mi := &file_chatservice_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBranchTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBranchTreeRequest) ProtoMessage() {}
+
+func (x *GetBranchTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBranchTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetBranchTreeRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *GetBranchTreeRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+type BranchNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Chat  *ChatInfo              `protobuf:"bytes,1,opt,name=chat,proto3" json:"chat,omitempty"`
+	// message of the parent chat the branch starts after, empty for the main branch
+	ParentMessageId string `protobuf:"bytes,2,opt,name=parent_message_id,json=parentMessageId,proto3" json:"parent_message_id,omitempty"`
+	// messages stored in the chat, for branches the ones after the branch point
+	MessageCount  int32         `protobuf:"varint,3,opt,name=message_count,json=messageCount,proto3" json:"message_count,omitempty"`
+	Children      []*BranchNode `protobuf:"bytes,4,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BranchNode) Reset() {
+	*x = BranchNode{}
+	mi := &file_chatservice_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BranchNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BranchNode) ProtoMessage() {}
+
+func (x *BranchNode) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BranchNode.ProtoReflect.Descriptor instead.
+func (*BranchNode) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *BranchNode) GetChat() *ChatInfo {
+	if x != nil {
+		return x.Chat
+	}
+	return nil
+}
+
+func (x *BranchNode) GetParentMessageId() string {
+	if x != nil {
+		return x.ParentMessageId
+	}
+	return ""
+}
+
+func (x *BranchNode) GetMessageCount() int32 {
+	if x != nil {
+		return x.MessageCount
+	}
+	return 0
+}
+
+func (x *BranchNode) GetChildren() []*BranchNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type GetBranchTreeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the main branch of the tree
+	Root          *BranchNode `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBranchTreeResponse) Reset() {
+	*x = GetBranchTreeResponse{}
+	mi := &file_chatservice_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBranchTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBranchTreeResponse) ProtoMessage() {}
+
+func (x *GetBranchTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBranchTreeResponse.ProtoReflect.Descriptor instead.
+func (*GetBranchTreeResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetBranchTreeResponse) GetRoot() *BranchNode {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+type DiffBranchesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatA         string                 `protobuf:"bytes,1,opt,name=chat_a,json=chatA,proto3" json:"chat_a,omitempty"`
+	ChatB         string                 `protobuf:"bytes,2,opt,name=chat_b,json=chatB,proto3" json:"chat_b,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffBranchesRequest) Reset() {
+	*x = DiffBranchesRequest{}
+	mi := &file_chatservice_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffBranchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffBranchesRequest) ProtoMessage() {}
+
+func (x *DiffBranchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffBranchesRequest.ProtoReflect.Descriptor instead.
+func (*DiffBranchesRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *DiffBranchesRequest) GetChatA() string {
+	if x != nil {
+		return x.ChatA
+	}
+	return ""
+}
+
+func (x *DiffBranchesRequest) GetChatB() string {
+	if x != nil {
+		return x.ChatB
+	}
+	return ""
+}
+
+type DiffBranchesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// messages both chats show, up to where they diverge
+	Common        []*ChatMessage `protobuf:"bytes,1,rep,name=common,proto3" json:"common,omitempty"`
+	OnlyA         []*ChatMessage `protobuf:"bytes,2,rep,name=only_a,json=onlyA,proto3" json:"only_a,omitempty"`
+	OnlyB         []*ChatMessage `protobuf:"bytes,3,rep,name=only_b,json=onlyB,proto3" json:"only_b,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffBranchesResponse) Reset() {
+	*x = DiffBranchesResponse{}
+	mi := &file_chatservice_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffBranchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffBranchesResponse) ProtoMessage() {}
+
+func (x *DiffBranchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffBranchesResponse.ProtoReflect.Descriptor instead.
+func (*DiffBranchesResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *DiffBranchesResponse) GetCommon() []*ChatMessage {
+	if x != nil {
+		return x.Common
+	}
+	return nil
+}
+
+func (x *DiffBranchesResponse) GetOnlyA() []*ChatMessage {
+	if x != nil {
+		return x.OnlyA
+	}
+	return nil
+}
+
+func (x *DiffBranchesResponse) GetOnlyB() []*ChatMessage {
+	if x != nil {
+		return x.OnlyB
+	}
+	return nil
+}
+
+type SummarizeBranchRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ChatId string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// defaults to the model that last replied in the branch
+	Model         string `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SummarizeBranchRequest) Reset() {
+	*x = SummarizeBranchRequest{}
+	mi := &file_chatservice_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SummarizeBranchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SummarizeBranchRequest) ProtoMessage() {}
+
+func (x *SummarizeBranchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SummarizeBranchRequest.ProtoReflect.Descriptor instead.
+func (*SummarizeBranchRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *SummarizeBranchRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *SummarizeBranchRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+type SummarizeBranchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the summary message added to the parent chat
+	MessageId     string `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ParentChatId  string `protobuf:"bytes,2,opt,name=parent_chat_id,json=parentChatId,proto3" json:"parent_chat_id,omitempty"`
+	Summary       string `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SummarizeBranchResponse) Reset() {
+	*x = SummarizeBranchResponse{}
+	mi := &file_chatservice_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SummarizeBranchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SummarizeBranchResponse) ProtoMessage() {}
+
+func (x *SummarizeBranchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SummarizeBranchResponse.ProtoReflect.Descriptor instead.
+func (*SummarizeBranchResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *SummarizeBranchResponse) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *SummarizeBranchResponse) GetParentChatId() string {
+	if x != nil {
+		return x.ParentChatId
+	}
+	return ""
+}
+
+func (x *SummarizeBranchResponse) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+type RenameChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameChatRequest) Reset() {
+	*x = RenameChatRequest{}
+	mi := &file_chatservice_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameChatRequest) ProtoMessage() {}
+
+func (x *RenameChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameChatRequest.ProtoReflect.Descriptor instead.
+func (*RenameChatRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *RenameChatRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *RenameChatRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RenameChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameChatResponse) Reset() {
+	*x = RenameChatResponse{}
+	mi := &file_chatservice_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameChatResponse) ProtoMessage() {}
+
+func (x *RenameChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameChatResponse.ProtoReflect.Descriptor instead.
+func (*RenameChatResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *RenameChatResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type DeleteChatRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the chat's branches are deleted with it
+	ChatId string `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// skip the trash, the messages are deleted for good. Only for the chat's creator and workspace owners
+	Permanent     bool `protobuf:"varint,2,opt,name=permanent,proto3" json:"permanent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteChatRequest) Reset() {
+	*x = DeleteChatRequest{}
+	mi := &file_chatservice_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteChatRequest) ProtoMessage() {}
+
+func (x *DeleteChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteChatRequest.ProtoReflect.Descriptor instead.
+func (*DeleteChatRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *DeleteChatRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *DeleteChatRequest) GetPermanent() bool {
+	if x != nil {
+		return x.Permanent
+	}
+	return false
+}
+
+type DeleteChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteChatResponse) Reset() {
+	*x = DeleteChatResponse{}
+	mi := &file_chatservice_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteChatResponse) ProtoMessage() {}
+
+func (x *DeleteChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteChatResponse.ProtoReflect.Descriptor instead.
+func (*DeleteChatResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *DeleteChatResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type RestoreChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreChatRequest) Reset() {
+	*x = RestoreChatRequest{}
+	mi := &file_chatservice_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreChatRequest) ProtoMessage() {}
+
+func (x *RestoreChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreChatRequest.ProtoReflect.Descriptor instead.
+func (*RestoreChatRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *RestoreChatRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+type RestoreChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreChatResponse) Reset() {
+	*x = RestoreChatResponse{}
+	mi := &file_chatservice_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreChatResponse) ProtoMessage() {}
+
+func (x *RestoreChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreChatResponse.ProtoReflect.Descriptor instead.
+func (*RestoreChatResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *RestoreChatResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListTrashRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// empty for the chats without project
+	ProjectId     string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashRequest) Reset() {
+	*x = ListTrashRequest{}
+	mi := &file_chatservice_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashRequest) ProtoMessage() {}
+
+func (x *ListTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashRequest.ProtoReflect.Descriptor instead.
+func (*ListTrashRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ListTrashRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type ListTrashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chats         []*ChatInfo            `protobuf:"bytes,1,rep,name=chats,proto3" json:"chats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashResponse) Reset() {
+	*x = ListTrashResponse{}
+	mi := &file_chatservice_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashResponse) ProtoMessage() {}
+
+func (x *ListTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashResponse.ProtoReflect.Descriptor instead.
+func (*ListTrashResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ListTrashResponse) GetChats() []*ChatInfo {
+	if x != nil {
+		return x.Chats
+	}
+	return nil
+}
+
+type ArchiveChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Archived      bool                   `protobuf:"varint,2,opt,name=archived,proto3" json:"archived,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveChatRequest) Reset() {
+	*x = ArchiveChatRequest{}
+	mi := &file_chatservice_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveChatRequest) ProtoMessage() {}
+
+func (x *ArchiveChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveChatRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveChatRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ArchiveChatRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ArchiveChatRequest) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+type ArchiveChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveChatResponse) Reset() {
+	*x = ArchiveChatResponse{}
+	mi := &file_chatservice_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveChatResponse) ProtoMessage() {}
+
+func (x *ArchiveChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveChatResponse.ProtoReflect.Descriptor instead.
+func (*ArchiveChatResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ArchiveChatResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type PinChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Pinned        bool                   `protobuf:"varint,2,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinChatRequest) Reset() {
+	*x = PinChatRequest{}
+	mi := &file_chatservice_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinChatRequest) ProtoMessage() {}
+
+func (x *PinChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinChatRequest.ProtoReflect.Descriptor instead.
+func (*PinChatRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *PinChatRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *PinChatRequest) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+type PinChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinChatResponse) Reset() {
+	*x = PinChatResponse{}
+	mi := &file_chatservice_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinChatResponse) ProtoMessage() {}
+
+func (x *PinChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinChatResponse.ProtoReflect.Descriptor instead.
+func (*PinChatResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *PinChatResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type MoveChatRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// a main branch, its branches move with it
+	ChatId string `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// empty moves the chat out of its project
+	ProjectId     string `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveChatRequest) Reset() {
+	*x = MoveChatRequest{}
+	mi := &file_chatservice_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveChatRequest) ProtoMessage() {}
+
+func (x *MoveChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveChatRequest.ProtoReflect.Descriptor instead.
+func (*MoveChatRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *MoveChatRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *MoveChatRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type MoveChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveChatResponse) Reset() {
+	*x = MoveChatResponse{}
+	mi := &file_chatservice_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveChatResponse) ProtoMessage() {}
+
+func (x *MoveChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveChatResponse.ProtoReflect.Descriptor instead.
+func (*MoveChatResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *MoveChatResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ChatSummary is sent instead of the older messages of a long chat, up to and including up_to_message_id
+type ChatSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Summary       string                 `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	UpToMessageId string                 `protobuf:"bytes,3,opt,name=up_to_message_id,json=upToMessageId,proto3" json:"up_to_message_id,omitempty"`
+	Model         string                 `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+	Cost          float64                `protobuf:"fixed64,5,opt,name=cost,proto3" json:"cost,omitempty"`                          // USD
+	UpdatedAt     string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // RFC 3339 in UTC
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatSummary) Reset() {
+	*x = ChatSummary{}
+	mi := &file_chatservice_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatSummary) ProtoMessage() {}
+
+func (x *ChatSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatSummary.ProtoReflect.Descriptor instead.
+func (*ChatSummary) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ChatSummary) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ChatSummary) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *ChatSummary) GetUpToMessageId() string {
+	if x != nil {
+		return x.UpToMessageId
+	}
+	return ""
+}
+
+func (x *ChatSummary) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ChatSummary) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *ChatSummary) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type GetChatSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChatSummaryRequest) Reset() {
+	*x = GetChatSummaryRequest{}
+	mi := &file_chatservice_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChatSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChatSummaryRequest) ProtoMessage() {}
+
+func (x *GetChatSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *Settings) GetOPENAI_API_URL() string {
-	if x != nil {
-		return x.OPENAI_API_URL
-	}
-	return ""
+// Deprecated: Use GetChatSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetChatSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *Settings) GetOLLAMA_URL() string {
+func (x *GetChatSummaryRequest) GetChatId() string {
 	if x != nil {
-		return x.OLLAMA_URL
+		return x.ChatId
 	}
 	return ""
 }
 
-type GetSettingRequest struct {
+type GetChatSummaryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Summary       *ChatSummary           `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"` // unset while the chat has no summary
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSettingRequest) Reset() {
-	*x = GetSettingRequest{}
-	mi := &file_chatservice_proto_msgTypes[1]
+func (x *GetChatSummaryResponse) Reset() {
+	*x = GetChatSummaryResponse{}
+	mi := &file_chatservice_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSettingRequest) String() string {
+func (x *GetChatSummaryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSettingRequest) ProtoMessage() {}
+func (*GetChatSummaryResponse) ProtoMessage() {}
 
-func (x *GetSettingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[1]
+func (x *GetChatSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -164,33 +5284,41 @@ func (x *GetSettingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSettingRequest.ProtoReflect.Descriptor instead.
-func (*GetSettingRequest) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use GetChatSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetChatSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{84}
 }
 
-type GetSettingResponse struct {
+func (x *GetChatSummaryResponse) GetSummary() *ChatSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+type RegenerateChatSummaryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Settings      *Settings              `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"` // defaults to the summarization model of the settings
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSettingResponse) Reset() {
-	*x = GetSettingResponse{}
-	mi := &file_chatservice_proto_msgTypes[2]
+func (x *RegenerateChatSummaryRequest) Reset() {
+	*x = RegenerateChatSummaryRequest{}
+	mi := &file_chatservice_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSettingResponse) String() string {
+func (x *RegenerateChatSummaryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSettingResponse) ProtoMessage() {}
+func (*RegenerateChatSummaryRequest) ProtoMessage() {}
 
-func (x *GetSettingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[2]
+func (x *RegenerateChatSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -201,40 +5329,47 @@ func (x *GetSettingResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSettingResponse.ProtoReflect.Descriptor instead.
-func (*GetSettingResponse) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use RegenerateChatSummaryRequest.ProtoReflect.Descriptor instead.
+func (*RegenerateChatSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{85}
 }
 
-func (x *GetSettingResponse) GetSettings() *Settings {
+func (x *RegenerateChatSummaryRequest) GetChatId() string {
 	if x != nil {
-		return x.Settings
+		return x.ChatId
 	}
-	return nil
+	return ""
 }
 
-type SetSettingRequest struct {
+func (x *RegenerateChatSummaryRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+type RegenerateChatSummaryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Settings      *Settings              `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	Summary       *ChatSummary           `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetSettingRequest) Reset() {
-	*x = SetSettingRequest{}
-	mi := &file_chatservice_proto_msgTypes[3]
+func (x *RegenerateChatSummaryResponse) Reset() {
+	*x = RegenerateChatSummaryResponse{}
+	mi := &file_chatservice_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetSettingRequest) String() string {
+func (x *RegenerateChatSummaryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetSettingRequest) ProtoMessage() {}
+func (*RegenerateChatSummaryResponse) ProtoMessage() {}
 
-func (x *SetSettingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[3]
+func (x *RegenerateChatSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -245,40 +5380,47 @@ func (x *SetSettingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetSettingRequest.ProtoReflect.Descriptor instead.
-func (*SetSettingRequest) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use RegenerateChatSummaryResponse.ProtoReflect.Descriptor instead.
+func (*RegenerateChatSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{86}
 }
 
-func (x *SetSettingRequest) GetSettings() *Settings {
+func (x *RegenerateChatSummaryResponse) GetSummary() *ChatSummary {
 	if x != nil {
-		return x.Settings
+		return x.Summary
 	}
 	return nil
 }
 
-type SetSettingResponse struct {
+// PromptTemplate is a reusable prompt, {{ name }} in its content stands for the variable name
+type PromptTemplate struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"` // empty for templates of the user who created them
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	Variables     []string               `protobuf:"bytes,6,rep,name=variables,proto3" json:"variables,omitempty"`                  // in the order they first appear
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC 3339 in UTC
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetSettingResponse) Reset() {
-	*x = SetSettingResponse{}
-	mi := &file_chatservice_proto_msgTypes[4]
+func (x *PromptTemplate) Reset() {
+	*x = PromptTemplate{}
+	mi := &file_chatservice_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetSettingResponse) String() string {
+func (x *PromptTemplate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetSettingResponse) ProtoMessage() {}
+func (*PromptTemplate) ProtoMessage() {}
 
-func (x *SetSettingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[4]
+func (x *PromptTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -289,41 +5431,89 @@ func (x *SetSettingResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetSettingResponse.ProtoReflect.Descriptor instead.
-func (*SetSettingResponse) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use PromptTemplate.ProtoReflect.Descriptor instead.
+func (*PromptTemplate) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{87}
 }
 
-func (x *SetSettingResponse) GetMessage() string {
+func (x *PromptTemplate) GetId() string {
 	if x != nil {
-		return x.Message
+		return x.Id
 	}
 	return ""
 }
 
-type CreateChatRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	ProjectId     string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+func (x *PromptTemplate) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *PromptTemplate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PromptTemplate) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PromptTemplate) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *PromptTemplate) GetVariables() []string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *PromptTemplate) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type SetSystemPromptRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// one of chat_id and project_id
+	ChatId    string `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	ProjectId string `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// an empty system prompt removes it, unless template_id is set
+	SystemPrompt string `protobuf:"bytes,3,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	// renders the template with variables instead of system_prompt
+	TemplateId    string            `protobuf:"bytes,4,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Variables     map[string]string `protobuf:"bytes,5,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateChatRequest) Reset() {
-	*x = CreateChatRequest{}
-	mi := &file_chatservice_proto_msgTypes[5]
+func (x *SetSystemPromptRequest) Reset() {
+	*x = SetSystemPromptRequest{}
+	mi := &file_chatservice_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateChatRequest) String() string {
+func (x *SetSystemPromptRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateChatRequest) ProtoMessage() {}
+func (*SetSystemPromptRequest) ProtoMessage() {}
 
-func (x *CreateChatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[5]
+func (x *SetSystemPromptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -334,48 +5524,68 @@ func (x *CreateChatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateChatRequest.ProtoReflect.Descriptor instead.
-func (*CreateChatRequest) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use SetSystemPromptRequest.ProtoReflect.Descriptor instead.
+func (*SetSystemPromptRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{88}
 }
 
-func (x *CreateChatRequest) GetName() string {
+func (x *SetSystemPromptRequest) GetChatId() string {
 	if x != nil {
-		return x.Name
+		return x.ChatId
 	}
 	return ""
 }
 
-func (x *CreateChatRequest) GetProjectId() string {
+func (x *SetSystemPromptRequest) GetProjectId() string {
 	if x != nil {
 		return x.ProjectId
 	}
 	return ""
 }
 
-type CreateChatResponse struct {
+func (x *SetSystemPromptRequest) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *SetSystemPromptRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *SetSystemPromptRequest) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+type SetSystemPromptResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	ChatId        string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	SystemPrompt  string                 `protobuf:"bytes,1,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateChatResponse) Reset() {
-	*x = CreateChatResponse{}
-	mi := &file_chatservice_proto_msgTypes[6]
+func (x *SetSystemPromptResponse) Reset() {
+	*x = SetSystemPromptResponse{}
+	mi := &file_chatservice_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateChatResponse) String() string {
+func (x *SetSystemPromptResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateChatResponse) ProtoMessage() {}
+func (*SetSystemPromptResponse) ProtoMessage() {}
 
-func (x *CreateChatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[6]
+func (x *SetSystemPromptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -386,50 +5596,43 @@ func (x *CreateChatResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateChatResponse.ProtoReflect.Descriptor instead.
-func (*CreateChatResponse) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *CreateChatResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
+// Deprecated: Use SetSystemPromptResponse.ProtoReflect.Descriptor instead.
+func (*SetSystemPromptResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{89}
 }
 
-func (x *CreateChatResponse) GetChatId() string {
+func (x *SetSystemPromptResponse) GetSystemPrompt() string {
 	if x != nil {
-		return x.ChatId
+		return x.SystemPrompt
 	}
 	return ""
 }
 
-type ChatRequest struct {
+type CreatePromptTemplateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
-	ChatId        string                 `protobuf:"bytes,2,opt,name=chatId,proto3" json:"chatId,omitempty"`
-	Model         string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
-	ProjectId     string                 `protobuf:"bytes,4,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"` // optional, shares the template with the project's members
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChatRequest) Reset() {
-	*x = ChatRequest{}
-	mi := &file_chatservice_proto_msgTypes[7]
+func (x *CreatePromptTemplateRequest) Reset() {
+	*x = CreatePromptTemplateRequest{}
+	mi := &file_chatservice_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChatRequest) String() string {
+func (x *CreatePromptTemplateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatRequest) ProtoMessage() {}
+func (*CreatePromptTemplateRequest) ProtoMessage() {}
 
-func (x *ChatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[7]
+func (x *CreatePromptTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -440,65 +5643,61 @@ func (x *ChatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChatRequest.ProtoReflect.Descriptor instead.
-func (*ChatRequest) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use CreatePromptTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreatePromptTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{90}
 }
 
-func (x *ChatRequest) GetText() string {
+func (x *CreatePromptTemplateRequest) GetProjectId() string {
 	if x != nil {
-		return x.Text
+		return x.ProjectId
 	}
 	return ""
 }
 
-func (x *ChatRequest) GetChatId() string {
+func (x *CreatePromptTemplateRequest) GetName() string {
 	if x != nil {
-		return x.ChatId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *ChatRequest) GetModel() string {
+func (x *CreatePromptTemplateRequest) GetDescription() string {
 	if x != nil {
-		return x.Model
+		return x.Description
 	}
 	return ""
 }
 
-func (x *ChatRequest) GetProjectId() string {
+func (x *CreatePromptTemplateRequest) GetContent() string {
 	if x != nil {
-		return x.ProjectId
+		return x.Content
 	}
 	return ""
 }
 
-type ChatResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Response:
-	//
-	//	*ChatResponse_Text
-	//	*ChatResponse_Summary
-	Response      isChatResponse_Response `protobuf_oneof:"response"`
+type CreatePromptTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *PromptTemplate        `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChatResponse) Reset() {
-	*x = ChatResponse{}
-	mi := &file_chatservice_proto_msgTypes[8]
+func (x *CreatePromptTemplateResponse) Reset() {
+	*x = CreatePromptTemplateResponse{}
+	mi := &file_chatservice_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChatResponse) String() string {
+func (x *CreatePromptTemplateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatResponse) ProtoMessage() {}
+func (*CreatePromptTemplateResponse) ProtoMessage() {}
 
-func (x *ChatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[8]
+func (x *CreatePromptTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -509,74 +5708,84 @@ func (x *ChatResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChatResponse.ProtoReflect.Descriptor instead.
-func (*ChatResponse) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use CreatePromptTemplateResponse.ProtoReflect.Descriptor instead.
+func (*CreatePromptTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{91}
 }
 
-func (x *ChatResponse) GetResponse() isChatResponse_Response {
+func (x *CreatePromptTemplateResponse) GetTemplate() *PromptTemplate {
 	if x != nil {
-		return x.Response
+		return x.Template
 	}
 	return nil
 }
 
-func (x *ChatResponse) GetText() string {
-	if x != nil {
-		if x, ok := x.Response.(*ChatResponse_Text); ok {
-			return x.Text
-		}
-	}
-	return ""
+type ListPromptTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"` // optional, adds the project's templates to the user's own
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChatResponse) GetSummary() *MessageSummary {
-	if x != nil {
-		if x, ok := x.Response.(*ChatResponse_Summary); ok {
-			return x.Summary
-		}
-	}
-	return nil
+func (x *ListPromptTemplatesRequest) Reset() {
+	*x = ListPromptTemplatesRequest{}
+	mi := &file_chatservice_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type isChatResponse_Response interface {
-	isChatResponse_Response()
+func (x *ListPromptTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type ChatResponse_Text struct {
-	Text string `protobuf:"bytes,1,opt,name=text,proto3,oneof"`
-}
+func (*ListPromptTemplatesRequest) ProtoMessage() {}
 
-type ChatResponse_Summary struct {
-	Summary *MessageSummary `protobuf:"bytes,2,opt,name=summary,proto3,oneof"`
+func (x *ListPromptTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (*ChatResponse_Text) isChatResponse_Response() {}
+// Deprecated: Use ListPromptTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListPromptTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{92}
+}
 
-func (*ChatResponse_Summary) isChatResponse_Response() {}
+func (x *ListPromptTemplatesRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
 
-type MessageSummary struct {
+type ListPromptTemplatesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Templates     []*PromptTemplate      `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MessageSummary) Reset() {
-	*x = MessageSummary{}
-	mi := &file_chatservice_proto_msgTypes[9]
+func (x *ListPromptTemplatesResponse) Reset() {
+	*x = ListPromptTemplatesResponse{}
+	mi := &file_chatservice_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MessageSummary) String() string {
+func (x *ListPromptTemplatesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MessageSummary) ProtoMessage() {}
+func (*ListPromptTemplatesResponse) ProtoMessage() {}
 
-func (x *MessageSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[9]
+func (x *ListPromptTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -587,40 +5796,43 @@ func (x *MessageSummary) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MessageSummary.ProtoReflect.Descriptor instead.
-func (*MessageSummary) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ListPromptTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListPromptTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{93}
 }
 
-func (x *MessageSummary) GetMessageId() string {
+func (x *ListPromptTemplatesResponse) GetTemplates() []*PromptTemplate {
 	if x != nil {
-		return x.MessageId
+		return x.Templates
 	}
-	return ""
+	return nil
 }
 
-type GetHistoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChatId        string                 `protobuf:"bytes,1,opt,name=chatId,proto3" json:"chatId,omitempty"`
+type SetGenerationOptionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// one of chat_id and project_id
+	ChatId        string             `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	ProjectId     string             `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Options       *GenerationOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"` // replaces the defaults, unset removes them
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetHistoryRequest) Reset() {
-	*x = GetHistoryRequest{}
-	mi := &file_chatservice_proto_msgTypes[10]
+func (x *SetGenerationOptionsRequest) Reset() {
+	*x = SetGenerationOptionsRequest{}
+	mi := &file_chatservice_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetHistoryRequest) String() string {
+func (x *SetGenerationOptionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetHistoryRequest) ProtoMessage() {}
+func (*SetGenerationOptionsRequest) ProtoMessage() {}
 
-func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[10]
+func (x *SetGenerationOptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -631,40 +5843,54 @@ func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetHistoryRequest.ProtoReflect.Descriptor instead.
-func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use SetGenerationOptionsRequest.ProtoReflect.Descriptor instead.
+func (*SetGenerationOptionsRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{94}
 }
 
-func (x *GetHistoryRequest) GetChatId() string {
+func (x *SetGenerationOptionsRequest) GetChatId() string {
 	if x != nil {
 		return x.ChatId
 	}
 	return ""
 }
 
-type GetHistoryResponse struct {
+func (x *SetGenerationOptionsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *SetGenerationOptionsRequest) GetOptions() *GenerationOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type SetGenerationOptionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	History       []*ChatMessage         `protobuf:"bytes,1,rep,name=history,proto3" json:"history,omitempty"`
+	Options       *GenerationOptions     `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetHistoryResponse) Reset() {
-	*x = GetHistoryResponse{}
-	mi := &file_chatservice_proto_msgTypes[11]
+func (x *SetGenerationOptionsResponse) Reset() {
+	*x = SetGenerationOptionsResponse{}
+	mi := &file_chatservice_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetHistoryResponse) String() string {
+func (x *SetGenerationOptionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetHistoryResponse) ProtoMessage() {}
+func (*SetGenerationOptionsResponse) ProtoMessage() {}
 
-func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[11]
+func (x *SetGenerationOptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -675,42 +5901,44 @@ func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetHistoryResponse.ProtoReflect.Descriptor instead.
-func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use SetGenerationOptionsResponse.ProtoReflect.Descriptor instead.
+func (*SetGenerationOptionsResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{95}
 }
 
-func (x *GetHistoryResponse) GetHistory() []*ChatMessage {
+func (x *SetGenerationOptionsResponse) GetOptions() *GenerationOptions {
 	if x != nil {
-		return x.History
+		return x.Options
 	}
 	return nil
 }
 
-type ChatMessage struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
-	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	MessageId     string                 `protobuf:"bytes,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+type User struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username  string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	CreatedAt string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// is_admin users manage the settings and the model catalog
+	IsAdmin       bool `protobuf:"varint,4,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChatMessage) Reset() {
-	*x = ChatMessage{}
-	mi := &file_chatservice_proto_msgTypes[12]
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_chatservice_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChatMessage) String() string {
+func (x *User) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatMessage) ProtoMessage() {}
+func (*User) ProtoMessage() {}
 
-func (x *ChatMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[12]
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -721,54 +5949,62 @@ func (x *ChatMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChatMessage.ProtoReflect.Descriptor instead.
-func (*ChatMessage) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{96}
 }
 
-func (x *ChatMessage) GetRole() string {
+func (x *User) GetId() string {
 	if x != nil {
-		return x.Role
+		return x.Id
 	}
 	return ""
 }
 
-func (x *ChatMessage) GetContent() string {
+func (x *User) GetUsername() string {
 	if x != nil {
-		return x.Content
+		return x.Username
 	}
 	return ""
 }
 
-func (x *ChatMessage) GetMessageId() string {
+func (x *User) GetCreatedAt() string {
 	if x != nil {
-		return x.MessageId
+		return x.CreatedAt
 	}
 	return ""
 }
 
-type GetChatListRequest struct {
+func (x *User) GetIsAdmin() bool {
+	if x != nil {
+		return x.IsAdmin
+	}
+	return false
+}
+
+type RegisterRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetChatListRequest) Reset() {
-	*x = GetChatListRequest{}
-	mi := &file_chatservice_proto_msgTypes[13]
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	mi := &file_chatservice_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetChatListRequest) String() string {
+func (x *RegisterRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetChatListRequest) ProtoMessage() {}
+func (*RegisterRequest) ProtoMessage() {}
 
-func (x *GetChatListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[13]
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -779,40 +6015,47 @@ func (x *GetChatListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetChatListRequest.ProtoReflect.Descriptor instead.
-func (*GetChatListRequest) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{97}
 }
 
-func (x *GetChatListRequest) GetProjectId() string {
+func (x *RegisterRequest) GetUsername() string {
 	if x != nil {
-		return x.ProjectId
+		return x.Username
 	}
 	return ""
 }
 
-type GetChatListResponse struct {
+func (x *RegisterRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type RegisterResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chats         []*ChatInfo            `protobuf:"bytes,1,rep,name=chats,proto3" json:"chats,omitempty"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetChatListResponse) Reset() {
-	*x = GetChatListResponse{}
-	mi := &file_chatservice_proto_msgTypes[14]
+func (x *RegisterResponse) Reset() {
+	*x = RegisterResponse{}
+	mi := &file_chatservice_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetChatListResponse) String() string {
+func (x *RegisterResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetChatListResponse) ProtoMessage() {}
+func (*RegisterResponse) ProtoMessage() {}
 
-func (x *GetChatListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[14]
+func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -823,41 +6066,41 @@ func (x *GetChatListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetChatListResponse.ProtoReflect.Descriptor instead.
-func (*GetChatListResponse) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use RegisterResponse.ProtoReflect.Descriptor instead.
+func (*RegisterResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{98}
 }
 
-func (x *GetChatListResponse) GetChats() []*ChatInfo {
+func (x *RegisterResponse) GetUser() *User {
 	if x != nil {
-		return x.Chats
+		return x.User
 	}
 	return nil
 }
 
-type ChatInfo struct {
+type LoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChatId        string                 `protobuf:"bytes,1,opt,name=chatId,proto3" json:"chatId,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChatInfo) Reset() {
-	*x = ChatInfo{}
-	mi := &file_chatservice_proto_msgTypes[15]
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_chatservice_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChatInfo) String() string {
+func (x *LoginRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatInfo) ProtoMessage() {}
+func (*LoginRequest) ProtoMessage() {}
 
-func (x *ChatInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[15]
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -868,52 +6111,49 @@ func (x *ChatInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChatInfo.ProtoReflect.Descriptor instead.
-func (*ChatInfo) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{99}
 }
 
-func (x *ChatInfo) GetChatId() string {
+func (x *LoginRequest) GetUsername() string {
 	if x != nil {
-		return x.ChatId
+		return x.Username
 	}
 	return ""
 }
 
-func (x *ChatInfo) GetName() string {
+func (x *LoginRequest) GetPassword() string {
 	if x != nil {
-		return x.Name
+		return x.Password
 	}
 	return ""
 }
 
-type ModelListInfo struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Label           string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
-	Provider        string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
-	Url             string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
-	InputTokenCost  float32                `protobuf:"fixed32,5,opt,name=input_token_cost,json=inputTokenCost,proto3" json:"input_token_cost,omitempty"`
-	OutputTokenCost float32                `protobuf:"fixed32,6,opt,name=output_token_cost,json=outputTokenCost,proto3" json:"output_token_cost,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type LoginResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                           // send as "authorization: Bearer <token>"
+	ExpiresAt     int64                  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // unix seconds
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ModelListInfo) Reset() {
-	*x = ModelListInfo{}
-	mi := &file_chatservice_proto_msgTypes[16]
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_chatservice_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ModelListInfo) String() string {
+func (x *LoginResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ModelListInfo) ProtoMessage() {}
+func (*LoginResponse) ProtoMessage() {}
 
-func (x *ModelListInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[16]
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -924,74 +6164,53 @@ func (x *ModelListInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ModelListInfo.ProtoReflect.Descriptor instead.
-func (*ModelListInfo) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{16}
-}
-
-func (x *ModelListInfo) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *ModelListInfo) GetLabel() string {
-	if x != nil {
-		return x.Label
-	}
-	return ""
-}
-
-func (x *ModelListInfo) GetProvider() string {
-	if x != nil {
-		return x.Provider
-	}
-	return ""
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{100}
 }
 
-func (x *ModelListInfo) GetUrl() string {
+func (x *LoginResponse) GetToken() string {
 	if x != nil {
-		return x.Url
+		return x.Token
 	}
 	return ""
 }
 
-func (x *ModelListInfo) GetInputTokenCost() float32 {
+func (x *LoginResponse) GetExpiresAt() int64 {
 	if x != nil {
-		return x.InputTokenCost
+		return x.ExpiresAt
 	}
 	return 0
 }
 
-func (x *ModelListInfo) GetOutputTokenCost() float32 {
+func (x *LoginResponse) GetUser() *User {
 	if x != nil {
-		return x.OutputTokenCost
+		return x.User
 	}
-	return 0
+	return nil
 }
 
-type ListModelsRequest struct {
+type GetCurrentUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListModelsRequest) Reset() {
-	*x = ListModelsRequest{}
-	mi := &file_chatservice_proto_msgTypes[17]
+func (x *GetCurrentUserRequest) Reset() {
+	*x = GetCurrentUserRequest{}
+	mi := &file_chatservice_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListModelsRequest) String() string {
+func (x *GetCurrentUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListModelsRequest) ProtoMessage() {}
+func (*GetCurrentUserRequest) ProtoMessage() {}
 
-func (x *ListModelsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[17]
+func (x *GetCurrentUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1002,33 +6221,34 @@ func (x *ListModelsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListModelsRequest.ProtoReflect.Descriptor instead.
-func (*ListModelsRequest) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use GetCurrentUserRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrentUserRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{101}
 }
 
-type ListModelsResponse struct {
+type GetCurrentUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Models        []*ModelListInfo       `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	AuthEnabled   bool                   `protobuf:"varint,2,opt,name=auth_enabled,json=authEnabled,proto3" json:"auth_enabled,omitempty"` // false in single user (desktop) mode, every request runs as the local user
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListModelsResponse) Reset() {
-	*x = ListModelsResponse{}
-	mi := &file_chatservice_proto_msgTypes[18]
+func (x *GetCurrentUserResponse) Reset() {
+	*x = GetCurrentUserResponse{}
+	mi := &file_chatservice_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListModelsResponse) String() string {
+func (x *GetCurrentUserResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListModelsResponse) ProtoMessage() {}
+func (*GetCurrentUserResponse) ProtoMessage() {}
 
-func (x *ListModelsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[18]
+func (x *GetCurrentUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1039,40 +6259,54 @@ func (x *ListModelsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListModelsResponse.ProtoReflect.Descriptor instead.
-func (*ListModelsResponse) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use GetCurrentUserResponse.ProtoReflect.Descriptor instead.
+func (*GetCurrentUserResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{102}
 }
 
-func (x *ListModelsResponse) GetModels() []*ModelListInfo {
+func (x *GetCurrentUserResponse) GetUser() *User {
 	if x != nil {
-		return x.Models
+		return x.User
 	}
 	return nil
 }
 
-type ChatSearchRequest struct {
+func (x *GetCurrentUserResponse) GetAuthEnabled() bool {
+	if x != nil {
+		return x.AuthEnabled
+	}
+	return false
+}
+
+// ApiToken describes a personal access token, the token itself is only returned once by CreateApiToken
+type ApiToken struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Scope         ApiTokenScope          `protobuf:"varint,3,opt,name=scope,proto3,enum=sortedchat.ApiTokenScope" json:"scope,omitempty"`
+	Prefix        string                 `protobuf:"bytes,4,opt,name=prefix,proto3" json:"prefix,omitempty"` // first characters of the token, to recognise it
+	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUsedAt    string                 `protobuf:"bytes,6,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"` // empty if never used
+	ExpiresAt     string                 `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`      // empty if the token does not expire
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChatSearchRequest) Reset() {
-	*x = ChatSearchRequest{}
-	mi := &file_chatservice_proto_msgTypes[19]
+func (x *ApiToken) Reset() {
+	*x = ApiToken{}
+	mi := &file_chatservice_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChatSearchRequest) String() string {
+func (x *ApiToken) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatSearchRequest) ProtoMessage() {}
+func (*ApiToken) ProtoMessage() {}
 
-func (x *ChatSearchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[19]
+func (x *ApiToken) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1083,42 +6317,84 @@ func (x *ChatSearchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChatSearchRequest.ProtoReflect.Descriptor instead.
-func (*ChatSearchRequest) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use ApiToken.ProtoReflect.Descriptor instead.
+func (*ApiToken) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *ApiToken) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApiToken) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApiToken) GetScope() ApiTokenScope {
+	if x != nil {
+		return x.Scope
+	}
+	return ApiTokenScope_API_TOKEN_SCOPE_UNSPECIFIED
+}
+
+func (x *ApiToken) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ApiToken) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
 }
 
-func (x *ChatSearchRequest) GetQuery() string {
+func (x *ApiToken) GetLastUsedAt() string {
 	if x != nil {
-		return x.Query
+		return x.LastUsedAt
 	}
 	return ""
 }
 
-type SearchResult struct {
+func (x *ApiToken) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type CreateApiTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChatName      string                 `protobuf:"bytes,1,opt,name=chat_name,json=chatName,proto3" json:"chat_name,omitempty"`
-	ChatId        string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	MatchedText   string                 `protobuf:"bytes,3,opt,name=matched_text,json=matchedText,proto3" json:"matched_text,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Scope         ApiTokenScope          `protobuf:"varint,2,opt,name=scope,proto3,enum=sortedchat.ApiTokenScope" json:"scope,omitempty"`
+	ExpiresInDays int32                  `protobuf:"varint,3,opt,name=expires_in_days,json=expiresInDays,proto3" json:"expires_in_days,omitempty"` // 0 never expires
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchResult) Reset() {
-	*x = SearchResult{}
-	mi := &file_chatservice_proto_msgTypes[20]
+func (x *CreateApiTokenRequest) Reset() {
+	*x = CreateApiTokenRequest{}
+	mi := &file_chatservice_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchResult) String() string {
+func (x *CreateApiTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchResult) ProtoMessage() {}
+func (*CreateApiTokenRequest) ProtoMessage() {}
 
-func (x *SearchResult) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[20]
+func (x *CreateApiTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1129,55 +6405,55 @@ func (x *SearchResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
-func (*SearchResult) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use CreateApiTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateApiTokenRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{104}
 }
 
-func (x *SearchResult) GetChatName() string {
+func (x *CreateApiTokenRequest) GetName() string {
 	if x != nil {
-		return x.ChatName
+		return x.Name
 	}
 	return ""
 }
 
-func (x *SearchResult) GetChatId() string {
+func (x *CreateApiTokenRequest) GetScope() ApiTokenScope {
 	if x != nil {
-		return x.ChatId
+		return x.Scope
 	}
-	return ""
+	return ApiTokenScope_API_TOKEN_SCOPE_UNSPECIFIED
 }
 
-func (x *SearchResult) GetMatchedText() string {
+func (x *CreateApiTokenRequest) GetExpiresInDays() int32 {
 	if x != nil {
-		return x.MatchedText
+		return x.ExpiresInDays
 	}
-	return ""
+	return 0
 }
 
-type ChatSearchResponse struct {
+type CreateApiTokenResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	Results       []*SearchResult        `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // send as "authorization: Bearer <token>", can't be retrieved again
+	ApiToken      *ApiToken              `protobuf:"bytes,2,opt,name=api_token,json=apiToken,proto3" json:"api_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChatSearchResponse) Reset() {
-	*x = ChatSearchResponse{}
-	mi := &file_chatservice_proto_msgTypes[21]
+func (x *CreateApiTokenResponse) Reset() {
+	*x = CreateApiTokenResponse{}
+	mi := &file_chatservice_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChatSearchResponse) String() string {
+func (x *CreateApiTokenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatSearchResponse) ProtoMessage() {}
+func (*CreateApiTokenResponse) ProtoMessage() {}
 
-func (x *ChatSearchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[21]
+func (x *CreateApiTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1188,49 +6464,46 @@ func (x *ChatSearchResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChatSearchResponse.ProtoReflect.Descriptor instead.
-func (*ChatSearchResponse) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use CreateApiTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateApiTokenResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{105}
 }
 
-func (x *ChatSearchResponse) GetQuery() string {
+func (x *CreateApiTokenResponse) GetToken() string {
 	if x != nil {
-		return x.Query
+		return x.Token
 	}
 	return ""
 }
 
-func (x *ChatSearchResponse) GetResults() []*SearchResult {
+func (x *CreateApiTokenResponse) GetApiToken() *ApiToken {
 	if x != nil {
-		return x.Results
+		return x.ApiToken
 	}
 	return nil
 }
 
-type CreateProjectRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description    string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	AdditionalData string                 `protobuf:"bytes,3,opt,name=additional_data,json=additionalData,proto3" json:"additional_data,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type ListApiTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateProjectRequest) Reset() {
-	*x = CreateProjectRequest{}
-	mi := &file_chatservice_proto_msgTypes[22]
+func (x *ListApiTokensRequest) Reset() {
+	*x = ListApiTokensRequest{}
+	mi := &file_chatservice_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateProjectRequest) String() string {
+func (x *ListApiTokensRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateProjectRequest) ProtoMessage() {}
+func (*ListApiTokensRequest) ProtoMessage() {}
 
-func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[22]
+func (x *ListApiTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1241,55 +6514,33 @@ func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateProjectRequest.ProtoReflect.Descriptor instead.
-func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{22}
-}
-
-func (x *CreateProjectRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *CreateProjectRequest) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
-}
-
-func (x *CreateProjectRequest) GetAdditionalData() string {
-	if x != nil {
-		return x.AdditionalData
-	}
-	return ""
+// Deprecated: Use ListApiTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListApiTokensRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{106}
 }
 
-type CreateProjectResponse struct {
+type ListApiTokensResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	ProjectId     string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	ApiTokens     []*ApiToken            `protobuf:"bytes,1,rep,name=api_tokens,json=apiTokens,proto3" json:"api_tokens,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateProjectResponse) Reset() {
-	*x = CreateProjectResponse{}
-	mi := &file_chatservice_proto_msgTypes[23]
+func (x *ListApiTokensResponse) Reset() {
+	*x = ListApiTokensResponse{}
+	mi := &file_chatservice_proto_msgTypes[107]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateProjectResponse) String() string {
+func (x *ListApiTokensResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateProjectResponse) ProtoMessage() {}
+func (*ListApiTokensResponse) ProtoMessage() {}
 
-func (x *CreateProjectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[23]
+func (x *ListApiTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[107]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1300,46 +6551,40 @@ func (x *CreateProjectResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateProjectResponse.ProtoReflect.Descriptor instead.
-func (*CreateProjectResponse) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{23}
-}
-
-func (x *CreateProjectResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
+// Deprecated: Use ListApiTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListApiTokensResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{107}
 }
 
-func (x *CreateProjectResponse) GetProjectId() string {
+func (x *ListApiTokensResponse) GetApiTokens() []*ApiToken {
 	if x != nil {
-		return x.ProjectId
+		return x.ApiTokens
 	}
-	return ""
+	return nil
 }
 
-type GetProjectsRequest struct {
+type RevokeApiTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProjectsRequest) Reset() {
-	*x = GetProjectsRequest{}
-	mi := &file_chatservice_proto_msgTypes[24]
+func (x *RevokeApiTokenRequest) Reset() {
+	*x = RevokeApiTokenRequest{}
+	mi := &file_chatservice_proto_msgTypes[108]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProjectsRequest) String() string {
+func (x *RevokeApiTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProjectsRequest) ProtoMessage() {}
+func (*RevokeApiTokenRequest) ProtoMessage() {}
 
-func (x *GetProjectsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[24]
+func (x *RevokeApiTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[108]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1350,33 +6595,40 @@ func (x *GetProjectsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProjectsRequest.ProtoReflect.Descriptor instead.
-func (*GetProjectsRequest) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use RevokeApiTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeApiTokenRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{108}
 }
 
-type GetProjectsResponse struct {
+func (x *RevokeApiTokenRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RevokeApiTokenResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Projects      []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProjectsResponse) Reset() {
-	*x = GetProjectsResponse{}
-	mi := &file_chatservice_proto_msgTypes[25]
+func (x *RevokeApiTokenResponse) Reset() {
+	*x = RevokeApiTokenResponse{}
+	mi := &file_chatservice_proto_msgTypes[109]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProjectsResponse) String() string {
+func (x *RevokeApiTokenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProjectsResponse) ProtoMessage() {}
+func (*RevokeApiTokenResponse) ProtoMessage() {}
 
-func (x *GetProjectsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[25]
+func (x *RevokeApiTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[109]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1387,45 +6639,43 @@ func (x *GetProjectsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProjectsResponse.ProtoReflect.Descriptor instead.
-func (*GetProjectsResponse) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use RevokeApiTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeApiTokenResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{109}
 }
 
-func (x *GetProjectsResponse) GetProjects() []*Project {
+func (x *RevokeApiTokenResponse) GetMessage() string {
 	if x != nil {
-		return x.Projects
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
-type Project struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	AdditionalData string                 `protobuf:"bytes,4,opt,name=additional_data,json=additionalData,proto3" json:"additional_data,omitempty"`
-	CreatedAt      string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt      string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type Workspace struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Role          WorkspaceRole          `protobuf:"varint,3,opt,name=role,proto3,enum=sortedchat.WorkspaceRole" json:"role,omitempty"` // role of the caller
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Project) Reset() {
-	*x = Project{}
-	mi := &file_chatservice_proto_msgTypes[26]
+func (x *Workspace) Reset() {
+	*x = Workspace{}
+	mi := &file_chatservice_proto_msgTypes[110]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Project) String() string {
+func (x *Workspace) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Project) ProtoMessage() {}
+func (*Workspace) ProtoMessage() {}
 
-func (x *Project) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[26]
+func (x *Workspace) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[110]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1436,75 +6686,129 @@ func (x *Project) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Project.ProtoReflect.Descriptor instead.
-func (*Project) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use Workspace.ProtoReflect.Descriptor instead.
+func (*Workspace) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{110}
 }
 
-func (x *Project) GetId() string {
+func (x *Workspace) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Project) GetName() string {
+func (x *Workspace) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Project) GetDescription() string {
+func (x *Workspace) GetRole() WorkspaceRole {
 	if x != nil {
-		return x.Description
+		return x.Role
+	}
+	return WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED
+}
+
+func (x *Workspace) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
 	}
 	return ""
 }
 
-func (x *Project) GetAdditionalData() string {
+type WorkspaceMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Role          WorkspaceRole          `protobuf:"varint,3,opt,name=role,proto3,enum=sortedchat.WorkspaceRole" json:"role,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkspaceMember) Reset() {
+	*x = WorkspaceMember{}
+	mi := &file_chatservice_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceMember) ProtoMessage() {}
+
+func (x *WorkspaceMember) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[111]
 	if x != nil {
-		return x.AdditionalData
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceMember.ProtoReflect.Descriptor instead.
+func (*WorkspaceMember) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *WorkspaceMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *Project) GetCreatedAt() string {
+func (x *WorkspaceMember) GetUsername() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Username
 	}
 	return ""
 }
 
-func (x *Project) GetUpdatedAt() string {
+func (x *WorkspaceMember) GetRole() WorkspaceRole {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Role
+	}
+	return WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED
+}
+
+func (x *WorkspaceMember) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
 	}
 	return ""
 }
 
-type ListDocumentsRequest struct {
+type CreateWorkspaceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListDocumentsRequest) Reset() {
-	*x = ListDocumentsRequest{}
-	mi := &file_chatservice_proto_msgTypes[27]
+func (x *CreateWorkspaceRequest) Reset() {
+	*x = CreateWorkspaceRequest{}
+	mi := &file_chatservice_proto_msgTypes[112]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListDocumentsRequest) String() string {
+func (x *CreateWorkspaceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDocumentsRequest) ProtoMessage() {}
+func (*CreateWorkspaceRequest) ProtoMessage() {}
 
-func (x *ListDocumentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[27]
+func (x *CreateWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[112]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1515,40 +6819,40 @@ func (x *ListDocumentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDocumentsRequest.ProtoReflect.Descriptor instead.
-func (*ListDocumentsRequest) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use CreateWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{112}
 }
 
-func (x *ListDocumentsRequest) GetProjectId() string {
+func (x *CreateWorkspaceRequest) GetName() string {
 	if x != nil {
-		return x.ProjectId
+		return x.Name
 	}
 	return ""
 }
 
-type ListDocumentsResponse struct {
+type CreateWorkspaceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Documents     []*Document            `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
+	Workspace     *Workspace             `protobuf:"bytes,1,opt,name=workspace,proto3" json:"workspace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListDocumentsResponse) Reset() {
-	*x = ListDocumentsResponse{}
-	mi := &file_chatservice_proto_msgTypes[28]
+func (x *CreateWorkspaceResponse) Reset() {
+	*x = CreateWorkspaceResponse{}
+	mi := &file_chatservice_proto_msgTypes[113]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListDocumentsResponse) String() string {
+func (x *CreateWorkspaceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDocumentsResponse) ProtoMessage() {}
+func (*CreateWorkspaceResponse) ProtoMessage() {}
 
-func (x *ListDocumentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[28]
+func (x *CreateWorkspaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[113]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1559,46 +6863,39 @@ func (x *ListDocumentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDocumentsResponse.ProtoReflect.Descriptor instead.
-func (*ListDocumentsResponse) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use CreateWorkspaceResponse.ProtoReflect.Descriptor instead.
+func (*CreateWorkspaceResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{113}
 }
 
-func (x *ListDocumentsResponse) GetDocuments() []*Document {
+func (x *CreateWorkspaceResponse) GetWorkspace() *Workspace {
 	if x != nil {
-		return x.Documents
+		return x.Workspace
 	}
 	return nil
 }
 
-type Document struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	ProjectId       string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	DocsId          string                 `protobuf:"bytes,3,opt,name=docs_id,json=docsId,proto3" json:"docs_id,omitempty"`
-	FileName        string                 `protobuf:"bytes,4,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
-	CreatedAt       string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt       string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	EmbeddingStatus Embedding_Status       `protobuf:"varint,7,opt,name=embedding_status,json=embeddingStatus,proto3,enum=sortedchat.Embedding_Status" json:"embedding_status,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type ListWorkspacesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Document) Reset() {
-	*x = Document{}
-	mi := &file_chatservice_proto_msgTypes[29]
+func (x *ListWorkspacesRequest) Reset() {
+	*x = ListWorkspacesRequest{}
+	mi := &file_chatservice_proto_msgTypes[114]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Document) String() string {
+func (x *ListWorkspacesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Document) ProtoMessage() {}
+func (*ListWorkspacesRequest) ProtoMessage() {}
 
-func (x *Document) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[29]
+func (x *ListWorkspacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[114]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1609,82 +6906,77 @@ func (x *Document) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Document.ProtoReflect.Descriptor instead.
-func (*Document) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use ListWorkspacesRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkspacesRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{114}
 }
 
-func (x *Document) GetId() int64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
+type ListWorkspacesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workspaces    []*Workspace           `protobuf:"bytes,1,rep,name=workspaces,proto3" json:"workspaces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Document) GetProjectId() string {
-	if x != nil {
-		return x.ProjectId
-	}
-	return ""
+func (x *ListWorkspacesResponse) Reset() {
+	*x = ListWorkspacesResponse{}
+	mi := &file_chatservice_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Document) GetDocsId() string {
-	if x != nil {
-		return x.DocsId
-	}
-	return ""
+func (x *ListWorkspacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Document) GetFileName() string {
-	if x != nil {
-		return x.FileName
-	}
-	return ""
-}
+func (*ListWorkspacesResponse) ProtoMessage() {}
 
-func (x *Document) GetCreatedAt() string {
+func (x *ListWorkspacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[115]
 	if x != nil {
-		return x.CreatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Document) GetUpdatedAt() string {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return ""
+// Deprecated: Use ListWorkspacesResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkspacesResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{115}
 }
 
-func (x *Document) GetEmbeddingStatus() Embedding_Status {
+func (x *ListWorkspacesResponse) GetWorkspaces() []*Workspace {
 	if x != nil {
-		return x.EmbeddingStatus
+		return x.Workspaces
 	}
-	return Embedding_Status_STATUS_QUEUED
+	return nil
 }
 
-type GenerateEmbeddingRequest struct {
+type ListWorkspaceMembersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateEmbeddingRequest) Reset() {
-	*x = GenerateEmbeddingRequest{}
-	mi := &file_chatservice_proto_msgTypes[30]
+func (x *ListWorkspaceMembersRequest) Reset() {
+	*x = ListWorkspaceMembersRequest{}
+	mi := &file_chatservice_proto_msgTypes[116]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateEmbeddingRequest) String() string {
+func (x *ListWorkspaceMembersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateEmbeddingRequest) ProtoMessage() {}
+func (*ListWorkspaceMembersRequest) ProtoMessage() {}
 
-func (x *GenerateEmbeddingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[30]
+func (x *ListWorkspaceMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[116]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1695,40 +6987,40 @@ func (x *GenerateEmbeddingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateEmbeddingRequest.ProtoReflect.Descriptor instead.
-func (*GenerateEmbeddingRequest) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use ListWorkspaceMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkspaceMembersRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{116}
 }
 
-func (x *GenerateEmbeddingRequest) GetProjectId() string {
+func (x *ListWorkspaceMembersRequest) GetWorkspaceId() string {
 	if x != nil {
-		return x.ProjectId
+		return x.WorkspaceId
 	}
 	return ""
 }
 
-type GenerateEmbeddingResponse struct {
+type ListWorkspaceMembersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Members       []*WorkspaceMember     `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateEmbeddingResponse) Reset() {
-	*x = GenerateEmbeddingResponse{}
-	mi := &file_chatservice_proto_msgTypes[31]
+func (x *ListWorkspaceMembersResponse) Reset() {
+	*x = ListWorkspaceMembersResponse{}
+	mi := &file_chatservice_proto_msgTypes[117]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateEmbeddingResponse) String() string {
+func (x *ListWorkspaceMembersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateEmbeddingResponse) ProtoMessage() {}
+func (*ListWorkspaceMembersResponse) ProtoMessage() {}
 
-func (x *GenerateEmbeddingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[31]
+func (x *ListWorkspaceMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[117]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1739,42 +7031,42 @@ func (x *GenerateEmbeddingResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateEmbeddingResponse.ProtoReflect.Descriptor instead.
-func (*GenerateEmbeddingResponse) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use ListWorkspaceMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkspaceMembersResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{117}
 }
 
-func (x *GenerateEmbeddingResponse) GetMessage() string {
+func (x *ListWorkspaceMembersResponse) GetMembers() []*WorkspaceMember {
 	if x != nil {
-		return x.Message
+		return x.Members
 	}
-	return ""
+	return nil
 }
 
-type GenerateChatNameRequest struct {
+type AddWorkspaceMemberRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"` //first message in new chat
-	Model         string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Role          WorkspaceRole          `protobuf:"varint,3,opt,name=role,proto3,enum=sortedchat.WorkspaceRole" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateChatNameRequest) Reset() {
-	*x = GenerateChatNameRequest{}
-	mi := &file_chatservice_proto_msgTypes[32]
+func (x *AddWorkspaceMemberRequest) Reset() {
+	*x = AddWorkspaceMemberRequest{}
+	mi := &file_chatservice_proto_msgTypes[118]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateChatNameRequest) String() string {
+func (x *AddWorkspaceMemberRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateChatNameRequest) ProtoMessage() {}
+func (*AddWorkspaceMemberRequest) ProtoMessage() {}
 
-func (x *GenerateChatNameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[32]
+func (x *AddWorkspaceMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[118]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1785,54 +7077,54 @@ func (x *GenerateChatNameRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateChatNameRequest.ProtoReflect.Descriptor instead.
-func (*GenerateChatNameRequest) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use AddWorkspaceMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddWorkspaceMemberRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{118}
 }
 
-func (x *GenerateChatNameRequest) GetChatId() string {
+func (x *AddWorkspaceMemberRequest) GetWorkspaceId() string {
 	if x != nil {
-		return x.ChatId
+		return x.WorkspaceId
 	}
 	return ""
 }
 
-func (x *GenerateChatNameRequest) GetMessage() string {
+func (x *AddWorkspaceMemberRequest) GetUsername() string {
 	if x != nil {
-		return x.Message
+		return x.Username
 	}
 	return ""
 }
 
-func (x *GenerateChatNameRequest) GetModel() string {
+func (x *AddWorkspaceMemberRequest) GetRole() WorkspaceRole {
 	if x != nil {
-		return x.Model
+		return x.Role
 	}
-	return ""
+	return WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED
 }
 
-type GenerateChatNameResponse struct {
+type AddWorkspaceMemberResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChatName      string                 `protobuf:"bytes,1,opt,name=chat_name,json=chatName,proto3" json:"chat_name,omitempty"`
+	Member        *WorkspaceMember       `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateChatNameResponse) Reset() {
-	*x = GenerateChatNameResponse{}
-	mi := &file_chatservice_proto_msgTypes[33]
+func (x *AddWorkspaceMemberResponse) Reset() {
+	*x = AddWorkspaceMemberResponse{}
+	mi := &file_chatservice_proto_msgTypes[119]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateChatNameResponse) String() string {
+func (x *AddWorkspaceMemberResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateChatNameResponse) ProtoMessage() {}
+func (*AddWorkspaceMemberResponse) ProtoMessage() {}
 
-func (x *GenerateChatNameResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[33]
+func (x *AddWorkspaceMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[119]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1843,42 +7135,42 @@ func (x *GenerateChatNameResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateChatNameResponse.ProtoReflect.Descriptor instead.
-func (*GenerateChatNameResponse) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use AddWorkspaceMemberResponse.ProtoReflect.Descriptor instead.
+func (*AddWorkspaceMemberResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{119}
 }
 
-func (x *GenerateChatNameResponse) GetChatName() string {
+func (x *AddWorkspaceMemberResponse) GetMember() *WorkspaceMember {
 	if x != nil {
-		return x.ChatName
+		return x.Member
 	}
-	return ""
+	return nil
 }
 
-type BranchAChatRequest struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	SourceChatId        string                 `protobuf:"bytes,1,opt,name=source_chat_id,json=sourceChatId,proto3" json:"source_chat_id,omitempty"`                        // Chat to branch from
-	BranchFromMessageId string                 `protobuf:"bytes,2,opt,name=branch_from_message_id,json=branchFromMessageId,proto3" json:"branch_from_message_id,omitempty"` // Message ID where branch starts
-	BranchName          string                 `protobuf:"bytes,3,opt,name=branch_name,json=branchName,proto3" json:"branch_name,omitempty"`                                // Optional name for new branch
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+type UpdateWorkspaceMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          WorkspaceRole          `protobuf:"varint,3,opt,name=role,proto3,enum=sortedchat.WorkspaceRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BranchAChatRequest) Reset() {
-	*x = BranchAChatRequest{}
-	mi := &file_chatservice_proto_msgTypes[34]
+func (x *UpdateWorkspaceMemberRequest) Reset() {
+	*x = UpdateWorkspaceMemberRequest{}
+	mi := &file_chatservice_proto_msgTypes[120]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BranchAChatRequest) String() string {
+func (x *UpdateWorkspaceMemberRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BranchAChatRequest) ProtoMessage() {}
+func (*UpdateWorkspaceMemberRequest) ProtoMessage() {}
 
-func (x *BranchAChatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[34]
+func (x *UpdateWorkspaceMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[120]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1889,55 +7181,54 @@ func (x *BranchAChatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BranchAChatRequest.ProtoReflect.Descriptor instead.
-func (*BranchAChatRequest) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use UpdateWorkspaceMemberRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWorkspaceMemberRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{120}
 }
 
-func (x *BranchAChatRequest) GetSourceChatId() string {
+func (x *UpdateWorkspaceMemberRequest) GetWorkspaceId() string {
 	if x != nil {
-		return x.SourceChatId
+		return x.WorkspaceId
 	}
 	return ""
 }
 
-func (x *BranchAChatRequest) GetBranchFromMessageId() string {
+func (x *UpdateWorkspaceMemberRequest) GetUserId() string {
 	if x != nil {
-		return x.BranchFromMessageId
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *BranchAChatRequest) GetBranchName() string {
+func (x *UpdateWorkspaceMemberRequest) GetRole() WorkspaceRole {
 	if x != nil {
-		return x.BranchName
+		return x.Role
 	}
-	return ""
+	return WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED
 }
 
-type BranchAChatResponse struct {
+type UpdateWorkspaceMemberResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`                        // Success/error message
-	NewChatId     string                 `protobuf:"bytes,2,opt,name=new_chat_id,json=newChatId,proto3" json:"new_chat_id,omitempty"` // ID of newly created branch
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BranchAChatResponse) Reset() {
-	*x = BranchAChatResponse{}
-	mi := &file_chatservice_proto_msgTypes[35]
+func (x *UpdateWorkspaceMemberResponse) Reset() {
+	*x = UpdateWorkspaceMemberResponse{}
+	mi := &file_chatservice_proto_msgTypes[121]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BranchAChatResponse) String() string {
+func (x *UpdateWorkspaceMemberResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BranchAChatResponse) ProtoMessage() {}
+func (*UpdateWorkspaceMemberResponse) ProtoMessage() {}
 
-func (x *BranchAChatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[35]
+func (x *UpdateWorkspaceMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[121]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1948,47 +7239,41 @@ func (x *BranchAChatResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BranchAChatResponse.ProtoReflect.Descriptor instead.
-func (*BranchAChatResponse) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use UpdateWorkspaceMemberResponse.ProtoReflect.Descriptor instead.
+func (*UpdateWorkspaceMemberResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{121}
 }
 
-func (x *BranchAChatResponse) GetMessage() string {
+func (x *UpdateWorkspaceMemberResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *BranchAChatResponse) GetNewChatId() string {
-	if x != nil {
-		return x.NewChatId
-	}
-	return ""
-}
-
-type ListChatBranchRequest struct {
+type RemoveWorkspaceMemberRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListChatBranchRequest) Reset() {
-	*x = ListChatBranchRequest{}
-	mi := &file_chatservice_proto_msgTypes[36]
+func (x *RemoveWorkspaceMemberRequest) Reset() {
+	*x = RemoveWorkspaceMemberRequest{}
+	mi := &file_chatservice_proto_msgTypes[122]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListChatBranchRequest) String() string {
+func (x *RemoveWorkspaceMemberRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListChatBranchRequest) ProtoMessage() {}
+func (*RemoveWorkspaceMemberRequest) ProtoMessage() {}
 
-func (x *ListChatBranchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[36]
+func (x *RemoveWorkspaceMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[122]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1999,40 +7284,47 @@ func (x *ListChatBranchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListChatBranchRequest.ProtoReflect.Descriptor instead.
-func (*ListChatBranchRequest) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use RemoveWorkspaceMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveWorkspaceMemberRequest) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{122}
 }
 
-func (x *ListChatBranchRequest) GetChatId() string {
+func (x *RemoveWorkspaceMemberRequest) GetWorkspaceId() string {
 	if x != nil {
-		return x.ChatId
+		return x.WorkspaceId
 	}
 	return ""
 }
 
-type ListChatBranchResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	BranchChatList []*ChatInfo            `protobuf:"bytes,1,rep,name=branch_chat_list,json=branchChatList,proto3" json:"branch_chat_list,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *RemoveWorkspaceMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
 }
 
-func (x *ListChatBranchResponse) Reset() {
-	*x = ListChatBranchResponse{}
-	mi := &file_chatservice_proto_msgTypes[37]
+type RemoveWorkspaceMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveWorkspaceMemberResponse) Reset() {
+	*x = RemoveWorkspaceMemberResponse{}
+	mi := &file_chatservice_proto_msgTypes[123]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListChatBranchResponse) String() string {
+func (x *RemoveWorkspaceMemberResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListChatBranchResponse) ProtoMessage() {}
+func (*RemoveWorkspaceMemberResponse) ProtoMessage() {}
 
-func (x *ListChatBranchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chatservice_proto_msgTypes[37]
+func (x *RemoveWorkspaceMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chatservice_proto_msgTypes[123]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2043,16 +7335,16 @@ func (x *ListChatBranchResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListChatBranchResponse.ProtoReflect.Descriptor instead.
-func (*ListChatBranchResponse) Descriptor() ([]byte, []int) {
-	return file_chatservice_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use RemoveWorkspaceMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveWorkspaceMemberResponse) Descriptor() ([]byte, []int) {
+	return file_chatservice_proto_rawDescGZIP(), []int{123}
 }
 
-func (x *ListChatBranchResponse) GetBranchChatList() []*ChatInfo {
+func (x *RemoveWorkspaceMemberResponse) GetMessage() string {
 	if x != nil {
-		return x.BranchChatList
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
 var File_chatservice_proto protoreflect.FileDescriptor
@@ -2060,12 +7352,38 @@ var File_chatservice_proto protoreflect.FileDescriptor
 const file_chatservice_proto_rawDesc = "" +
 	"\n" +
 	"\x11chatservice.proto\x12\n" +
-	"sortedchat\"u\n" +
+	"sortedchat\"\xf8\x02\n" +
 	"\bSettings\x12$\n" +
 	"\x0eOPENAI_API_KEY\x18\x01 \x01(\tR\fOPENAIAPIKEY\x12$\n" +
 	"\x0eOPENAI_API_URL\x18\x02 \x01(\tR\fOPENAIAPIURL\x12\x1d\n" +
 	"\n" +
-	"OLLAMA_URL\x18\x03 \x01(\tR\tOLLAMAURL\"\x13\n" +
+	"OLLAMA_URL\x18\x03 \x01(\tR\tOLLAMAURL\x12*\n" +
+	"\x11ANTHROPIC_API_KEY\x18\x04 \x01(\tR\x0fANTHROPICAPIKEY\x12$\n" +
+	"\x0eGEMINI_API_KEY\x18\x05 \x01(\tR\fGEMINIAPIKEY\x128\n" +
+	"\tproviders\x18\x06 \x03(\v2\x1a.sortedchat.ProviderConfigR\tproviders\x12,\n" +
+	"\abudgets\x18\a \x03(\v2\x12.sortedchat.BudgetR\abudgets\x12G\n" +
+	"\rsummarization\x18\b \x01(\v2!.sortedchat.SummarizationSettingsR\rsummarization\"\x8a\x01\n" +
+	"\x15SummarizationSettings\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12)\n" +
+	"\x10threshold_tokens\x18\x02 \x01(\x05R\x0fthresholdTokens\x120\n" +
+	"\x14keep_recent_messages\x18\x03 \x01(\x05R\x12keepRecentMessages\"\xbe\x01\n" +
+	"\x06Budget\x12\x14\n" +
+	"\x05scope\x18\x01 \x01(\tR\x05scope\x12\x19\n" +
+	"\bscope_id\x18\x02 \x01(\tR\ascopeId\x12\x16\n" +
+	"\x06period\x18\x03 \x01(\tR\x06period\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x01R\x05limit\x12,\n" +
+	"\x12soft_limit_percent\x18\x05 \x01(\x01R\x10softLimitPercent\x12'\n" +
+	"\x0fdowngrade_model\x18\x06 \x01(\tR\x0edowngradeModel\"\x94\x02\n" +
+	"\x0eProviderConfig\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x19\n" +
+	"\bbase_url\x18\x03 \x01(\tR\abaseUrl\x12\x17\n" +
+	"\aapi_key\x18\x04 \x01(\tR\x06apiKey\x12A\n" +
+	"\aheaders\x18\x05 \x03(\v2'.sortedchat.ProviderConfig.HeadersEntryR\aheaders\x12'\n" +
+	"\x0ftimeout_seconds\x18\x06 \x01(\x05R\x0etimeoutSeconds\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x13\n" +
 	"\x11GetSettingRequest\"F\n" +
 	"\x12GetSettingResponse\x120\n" +
 	"\bsettings\x18\x01 \x01(\v2\x14.sortedchat.SettingsR\bsettings\"E\n" +
@@ -2079,48 +7397,164 @@ const file_chatservice_proto_rawDesc = "" +
 	"project_id\x18\x02 \x01(\tR\tprojectId\"G\n" +
 	"\x12CreateChatResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x17\n" +
-	"\achat_id\x18\x02 \x01(\tR\x06chatId\"n\n" +
+	"\achat_id\x18\x02 \x01(\tR\x06chatId\"\xa7\x01\n" +
 	"\vChatRequest\x12\x12\n" +
 	"\x04text\x18\x01 \x01(\tR\x04text\x12\x16\n" +
 	"\x06chatId\x18\x02 \x01(\tR\x06chatId\x12\x14\n" +
 	"\x05model\x18\x03 \x01(\tR\x05model\x12\x1d\n" +
 	"\n" +
-	"project_id\x18\x04 \x01(\tR\tprojectId\"h\n" +
+	"project_id\x18\x04 \x01(\tR\tprojectId\x127\n" +
+	"\aoptions\x18\x05 \x01(\v2\x1d.sortedchat.GenerationOptionsR\aoptions\"\x8b\x02\n" +
+	"\x11GenerationOptions\x12%\n" +
+	"\vtemperature\x18\x01 \x01(\x01H\x00R\vtemperature\x88\x01\x01\x12\x18\n" +
+	"\x05top_p\x18\x02 \x01(\x01H\x01R\x04topP\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"max_tokens\x18\x03 \x01(\x05R\tmaxTokens\x12\x12\n" +
+	"\x04stop\x18\x04 \x03(\tR\x04stop\x12\x17\n" +
+	"\x04seed\x18\x05 \x01(\x03H\x02R\x04seed\x88\x01\x01\x12F\n" +
+	"\x10reasoning_effort\x18\x06 \x01(\x0e2\x1b.sortedchat.ReasoningEffortR\x0freasoningEffortB\x0e\n" +
+	"\f_temperatureB\b\n" +
+	"\x06_top_pB\a\n" +
+	"\x05_seed\"\xa9\x01\n" +
 	"\fChatResponse\x12\x14\n" +
 	"\x04text\x18\x01 \x01(\tH\x00R\x04text\x126\n" +
-	"\asummary\x18\x02 \x01(\v2\x1a.sortedchat.MessageSummaryH\x00R\asummaryB\n" +
+	"\asummary\x18\x02 \x01(\v2\x1a.sortedchat.MessageSummaryH\x00R\asummary\x12?\n" +
+	"\n" +
+	"truncation\x18\x03 \x01(\v2\x1d.sortedchat.ContextTruncationH\x00R\n" +
+	"truncationB\n" +
 	"\n" +
-	"\bresponse\"/\n" +
+	"\bresponse\"\xdf\x01\n" +
+	"\x11ContextTruncation\x12)\n" +
+	"\x10dropped_messages\x18\x01 \x01(\x05R\x0fdroppedMessages\x12)\n" +
+	"\x10trimmed_messages\x18\x02 \x01(\x05R\x0ftrimmedMessages\x12(\n" +
+	"\x10first_message_id\x18\x03 \x01(\tR\x0efirstMessageId\x12#\n" +
+	"\rprompt_tokens\x18\x04 \x01(\x05R\fpromptTokens\x12%\n" +
+	"\x0econtext_window\x18\x05 \x01(\x05R\rcontextWindow\"Q\n" +
 	"\x0eMessageSummary\x12\x1d\n" +
 	"\n" +
-	"message_id\x18\x01 \x01(\tR\tmessageId\"+\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12 \n" +
+	"\vinterrupted\x18\x02 \x01(\bR\vinterrupted\",\n" +
+	"\x11CancelChatRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\".\n" +
+	"\x12CancelChatResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\xa1\x01\n" +
+	"\x18RegenerateMessageRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x127\n" +
+	"\aoptions\x18\x04 \x01(\v2\x1d.sortedchat.GenerationOptionsR\aoptions\"\xb6\x01\n" +
+	"\x12EditMessageRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x19\n" +
+	"\bnew_text\x18\x03 \x01(\tR\anewText\x12\x14\n" +
+	"\x05model\x18\x04 \x01(\tR\x05model\x127\n" +
+	"\aoptions\x18\x05 \x01(\v2\x1d.sortedchat.GenerationOptionsR\aoptions\"M\n" +
+	"\x11ResumeChatRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1f\n" +
+	"\vfrom_offset\x18\x02 \x01(\x05R\n" +
+	"fromOffset\"\x94\x01\n" +
 	"\x11GetHistoryRequest\x12\x16\n" +
-	"\x06chatId\x18\x01 \x01(\tR\x06chatId\"G\n" +
+	"\x06chatId\x18\x01 \x01(\tR\x06chatId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12+\n" +
+	"\x05order\x18\x04 \x01(\x0e2\x15.sortedchat.SortOrderR\x05order\"o\n" +
 	"\x12GetHistoryResponse\x121\n" +
-	"\ahistory\x18\x01 \x03(\v2\x17.sortedchat.ChatMessageR\ahistory\"Z\n" +
+	"\ahistory\x18\x01 \x03(\v2\x17.sortedchat.ChatMessageR\ahistory\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xd9\x01\n" +
 	"\vChatMessage\x12\x12\n" +
 	"\x04role\x18\x01 \x01(\tR\x04role\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1d\n" +
 	"\n" +
-	"message_id\x18\x03 \x01(\tR\tmessageId\"3\n" +
+	"message_id\x18\x03 \x01(\tR\tmessageId\x12 \n" +
+	"\vinterrupted\x18\x04 \x01(\bR\vinterrupted\x12\"\n" +
+	"\falternatives\x18\x05 \x01(\x05R\falternatives\x127\n" +
+	"\aoptions\x18\x06 \x01(\v2\x1d.sortedchat.GenerationOptionsR\aoptions\"\xf1\x01\n" +
 	"\x12GetChatListRequest\x12\x1d\n" +
 	"\n" +
-	"project_id\x18\x01 \x01(\tR\tprojectId\"A\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12)\n" +
+	"\x10include_archived\x18\x02 \x01(\bR\x0fincludeArchived\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12(\n" +
+	"\x04sort\x18\x05 \x01(\x0e2\x14.sortedchat.ChatSortR\x04sort\x12+\n" +
+	"\x05order\x18\x06 \x01(\x0e2\x15.sortedchat.SortOrderR\x05order\"i\n" +
 	"\x13GetChatListResponse\x12*\n" +
-	"\x05chats\x18\x01 \x03(\v2\x14.sortedchat.ChatInfoR\x05chats\"6\n" +
+	"\x05chats\x18\x01 \x03(\v2\x14.sortedchat.ChatInfoR\x05chats\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x8f\x03\n" +
 	"\bChatInfo\x12\x16\n" +
 	"\x06chatId\x18\x01 \x01(\tR\x06chatId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"\xb9\x01\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12$\n" +
+	"\x0eparent_chat_id\x18\x03 \x01(\tR\fparentChatId\x12$\n" +
+	"\x0eis_main_branch\x18\x04 \x01(\bR\fisMainBranch\x12\x16\n" +
+	"\x06pinned\x18\x05 \x01(\bR\x06pinned\x12\x1a\n" +
+	"\barchived\x18\x06 \x01(\bR\barchived\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\x12&\n" +
+	"\x0flast_message_at\x18\t \x01(\tR\rlastMessageAt\x12#\n" +
+	"\rsystem_prompt\x18\n" +
+	" \x01(\tR\fsystemPrompt\x12L\n" +
+	"\x12generation_options\x18\v \x01(\v2\x1d.sortedchat.GenerationOptionsR\x11generationOptions\"\xe2\x03\n" +
 	"\rModelListInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05label\x18\x02 \x01(\tR\x05label\x12\x1a\n" +
 	"\bprovider\x18\x03 \x01(\tR\bprovider\x12\x10\n" +
 	"\x03url\x18\x04 \x01(\tR\x03url\x12(\n" +
 	"\x10input_token_cost\x18\x05 \x01(\x02R\x0einputTokenCost\x12*\n" +
-	"\x11output_token_cost\x18\x06 \x01(\x02R\x0foutputTokenCost\"\x13\n" +
-	"\x11ListModelsRequest\"G\n" +
+	"\x11output_token_cost\x18\x06 \x01(\x02R\x0foutputTokenCost\x12'\n" +
+	"\x0fsupports_vision\x18\a \x01(\bR\x0esupportsVision\x12%\n" +
+	"\x0esupports_tools\x18\b \x01(\bR\rsupportsTools\x12%\n" +
+	"\x0econtext_window\x18\t \x01(\x05R\rcontextWindow\x12\x1d\n" +
+	"\aenabled\x18\n" +
+	" \x01(\bH\x00R\aenabled\x88\x01\x01\x12-\n" +
+	"\x12supports_reasoning\x18\v \x01(\bR\x11supportsReasoning\x12*\n" +
+	"\x11max_output_tokens\x18\f \x01(\x05R\x0fmaxOutputTokens\x12*\n" +
+	"\x11provider_model_id\x18\r \x01(\tR\x0fproviderModelIdB\n" +
+	"\n" +
+	"\b_enabled\">\n" +
+	"\x11ListModelsRequest\x12)\n" +
+	"\x10include_disabled\x18\x01 \x01(\bR\x0fincludeDisabled\"G\n" +
 	"\x12ListModelsResponse\x121\n" +
-	"\x06models\x18\x01 \x03(\v2\x19.sortedchat.ModelListInfoR\x06models\")\n" +
+	"\x06models\x18\x01 \x03(\v2\x19.sortedchat.ModelListInfoR\x06models\"E\n" +
+	"\x12CreateModelRequest\x12/\n" +
+	"\x05model\x18\x01 \x01(\v2\x19.sortedchat.ModelListInfoR\x05model\"F\n" +
+	"\x13CreateModelResponse\x12/\n" +
+	"\x05model\x18\x01 \x01(\v2\x19.sortedchat.ModelListInfoR\x05model\"E\n" +
+	"\x12UpdateModelRequest\x12/\n" +
+	"\x05model\x18\x01 \x01(\v2\x19.sortedchat.ModelListInfoR\x05model\"F\n" +
+	"\x13UpdateModelResponse\x12/\n" +
+	"\x05model\x18\x01 \x01(\v2\x19.sortedchat.ModelListInfoR\x05model\"$\n" +
+	"\x12DeleteModelRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"/\n" +
+	"\x13DeleteModelResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\";\n" +
+	"\x1dSyncModelsFromProviderRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\"g\n" +
+	"\x1eSyncModelsFromProviderResponse\x12/\n" +
+	"\x05added\x18\x01 \x03(\v2\x19.sortedchat.ModelListInfoR\x05added\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\x89\x01\n" +
+	"\x0fGetUsageRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x123\n" +
+	"\bgroup_by\x18\x03 \x01(\x0e2\x18.sortedchat.UsageGroupByR\agroupBy\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x04 \x01(\tR\tprojectId\"\xb6\x01\n" +
+	"\vUsageBucket\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12!\n" +
+	"\finput_tokens\x18\x03 \x01(\x03R\vinputTokens\x12#\n" +
+	"\routput_tokens\x18\x04 \x01(\x03R\foutputTokens\x12\x12\n" +
+	"\x04cost\x18\x05 \x01(\x01R\x04cost\x12#\n" +
+	"\rmessage_count\x18\x06 \x01(\x03R\fmessageCount\"\x98\x01\n" +
+	"\x10GetUsageResponse\x121\n" +
+	"\abuckets\x18\x01 \x03(\v2\x17.sortedchat.UsageBucketR\abuckets\x12-\n" +
+	"\x05total\x18\x02 \x01(\v2\x17.sortedchat.UsageBucketR\x05total\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\")\n" +
 	"\x11ChatSearchRequest\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\"g\n" +
 	"\fSearchResult\x12\x1b\n" +
@@ -2129,18 +7563,20 @@ const file_chatservice_proto_rawDesc = "" +
 	"\fmatched_text\x18\x03 \x01(\tR\vmatchedText\"^\n" +
 	"\x12ChatSearchResponse\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x122\n" +
-	"\aresults\x18\x02 \x03(\v2\x18.sortedchat.SearchResultR\aresults\"u\n" +
+	"\aresults\x18\x02 \x03(\v2\x18.sortedchat.SearchResultR\aresults\"\xbd\x01\n" +
 	"\x14CreateProjectRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12'\n" +
-	"\x0fadditional_data\x18\x03 \x01(\tR\x0eadditionalData\"P\n" +
+	"\x0fadditional_data\x18\x03 \x01(\tR\x0eadditionalData\x12!\n" +
+	"\fworkspace_id\x18\x04 \x01(\tR\vworkspaceId\x12#\n" +
+	"\rsystem_prompt\x18\x05 \x01(\tR\fsystemPrompt\"P\n" +
 	"\x15CreateProjectResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1d\n" +
 	"\n" +
 	"project_id\x18\x02 \x01(\tR\tprojectId\"\x14\n" +
 	"\x12GetProjectsRequest\"F\n" +
 	"\x13GetProjectsResponse\x12/\n" +
-	"\bprojects\x18\x01 \x03(\v2\x13.sortedchat.ProjectR\bprojects\"\xb6\x01\n" +
+	"\bprojects\x18\x01 \x03(\v2\x13.sortedchat.ProjectR\bprojects\"\xcc\x02\n" +
 	"\aProject\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -2149,12 +7585,21 @@ const file_chatservice_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\tR\tupdatedAt\"5\n" +
+	"updated_at\x18\x06 \x01(\tR\tupdatedAt\x12!\n" +
+	"\fworkspace_id\x18\a \x01(\tR\vworkspaceId\x12#\n" +
+	"\rsystem_prompt\x18\b \x01(\tR\fsystemPrompt\x12L\n" +
+	"\x12generation_options\x18\t \x01(\v2\x1d.sortedchat.GenerationOptionsR\x11generationOptions\"5\n" +
 	"\x14ListDocumentsRequest\x12\x1d\n" +
 	"\n" +
 	"project_id\x18\x01 \x01(\tR\tprojectId\"K\n" +
 	"\x15ListDocumentsResponse\x122\n" +
-	"\tdocuments\x18\x01 \x03(\v2\x14.sortedchat.DocumentR\tdocuments\"\xf6\x01\n" +
+	"\tdocuments\x18\x01 \x03(\v2\x14.sortedchat.DocumentR\tdocuments\"1\n" +
+	"\x16GetDocumentLinkRequest\x12\x17\n" +
+	"\adocs_id\x18\x01 \x01(\tR\x06docsId\"J\n" +
+	"\x17GetDocumentLinkResponse\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\tR\texpiresAt\"\xf6\x01\n" +
 	"\bDocument\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
 	"\n" +
@@ -2188,35 +7633,318 @@ const file_chatservice_proto_rawDesc = "" +
 	"\x15ListChatBranchRequest\x12\x17\n" +
 	"\achat_id\x18\x01 \x01(\tR\x06chatId\"X\n" +
 	"\x16ListChatBranchResponse\x12>\n" +
-	"\x10branch_chat_list\x18\x01 \x03(\v2\x14.sortedchat.ChatInfoR\x0ebranchChatList*c\n" +
+	"\x10branch_chat_list\x18\x01 \x03(\v2\x14.sortedchat.ChatInfoR\x0ebranchChatList\"/\n" +
+	"\x14GetBranchTreeRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\"\xbb\x01\n" +
+	"\n" +
+	"BranchNode\x12(\n" +
+	"\x04chat\x18\x01 \x01(\v2\x14.sortedchat.ChatInfoR\x04chat\x12*\n" +
+	"\x11parent_message_id\x18\x02 \x01(\tR\x0fparentMessageId\x12#\n" +
+	"\rmessage_count\x18\x03 \x01(\x05R\fmessageCount\x122\n" +
+	"\bchildren\x18\x04 \x03(\v2\x16.sortedchat.BranchNodeR\bchildren\"C\n" +
+	"\x15GetBranchTreeResponse\x12*\n" +
+	"\x04root\x18\x01 \x01(\v2\x16.sortedchat.BranchNodeR\x04root\"C\n" +
+	"\x13DiffBranchesRequest\x12\x15\n" +
+	"\x06chat_a\x18\x01 \x01(\tR\x05chatA\x12\x15\n" +
+	"\x06chat_b\x18\x02 \x01(\tR\x05chatB\"\xa7\x01\n" +
+	"\x14DiffBranchesResponse\x12/\n" +
+	"\x06common\x18\x01 \x03(\v2\x17.sortedchat.ChatMessageR\x06common\x12.\n" +
+	"\x06only_a\x18\x02 \x03(\v2\x17.sortedchat.ChatMessageR\x05onlyA\x12.\n" +
+	"\x06only_b\x18\x03 \x03(\v2\x17.sortedchat.ChatMessageR\x05onlyB\"G\n" +
+	"\x16SummarizeBranchRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\"x\n" +
+	"\x17SummarizeBranchResponse\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12$\n" +
+	"\x0eparent_chat_id\x18\x02 \x01(\tR\fparentChatId\x12\x18\n" +
+	"\asummary\x18\x03 \x01(\tR\asummary\"@\n" +
+	"\x11RenameChatRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\".\n" +
+	"\x12RenameChatResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"J\n" +
+	"\x11DeleteChatRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1c\n" +
+	"\tpermanent\x18\x02 \x01(\bR\tpermanent\".\n" +
+	"\x12DeleteChatResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"-\n" +
+	"\x12RestoreChatRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\"/\n" +
+	"\x13RestoreChatResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"1\n" +
+	"\x10ListTrashRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\"?\n" +
+	"\x11ListTrashResponse\x12*\n" +
+	"\x05chats\x18\x01 \x03(\v2\x14.sortedchat.ChatInfoR\x05chats\"I\n" +
+	"\x12ArchiveChatRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1a\n" +
+	"\barchived\x18\x02 \x01(\bR\barchived\"/\n" +
+	"\x13ArchiveChatResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"A\n" +
+	"\x0ePinChatRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x16\n" +
+	"\x06pinned\x18\x02 \x01(\bR\x06pinned\"+\n" +
+	"\x0fPinChatResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"I\n" +
+	"\x0fMoveChatRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tR\tprojectId\",\n" +
+	"\x10MoveChatResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\xb2\x01\n" +
+	"\vChatSummary\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x18\n" +
+	"\asummary\x18\x02 \x01(\tR\asummary\x12'\n" +
+	"\x10up_to_message_id\x18\x03 \x01(\tR\rupToMessageId\x12\x14\n" +
+	"\x05model\x18\x04 \x01(\tR\x05model\x12\x12\n" +
+	"\x04cost\x18\x05 \x01(\x01R\x04cost\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\tR\tupdatedAt\"0\n" +
+	"\x15GetChatSummaryRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\"K\n" +
+	"\x16GetChatSummaryResponse\x121\n" +
+	"\asummary\x18\x01 \x01(\v2\x17.sortedchat.ChatSummaryR\asummary\"M\n" +
+	"\x1cRegenerateChatSummaryRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\"R\n" +
+	"\x1dRegenerateChatSummaryResponse\x121\n" +
+	"\asummary\x18\x01 \x01(\v2\x17.sortedchat.ChatSummaryR\asummary\"\xcc\x01\n" +
+	"\x0ePromptTemplate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tR\tprojectId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x12\x1c\n" +
+	"\tvariables\x18\x06 \x03(\tR\tvariables\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"\xa5\x02\n" +
+	"\x16SetSystemPromptRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tR\tprojectId\x12#\n" +
+	"\rsystem_prompt\x18\x03 \x01(\tR\fsystemPrompt\x12\x1f\n" +
+	"\vtemplate_id\x18\x04 \x01(\tR\n" +
+	"templateId\x12O\n" +
+	"\tvariables\x18\x05 \x03(\v21.sortedchat.SetSystemPromptRequest.VariablesEntryR\tvariables\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\">\n" +
+	"\x17SetSystemPromptResponse\x12#\n" +
+	"\rsystem_prompt\x18\x01 \x01(\tR\fsystemPrompt\"\x8c\x01\n" +
+	"\x1bCreatePromptTemplateRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\"V\n" +
+	"\x1cCreatePromptTemplateResponse\x126\n" +
+	"\btemplate\x18\x01 \x01(\v2\x1a.sortedchat.PromptTemplateR\btemplate\";\n" +
+	"\x1aListPromptTemplatesRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\"W\n" +
+	"\x1bListPromptTemplatesResponse\x128\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x1a.sortedchat.PromptTemplateR\ttemplates\"\x8e\x01\n" +
+	"\x1bSetGenerationOptionsRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tR\tprojectId\x127\n" +
+	"\aoptions\x18\x03 \x01(\v2\x1d.sortedchat.GenerationOptionsR\aoptions\"W\n" +
+	"\x1cSetGenerationOptionsResponse\x127\n" +
+	"\aoptions\x18\x01 \x01(\v2\x1d.sortedchat.GenerationOptionsR\aoptions\"l\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\tR\tcreatedAt\x12\x19\n" +
+	"\bis_admin\x18\x04 \x01(\bR\aisAdmin\"I\n" +
+	"\x0fRegisterRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"8\n" +
+	"\x10RegisterResponse\x12$\n" +
+	"\x04user\x18\x01 \x01(\v2\x10.sortedchat.UserR\x04user\"F\n" +
+	"\fLoginRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"j\n" +
+	"\rLoginResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\x03R\texpiresAt\x12$\n" +
+	"\x04user\x18\x03 \x01(\v2\x10.sortedchat.UserR\x04user\"\x17\n" +
+	"\x15GetCurrentUserRequest\"a\n" +
+	"\x16GetCurrentUserResponse\x12$\n" +
+	"\x04user\x18\x01 \x01(\v2\x10.sortedchat.UserR\x04user\x12!\n" +
+	"\fauth_enabled\x18\x02 \x01(\bR\vauthEnabled\"\xd7\x01\n" +
+	"\bApiToken\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12/\n" +
+	"\x05scope\x18\x03 \x01(\x0e2\x19.sortedchat.ApiTokenScopeR\x05scope\x12\x16\n" +
+	"\x06prefix\x18\x04 \x01(\tR\x06prefix\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12 \n" +
+	"\flast_used_at\x18\x06 \x01(\tR\n" +
+	"lastUsedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\tR\texpiresAt\"\x84\x01\n" +
+	"\x15CreateApiTokenRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12/\n" +
+	"\x05scope\x18\x02 \x01(\x0e2\x19.sortedchat.ApiTokenScopeR\x05scope\x12&\n" +
+	"\x0fexpires_in_days\x18\x03 \x01(\x05R\rexpiresInDays\"a\n" +
+	"\x16CreateApiTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x121\n" +
+	"\tapi_token\x18\x02 \x01(\v2\x14.sortedchat.ApiTokenR\bapiToken\"\x16\n" +
+	"\x14ListApiTokensRequest\"L\n" +
+	"\x15ListApiTokensResponse\x123\n" +
+	"\n" +
+	"api_tokens\x18\x01 \x03(\v2\x14.sortedchat.ApiTokenR\tapiTokens\"'\n" +
+	"\x15RevokeApiTokenRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"2\n" +
+	"\x16RevokeApiTokenResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"}\n" +
+	"\tWorkspace\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12-\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x19.sortedchat.WorkspaceRoleR\x04role\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\"\x94\x01\n" +
+	"\x0fWorkspaceMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12-\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x19.sortedchat.WorkspaceRoleR\x04role\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\",\n" +
+	"\x16CreateWorkspaceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"N\n" +
+	"\x17CreateWorkspaceResponse\x123\n" +
+	"\tworkspace\x18\x01 \x01(\v2\x15.sortedchat.WorkspaceR\tworkspace\"\x17\n" +
+	"\x15ListWorkspacesRequest\"O\n" +
+	"\x16ListWorkspacesResponse\x125\n" +
+	"\n" +
+	"workspaces\x18\x01 \x03(\v2\x15.sortedchat.WorkspaceR\n" +
+	"workspaces\"@\n" +
+	"\x1bListWorkspaceMembersRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\"U\n" +
+	"\x1cListWorkspaceMembersResponse\x125\n" +
+	"\amembers\x18\x01 \x03(\v2\x1b.sortedchat.WorkspaceMemberR\amembers\"\x89\x01\n" +
+	"\x19AddWorkspaceMemberRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12-\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x19.sortedchat.WorkspaceRoleR\x04role\"Q\n" +
+	"\x1aAddWorkspaceMemberResponse\x123\n" +
+	"\x06member\x18\x01 \x01(\v2\x1b.sortedchat.WorkspaceMemberR\x06member\"\x89\x01\n" +
+	"\x1cUpdateWorkspaceMemberRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12-\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x19.sortedchat.WorkspaceRoleR\x04role\"9\n" +
+	"\x1dUpdateWorkspaceMemberResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"Z\n" +
+	"\x1cRemoveWorkspaceMemberRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"9\n" +
+	"\x1dRemoveWorkspaceMemberResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage*\x85\x01\n" +
+	"\x0fReasoningEffort\x12 \n" +
+	"\x1cREASONING_EFFORT_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14REASONING_EFFORT_LOW\x10\x01\x12\x1b\n" +
+	"\x17REASONING_EFFORT_MEDIUM\x10\x02\x12\x19\n" +
+	"\x15REASONING_EFFORT_HIGH\x10\x03*X\n" +
+	"\tSortOrder\x12\x16\n" +
+	"\x12SORT_ORDER_DEFAULT\x10\x00\x12\x18\n" +
+	"\x14SORT_ORDER_ASCENDING\x10\x01\x12\x19\n" +
+	"\x15SORT_ORDER_DESCENDING\x10\x02*h\n" +
+	"\bChatSort\x12\x1a\n" +
+	"\x16CHAT_SORT_LAST_MESSAGE\x10\x00\x12\x15\n" +
+	"\x11CHAT_SORT_CREATED\x10\x01\x12\x15\n" +
+	"\x11CHAT_SORT_UPDATED\x10\x02\x12\x12\n" +
+	"\x0eCHAT_SORT_NAME\x10\x03*]\n" +
+	"\fUsageGroupBy\x12\x10\n" +
+	"\fUSAGE_BY_DAY\x10\x00\x12\x12\n" +
+	"\x0eUSAGE_BY_MODEL\x10\x01\x12\x14\n" +
+	"\x10USAGE_BY_PROJECT\x10\x02\x12\x11\n" +
+	"\rUSAGE_BY_CHAT\x10\x03*c\n" +
 	"\x10Embedding_Status\x12\x11\n" +
 	"\rSTATUS_QUEUED\x10\x00\x12\x16\n" +
 	"\x12STATUS_IN_PROGRESS\x10\x01\x12\x10\n" +
 	"\fSTATUS_ERROR\x10\x02\x12\x12\n" +
-	"\x0eSTATUS_SUCCESS\x10\x032\xbc\b\n" +
+	"\x0eSTATUS_SUCCESS\x10\x03*\x84\x01\n" +
+	"\rApiTokenScope\x12\x1f\n" +
+	"\x1bAPI_TOKEN_SCOPE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19API_TOKEN_SCOPE_READ_ONLY\x10\x01\x12\x18\n" +
+	"\x14API_TOKEN_SCOPE_CHAT\x10\x02\x12\x19\n" +
+	"\x15API_TOKEN_SCOPE_ADMIN\x10\x03*\x7f\n" +
+	"\rWorkspaceRole\x12\x1e\n" +
+	"\x1aWORKSPACE_ROLE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14WORKSPACE_ROLE_OWNER\x10\x01\x12\x19\n" +
+	"\x15WORKSPACE_ROLE_EDITOR\x10\x02\x12\x19\n" +
+	"\x15WORKSPACE_ROLE_VIEWER\x10\x032\xce\x1e\n" +
 	"\n" +
 	"SortedChat\x12;\n" +
-	"\x04Chat\x12\x17.sortedchat.ChatRequest\x1a\x18.sortedchat.ChatResponse0\x01\x12]\n" +
+	"\x04Chat\x12\x17.sortedchat.ChatRequest\x1a\x18.sortedchat.ChatResponse0\x01\x12K\n" +
+	"\n" +
+	"CancelChat\x12\x1d.sortedchat.CancelChatRequest\x1a\x1e.sortedchat.CancelChatResponse\x12G\n" +
+	"\n" +
+	"ResumeChat\x12\x1d.sortedchat.ResumeChatRequest\x1a\x18.sortedchat.ChatResponse0\x01\x12U\n" +
+	"\x11RegenerateMessage\x12$.sortedchat.RegenerateMessageRequest\x1a\x18.sortedchat.ChatResponse0\x01\x12I\n" +
+	"\vEditMessage\x12\x1e.sortedchat.EditMessageRequest\x1a\x18.sortedchat.ChatResponse0\x01\x12]\n" +
 	"\x10GenerateChatName\x12#.sortedchat.GenerateChatNameRequest\x1a$.sortedchat.GenerateChatNameResponse\x12K\n" +
 	"\n" +
 	"GetHistory\x12\x1d.sortedchat.GetHistoryRequest\x1a\x1e.sortedchat.GetHistoryResponse\x12N\n" +
 	"\vGetChatList\x12\x1e.sortedchat.GetChatListRequest\x1a\x1f.sortedchat.GetChatListResponse\x12K\n" +
 	"\n" +
 	"CreateChat\x12\x1d.sortedchat.CreateChatRequest\x1a\x1e.sortedchat.CreateChatResponse\x12J\n" +
-	"\tListModel\x12\x1d.sortedchat.ListModelsRequest\x1a\x1e.sortedchat.ListModelsResponse\x12K\n" +
+	"\tListModel\x12\x1d.sortedchat.ListModelsRequest\x1a\x1e.sortedchat.ListModelsResponse\x12N\n" +
+	"\vCreateModel\x12\x1e.sortedchat.CreateModelRequest\x1a\x1f.sortedchat.CreateModelResponse\x12N\n" +
+	"\vUpdateModel\x12\x1e.sortedchat.UpdateModelRequest\x1a\x1f.sortedchat.UpdateModelResponse\x12N\n" +
+	"\vDeleteModel\x12\x1e.sortedchat.DeleteModelRequest\x1a\x1f.sortedchat.DeleteModelResponse\x12o\n" +
+	"\x16SyncModelsFromProvider\x12).sortedchat.SyncModelsFromProviderRequest\x1a*.sortedchat.SyncModelsFromProviderResponse\x12E\n" +
+	"\bGetUsage\x12\x1b.sortedchat.GetUsageRequest\x1a\x1c.sortedchat.GetUsageResponse\x12K\n" +
 	"\n" +
 	"SearchChat\x12\x1d.sortedchat.ChatSearchRequest\x1a\x1e.sortedchat.ChatSearchResponse\x12T\n" +
 	"\rCreateProject\x12 .sortedchat.CreateProjectRequest\x1a!.sortedchat.CreateProjectResponse\x12N\n" +
 	"\vGetProjects\x12\x1e.sortedchat.GetProjectsRequest\x1a\x1f.sortedchat.GetProjectsResponse\x12T\n" +
-	"\rListDocuments\x12 .sortedchat.ListDocumentsRequest\x1a!.sortedchat.ListDocumentsResponse\x12j\n" +
+	"\rListDocuments\x12 .sortedchat.ListDocumentsRequest\x1a!.sortedchat.ListDocumentsResponse\x12Z\n" +
+	"\x0fGetDocumentLink\x12\".sortedchat.GetDocumentLinkRequest\x1a#.sortedchat.GetDocumentLinkResponse\x12j\n" +
 	"\x1bSubmitGenerateEmbeddingsJob\x12$.sortedchat.GenerateEmbeddingRequest\x1a%.sortedchat.GenerateEmbeddingResponse\x12N\n" +
 	"\vBranchAChat\x12\x1e.sortedchat.BranchAChatRequest\x1a\x1f.sortedchat.BranchAChatResponse\x12W\n" +
-	"\x0eListChatBranch\x12!.sortedchat.ListChatBranchRequest\x1a\".sortedchat.ListChatBranchResponse2\xaa\x01\n" +
+	"\x0eListChatBranch\x12!.sortedchat.ListChatBranchRequest\x1a\".sortedchat.ListChatBranchResponse\x12T\n" +
+	"\rGetBranchTree\x12 .sortedchat.GetBranchTreeRequest\x1a!.sortedchat.GetBranchTreeResponse\x12Q\n" +
+	"\fDiffBranches\x12\x1f.sortedchat.DiffBranchesRequest\x1a .sortedchat.DiffBranchesResponse\x12Z\n" +
+	"\x0fSummarizeBranch\x12\".sortedchat.SummarizeBranchRequest\x1a#.sortedchat.SummarizeBranchResponse\x12K\n" +
+	"\n" +
+	"RenameChat\x12\x1d.sortedchat.RenameChatRequest\x1a\x1e.sortedchat.RenameChatResponse\x12K\n" +
+	"\n" +
+	"DeleteChat\x12\x1d.sortedchat.DeleteChatRequest\x1a\x1e.sortedchat.DeleteChatResponse\x12N\n" +
+	"\vRestoreChat\x12\x1e.sortedchat.RestoreChatRequest\x1a\x1f.sortedchat.RestoreChatResponse\x12H\n" +
+	"\tListTrash\x12\x1c.sortedchat.ListTrashRequest\x1a\x1d.sortedchat.ListTrashResponse\x12N\n" +
+	"\vArchiveChat\x12\x1e.sortedchat.ArchiveChatRequest\x1a\x1f.sortedchat.ArchiveChatResponse\x12B\n" +
+	"\aPinChat\x12\x1a.sortedchat.PinChatRequest\x1a\x1b.sortedchat.PinChatResponse\x12E\n" +
+	"\bMoveChat\x12\x1b.sortedchat.MoveChatRequest\x1a\x1c.sortedchat.MoveChatResponse\x12W\n" +
+	"\x0eGetChatSummary\x12!.sortedchat.GetChatSummaryRequest\x1a\".sortedchat.GetChatSummaryResponse\x12l\n" +
+	"\x15RegenerateChatSummary\x12(.sortedchat.RegenerateChatSummaryRequest\x1a).sortedchat.RegenerateChatSummaryResponse\x12Z\n" +
+	"\x0fSetSystemPrompt\x12\".sortedchat.SetSystemPromptRequest\x1a#.sortedchat.SetSystemPromptResponse\x12i\n" +
+	"\x14CreatePromptTemplate\x12'.sortedchat.CreatePromptTemplateRequest\x1a(.sortedchat.CreatePromptTemplateResponse\x12f\n" +
+	"\x13ListPromptTemplates\x12&.sortedchat.ListPromptTemplatesRequest\x1a'.sortedchat.ListPromptTemplatesResponse\x12i\n" +
+	"\x14SetGenerationOptions\x12'.sortedchat.SetGenerationOptionsRequest\x1a(.sortedchat.SetGenerationOptionsResponse\x12Z\n" +
+	"\x0fCreateWorkspace\x12\".sortedchat.CreateWorkspaceRequest\x1a#.sortedchat.CreateWorkspaceResponse\x12W\n" +
+	"\x0eListWorkspaces\x12!.sortedchat.ListWorkspacesRequest\x1a\".sortedchat.ListWorkspacesResponse\x12i\n" +
+	"\x14ListWorkspaceMembers\x12'.sortedchat.ListWorkspaceMembersRequest\x1a(.sortedchat.ListWorkspaceMembersResponse\x12c\n" +
+	"\x12AddWorkspaceMember\x12%.sortedchat.AddWorkspaceMemberRequest\x1a&.sortedchat.AddWorkspaceMemberResponse\x12l\n" +
+	"\x15UpdateWorkspaceMember\x12(.sortedchat.UpdateWorkspaceMemberRequest\x1a).sortedchat.UpdateWorkspaceMemberResponse\x12l\n" +
+	"\x15RemoveWorkspaceMember\x12(.sortedchat.RemoveWorkspaceMemberRequest\x1a).sortedchat.RemoveWorkspaceMemberResponse2\xaa\x01\n" +
 	"\x0eSettingService\x12K\n" +
 	"\n" +
 	"GetSetting\x12\x1d.sortedchat.GetSettingRequest\x1a\x1e.sortedchat.GetSettingResponse\x12K\n" +
 	"\n" +
-	"SetSetting\x12\x1d.sortedchat.SetSettingRequest\x1a\x1e.sortedchat.SetSettingResponseB!Z\x1fsortedstartup/chatservice/protob\x06proto3"
+	"SetSetting\x12\x1d.sortedchat.SetSettingRequest\x1a\x1e.sortedchat.SetSettingResponse2\xf3\x03\n" +
+	"\vAuthService\x12E\n" +
+	"\bRegister\x12\x1b.sortedchat.RegisterRequest\x1a\x1c.sortedchat.RegisterResponse\x12<\n" +
+	"\x05Login\x12\x18.sortedchat.LoginRequest\x1a\x19.sortedchat.LoginResponse\x12W\n" +
+	"\x0eGetCurrentUser\x12!.sortedchat.GetCurrentUserRequest\x1a\".sortedchat.GetCurrentUserResponse\x12W\n" +
+	"\x0eCreateApiToken\x12!.sortedchat.CreateApiTokenRequest\x1a\".sortedchat.CreateApiTokenResponse\x12T\n" +
+	"\rListApiTokens\x12 .sortedchat.ListApiTokensRequest\x1a!.sortedchat.ListApiTokensResponse\x12W\n" +
+	"\x0eRevokeApiToken\x12!.sortedchat.RevokeApiTokenRequest\x1a\".sortedchat.RevokeApiTokenResponseB!Z\x1fsortedstartup/chatservice/protob\x06proto3"
 
 var (
 	file_chatservice_proto_rawDescOnce sync.Once
@@ -2230,96 +7958,318 @@ func file_chatservice_proto_rawDescGZIP() []byte {
 	return file_chatservice_proto_rawDescData
 }
 
-var file_chatservice_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_chatservice_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
+var file_chatservice_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_chatservice_proto_msgTypes = make([]protoimpl.MessageInfo, 126)
 var file_chatservice_proto_goTypes = []any{
-	(Embedding_Status)(0),             // 0: sortedchat.Embedding_Status
-	(*Settings)(nil),                  // 1: sortedchat.Settings
-	(*GetSettingRequest)(nil),         // 2: sortedchat.GetSettingRequest
-	(*GetSettingResponse)(nil),        // 3: sortedchat.GetSettingResponse
-	(*SetSettingRequest)(nil),         // 4: sortedchat.SetSettingRequest
-	(*SetSettingResponse)(nil),        // 5: sortedchat.SetSettingResponse
-	(*CreateChatRequest)(nil),         // 6: sortedchat.CreateChatRequest
-	(*CreateChatResponse)(nil),        // 7: sortedchat.CreateChatResponse
-	(*ChatRequest)(nil),               // 8: sortedchat.ChatRequest
-	(*ChatResponse)(nil),              // 9: sortedchat.ChatResponse
-	(*MessageSummary)(nil),            // 10: sortedchat.MessageSummary
-	(*GetHistoryRequest)(nil),         // 11: sortedchat.GetHistoryRequest
-	(*GetHistoryResponse)(nil),        // 12: sortedchat.GetHistoryResponse
-	(*ChatMessage)(nil),               // 13: sortedchat.ChatMessage
-	(*GetChatListRequest)(nil),        // 14: sortedchat.GetChatListRequest
-	(*GetChatListResponse)(nil),       // 15: sortedchat.GetChatListResponse
-	(*ChatInfo)(nil),                  // 16: sortedchat.ChatInfo
-	(*ModelListInfo)(nil),             // 17: sortedchat.ModelListInfo
-	(*ListModelsRequest)(nil),         // 18: sortedchat.ListModelsRequest
-	(*ListModelsResponse)(nil),        // 19: sortedchat.ListModelsResponse
-	(*ChatSearchRequest)(nil),         // 20: sortedchat.ChatSearchRequest
-	(*SearchResult)(nil),              // 21: sortedchat.SearchResult
-	(*ChatSearchResponse)(nil),        // 22: sortedchat.ChatSearchResponse
-	(*CreateProjectRequest)(nil),      // 23: sortedchat.CreateProjectRequest
-	(*CreateProjectResponse)(nil),     // 24: sortedchat.CreateProjectResponse
-	(*GetProjectsRequest)(nil),        // 25: sortedchat.GetProjectsRequest
-	(*GetProjectsResponse)(nil),       // 26: sortedchat.GetProjectsResponse
-	(*Project)(nil),                   // 27: sortedchat.Project
-	(*ListDocumentsRequest)(nil),      // 28: sortedchat.ListDocumentsRequest
-	(*ListDocumentsResponse)(nil),     // 29: sortedchat.ListDocumentsResponse
-	(*Document)(nil),                  // 30: sortedchat.Document
-	(*GenerateEmbeddingRequest)(nil),  // 31: sortedchat.GenerateEmbeddingRequest
-	(*GenerateEmbeddingResponse)(nil), // 32: sortedchat.GenerateEmbeddingResponse
-	(*GenerateChatNameRequest)(nil),   // 33: sortedchat.GenerateChatNameRequest
-	(*GenerateChatNameResponse)(nil),  // 34: sortedchat.GenerateChatNameResponse
-	(*BranchAChatRequest)(nil),        // 35: sortedchat.BranchAChatRequest
-	(*BranchAChatResponse)(nil),       // 36: sortedchat.BranchAChatResponse
-	(*ListChatBranchRequest)(nil),     // 37: sortedchat.ListChatBranchRequest
-	(*ListChatBranchResponse)(nil),    // 38: sortedchat.ListChatBranchResponse
+	(ReasoningEffort)(0),                   // 0: sortedchat.ReasoningEffort
+	(SortOrder)(0),                         // 1: sortedchat.SortOrder
+	(ChatSort)(0),                          // 2: sortedchat.ChatSort
+	(UsageGroupBy)(0),                      // 3: sortedchat.UsageGroupBy
+	(Embedding_Status)(0),                  // 4: sortedchat.Embedding_Status
+	(ApiTokenScope)(0),                     // 5: sortedchat.ApiTokenScope
+	(WorkspaceRole)(0),                     // 6: sortedchat.WorkspaceRole
+	(*Settings)(nil),                       // 7: sortedchat.Settings
+	(*SummarizationSettings)(nil),          // 8: sortedchat.SummarizationSettings
+	(*Budget)(nil),                         // 9: sortedchat.Budget
+	(*ProviderConfig)(nil),                 // 10: sortedchat.ProviderConfig
+	(*GetSettingRequest)(nil),              // 11: sortedchat.GetSettingRequest
+	(*GetSettingResponse)(nil),             // 12: sortedchat.GetSettingResponse
+	(*SetSettingRequest)(nil),              // 13: sortedchat.SetSettingRequest
+	(*SetSettingResponse)(nil),             // 14: sortedchat.SetSettingResponse
+	(*CreateChatRequest)(nil),              // 15: sortedchat.CreateChatRequest
+	(*CreateChatResponse)(nil),             // 16: sortedchat.CreateChatResponse
+	(*ChatRequest)(nil),                    // 17: sortedchat.ChatRequest
+	(*GenerationOptions)(nil),              // 18: sortedchat.GenerationOptions
+	(*ChatResponse)(nil),                   // 19: sortedchat.ChatResponse
+	(*ContextTruncation)(nil),              // 20: sortedchat.ContextTruncation
+	(*MessageSummary)(nil),                 // 21: sortedchat.MessageSummary
+	(*CancelChatRequest)(nil),              // 22: sortedchat.CancelChatRequest
+	(*CancelChatResponse)(nil),             // 23: sortedchat.CancelChatResponse
+	(*RegenerateMessageRequest)(nil),       // 24: sortedchat.RegenerateMessageRequest
+	(*EditMessageRequest)(nil),             // 25: sortedchat.EditMessageRequest
+	(*ResumeChatRequest)(nil),              // 26: sortedchat.ResumeChatRequest
+	(*GetHistoryRequest)(nil),              // 27: sortedchat.GetHistoryRequest
+	(*GetHistoryResponse)(nil),             // 28: sortedchat.GetHistoryResponse
+	(*ChatMessage)(nil),                    // 29: sortedchat.ChatMessage
+	(*GetChatListRequest)(nil),             // 30: sortedchat.GetChatListRequest
+	(*GetChatListResponse)(nil),            // 31: sortedchat.GetChatListResponse
+	(*ChatInfo)(nil),                       // 32: sortedchat.ChatInfo
+	(*ModelListInfo)(nil),                  // 33: sortedchat.ModelListInfo
+	(*ListModelsRequest)(nil),              // 34: sortedchat.ListModelsRequest
+	(*ListModelsResponse)(nil),             // 35: sortedchat.ListModelsResponse
+	(*CreateModelRequest)(nil),             // 36: sortedchat.CreateModelRequest
+	(*CreateModelResponse)(nil),            // 37: sortedchat.CreateModelResponse
+	(*UpdateModelRequest)(nil),             // 38: sortedchat.UpdateModelRequest
+	(*UpdateModelResponse)(nil),            // 39: sortedchat.UpdateModelResponse
+	(*DeleteModelRequest)(nil),             // 40: sortedchat.DeleteModelRequest
+	(*DeleteModelResponse)(nil),            // 41: sortedchat.DeleteModelResponse
+	(*SyncModelsFromProviderRequest)(nil),  // 42: sortedchat.SyncModelsFromProviderRequest
+	(*SyncModelsFromProviderResponse)(nil), // 43: sortedchat.SyncModelsFromProviderResponse
+	(*GetUsageRequest)(nil),                // 44: sortedchat.GetUsageRequest
+	(*UsageBucket)(nil),                    // 45: sortedchat.UsageBucket
+	(*GetUsageResponse)(nil),               // 46: sortedchat.GetUsageResponse
+	(*ChatSearchRequest)(nil),              // 47: sortedchat.ChatSearchRequest
+	(*SearchResult)(nil),                   // 48: sortedchat.SearchResult
+	(*ChatSearchResponse)(nil),             // 49: sortedchat.ChatSearchResponse
+	(*CreateProjectRequest)(nil),           // 50: sortedchat.CreateProjectRequest
+	(*CreateProjectResponse)(nil),          // 51: sortedchat.CreateProjectResponse
+	(*GetProjectsRequest)(nil),             // 52: sortedchat.GetProjectsRequest
+	(*GetProjectsResponse)(nil),            // 53: sortedchat.GetProjectsResponse
+	(*Project)(nil),                        // 54: sortedchat.Project
+	(*ListDocumentsRequest)(nil),           // 55: sortedchat.ListDocumentsRequest
+	(*ListDocumentsResponse)(nil),          // 56: sortedchat.ListDocumentsResponse
+	(*GetDocumentLinkRequest)(nil),         // 57: sortedchat.GetDocumentLinkRequest
+	(*GetDocumentLinkResponse)(nil),        // 58: sortedchat.GetDocumentLinkResponse
+	(*Document)(nil),                       // 59: sortedchat.Document
+	(*GenerateEmbeddingRequest)(nil),       // 60: sortedchat.GenerateEmbeddingRequest
+	(*GenerateEmbeddingResponse)(nil),      // 61: sortedchat.GenerateEmbeddingResponse
+	(*GenerateChatNameRequest)(nil),        // 62: sortedchat.GenerateChatNameRequest
+	(*GenerateChatNameResponse)(nil),       // 63: sortedchat.GenerateChatNameResponse
+	(*BranchAChatRequest)(nil),             // 64: sortedchat.BranchAChatRequest
+	(*BranchAChatResponse)(nil),            // 65: sortedchat.BranchAChatResponse
+	(*ListChatBranchRequest)(nil),          // 66: sortedchat.ListChatBranchRequest
+	(*ListChatBranchResponse)(nil),         // 67: sortedchat.ListChatBranchResponse
+	(*GetBranchTreeRequest)(nil),           // 68: sortedchat.GetBranchTreeRequest
+	(*BranchNode)(nil),                     // 69: sortedchat.BranchNode
+	(*GetBranchTreeResponse)(nil),          // 70: sortedchat.GetBranchTreeResponse
+	(*DiffBranchesRequest)(nil),            // 71: sortedchat.DiffBranchesRequest
+	(*DiffBranchesResponse)(nil),           // 72: sortedchat.DiffBranchesResponse
+	(*SummarizeBranchRequest)(nil),         // 73: sortedchat.SummarizeBranchRequest
+	(*SummarizeBranchResponse)(nil),        // 74: sortedchat.SummarizeBranchResponse
+	(*RenameChatRequest)(nil),              // 75: sortedchat.RenameChatRequest
+	(*RenameChatResponse)(nil),             // 76: sortedchat.RenameChatResponse
+	(*DeleteChatRequest)(nil),              // 77: sortedchat.DeleteChatRequest
+	(*DeleteChatResponse)(nil),             // 78: sortedchat.DeleteChatResponse
+	(*RestoreChatRequest)(nil),             // 79: sortedchat.RestoreChatRequest
+	(*RestoreChatResponse)(nil),            // 80: sortedchat.RestoreChatResponse
+	(*ListTrashRequest)(nil),               // 81: sortedchat.ListTrashRequest
+	(*ListTrashResponse)(nil),              // 82: sortedchat.ListTrashResponse
+	(*ArchiveChatRequest)(nil),             // 83: sortedchat.ArchiveChatRequest
+	(*ArchiveChatResponse)(nil),            // 84: sortedchat.ArchiveChatResponse
+	(*PinChatRequest)(nil),                 // 85: sortedchat.PinChatRequest
+	(*PinChatResponse)(nil),                // 86: sortedchat.PinChatResponse
+	(*MoveChatRequest)(nil),                // 87: sortedchat.MoveChatRequest
+	(*MoveChatResponse)(nil),               // 88: sortedchat.MoveChatResponse
+	(*ChatSummary)(nil),                    // 89: sortedchat.ChatSummary
+	(*GetChatSummaryRequest)(nil),          // 90: sortedchat.GetChatSummaryRequest
+	(*GetChatSummaryResponse)(nil),         // 91: sortedchat.GetChatSummaryResponse
+	(*RegenerateChatSummaryRequest)(nil),   // 92: sortedchat.RegenerateChatSummaryRequest
+	(*RegenerateChatSummaryResponse)(nil),  // 93: sortedchat.RegenerateChatSummaryResponse
+	(*PromptTemplate)(nil),                 // 94: sortedchat.PromptTemplate
+	(*SetSystemPromptRequest)(nil),         // 95: sortedchat.SetSystemPromptRequest
+	(*SetSystemPromptResponse)(nil),        // 96: sortedchat.SetSystemPromptResponse
+	(*CreatePromptTemplateRequest)(nil),    // 97: sortedchat.CreatePromptTemplateRequest
+	(*CreatePromptTemplateResponse)(nil),   // 98: sortedchat.CreatePromptTemplateResponse
+	(*ListPromptTemplatesRequest)(nil),     // 99: sortedchat.ListPromptTemplatesRequest
+	(*ListPromptTemplatesResponse)(nil),    // 100: sortedchat.ListPromptTemplatesResponse
+	(*SetGenerationOptionsRequest)(nil),    // 101: sortedchat.SetGenerationOptionsRequest
+	(*SetGenerationOptionsResponse)(nil),   // 102: sortedchat.SetGenerationOptionsResponse
+	(*User)(nil),                           // 103: sortedchat.User
+	(*RegisterRequest)(nil),                // 104: sortedchat.RegisterRequest
+	(*RegisterResponse)(nil),               // 105: sortedchat.RegisterResponse
+	(*LoginRequest)(nil),                   // 106: sortedchat.LoginRequest
+	(*LoginResponse)(nil),                  // 107: sortedchat.LoginResponse
+	(*GetCurrentUserRequest)(nil),          // 108: sortedchat.GetCurrentUserRequest
+	(*GetCurrentUserResponse)(nil),         // 109: sortedchat.GetCurrentUserResponse
+	(*ApiToken)(nil),                       // 110: sortedchat.ApiToken
+	(*CreateApiTokenRequest)(nil),          // 111: sortedchat.CreateApiTokenRequest
+	(*CreateApiTokenResponse)(nil),         // 112: sortedchat.CreateApiTokenResponse
+	(*ListApiTokensRequest)(nil),           // 113: sortedchat.ListApiTokensRequest
+	(*ListApiTokensResponse)(nil),          // 114: sortedchat.ListApiTokensResponse
+	(*RevokeApiTokenRequest)(nil),          // 115: sortedchat.RevokeApiTokenRequest
+	(*RevokeApiTokenResponse)(nil),         // 116: sortedchat.RevokeApiTokenResponse
+	(*Workspace)(nil),                      // 117: sortedchat.Workspace
+	(*WorkspaceMember)(nil),                // 118: sortedchat.WorkspaceMember
+	(*CreateWorkspaceRequest)(nil),         // 119: sortedchat.CreateWorkspaceRequest
+	(*CreateWorkspaceResponse)(nil),        // 120: sortedchat.CreateWorkspaceResponse
+	(*ListWorkspacesRequest)(nil),          // 121: sortedchat.ListWorkspacesRequest
+	(*ListWorkspacesResponse)(nil),         // 122: sortedchat.ListWorkspacesResponse
+	(*ListWorkspaceMembersRequest)(nil),    // 123: sortedchat.ListWorkspaceMembersRequest
+	(*ListWorkspaceMembersResponse)(nil),   // 124: sortedchat.ListWorkspaceMembersResponse
+	(*AddWorkspaceMemberRequest)(nil),      // 125: sortedchat.AddWorkspaceMemberRequest
+	(*AddWorkspaceMemberResponse)(nil),     // 126: sortedchat.AddWorkspaceMemberResponse
+	(*UpdateWorkspaceMemberRequest)(nil),   // 127: sortedchat.UpdateWorkspaceMemberRequest
+	(*UpdateWorkspaceMemberResponse)(nil),  // 128: sortedchat.UpdateWorkspaceMemberResponse
+	(*RemoveWorkspaceMemberRequest)(nil),   // 129: sortedchat.RemoveWorkspaceMemberRequest
+	(*RemoveWorkspaceMemberResponse)(nil),  // 130: sortedchat.RemoveWorkspaceMemberResponse
+	nil,                                    // 131: sortedchat.ProviderConfig.HeadersEntry
+	nil,                                    // 132: sortedchat.SetSystemPromptRequest.VariablesEntry
 }
 var file_chatservice_proto_depIdxs = []int32{
-	1,  // 0: sortedchat.GetSettingResponse.settings:type_name -> sortedchat.Settings
-	1,  // 1: sortedchat.SetSettingRequest.settings:type_name -> sortedchat.Settings
-	10, // 2: sortedchat.ChatResponse.summary:type_name -> sortedchat.MessageSummary
-	13, // 3: sortedchat.GetHistoryResponse.history:type_name -> sortedchat.ChatMessage
-	16, // 4: sortedchat.GetChatListResponse.chats:type_name -> sortedchat.ChatInfo
-	17, // 5: sortedchat.ListModelsResponse.models:type_name -> sortedchat.ModelListInfo
-	21, // 6: sortedchat.ChatSearchResponse.results:type_name -> sortedchat.SearchResult
-	27, // 7: sortedchat.GetProjectsResponse.projects:type_name -> sortedchat.Project
-	30, // 8: sortedchat.ListDocumentsResponse.documents:type_name -> sortedchat.Document
-	0,  // 9: sortedchat.Document.embedding_status:type_name -> sortedchat.Embedding_Status
-	16, // 10: sortedchat.ListChatBranchResponse.branch_chat_list:type_name -> sortedchat.ChatInfo
-	8,  // 11: sortedchat.SortedChat.Chat:input_type -> sortedchat.ChatRequest
-	33, // 12: sortedchat.SortedChat.GenerateChatName:input_type -> sortedchat.GenerateChatNameRequest
-	11, // 13: sortedchat.SortedChat.GetHistory:input_type -> sortedchat.GetHistoryRequest
-	14, // 14: sortedchat.SortedChat.GetChatList:input_type -> sortedchat.GetChatListRequest
-	6,  // 15: sortedchat.SortedChat.CreateChat:input_type -> sortedchat.CreateChatRequest
-	18, // 16: sortedchat.SortedChat.ListModel:input_type -> sortedchat.ListModelsRequest
-	20, // 17: sortedchat.SortedChat.SearchChat:input_type -> sortedchat.ChatSearchRequest
-	23, // 18: sortedchat.SortedChat.CreateProject:input_type -> sortedchat.CreateProjectRequest
-	25, // 19: sortedchat.SortedChat.GetProjects:input_type -> sortedchat.GetProjectsRequest
-	28, // 20: sortedchat.SortedChat.ListDocuments:input_type -> sortedchat.ListDocumentsRequest
-	31, // 21: sortedchat.SortedChat.SubmitGenerateEmbeddingsJob:input_type -> sortedchat.GenerateEmbeddingRequest
-	35, // 22: sortedchat.SortedChat.BranchAChat:input_type -> sortedchat.BranchAChatRequest
-	37, // 23: sortedchat.SortedChat.ListChatBranch:input_type -> sortedchat.ListChatBranchRequest
-	2,  // 24: sortedchat.SettingService.GetSetting:input_type -> sortedchat.GetSettingRequest
-	4,  // 25: sortedchat.SettingService.SetSetting:input_type -> sortedchat.SetSettingRequest
-	9,  // 26: sortedchat.SortedChat.Chat:output_type -> sortedchat.ChatResponse
-	34, // 27: sortedchat.SortedChat.GenerateChatName:output_type -> sortedchat.GenerateChatNameResponse
-	12, // 28: sortedchat.SortedChat.GetHistory:output_type -> sortedchat.GetHistoryResponse
-	15, // 29: sortedchat.SortedChat.GetChatList:output_type -> sortedchat.GetChatListResponse
-	7,  // 30: sortedchat.SortedChat.CreateChat:output_type -> sortedchat.CreateChatResponse
-	19, // 31: sortedchat.SortedChat.ListModel:output_type -> sortedchat.ListModelsResponse
-	22, // 32: sortedchat.SortedChat.SearchChat:output_type -> sortedchat.ChatSearchResponse
-	24, // 33: sortedchat.SortedChat.CreateProject:output_type -> sortedchat.CreateProjectResponse
-	26, // 34: sortedchat.SortedChat.GetProjects:output_type -> sortedchat.GetProjectsResponse
-	29, // 35: sortedchat.SortedChat.ListDocuments:output_type -> sortedchat.ListDocumentsResponse
-	32, // 36: sortedchat.SortedChat.SubmitGenerateEmbeddingsJob:output_type -> sortedchat.GenerateEmbeddingResponse
-	36, // 37: sortedchat.SortedChat.BranchAChat:output_type -> sortedchat.BranchAChatResponse
-	38, // 38: sortedchat.SortedChat.ListChatBranch:output_type -> sortedchat.ListChatBranchResponse
-	3,  // 39: sortedchat.SettingService.GetSetting:output_type -> sortedchat.GetSettingResponse
-	5,  // 40: sortedchat.SettingService.SetSetting:output_type -> sortedchat.SetSettingResponse
-	26, // [26:41] is the sub-list for method output_type
-	11, // [11:26] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	10,  // 0: sortedchat.Settings.providers:type_name -> sortedchat.ProviderConfig
+	9,   // 1: sortedchat.Settings.budgets:type_name -> sortedchat.Budget
+	8,   // 2: sortedchat.Settings.summarization:type_name -> sortedchat.SummarizationSettings
+	131, // 3: sortedchat.ProviderConfig.headers:type_name -> sortedchat.ProviderConfig.HeadersEntry
+	7,   // 4: sortedchat.GetSettingResponse.settings:type_name -> sortedchat.Settings
+	7,   // 5: sortedchat.SetSettingRequest.settings:type_name -> sortedchat.Settings
+	18,  // 6: sortedchat.ChatRequest.options:type_name -> sortedchat.GenerationOptions
+	0,   // 7: sortedchat.GenerationOptions.reasoning_effort:type_name -> sortedchat.ReasoningEffort
+	21,  // 8: sortedchat.ChatResponse.summary:type_name -> sortedchat.MessageSummary
+	20,  // 9: sortedchat.ChatResponse.truncation:type_name -> sortedchat.ContextTruncation
+	18,  // 10: sortedchat.RegenerateMessageRequest.options:type_name -> sortedchat.GenerationOptions
+	18,  // 11: sortedchat.EditMessageRequest.options:type_name -> sortedchat.GenerationOptions
+	1,   // 12: sortedchat.GetHistoryRequest.order:type_name -> sortedchat.SortOrder
+	29,  // 13: sortedchat.GetHistoryResponse.history:type_name -> sortedchat.ChatMessage
+	18,  // 14: sortedchat.ChatMessage.options:type_name -> sortedchat.GenerationOptions
+	2,   // 15: sortedchat.GetChatListRequest.sort:type_name -> sortedchat.ChatSort
+	1,   // 16: sortedchat.GetChatListRequest.order:type_name -> sortedchat.SortOrder
+	32,  // 17: sortedchat.GetChatListResponse.chats:type_name -> sortedchat.ChatInfo
+	18,  // 18: sortedchat.ChatInfo.generation_options:type_name -> sortedchat.GenerationOptions
+	33,  // 19: sortedchat.ListModelsResponse.models:type_name -> sortedchat.ModelListInfo
+	33,  // 20: sortedchat.CreateModelRequest.model:type_name -> sortedchat.ModelListInfo
+	33,  // 21: sortedchat.CreateModelResponse.model:type_name -> sortedchat.ModelListInfo
+	33,  // 22: sortedchat.UpdateModelRequest.model:type_name -> sortedchat.ModelListInfo
+	33,  // 23: sortedchat.UpdateModelResponse.model:type_name -> sortedchat.ModelListInfo
+	33,  // 24: sortedchat.SyncModelsFromProviderResponse.added:type_name -> sortedchat.ModelListInfo
+	3,   // 25: sortedchat.GetUsageRequest.group_by:type_name -> sortedchat.UsageGroupBy
+	45,  // 26: sortedchat.GetUsageResponse.buckets:type_name -> sortedchat.UsageBucket
+	45,  // 27: sortedchat.GetUsageResponse.total:type_name -> sortedchat.UsageBucket
+	48,  // 28: sortedchat.ChatSearchResponse.results:type_name -> sortedchat.SearchResult
+	54,  // 29: sortedchat.GetProjectsResponse.projects:type_name -> sortedchat.Project
+	18,  // 30: sortedchat.Project.generation_options:type_name -> sortedchat.GenerationOptions
+	59,  // 31: sortedchat.ListDocumentsResponse.documents:type_name -> sortedchat.Document
+	4,   // 32: sortedchat.Document.embedding_status:type_name -> sortedchat.Embedding_Status
+	32,  // 33: sortedchat.ListChatBranchResponse.branch_chat_list:type_name -> sortedchat.ChatInfo
+	32,  // 34: sortedchat.BranchNode.chat:type_name -> sortedchat.ChatInfo
+	69,  // 35: sortedchat.BranchNode.children:type_name -> sortedchat.BranchNode
+	69,  // 36: sortedchat.GetBranchTreeResponse.root:type_name -> sortedchat.BranchNode
+	29,  // 37: sortedchat.DiffBranchesResponse.common:type_name -> sortedchat.ChatMessage
+	29,  // 38: sortedchat.DiffBranchesResponse.only_a:type_name -> sortedchat.ChatMessage
+	29,  // 39: sortedchat.DiffBranchesResponse.only_b:type_name -> sortedchat.ChatMessage
+	32,  // 40: sortedchat.ListTrashResponse.chats:type_name -> sortedchat.ChatInfo
+	89,  // 41: sortedchat.GetChatSummaryResponse.summary:type_name -> sortedchat.ChatSummary
+	89,  // 42: sortedchat.RegenerateChatSummaryResponse.summary:type_name -> sortedchat.ChatSummary
+	132, // 43: sortedchat.SetSystemPromptRequest.variables:type_name -> sortedchat.SetSystemPromptRequest.VariablesEntry
+	94,  // 44: sortedchat.CreatePromptTemplateResponse.template:type_name -> sortedchat.PromptTemplate
+	94,  // 45: sortedchat.ListPromptTemplatesResponse.templates:type_name -> sortedchat.PromptTemplate
+	18,  // 46: sortedchat.SetGenerationOptionsRequest.options:type_name -> sortedchat.GenerationOptions
+	18,  // 47: sortedchat.SetGenerationOptionsResponse.options:type_name -> sortedchat.GenerationOptions
+	103, // 48: sortedchat.RegisterResponse.user:type_name -> sortedchat.User
+	103, // 49: sortedchat.LoginResponse.user:type_name -> sortedchat.User
+	103, // 50: sortedchat.GetCurrentUserResponse.user:type_name -> sortedchat.User
+	5,   // 51: sortedchat.ApiToken.scope:type_name -> sortedchat.ApiTokenScope
+	5,   // 52: sortedchat.CreateApiTokenRequest.scope:type_name -> sortedchat.ApiTokenScope
+	110, // 53: sortedchat.CreateApiTokenResponse.api_token:type_name -> sortedchat.ApiToken
+	110, // 54: sortedchat.ListApiTokensResponse.api_tokens:type_name -> sortedchat.ApiToken
+	6,   // 55: sortedchat.Workspace.role:type_name -> sortedchat.WorkspaceRole
+	6,   // 56: sortedchat.WorkspaceMember.role:type_name -> sortedchat.WorkspaceRole
+	117, // 57: sortedchat.CreateWorkspaceResponse.workspace:type_name -> sortedchat.Workspace
+	117, // 58: sortedchat.ListWorkspacesResponse.workspaces:type_name -> sortedchat.Workspace
+	118, // 59: sortedchat.ListWorkspaceMembersResponse.members:type_name -> sortedchat.WorkspaceMember
+	6,   // 60: sortedchat.AddWorkspaceMemberRequest.role:type_name -> sortedchat.WorkspaceRole
+	118, // 61: sortedchat.AddWorkspaceMemberResponse.member:type_name -> sortedchat.WorkspaceMember
+	6,   // 62: sortedchat.UpdateWorkspaceMemberRequest.role:type_name -> sortedchat.WorkspaceRole
+	17,  // 63: sortedchat.SortedChat.Chat:input_type -> sortedchat.ChatRequest
+	22,  // 64: sortedchat.SortedChat.CancelChat:input_type -> sortedchat.CancelChatRequest
+	26,  // 65: sortedchat.SortedChat.ResumeChat:input_type -> sortedchat.ResumeChatRequest
+	24,  // 66: sortedchat.SortedChat.RegenerateMessage:input_type -> sortedchat.RegenerateMessageRequest
+	25,  // 67: sortedchat.SortedChat.EditMessage:input_type -> sortedchat.EditMessageRequest
+	62,  // 68: sortedchat.SortedChat.GenerateChatName:input_type -> sortedchat.GenerateChatNameRequest
+	27,  // 69: sortedchat.SortedChat.GetHistory:input_type -> sortedchat.GetHistoryRequest
+	30,  // 70: sortedchat.SortedChat.GetChatList:input_type -> sortedchat.GetChatListRequest
+	15,  // 71: sortedchat.SortedChat.CreateChat:input_type -> sortedchat.CreateChatRequest
+	34,  // 72: sortedchat.SortedChat.ListModel:input_type -> sortedchat.ListModelsRequest
+	36,  // 73: sortedchat.SortedChat.CreateModel:input_type -> sortedchat.CreateModelRequest
+	38,  // 74: sortedchat.SortedChat.UpdateModel:input_type -> sortedchat.UpdateModelRequest
+	40,  // 75: sortedchat.SortedChat.DeleteModel:input_type -> sortedchat.DeleteModelRequest
+	42,  // 76: sortedchat.SortedChat.SyncModelsFromProvider:input_type -> sortedchat.SyncModelsFromProviderRequest
+	44,  // 77: sortedchat.SortedChat.GetUsage:input_type -> sortedchat.GetUsageRequest
+	47,  // 78: sortedchat.SortedChat.SearchChat:input_type -> sortedchat.ChatSearchRequest
+	50,  // 79: sortedchat.SortedChat.CreateProject:input_type -> sortedchat.CreateProjectRequest
+	52,  // 80: sortedchat.SortedChat.GetProjects:input_type -> sortedchat.GetProjectsRequest
+	55,  // 81: sortedchat.SortedChat.ListDocuments:input_type -> sortedchat.ListDocumentsRequest
+	57,  // 82: sortedchat.SortedChat.GetDocumentLink:input_type -> sortedchat.GetDocumentLinkRequest
+	60,  // 83: sortedchat.SortedChat.SubmitGenerateEmbeddingsJob:input_type -> sortedchat.GenerateEmbeddingRequest
+	64,  // 84: sortedchat.SortedChat.BranchAChat:input_type -> sortedchat.BranchAChatRequest
+	66,  // 85: sortedchat.SortedChat.ListChatBranch:input_type -> sortedchat.ListChatBranchRequest
+	68,  // 86: sortedchat.SortedChat.GetBranchTree:input_type -> sortedchat.GetBranchTreeRequest
+	71,  // 87: sortedchat.SortedChat.DiffBranches:input_type -> sortedchat.DiffBranchesRequest
+	73,  // 88: sortedchat.SortedChat.SummarizeBranch:input_type -> sortedchat.SummarizeBranchRequest
+	75,  // 89: sortedchat.SortedChat.RenameChat:input_type -> sortedchat.RenameChatRequest
+	77,  // 90: sortedchat.SortedChat.DeleteChat:input_type -> sortedchat.DeleteChatRequest
+	79,  // 91: sortedchat.SortedChat.RestoreChat:input_type -> sortedchat.RestoreChatRequest
+	81,  // 92: sortedchat.SortedChat.ListTrash:input_type -> sortedchat.ListTrashRequest
+	83,  // 93: sortedchat.SortedChat.ArchiveChat:input_type -> sortedchat.ArchiveChatRequest
+	85,  // 94: sortedchat.SortedChat.PinChat:input_type -> sortedchat.PinChatRequest
+	87,  // 95: sortedchat.SortedChat.MoveChat:input_type -> sortedchat.MoveChatRequest
+	90,  // 96: sortedchat.SortedChat.GetChatSummary:input_type -> sortedchat.GetChatSummaryRequest
+	92,  // 97: sortedchat.SortedChat.RegenerateChatSummary:input_type -> sortedchat.RegenerateChatSummaryRequest
+	95,  // 98: sortedchat.SortedChat.SetSystemPrompt:input_type -> sortedchat.SetSystemPromptRequest
+	97,  // 99: sortedchat.SortedChat.CreatePromptTemplate:input_type -> sortedchat.CreatePromptTemplateRequest
+	99,  // 100: sortedchat.SortedChat.ListPromptTemplates:input_type -> sortedchat.ListPromptTemplatesRequest
+	101, // 101: sortedchat.SortedChat.SetGenerationOptions:input_type -> sortedchat.SetGenerationOptionsRequest
+	119, // 102: sortedchat.SortedChat.CreateWorkspace:input_type -> sortedchat.CreateWorkspaceRequest
+	121, // 103: sortedchat.SortedChat.ListWorkspaces:input_type -> sortedchat.ListWorkspacesRequest
+	123, // 104: sortedchat.SortedChat.ListWorkspaceMembers:input_type -> sortedchat.ListWorkspaceMembersRequest
+	125, // 105: sortedchat.SortedChat.AddWorkspaceMember:input_type -> sortedchat.AddWorkspaceMemberRequest
+	127, // 106: sortedchat.SortedChat.UpdateWorkspaceMember:input_type -> sortedchat.UpdateWorkspaceMemberRequest
+	129, // 107: sortedchat.SortedChat.RemoveWorkspaceMember:input_type -> sortedchat.RemoveWorkspaceMemberRequest
+	11,  // 108: sortedchat.SettingService.GetSetting:input_type -> sortedchat.GetSettingRequest
+	13,  // 109: sortedchat.SettingService.SetSetting:input_type -> sortedchat.SetSettingRequest
+	104, // 110: sortedchat.AuthService.Register:input_type -> sortedchat.RegisterRequest
+	106, // 111: sortedchat.AuthService.Login:input_type -> sortedchat.LoginRequest
+	108, // 112: sortedchat.AuthService.GetCurrentUser:input_type -> sortedchat.GetCurrentUserRequest
+	111, // 113: sortedchat.AuthService.CreateApiToken:input_type -> sortedchat.CreateApiTokenRequest
+	113, // 114: sortedchat.AuthService.ListApiTokens:input_type -> sortedchat.ListApiTokensRequest
+	115, // 115: sortedchat.AuthService.RevokeApiToken:input_type -> sortedchat.RevokeApiTokenRequest
+	19,  // 116: sortedchat.SortedChat.Chat:output_type -> sortedchat.ChatResponse
+	23,  // 117: sortedchat.SortedChat.CancelChat:output_type -> sortedchat.CancelChatResponse
+	19,  // 118: sortedchat.SortedChat.ResumeChat:output_type -> sortedchat.ChatResponse
+	19,  // 119: sortedchat.SortedChat.RegenerateMessage:output_type -> sortedchat.ChatResponse
+	19,  // 120: sortedchat.SortedChat.EditMessage:output_type -> sortedchat.ChatResponse
+	63,  // 121: sortedchat.SortedChat.GenerateChatName:output_type -> sortedchat.GenerateChatNameResponse
+	28,  // 122: sortedchat.SortedChat.GetHistory:output_type -> sortedchat.GetHistoryResponse
+	31,  // 123: sortedchat.SortedChat.GetChatList:output_type -> sortedchat.GetChatListResponse
+	16,  // 124: sortedchat.SortedChat.CreateChat:output_type -> sortedchat.CreateChatResponse
+	35,  // 125: sortedchat.SortedChat.ListModel:output_type -> sortedchat.ListModelsResponse
+	37,  // 126: sortedchat.SortedChat.CreateModel:output_type -> sortedchat.CreateModelResponse
+	39,  // 127: sortedchat.SortedChat.UpdateModel:output_type -> sortedchat.UpdateModelResponse
+	41,  // 128: sortedchat.SortedChat.DeleteModel:output_type -> sortedchat.DeleteModelResponse
+	43,  // 129: sortedchat.SortedChat.SyncModelsFromProvider:output_type -> sortedchat.SyncModelsFromProviderResponse
+	46,  // 130: sortedchat.SortedChat.GetUsage:output_type -> sortedchat.GetUsageResponse
+	49,  // 131: sortedchat.SortedChat.SearchChat:output_type -> sortedchat.ChatSearchResponse
+	51,  // 132: sortedchat.SortedChat.CreateProject:output_type -> sortedchat.CreateProjectResponse
+	53,  // 133: sortedchat.SortedChat.GetProjects:output_type -> sortedchat.GetProjectsResponse
+	56,  // 134: sortedchat.SortedChat.ListDocuments:output_type -> sortedchat.ListDocumentsResponse
+	58,  // 135: sortedchat.SortedChat.GetDocumentLink:output_type -> sortedchat.GetDocumentLinkResponse
+	61,  // 136: sortedchat.SortedChat.SubmitGenerateEmbeddingsJob:output_type -> sortedchat.GenerateEmbeddingResponse
+	65,  // 137: sortedchat.SortedChat.BranchAChat:output_type -> sortedchat.BranchAChatResponse
+	67,  // 138: sortedchat.SortedChat.ListChatBranch:output_type -> sortedchat.ListChatBranchResponse
+	70,  // 139: sortedchat.SortedChat.GetBranchTree:output_type -> sortedchat.GetBranchTreeResponse
+	72,  // 140: sortedchat.SortedChat.DiffBranches:output_type -> sortedchat.DiffBranchesResponse
+	74,  // 141: sortedchat.SortedChat.SummarizeBranch:output_type -> sortedchat.SummarizeBranchResponse
+	76,  // 142: sortedchat.SortedChat.RenameChat:output_type -> sortedchat.RenameChatResponse
+	78,  // 143: sortedchat.SortedChat.DeleteChat:output_type -> sortedchat.DeleteChatResponse
+	80,  // 144: sortedchat.SortedChat.RestoreChat:output_type -> sortedchat.RestoreChatResponse
+	82,  // 145: sortedchat.SortedChat.ListTrash:output_type -> sortedchat.ListTrashResponse
+	84,  // 146: sortedchat.SortedChat.ArchiveChat:output_type -> sortedchat.ArchiveChatResponse
+	86,  // 147: sortedchat.SortedChat.PinChat:output_type -> sortedchat.PinChatResponse
+	88,  // 148: sortedchat.SortedChat.MoveChat:output_type -> sortedchat.MoveChatResponse
+	91,  // 149: sortedchat.SortedChat.GetChatSummary:output_type -> sortedchat.GetChatSummaryResponse
+	93,  // 150: sortedchat.SortedChat.RegenerateChatSummary:output_type -> sortedchat.RegenerateChatSummaryResponse
+	96,  // 151: sortedchat.SortedChat.SetSystemPrompt:output_type -> sortedchat.SetSystemPromptResponse
+	98,  // 152: sortedchat.SortedChat.CreatePromptTemplate:output_type -> sortedchat.CreatePromptTemplateResponse
+	100, // 153: sortedchat.SortedChat.ListPromptTemplates:output_type -> sortedchat.ListPromptTemplatesResponse
+	102, // 154: sortedchat.SortedChat.SetGenerationOptions:output_type -> sortedchat.SetGenerationOptionsResponse
+	120, // 155: sortedchat.SortedChat.CreateWorkspace:output_type -> sortedchat.CreateWorkspaceResponse
+	122, // 156: sortedchat.SortedChat.ListWorkspaces:output_type -> sortedchat.ListWorkspacesResponse
+	124, // 157: sortedchat.SortedChat.ListWorkspaceMembers:output_type -> sortedchat.ListWorkspaceMembersResponse
+	126, // 158: sortedchat.SortedChat.AddWorkspaceMember:output_type -> sortedchat.AddWorkspaceMemberResponse
+	128, // 159: sortedchat.SortedChat.UpdateWorkspaceMember:output_type -> sortedchat.UpdateWorkspaceMemberResponse
+	130, // 160: sortedchat.SortedChat.RemoveWorkspaceMember:output_type -> sortedchat.RemoveWorkspaceMemberResponse
+	12,  // 161: sortedchat.SettingService.GetSetting:output_type -> sortedchat.GetSettingResponse
+	14,  // 162: sortedchat.SettingService.SetSetting:output_type -> sortedchat.SetSettingResponse
+	105, // 163: sortedchat.AuthService.Register:output_type -> sortedchat.RegisterResponse
+	107, // 164: sortedchat.AuthService.Login:output_type -> sortedchat.LoginResponse
+	109, // 165: sortedchat.AuthService.GetCurrentUser:output_type -> sortedchat.GetCurrentUserResponse
+	112, // 166: sortedchat.AuthService.CreateApiToken:output_type -> sortedchat.CreateApiTokenResponse
+	114, // 167: sortedchat.AuthService.ListApiTokens:output_type -> sortedchat.ListApiTokensResponse
+	116, // 168: sortedchat.AuthService.RevokeApiToken:output_type -> sortedchat.RevokeApiTokenResponse
+	116, // [116:169] is the sub-list for method output_type
+	63,  // [63:116] is the sub-list for method input_type
+	63,  // [63:63] is the sub-list for extension type_name
+	63,  // [63:63] is the sub-list for extension extendee
+	0,   // [0:63] is the sub-list for field type_name
 }
 
 func init() { file_chatservice_proto_init() }
@@ -2327,19 +8277,22 @@ func file_chatservice_proto_init() {
 	if File_chatservice_proto != nil {
 		return
 	}
-	file_chatservice_proto_msgTypes[8].OneofWrappers = []any{
+	file_chatservice_proto_msgTypes[11].OneofWrappers = []any{}
+	file_chatservice_proto_msgTypes[12].OneofWrappers = []any{
 		(*ChatResponse_Text)(nil),
 		(*ChatResponse_Summary)(nil),
+		(*ChatResponse_Truncation)(nil),
 	}
+	file_chatservice_proto_msgTypes[26].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chatservice_proto_rawDesc), len(file_chatservice_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   38,
+			NumEnums:      7,
+			NumMessages:   126,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   3,
 		},
 		GoTypes:           file_chatservice_proto_goTypes,
 		DependencyIndexes: file_chatservice_proto_depIdxs,
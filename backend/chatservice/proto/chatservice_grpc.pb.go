@@ -20,18 +20,50 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	SortedChat_Chat_FullMethodName                        = "/sortedchat.SortedChat/Chat"
+	SortedChat_CancelChat_FullMethodName                  = "/sortedchat.SortedChat/CancelChat"
+	SortedChat_ResumeChat_FullMethodName                  = "/sortedchat.SortedChat/ResumeChat"
+	SortedChat_RegenerateMessage_FullMethodName           = "/sortedchat.SortedChat/RegenerateMessage"
+	SortedChat_EditMessage_FullMethodName                 = "/sortedchat.SortedChat/EditMessage"
 	SortedChat_GenerateChatName_FullMethodName            = "/sortedchat.SortedChat/GenerateChatName"
 	SortedChat_GetHistory_FullMethodName                  = "/sortedchat.SortedChat/GetHistory"
 	SortedChat_GetChatList_FullMethodName                 = "/sortedchat.SortedChat/GetChatList"
 	SortedChat_CreateChat_FullMethodName                  = "/sortedchat.SortedChat/CreateChat"
 	SortedChat_ListModel_FullMethodName                   = "/sortedchat.SortedChat/ListModel"
+	SortedChat_CreateModel_FullMethodName                 = "/sortedchat.SortedChat/CreateModel"
+	SortedChat_UpdateModel_FullMethodName                 = "/sortedchat.SortedChat/UpdateModel"
+	SortedChat_DeleteModel_FullMethodName                 = "/sortedchat.SortedChat/DeleteModel"
+	SortedChat_SyncModelsFromProvider_FullMethodName      = "/sortedchat.SortedChat/SyncModelsFromProvider"
+	SortedChat_GetUsage_FullMethodName                    = "/sortedchat.SortedChat/GetUsage"
 	SortedChat_SearchChat_FullMethodName                  = "/sortedchat.SortedChat/SearchChat"
 	SortedChat_CreateProject_FullMethodName               = "/sortedchat.SortedChat/CreateProject"
 	SortedChat_GetProjects_FullMethodName                 = "/sortedchat.SortedChat/GetProjects"
 	SortedChat_ListDocuments_FullMethodName               = "/sortedchat.SortedChat/ListDocuments"
+	SortedChat_GetDocumentLink_FullMethodName             = "/sortedchat.SortedChat/GetDocumentLink"
 	SortedChat_SubmitGenerateEmbeddingsJob_FullMethodName = "/sortedchat.SortedChat/SubmitGenerateEmbeddingsJob"
 	SortedChat_BranchAChat_FullMethodName                 = "/sortedchat.SortedChat/BranchAChat"
 	SortedChat_ListChatBranch_FullMethodName              = "/sortedchat.SortedChat/ListChatBranch"
+	SortedChat_GetBranchTree_FullMethodName               = "/sortedchat.SortedChat/GetBranchTree"
+	SortedChat_DiffBranches_FullMethodName                = "/sortedchat.SortedChat/DiffBranches"
+	SortedChat_SummarizeBranch_FullMethodName             = "/sortedchat.SortedChat/SummarizeBranch"
+	SortedChat_RenameChat_FullMethodName                  = "/sortedchat.SortedChat/RenameChat"
+	SortedChat_DeleteChat_FullMethodName                  = "/sortedchat.SortedChat/DeleteChat"
+	SortedChat_RestoreChat_FullMethodName                 = "/sortedchat.SortedChat/RestoreChat"
+	SortedChat_ListTrash_FullMethodName                   = "/sortedchat.SortedChat/ListTrash"
+	SortedChat_ArchiveChat_FullMethodName                 = "/sortedchat.SortedChat/ArchiveChat"
+	SortedChat_PinChat_FullMethodName                     = "/sortedchat.SortedChat/PinChat"
+	SortedChat_MoveChat_FullMethodName                    = "/sortedchat.SortedChat/MoveChat"
+	SortedChat_GetChatSummary_FullMethodName              = "/sortedchat.SortedChat/GetChatSummary"
+	SortedChat_RegenerateChatSummary_FullMethodName       = "/sortedchat.SortedChat/RegenerateChatSummary"
+	SortedChat_SetSystemPrompt_FullMethodName             = "/sortedchat.SortedChat/SetSystemPrompt"
+	SortedChat_CreatePromptTemplate_FullMethodName        = "/sortedchat.SortedChat/CreatePromptTemplate"
+	SortedChat_ListPromptTemplates_FullMethodName         = "/sortedchat.SortedChat/ListPromptTemplates"
+	SortedChat_SetGenerationOptions_FullMethodName        = "/sortedchat.SortedChat/SetGenerationOptions"
+	SortedChat_CreateWorkspace_FullMethodName             = "/sortedchat.SortedChat/CreateWorkspace"
+	SortedChat_ListWorkspaces_FullMethodName              = "/sortedchat.SortedChat/ListWorkspaces"
+	SortedChat_ListWorkspaceMembers_FullMethodName        = "/sortedchat.SortedChat/ListWorkspaceMembers"
+	SortedChat_AddWorkspaceMember_FullMethodName          = "/sortedchat.SortedChat/AddWorkspaceMember"
+	SortedChat_UpdateWorkspaceMember_FullMethodName       = "/sortedchat.SortedChat/UpdateWorkspaceMember"
+	SortedChat_RemoveWorkspaceMember_FullMethodName       = "/sortedchat.SortedChat/RemoveWorkspaceMember"
 )
 
 // SortedChatClient is the client API for SortedChat service.
@@ -39,18 +71,57 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type SortedChatClient interface {
 	Chat(ctx context.Context, in *ChatRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChatResponse], error)
+	// CancelChat stops the reply being generated for a chat, the partial reply is kept as interrupted
+	CancelChat(ctx context.Context, in *CancelChatRequest, opts ...grpc.CallOption) (*CancelChatResponse, error)
+	// ResumeChat replays the reply being generated for a chat and then streams the rest of it live
+	ResumeChat(ctx context.Context, in *ResumeChatRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChatResponse], error)
+	// RegenerateMessage and EditMessage keep the replaced message, the new version becomes the active alternative
+	RegenerateMessage(ctx context.Context, in *RegenerateMessageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChatResponse], error)
+	EditMessage(ctx context.Context, in *EditMessageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChatResponse], error)
 	GenerateChatName(ctx context.Context, in *GenerateChatNameRequest, opts ...grpc.CallOption) (*GenerateChatNameResponse, error)
 	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
 	GetChatList(ctx context.Context, in *GetChatListRequest, opts ...grpc.CallOption) (*GetChatListResponse, error)
 	CreateChat(ctx context.Context, in *CreateChatRequest, opts ...grpc.CallOption) (*CreateChatResponse, error)
 	ListModel(ctx context.Context, in *ListModelsRequest, opts ...grpc.CallOption) (*ListModelsResponse, error)
+	CreateModel(ctx context.Context, in *CreateModelRequest, opts ...grpc.CallOption) (*CreateModelResponse, error)
+	UpdateModel(ctx context.Context, in *UpdateModelRequest, opts ...grpc.CallOption) (*UpdateModelResponse, error)
+	DeleteModel(ctx context.Context, in *DeleteModelRequest, opts ...grpc.CallOption) (*DeleteModelResponse, error)
+	SyncModelsFromProvider(ctx context.Context, in *SyncModelsFromProviderRequest, opts ...grpc.CallOption) (*SyncModelsFromProviderResponse, error)
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error)
 	SearchChat(ctx context.Context, in *ChatSearchRequest, opts ...grpc.CallOption) (*ChatSearchResponse, error)
 	CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*CreateProjectResponse, error)
 	GetProjects(ctx context.Context, in *GetProjectsRequest, opts ...grpc.CallOption) (*GetProjectsResponse, error)
 	ListDocuments(ctx context.Context, in *ListDocumentsRequest, opts ...grpc.CallOption) (*ListDocumentsResponse, error)
+	// GetDocumentLink returns a link that downloads the document without an authorization header for a few minutes
+	GetDocumentLink(ctx context.Context, in *GetDocumentLinkRequest, opts ...grpc.CallOption) (*GetDocumentLinkResponse, error)
 	SubmitGenerateEmbeddingsJob(ctx context.Context, in *GenerateEmbeddingRequest, opts ...grpc.CallOption) (*GenerateEmbeddingResponse, error)
 	BranchAChat(ctx context.Context, in *BranchAChatRequest, opts ...grpc.CallOption) (*BranchAChatResponse, error)
 	ListChatBranch(ctx context.Context, in *ListChatBranchRequest, opts ...grpc.CallOption) (*ListChatBranchResponse, error)
+	GetBranchTree(ctx context.Context, in *GetBranchTreeRequest, opts ...grpc.CallOption) (*GetBranchTreeResponse, error)
+	DiffBranches(ctx context.Context, in *DiffBranchesRequest, opts ...grpc.CallOption) (*DiffBranchesResponse, error)
+	SummarizeBranch(ctx context.Context, in *SummarizeBranchRequest, opts ...grpc.CallOption) (*SummarizeBranchResponse, error)
+	// Chat lifecycle, deleted chats go to the trash unless deleted permanently
+	RenameChat(ctx context.Context, in *RenameChatRequest, opts ...grpc.CallOption) (*RenameChatResponse, error)
+	DeleteChat(ctx context.Context, in *DeleteChatRequest, opts ...grpc.CallOption) (*DeleteChatResponse, error)
+	RestoreChat(ctx context.Context, in *RestoreChatRequest, opts ...grpc.CallOption) (*RestoreChatResponse, error)
+	ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error)
+	ArchiveChat(ctx context.Context, in *ArchiveChatRequest, opts ...grpc.CallOption) (*ArchiveChatResponse, error)
+	PinChat(ctx context.Context, in *PinChatRequest, opts ...grpc.CallOption) (*PinChatResponse, error)
+	MoveChat(ctx context.Context, in *MoveChatRequest, opts ...grpc.CallOption) (*MoveChatResponse, error)
+	GetChatSummary(ctx context.Context, in *GetChatSummaryRequest, opts ...grpc.CallOption) (*GetChatSummaryResponse, error)
+	RegenerateChatSummary(ctx context.Context, in *RegenerateChatSummaryRequest, opts ...grpc.CallOption) (*RegenerateChatSummaryResponse, error)
+	// System prompts, a chat's own system prompt takes the place of its project's
+	SetSystemPrompt(ctx context.Context, in *SetSystemPromptRequest, opts ...grpc.CallOption) (*SetSystemPromptResponse, error)
+	CreatePromptTemplate(ctx context.Context, in *CreatePromptTemplateRequest, opts ...grpc.CallOption) (*CreatePromptTemplateResponse, error)
+	ListPromptTemplates(ctx context.Context, in *ListPromptTemplatesRequest, opts ...grpc.CallOption) (*ListPromptTemplatesResponse, error)
+	// SetGenerationOptions sets the defaults of a chat or project, options of a request take their place
+	SetGenerationOptions(ctx context.Context, in *SetGenerationOptionsRequest, opts ...grpc.CallOption) (*SetGenerationOptionsResponse, error)
+	CreateWorkspace(ctx context.Context, in *CreateWorkspaceRequest, opts ...grpc.CallOption) (*CreateWorkspaceResponse, error)
+	ListWorkspaces(ctx context.Context, in *ListWorkspacesRequest, opts ...grpc.CallOption) (*ListWorkspacesResponse, error)
+	ListWorkspaceMembers(ctx context.Context, in *ListWorkspaceMembersRequest, opts ...grpc.CallOption) (*ListWorkspaceMembersResponse, error)
+	AddWorkspaceMember(ctx context.Context, in *AddWorkspaceMemberRequest, opts ...grpc.CallOption) (*AddWorkspaceMemberResponse, error)
+	UpdateWorkspaceMember(ctx context.Context, in *UpdateWorkspaceMemberRequest, opts ...grpc.CallOption) (*UpdateWorkspaceMemberResponse, error)
+	RemoveWorkspaceMember(ctx context.Context, in *RemoveWorkspaceMemberRequest, opts ...grpc.CallOption) (*RemoveWorkspaceMemberResponse, error)
 }
 
 type sortedChatClient struct {
@@ -80,6 +151,73 @@ func (c *sortedChatClient) Chat(ctx context.Context, in *ChatRequest, opts ...gr
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type SortedChat_ChatClient = grpc.ServerStreamingClient[ChatResponse]
 
+func (c *sortedChatClient) CancelChat(ctx context.Context, in *CancelChatRequest, opts ...grpc.CallOption) (*CancelChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelChatResponse)
+	err := c.cc.Invoke(ctx, SortedChat_CancelChat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) ResumeChat(ctx context.Context, in *ResumeChatRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChatResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SortedChat_ServiceDesc.Streams[1], SortedChat_ResumeChat_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ResumeChatRequest, ChatResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SortedChat_ResumeChatClient = grpc.ServerStreamingClient[ChatResponse]
+
+func (c *sortedChatClient) RegenerateMessage(ctx context.Context, in *RegenerateMessageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChatResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SortedChat_ServiceDesc.Streams[2], SortedChat_RegenerateMessage_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RegenerateMessageRequest, ChatResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SortedChat_RegenerateMessageClient = grpc.ServerStreamingClient[ChatResponse]
+
+func (c *sortedChatClient) EditMessage(ctx context.Context, in *EditMessageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChatResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SortedChat_ServiceDesc.Streams[3], SortedChat_EditMessage_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[EditMessageRequest, ChatResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SortedChat_EditMessageClient = grpc.ServerStreamingClient[ChatResponse]
+
 func (c *sortedChatClient) GenerateChatName(ctx context.Context, in *GenerateChatNameRequest, opts ...grpc.CallOption) (*GenerateChatNameResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GenerateChatNameResponse)
@@ -130,6 +268,56 @@ func (c *sortedChatClient) ListModel(ctx context.Context, in *ListModelsRequest,
 	return out, nil
 }
 
+func (c *sortedChatClient) CreateModel(ctx context.Context, in *CreateModelRequest, opts ...grpc.CallOption) (*CreateModelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateModelResponse)
+	err := c.cc.Invoke(ctx, SortedChat_CreateModel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) UpdateModel(ctx context.Context, in *UpdateModelRequest, opts ...grpc.CallOption) (*UpdateModelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateModelResponse)
+	err := c.cc.Invoke(ctx, SortedChat_UpdateModel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) DeleteModel(ctx context.Context, in *DeleteModelRequest, opts ...grpc.CallOption) (*DeleteModelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteModelResponse)
+	err := c.cc.Invoke(ctx, SortedChat_DeleteModel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) SyncModelsFromProvider(ctx context.Context, in *SyncModelsFromProviderRequest, opts ...grpc.CallOption) (*SyncModelsFromProviderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncModelsFromProviderResponse)
+	err := c.cc.Invoke(ctx, SortedChat_SyncModelsFromProvider_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUsageResponse)
+	err := c.cc.Invoke(ctx, SortedChat_GetUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *sortedChatClient) SearchChat(ctx context.Context, in *ChatSearchRequest, opts ...grpc.CallOption) (*ChatSearchResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ChatSearchResponse)
@@ -170,6 +358,16 @@ func (c *sortedChatClient) ListDocuments(ctx context.Context, in *ListDocumentsR
 	return out, nil
 }
 
+func (c *sortedChatClient) GetDocumentLink(ctx context.Context, in *GetDocumentLinkRequest, opts ...grpc.CallOption) (*GetDocumentLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDocumentLinkResponse)
+	err := c.cc.Invoke(ctx, SortedChat_GetDocumentLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *sortedChatClient) SubmitGenerateEmbeddingsJob(ctx context.Context, in *GenerateEmbeddingRequest, opts ...grpc.CallOption) (*GenerateEmbeddingResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GenerateEmbeddingResponse)
@@ -200,23 +398,282 @@ func (c *sortedChatClient) ListChatBranch(ctx context.Context, in *ListChatBranc
 	return out, nil
 }
 
+func (c *sortedChatClient) GetBranchTree(ctx context.Context, in *GetBranchTreeRequest, opts ...grpc.CallOption) (*GetBranchTreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBranchTreeResponse)
+	err := c.cc.Invoke(ctx, SortedChat_GetBranchTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) DiffBranches(ctx context.Context, in *DiffBranchesRequest, opts ...grpc.CallOption) (*DiffBranchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffBranchesResponse)
+	err := c.cc.Invoke(ctx, SortedChat_DiffBranches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) SummarizeBranch(ctx context.Context, in *SummarizeBranchRequest, opts ...grpc.CallOption) (*SummarizeBranchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SummarizeBranchResponse)
+	err := c.cc.Invoke(ctx, SortedChat_SummarizeBranch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) RenameChat(ctx context.Context, in *RenameChatRequest, opts ...grpc.CallOption) (*RenameChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenameChatResponse)
+	err := c.cc.Invoke(ctx, SortedChat_RenameChat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) DeleteChat(ctx context.Context, in *DeleteChatRequest, opts ...grpc.CallOption) (*DeleteChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteChatResponse)
+	err := c.cc.Invoke(ctx, SortedChat_DeleteChat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) RestoreChat(ctx context.Context, in *RestoreChatRequest, opts ...grpc.CallOption) (*RestoreChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreChatResponse)
+	err := c.cc.Invoke(ctx, SortedChat_RestoreChat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrashResponse)
+	err := c.cc.Invoke(ctx, SortedChat_ListTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) ArchiveChat(ctx context.Context, in *ArchiveChatRequest, opts ...grpc.CallOption) (*ArchiveChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ArchiveChatResponse)
+	err := c.cc.Invoke(ctx, SortedChat_ArchiveChat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) PinChat(ctx context.Context, in *PinChatRequest, opts ...grpc.CallOption) (*PinChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PinChatResponse)
+	err := c.cc.Invoke(ctx, SortedChat_PinChat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) MoveChat(ctx context.Context, in *MoveChatRequest, opts ...grpc.CallOption) (*MoveChatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveChatResponse)
+	err := c.cc.Invoke(ctx, SortedChat_MoveChat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) GetChatSummary(ctx context.Context, in *GetChatSummaryRequest, opts ...grpc.CallOption) (*GetChatSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetChatSummaryResponse)
+	err := c.cc.Invoke(ctx, SortedChat_GetChatSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) RegenerateChatSummary(ctx context.Context, in *RegenerateChatSummaryRequest, opts ...grpc.CallOption) (*RegenerateChatSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegenerateChatSummaryResponse)
+	err := c.cc.Invoke(ctx, SortedChat_RegenerateChatSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) SetSystemPrompt(ctx context.Context, in *SetSystemPromptRequest, opts ...grpc.CallOption) (*SetSystemPromptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetSystemPromptResponse)
+	err := c.cc.Invoke(ctx, SortedChat_SetSystemPrompt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) CreatePromptTemplate(ctx context.Context, in *CreatePromptTemplateRequest, opts ...grpc.CallOption) (*CreatePromptTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePromptTemplateResponse)
+	err := c.cc.Invoke(ctx, SortedChat_CreatePromptTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) ListPromptTemplates(ctx context.Context, in *ListPromptTemplatesRequest, opts ...grpc.CallOption) (*ListPromptTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPromptTemplatesResponse)
+	err := c.cc.Invoke(ctx, SortedChat_ListPromptTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) SetGenerationOptions(ctx context.Context, in *SetGenerationOptionsRequest, opts ...grpc.CallOption) (*SetGenerationOptionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetGenerationOptionsResponse)
+	err := c.cc.Invoke(ctx, SortedChat_SetGenerationOptions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) CreateWorkspace(ctx context.Context, in *CreateWorkspaceRequest, opts ...grpc.CallOption) (*CreateWorkspaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateWorkspaceResponse)
+	err := c.cc.Invoke(ctx, SortedChat_CreateWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) ListWorkspaces(ctx context.Context, in *ListWorkspacesRequest, opts ...grpc.CallOption) (*ListWorkspacesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWorkspacesResponse)
+	err := c.cc.Invoke(ctx, SortedChat_ListWorkspaces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) ListWorkspaceMembers(ctx context.Context, in *ListWorkspaceMembersRequest, opts ...grpc.CallOption) (*ListWorkspaceMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWorkspaceMembersResponse)
+	err := c.cc.Invoke(ctx, SortedChat_ListWorkspaceMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) AddWorkspaceMember(ctx context.Context, in *AddWorkspaceMemberRequest, opts ...grpc.CallOption) (*AddWorkspaceMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddWorkspaceMemberResponse)
+	err := c.cc.Invoke(ctx, SortedChat_AddWorkspaceMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) UpdateWorkspaceMember(ctx context.Context, in *UpdateWorkspaceMemberRequest, opts ...grpc.CallOption) (*UpdateWorkspaceMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateWorkspaceMemberResponse)
+	err := c.cc.Invoke(ctx, SortedChat_UpdateWorkspaceMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sortedChatClient) RemoveWorkspaceMember(ctx context.Context, in *RemoveWorkspaceMemberRequest, opts ...grpc.CallOption) (*RemoveWorkspaceMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveWorkspaceMemberResponse)
+	err := c.cc.Invoke(ctx, SortedChat_RemoveWorkspaceMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SortedChatServer is the server API for SortedChat service.
 // All implementations must embed UnimplementedSortedChatServer
 // for forward compatibility.
 type SortedChatServer interface {
 	Chat(*ChatRequest, grpc.ServerStreamingServer[ChatResponse]) error
+	// CancelChat stops the reply being generated for a chat, the partial reply is kept as interrupted
+	CancelChat(context.Context, *CancelChatRequest) (*CancelChatResponse, error)
+	// ResumeChat replays the reply being generated for a chat and then streams the rest of it live
+	ResumeChat(*ResumeChatRequest, grpc.ServerStreamingServer[ChatResponse]) error
+	// RegenerateMessage and EditMessage keep the replaced message, the new version becomes the active alternative
+	RegenerateMessage(*RegenerateMessageRequest, grpc.ServerStreamingServer[ChatResponse]) error
+	EditMessage(*EditMessageRequest, grpc.ServerStreamingServer[ChatResponse]) error
 	GenerateChatName(context.Context, *GenerateChatNameRequest) (*GenerateChatNameResponse, error)
 	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
 	GetChatList(context.Context, *GetChatListRequest) (*GetChatListResponse, error)
 	CreateChat(context.Context, *CreateChatRequest) (*CreateChatResponse, error)
 	ListModel(context.Context, *ListModelsRequest) (*ListModelsResponse, error)
+	CreateModel(context.Context, *CreateModelRequest) (*CreateModelResponse, error)
+	UpdateModel(context.Context, *UpdateModelRequest) (*UpdateModelResponse, error)
+	DeleteModel(context.Context, *DeleteModelRequest) (*DeleteModelResponse, error)
+	SyncModelsFromProvider(context.Context, *SyncModelsFromProviderRequest) (*SyncModelsFromProviderResponse, error)
+	GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error)
 	SearchChat(context.Context, *ChatSearchRequest) (*ChatSearchResponse, error)
 	CreateProject(context.Context, *CreateProjectRequest) (*CreateProjectResponse, error)
 	GetProjects(context.Context, *GetProjectsRequest) (*GetProjectsResponse, error)
 	ListDocuments(context.Context, *ListDocumentsRequest) (*ListDocumentsResponse, error)
+	// GetDocumentLink returns a link that downloads the document without an authorization header for a few minutes
+	GetDocumentLink(context.Context, *GetDocumentLinkRequest) (*GetDocumentLinkResponse, error)
 	SubmitGenerateEmbeddingsJob(context.Context, *GenerateEmbeddingRequest) (*GenerateEmbeddingResponse, error)
 	BranchAChat(context.Context, *BranchAChatRequest) (*BranchAChatResponse, error)
 	ListChatBranch(context.Context, *ListChatBranchRequest) (*ListChatBranchResponse, error)
+	GetBranchTree(context.Context, *GetBranchTreeRequest) (*GetBranchTreeResponse, error)
+	DiffBranches(context.Context, *DiffBranchesRequest) (*DiffBranchesResponse, error)
+	SummarizeBranch(context.Context, *SummarizeBranchRequest) (*SummarizeBranchResponse, error)
+	// Chat lifecycle, deleted chats go to the trash unless deleted permanently
+	RenameChat(context.Context, *RenameChatRequest) (*RenameChatResponse, error)
+	DeleteChat(context.Context, *DeleteChatRequest) (*DeleteChatResponse, error)
+	RestoreChat(context.Context, *RestoreChatRequest) (*RestoreChatResponse, error)
+	ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error)
+	ArchiveChat(context.Context, *ArchiveChatRequest) (*ArchiveChatResponse, error)
+	PinChat(context.Context, *PinChatRequest) (*PinChatResponse, error)
+	MoveChat(context.Context, *MoveChatRequest) (*MoveChatResponse, error)
+	GetChatSummary(context.Context, *GetChatSummaryRequest) (*GetChatSummaryResponse, error)
+	RegenerateChatSummary(context.Context, *RegenerateChatSummaryRequest) (*RegenerateChatSummaryResponse, error)
+	// System prompts, a chat's own system prompt takes the place of its project's
+	SetSystemPrompt(context.Context, *SetSystemPromptRequest) (*SetSystemPromptResponse, error)
+	CreatePromptTemplate(context.Context, *CreatePromptTemplateRequest) (*CreatePromptTemplateResponse, error)
+	ListPromptTemplates(context.Context, *ListPromptTemplatesRequest) (*ListPromptTemplatesResponse, error)
+	// SetGenerationOptions sets the defaults of a chat or project, options of a request take their place
+	SetGenerationOptions(context.Context, *SetGenerationOptionsRequest) (*SetGenerationOptionsResponse, error)
+	CreateWorkspace(context.Context, *CreateWorkspaceRequest) (*CreateWorkspaceResponse, error)
+	ListWorkspaces(context.Context, *ListWorkspacesRequest) (*ListWorkspacesResponse, error)
+	ListWorkspaceMembers(context.Context, *ListWorkspaceMembersRequest) (*ListWorkspaceMembersResponse, error)
+	AddWorkspaceMember(context.Context, *AddWorkspaceMemberRequest) (*AddWorkspaceMemberResponse, error)
+	UpdateWorkspaceMember(context.Context, *UpdateWorkspaceMemberRequest) (*UpdateWorkspaceMemberResponse, error)
+	RemoveWorkspaceMember(context.Context, *RemoveWorkspaceMemberRequest) (*RemoveWorkspaceMemberResponse, error)
 	mustEmbedUnimplementedSortedChatServer()
 }
 
@@ -230,6 +687,18 @@ type UnimplementedSortedChatServer struct{}
 func (UnimplementedSortedChatServer) Chat(*ChatRequest, grpc.ServerStreamingServer[ChatResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method Chat not implemented")
 }
+func (UnimplementedSortedChatServer) CancelChat(context.Context, *CancelChatRequest) (*CancelChatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelChat not implemented")
+}
+func (UnimplementedSortedChatServer) ResumeChat(*ResumeChatRequest, grpc.ServerStreamingServer[ChatResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ResumeChat not implemented")
+}
+func (UnimplementedSortedChatServer) RegenerateMessage(*RegenerateMessageRequest, grpc.ServerStreamingServer[ChatResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method RegenerateMessage not implemented")
+}
+func (UnimplementedSortedChatServer) EditMessage(*EditMessageRequest, grpc.ServerStreamingServer[ChatResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method EditMessage not implemented")
+}
 func (UnimplementedSortedChatServer) GenerateChatName(context.Context, *GenerateChatNameRequest) (*GenerateChatNameResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateChatName not implemented")
 }
@@ -245,11 +714,26 @@ func (UnimplementedSortedChatServer) CreateChat(context.Context, *CreateChatRequ
 func (UnimplementedSortedChatServer) ListModel(context.Context, *ListModelsRequest) (*ListModelsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListModel not implemented")
 }
-func (UnimplementedSortedChatServer) SearchChat(context.Context, *ChatSearchRequest) (*ChatSearchResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SearchChat not implemented")
+func (UnimplementedSortedChatServer) CreateModel(context.Context, *CreateModelRequest) (*CreateModelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateModel not implemented")
 }
-func (UnimplementedSortedChatServer) CreateProject(context.Context, *CreateProjectRequest) (*CreateProjectResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateProject not implemented")
+func (UnimplementedSortedChatServer) UpdateModel(context.Context, *UpdateModelRequest) (*UpdateModelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateModel not implemented")
+}
+func (UnimplementedSortedChatServer) DeleteModel(context.Context, *DeleteModelRequest) (*DeleteModelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteModel not implemented")
+}
+func (UnimplementedSortedChatServer) SyncModelsFromProvider(context.Context, *SyncModelsFromProviderRequest) (*SyncModelsFromProviderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncModelsFromProvider not implemented")
+}
+func (UnimplementedSortedChatServer) GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (UnimplementedSortedChatServer) SearchChat(context.Context, *ChatSearchRequest) (*ChatSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchChat not implemented")
+}
+func (UnimplementedSortedChatServer) CreateProject(context.Context, *CreateProjectRequest) (*CreateProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProject not implemented")
 }
 func (UnimplementedSortedChatServer) GetProjects(context.Context, *GetProjectsRequest) (*GetProjectsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProjects not implemented")
@@ -257,6 +741,9 @@ func (UnimplementedSortedChatServer) GetProjects(context.Context, *GetProjectsRe
 func (UnimplementedSortedChatServer) ListDocuments(context.Context, *ListDocumentsRequest) (*ListDocumentsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListDocuments not implemented")
 }
+func (UnimplementedSortedChatServer) GetDocumentLink(context.Context, *GetDocumentLinkRequest) (*GetDocumentLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDocumentLink not implemented")
+}
 func (UnimplementedSortedChatServer) SubmitGenerateEmbeddingsJob(context.Context, *GenerateEmbeddingRequest) (*GenerateEmbeddingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SubmitGenerateEmbeddingsJob not implemented")
 }
@@ -266,6 +753,72 @@ func (UnimplementedSortedChatServer) BranchAChat(context.Context, *BranchAChatRe
 func (UnimplementedSortedChatServer) ListChatBranch(context.Context, *ListChatBranchRequest) (*ListChatBranchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListChatBranch not implemented")
 }
+func (UnimplementedSortedChatServer) GetBranchTree(context.Context, *GetBranchTreeRequest) (*GetBranchTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBranchTree not implemented")
+}
+func (UnimplementedSortedChatServer) DiffBranches(context.Context, *DiffBranchesRequest) (*DiffBranchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffBranches not implemented")
+}
+func (UnimplementedSortedChatServer) SummarizeBranch(context.Context, *SummarizeBranchRequest) (*SummarizeBranchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SummarizeBranch not implemented")
+}
+func (UnimplementedSortedChatServer) RenameChat(context.Context, *RenameChatRequest) (*RenameChatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameChat not implemented")
+}
+func (UnimplementedSortedChatServer) DeleteChat(context.Context, *DeleteChatRequest) (*DeleteChatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteChat not implemented")
+}
+func (UnimplementedSortedChatServer) RestoreChat(context.Context, *RestoreChatRequest) (*RestoreChatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreChat not implemented")
+}
+func (UnimplementedSortedChatServer) ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedSortedChatServer) ArchiveChat(context.Context, *ArchiveChatRequest) (*ArchiveChatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveChat not implemented")
+}
+func (UnimplementedSortedChatServer) PinChat(context.Context, *PinChatRequest) (*PinChatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinChat not implemented")
+}
+func (UnimplementedSortedChatServer) MoveChat(context.Context, *MoveChatRequest) (*MoveChatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveChat not implemented")
+}
+func (UnimplementedSortedChatServer) GetChatSummary(context.Context, *GetChatSummaryRequest) (*GetChatSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChatSummary not implemented")
+}
+func (UnimplementedSortedChatServer) RegenerateChatSummary(context.Context, *RegenerateChatSummaryRequest) (*RegenerateChatSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegenerateChatSummary not implemented")
+}
+func (UnimplementedSortedChatServer) SetSystemPrompt(context.Context, *SetSystemPromptRequest) (*SetSystemPromptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSystemPrompt not implemented")
+}
+func (UnimplementedSortedChatServer) CreatePromptTemplate(context.Context, *CreatePromptTemplateRequest) (*CreatePromptTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePromptTemplate not implemented")
+}
+func (UnimplementedSortedChatServer) ListPromptTemplates(context.Context, *ListPromptTemplatesRequest) (*ListPromptTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPromptTemplates not implemented")
+}
+func (UnimplementedSortedChatServer) SetGenerationOptions(context.Context, *SetGenerationOptionsRequest) (*SetGenerationOptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetGenerationOptions not implemented")
+}
+func (UnimplementedSortedChatServer) CreateWorkspace(context.Context, *CreateWorkspaceRequest) (*CreateWorkspaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWorkspace not implemented")
+}
+func (UnimplementedSortedChatServer) ListWorkspaces(context.Context, *ListWorkspacesRequest) (*ListWorkspacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkspaces not implemented")
+}
+func (UnimplementedSortedChatServer) ListWorkspaceMembers(context.Context, *ListWorkspaceMembersRequest) (*ListWorkspaceMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkspaceMembers not implemented")
+}
+func (UnimplementedSortedChatServer) AddWorkspaceMember(context.Context, *AddWorkspaceMemberRequest) (*AddWorkspaceMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWorkspaceMember not implemented")
+}
+func (UnimplementedSortedChatServer) UpdateWorkspaceMember(context.Context, *UpdateWorkspaceMemberRequest) (*UpdateWorkspaceMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWorkspaceMember not implemented")
+}
+func (UnimplementedSortedChatServer) RemoveWorkspaceMember(context.Context, *RemoveWorkspaceMemberRequest) (*RemoveWorkspaceMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWorkspaceMember not implemented")
+}
 func (UnimplementedSortedChatServer) mustEmbedUnimplementedSortedChatServer() {}
 func (UnimplementedSortedChatServer) testEmbeddedByValue()                    {}
 
@@ -298,6 +851,57 @@ func _SortedChat_Chat_Handler(srv interface{}, stream grpc.ServerStream) error {
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type SortedChat_ChatServer = grpc.ServerStreamingServer[ChatResponse]
 
+func _SortedChat_CancelChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).CancelChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_CancelChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).CancelChat(ctx, req.(*CancelChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_ResumeChat_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ResumeChatRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SortedChatServer).ResumeChat(m, &grpc.GenericServerStream[ResumeChatRequest, ChatResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SortedChat_ResumeChatServer = grpc.ServerStreamingServer[ChatResponse]
+
+func _SortedChat_RegenerateMessage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RegenerateMessageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SortedChatServer).RegenerateMessage(m, &grpc.GenericServerStream[RegenerateMessageRequest, ChatResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SortedChat_RegenerateMessageServer = grpc.ServerStreamingServer[ChatResponse]
+
+func _SortedChat_EditMessage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EditMessageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SortedChatServer).EditMessage(m, &grpc.GenericServerStream[EditMessageRequest, ChatResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SortedChat_EditMessageServer = grpc.ServerStreamingServer[ChatResponse]
+
 func _SortedChat_GenerateChatName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GenerateChatNameRequest)
 	if err := dec(in); err != nil {
@@ -388,6 +992,96 @@ func _SortedChat_ListModel_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SortedChat_CreateModel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateModelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).CreateModel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_CreateModel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).CreateModel(ctx, req.(*CreateModelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_UpdateModel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateModelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).UpdateModel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_UpdateModel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).UpdateModel(ctx, req.(*UpdateModelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_DeleteModel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteModelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).DeleteModel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_DeleteModel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).DeleteModel(ctx, req.(*DeleteModelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_SyncModelsFromProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncModelsFromProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).SyncModelsFromProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_SyncModelsFromProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).SyncModelsFromProvider(ctx, req.(*SyncModelsFromProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_GetUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).GetUsage(ctx, req.(*GetUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SortedChat_SearchChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ChatSearchRequest)
 	if err := dec(in); err != nil {
@@ -460,6 +1154,24 @@ func _SortedChat_ListDocuments_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SortedChat_GetDocumentLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDocumentLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).GetDocumentLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_GetDocumentLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).GetDocumentLink(ctx, req.(*GetDocumentLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SortedChat_SubmitGenerateEmbeddingsJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GenerateEmbeddingRequest)
 	if err := dec(in); err != nil {
@@ -473,43 +1185,439 @@ func _SortedChat_SubmitGenerateEmbeddingsJob_Handler(srv interface{}, ctx contex
 		FullMethod: SortedChat_SubmitGenerateEmbeddingsJob_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SortedChatServer).SubmitGenerateEmbeddingsJob(ctx, req.(*GenerateEmbeddingRequest))
+		return srv.(SortedChatServer).SubmitGenerateEmbeddingsJob(ctx, req.(*GenerateEmbeddingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_BranchAChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BranchAChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).BranchAChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_BranchAChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).BranchAChat(ctx, req.(*BranchAChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_ListChatBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChatBranchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).ListChatBranch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_ListChatBranch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).ListChatBranch(ctx, req.(*ListChatBranchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_GetBranchTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBranchTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).GetBranchTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_GetBranchTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).GetBranchTree(ctx, req.(*GetBranchTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_DiffBranches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffBranchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).DiffBranches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_DiffBranches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).DiffBranches(ctx, req.(*DiffBranchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_SummarizeBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SummarizeBranchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).SummarizeBranch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_SummarizeBranch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).SummarizeBranch(ctx, req.(*SummarizeBranchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_RenameChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).RenameChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_RenameChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).RenameChat(ctx, req.(*RenameChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_DeleteChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).DeleteChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_DeleteChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).DeleteChat(ctx, req.(*DeleteChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_RestoreChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).RestoreChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_RestoreChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).RestoreChat(ctx, req.(*RestoreChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_ListTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).ListTrash(ctx, req.(*ListTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_ArchiveChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).ArchiveChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_ArchiveChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).ArchiveChat(ctx, req.(*ArchiveChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_PinChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).PinChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_PinChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).PinChat(ctx, req.(*PinChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_MoveChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).MoveChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_MoveChat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).MoveChat(ctx, req.(*MoveChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_GetChatSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChatSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).GetChatSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_GetChatSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).GetChatSummary(ctx, req.(*GetChatSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_RegenerateChatSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegenerateChatSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).RegenerateChatSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_RegenerateChatSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).RegenerateChatSummary(ctx, req.(*RegenerateChatSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_SetSystemPrompt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSystemPromptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).SetSystemPrompt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_SetSystemPrompt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).SetSystemPrompt(ctx, req.(*SetSystemPromptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_CreatePromptTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePromptTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).CreatePromptTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_CreatePromptTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).CreatePromptTemplate(ctx, req.(*CreatePromptTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_ListPromptTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPromptTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).ListPromptTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_ListPromptTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).ListPromptTemplates(ctx, req.(*ListPromptTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_SetGenerationOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetGenerationOptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).SetGenerationOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_SetGenerationOptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).SetGenerationOptions(ctx, req.(*SetGenerationOptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_CreateWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).CreateWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_CreateWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).CreateWorkspace(ctx, req.(*CreateWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_ListWorkspaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkspacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).ListWorkspaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_ListWorkspaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).ListWorkspaces(ctx, req.(*ListWorkspacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_ListWorkspaceMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkspaceMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).ListWorkspaceMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_ListWorkspaceMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).ListWorkspaceMembers(ctx, req.(*ListWorkspaceMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SortedChat_AddWorkspaceMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWorkspaceMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SortedChatServer).AddWorkspaceMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SortedChat_AddWorkspaceMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SortedChatServer).AddWorkspaceMember(ctx, req.(*AddWorkspaceMemberRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SortedChat_BranchAChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BranchAChatRequest)
+func _SortedChat_UpdateWorkspaceMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWorkspaceMemberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SortedChatServer).BranchAChat(ctx, in)
+		return srv.(SortedChatServer).UpdateWorkspaceMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SortedChat_BranchAChat_FullMethodName,
+		FullMethod: SortedChat_UpdateWorkspaceMember_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SortedChatServer).BranchAChat(ctx, req.(*BranchAChatRequest))
+		return srv.(SortedChatServer).UpdateWorkspaceMember(ctx, req.(*UpdateWorkspaceMemberRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SortedChat_ListChatBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListChatBranchRequest)
+func _SortedChat_RemoveWorkspaceMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveWorkspaceMemberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SortedChatServer).ListChatBranch(ctx, in)
+		return srv.(SortedChatServer).RemoveWorkspaceMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SortedChat_ListChatBranch_FullMethodName,
+		FullMethod: SortedChat_RemoveWorkspaceMember_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SortedChatServer).ListChatBranch(ctx, req.(*ListChatBranchRequest))
+		return srv.(SortedChatServer).RemoveWorkspaceMember(ctx, req.(*RemoveWorkspaceMemberRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -521,6 +1629,10 @@ var SortedChat_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "sortedchat.SortedChat",
 	HandlerType: (*SortedChatServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CancelChat",
+			Handler:    _SortedChat_CancelChat_Handler,
+		},
 		{
 			MethodName: "GenerateChatName",
 			Handler:    _SortedChat_GenerateChatName_Handler,
@@ -541,6 +1653,26 @@ var SortedChat_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListModel",
 			Handler:    _SortedChat_ListModel_Handler,
 		},
+		{
+			MethodName: "CreateModel",
+			Handler:    _SortedChat_CreateModel_Handler,
+		},
+		{
+			MethodName: "UpdateModel",
+			Handler:    _SortedChat_UpdateModel_Handler,
+		},
+		{
+			MethodName: "DeleteModel",
+			Handler:    _SortedChat_DeleteModel_Handler,
+		},
+		{
+			MethodName: "SyncModelsFromProvider",
+			Handler:    _SortedChat_SyncModelsFromProvider_Handler,
+		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _SortedChat_GetUsage_Handler,
+		},
 		{
 			MethodName: "SearchChat",
 			Handler:    _SortedChat_SearchChat_Handler,
@@ -557,6 +1689,10 @@ var SortedChat_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListDocuments",
 			Handler:    _SortedChat_ListDocuments_Handler,
 		},
+		{
+			MethodName: "GetDocumentLink",
+			Handler:    _SortedChat_GetDocumentLink_Handler,
+		},
 		{
 			MethodName: "SubmitGenerateEmbeddingsJob",
 			Handler:    _SortedChat_SubmitGenerateEmbeddingsJob_Handler,
@@ -569,6 +1705,94 @@ var SortedChat_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListChatBranch",
 			Handler:    _SortedChat_ListChatBranch_Handler,
 		},
+		{
+			MethodName: "GetBranchTree",
+			Handler:    _SortedChat_GetBranchTree_Handler,
+		},
+		{
+			MethodName: "DiffBranches",
+			Handler:    _SortedChat_DiffBranches_Handler,
+		},
+		{
+			MethodName: "SummarizeBranch",
+			Handler:    _SortedChat_SummarizeBranch_Handler,
+		},
+		{
+			MethodName: "RenameChat",
+			Handler:    _SortedChat_RenameChat_Handler,
+		},
+		{
+			MethodName: "DeleteChat",
+			Handler:    _SortedChat_DeleteChat_Handler,
+		},
+		{
+			MethodName: "RestoreChat",
+			Handler:    _SortedChat_RestoreChat_Handler,
+		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _SortedChat_ListTrash_Handler,
+		},
+		{
+			MethodName: "ArchiveChat",
+			Handler:    _SortedChat_ArchiveChat_Handler,
+		},
+		{
+			MethodName: "PinChat",
+			Handler:    _SortedChat_PinChat_Handler,
+		},
+		{
+			MethodName: "MoveChat",
+			Handler:    _SortedChat_MoveChat_Handler,
+		},
+		{
+			MethodName: "GetChatSummary",
+			Handler:    _SortedChat_GetChatSummary_Handler,
+		},
+		{
+			MethodName: "RegenerateChatSummary",
+			Handler:    _SortedChat_RegenerateChatSummary_Handler,
+		},
+		{
+			MethodName: "SetSystemPrompt",
+			Handler:    _SortedChat_SetSystemPrompt_Handler,
+		},
+		{
+			MethodName: "CreatePromptTemplate",
+			Handler:    _SortedChat_CreatePromptTemplate_Handler,
+		},
+		{
+			MethodName: "ListPromptTemplates",
+			Handler:    _SortedChat_ListPromptTemplates_Handler,
+		},
+		{
+			MethodName: "SetGenerationOptions",
+			Handler:    _SortedChat_SetGenerationOptions_Handler,
+		},
+		{
+			MethodName: "CreateWorkspace",
+			Handler:    _SortedChat_CreateWorkspace_Handler,
+		},
+		{
+			MethodName: "ListWorkspaces",
+			Handler:    _SortedChat_ListWorkspaces_Handler,
+		},
+		{
+			MethodName: "ListWorkspaceMembers",
+			Handler:    _SortedChat_ListWorkspaceMembers_Handler,
+		},
+		{
+			MethodName: "AddWorkspaceMember",
+			Handler:    _SortedChat_AddWorkspaceMember_Handler,
+		},
+		{
+			MethodName: "UpdateWorkspaceMember",
+			Handler:    _SortedChat_UpdateWorkspaceMember_Handler,
+		},
+		{
+			MethodName: "RemoveWorkspaceMember",
+			Handler:    _SortedChat_RemoveWorkspaceMember_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -576,6 +1800,21 @@ var SortedChat_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _SortedChat_Chat_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ResumeChat",
+			Handler:       _SortedChat_ResumeChat_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RegenerateMessage",
+			Handler:       _SortedChat_RegenerateMessage_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "EditMessage",
+			Handler:       _SortedChat_EditMessage_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "chatservice.proto",
 }
@@ -719,3 +1958,295 @@ var SettingService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "chatservice.proto",
 }
+
+const (
+	AuthService_Register_FullMethodName       = "/sortedchat.AuthService/Register"
+	AuthService_Login_FullMethodName          = "/sortedchat.AuthService/Login"
+	AuthService_GetCurrentUser_FullMethodName = "/sortedchat.AuthService/GetCurrentUser"
+	AuthService_CreateApiToken_FullMethodName = "/sortedchat.AuthService/CreateApiToken"
+	AuthService_ListApiTokens_FullMethodName  = "/sortedchat.AuthService/ListApiTokens"
+	AuthService_RevokeApiToken_FullMethodName = "/sortedchat.AuthService/RevokeApiToken"
+)
+
+// AuthServiceClient is the client API for AuthService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AuthServiceClient interface {
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	GetCurrentUser(ctx context.Context, in *GetCurrentUserRequest, opts ...grpc.CallOption) (*GetCurrentUserResponse, error)
+	CreateApiToken(ctx context.Context, in *CreateApiTokenRequest, opts ...grpc.CallOption) (*CreateApiTokenResponse, error)
+	ListApiTokens(ctx context.Context, in *ListApiTokensRequest, opts ...grpc.CallOption) (*ListApiTokensResponse, error)
+	RevokeApiToken(ctx context.Context, in *RevokeApiTokenRequest, opts ...grpc.CallOption) (*RevokeApiTokenResponse, error)
+}
+
+type authServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuthServiceClient(cc grpc.ClientConnInterface) AuthServiceClient {
+	return &authServiceClient{cc}
+}
+
+func (c *authServiceClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterResponse)
+	err := c.cc.Invoke(ctx, AuthService_Register_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, AuthService_Login_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetCurrentUser(ctx context.Context, in *GetCurrentUserRequest, opts ...grpc.CallOption) (*GetCurrentUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCurrentUserResponse)
+	err := c.cc.Invoke(ctx, AuthService_GetCurrentUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) CreateApiToken(ctx context.Context, in *CreateApiTokenRequest, opts ...grpc.CallOption) (*CreateApiTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateApiTokenResponse)
+	err := c.cc.Invoke(ctx, AuthService_CreateApiToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListApiTokens(ctx context.Context, in *ListApiTokensRequest, opts ...grpc.CallOption) (*ListApiTokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListApiTokensResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListApiTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RevokeApiToken(ctx context.Context, in *RevokeApiTokenRequest, opts ...grpc.CallOption) (*RevokeApiTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeApiTokenResponse)
+	err := c.cc.Invoke(ctx, AuthService_RevokeApiToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthServiceServer is the server API for AuthService service.
+// All implementations must embed UnimplementedAuthServiceServer
+// for forward compatibility.
+type AuthServiceServer interface {
+	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	GetCurrentUser(context.Context, *GetCurrentUserRequest) (*GetCurrentUserResponse, error)
+	CreateApiToken(context.Context, *CreateApiTokenRequest) (*CreateApiTokenResponse, error)
+	ListApiTokens(context.Context, *ListApiTokensRequest) (*ListApiTokensResponse, error)
+	RevokeApiToken(context.Context, *RevokeApiTokenRequest) (*RevokeApiTokenResponse, error)
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+// UnimplementedAuthServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAuthServiceServer struct{}
+
+func (UnimplementedAuthServiceServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedAuthServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedAuthServiceServer) GetCurrentUser(context.Context, *GetCurrentUserRequest) (*GetCurrentUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentUser not implemented")
+}
+func (UnimplementedAuthServiceServer) CreateApiToken(context.Context, *CreateApiTokenRequest) (*CreateApiTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateApiToken not implemented")
+}
+func (UnimplementedAuthServiceServer) ListApiTokens(context.Context, *ListApiTokensRequest) (*ListApiTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListApiTokens not implemented")
+}
+func (UnimplementedAuthServiceServer) RevokeApiToken(context.Context, *RevokeApiTokenRequest) (*RevokeApiTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeApiToken not implemented")
+}
+func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
+func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthServiceServer will
+// result in compilation errors.
+type UnsafeAuthServiceServer interface {
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+func RegisterAuthServiceServer(s grpc.ServiceRegistrar, srv AuthServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAuthServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AuthService_ServiceDesc, srv)
+}
+
+func _AuthService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetCurrentUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCurrentUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetCurrentUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetCurrentUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetCurrentUser(ctx, req.(*GetCurrentUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_CreateApiToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateApiTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CreateApiToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CreateApiToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CreateApiToken(ctx, req.(*CreateApiTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListApiTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListApiTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListApiTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListApiTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListApiTokens(ctx, req.(*ListApiTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RevokeApiToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeApiTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RevokeApiToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RevokeApiToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RevokeApiToken(ctx, req.(*RevokeApiTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuthService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sortedchat.AuthService",
+	HandlerType: (*AuthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _AuthService_Register_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _AuthService_Login_Handler,
+		},
+		{
+			MethodName: "GetCurrentUser",
+			Handler:    _AuthService_GetCurrentUser_Handler,
+		},
+		{
+			MethodName: "CreateApiToken",
+			Handler:    _AuthService_CreateApiToken_Handler,
+		},
+		{
+			MethodName: "ListApiTokens",
+			Handler:    _AuthService_ListApiTokens_Handler,
+		},
+		{
+			MethodName: "RevokeApiToken",
+			Handler:    _AuthService_RevokeApiToken_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "chatservice.proto",
+}
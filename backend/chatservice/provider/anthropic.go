@@ -0,0 +1,293 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	AnthropicDefaultURL = "https://api.anthropic.com/v1/messages"
+	anthropicAPIVersion = "2023-06-01"
+	// the Messages API requires max_tokens, this is used when the request does not set one
+	anthropicDefaultMaxTokens = 4096
+)
+
+// AnthropicProvider talks to the Anthropic Messages API
+type AnthropicProvider struct {
+	url     string
+	apiKey  string
+	headers map[string]string
+	client  *http.Client
+}
+
+// NewAnthropicProvider accepts either the full messages endpoint or a base URL like https://api.anthropic.com
+func NewAnthropicProvider(cfg Config) *AnthropicProvider {
+	url := strings.TrimRight(cfg.URL, "/")
+	if url == "" {
+		url = AnthropicDefaultURL
+	}
+	if !strings.HasSuffix(url, "/messages") {
+		if !strings.HasSuffix(url, "/v1") {
+			url += "/v1"
+		}
+		url += "/messages"
+	}
+
+	return &AnthropicProvider{
+		url:     url,
+		apiKey:  cfg.APIKey,
+		headers: cfg.Headers,
+		client:  httpClient(cfg),
+	}
+}
+
+type anthropicRequest struct {
+	Model         string             `json:"model"`
+	System        string             `json:"system,omitempty"`
+	Messages      []Message          `json:"messages"`
+	MaxTokens     int                `json:"max_tokens"`
+	Stream        bool               `json:"stream"`
+	Temperature   *float64           `json:"temperature,omitempty"`
+	TopP          *float64           `json:"top_p,omitempty"`
+	StopSequences []string           `json:"stop_sequences,omitempty"`
+	Thinking      *anthropicThinking `json:"thinking,omitempty"`
+}
+
+type anthropicThinking struct {
+	Type         string `json:"type"`
+	BudgetTokens int    `json:"budget_tokens"`
+}
+
+type anthropicUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+type anthropicError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// anthropicStreamEvent covers the fields of all the SSE event types we care about
+type anthropicStreamEvent struct {
+	Type    string `json:"type"`
+	Message struct {
+		Usage anthropicUsage `json:"usage"`
+	} `json:"message"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+	Usage *anthropicUsage `json:"usage"`
+	Error *anthropicError `json:"error"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	Usage anthropicUsage `json:"usage"`
+}
+
+// toAnthropicRequest moves system messages into the top level `system` field,
+// the Messages API only accepts user and assistant roles in `messages`.
+// A reasoning effort turns on extended thinking with a token budget, the budget counts towards max_tokens.
+// The Messages API has no seed.
+func toAnthropicRequest(req CompletionRequest, stream bool) anthropicRequest {
+	var system []string
+	messages := make([]Message, 0, len(req.Messages))
+	for _, m := range req.Messages {
+		if m.Role == "system" {
+			system = append(system, m.Content)
+			continue
+		}
+		messages = append(messages, m)
+	}
+
+	body := anthropicRequest{
+		Model:         req.Model,
+		System:        strings.Join(system, "\n\n"),
+		Messages:      messages,
+		MaxTokens:     req.Options.MaxTokens,
+		Stream:        stream,
+		Temperature:   req.Options.Temperature,
+		TopP:          req.Options.TopP,
+		StopSequences: req.Options.Stop,
+	}
+	if budget, ok := reasoningBudgets[req.Options.ReasoningEffort]; ok {
+		if body.MaxTokens == 0 {
+			body.MaxTokens = budget + anthropicDefaultMaxTokens
+		}
+		body.Thinking = &anthropicThinking{Type: "enabled", BudgetTokens: min(budget, body.MaxTokens-1)}
+	}
+	if body.MaxTokens == 0 {
+		body.MaxTokens = anthropicDefaultMaxTokens
+	}
+	return body
+}
+
+// CheckOptions rejects what the Messages API doesn't take: a seed, a temperature above 1, and with extended
+// thinking a temperature, top_p or fewer max_tokens than the smallest thinking budget
+func (p *AnthropicProvider) CheckOptions(opts GenerationOptions) error {
+	switch {
+	case opts.Seed != nil:
+		return fmt.Errorf("Anthropic models take no seed")
+	case opts.Temperature != nil && *opts.Temperature > 1:
+		return fmt.Errorf("Anthropic models take a temperature of at most 1")
+	case opts.ReasoningEffort != "" && (opts.Temperature != nil || opts.TopP != nil):
+		return fmt.Errorf("extended thinking takes no temperature or top_p")
+	case opts.ReasoningEffort != "" && opts.MaxTokens > 0 && opts.MaxTokens <= reasoningBudgets[ReasoningLow]:
+		return fmt.Errorf("extended thinking needs more than %d max_tokens", reasoningBudgets[ReasoningLow])
+	}
+	return nil
+}
+
+func (p *AnthropicProvider) StreamCompletion(ctx context.Context, req CompletionRequest, onDelta func(delta string) error) (Usage, error) {
+	var usage Usage
+
+	resp, err := p.post(ctx, toAnthropicRequest(req, true))
+	if err != nil {
+		return usage, err
+	}
+	defer resp.Body.Close()
+
+	err = readSSE(resp.Body, func(ev sseEvent) error {
+		var event anthropicStreamEvent
+		if err := json.Unmarshal([]byte(ev.Data), &event); err != nil {
+			log.Printf("Failed to parse Anthropic event: %v", err)
+			return nil
+		}
+
+		switch event.Type {
+		case "message_start":
+			usage.InputTokens = event.Message.Usage.InputTokens
+			usage.OutputTokens = event.Message.Usage.OutputTokens
+		case "content_block_delta":
+			if event.Delta.Type == "text_delta" && event.Delta.Text != "" {
+				return onDelta(event.Delta.Text)
+			}
+		case "message_delta":
+			// output_tokens in message_delta is cumulative
+			if event.Usage != nil {
+				usage.OutputTokens = event.Usage.OutputTokens
+			}
+		case "error":
+			if event.Error != nil {
+				return fmt.Errorf("Anthropic stream error: %s - %s", event.Error.Type, event.Error.Message)
+			}
+			return fmt.Errorf("Anthropic stream error: %s", ev.Data)
+		}
+		return nil
+	})
+	if err != nil {
+		return usage, fmt.Errorf("error reading stream: %w", err)
+	}
+
+	return usage, nil
+}
+
+func (p *AnthropicProvider) Completion(ctx context.Context, req CompletionRequest) (*CompletionResponse, error) {
+	resp, err := p.post(ctx, toAnthropicRequest(req, false))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var anthropicResp anthropicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&anthropicResp); err != nil {
+		return nil, fmt.Errorf("failed to parse Anthropic response: %w", err)
+	}
+
+	var content strings.Builder
+	for _, block := range anthropicResp.Content {
+		if block.Type == "text" {
+			content.WriteString(block.Text)
+		}
+	}
+
+	if content.Len() == 0 {
+		return nil, fmt.Errorf("no text content returned from Anthropic")
+	}
+
+	return &CompletionResponse{
+		Content: content.String(),
+		Usage: Usage{
+			InputTokens:  anthropicResp.Usage.InputTokens,
+			OutputTokens: anthropicResp.Usage.OutputTokens,
+		},
+	}, nil
+}
+
+type anthropicModelList struct {
+	Data []struct {
+		ID          string `json:"id"`
+		DisplayName string `json:"display_name"`
+	} `json:"data"`
+	HasMore bool   `json:"has_more"`
+	LastID  string `json:"last_id"`
+}
+
+// ListModels pages through GET /v1/models
+func (p *AnthropicProvider) ListModels(ctx context.Context) ([]ModelInfo, error) {
+	base := strings.TrimSuffix(p.url, "/messages") + "/models?limit=1000"
+
+	var models []ModelInfo
+	pageURL := base
+	for {
+		var list anthropicModelList
+		err := getJSON(ctx, p.client, pageURL, "Anthropic", func(req *http.Request) {
+			req.Header.Set("x-api-key", p.apiKey)
+			req.Header.Set("anthropic-version", anthropicAPIVersion)
+			setHeaders(req, p.headers)
+		}, &list)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range list.Data {
+			models = append(models, ModelInfo{ID: m.ID, Name: m.DisplayName})
+		}
+
+		if !list.HasMore || list.LastID == "" {
+			return models, nil
+		}
+		pageURL = base + "&after_id=" + url.QueryEscape(list.LastID)
+	}
+}
+
+func (p *AnthropicProvider) post(ctx context.Context, body anthropicRequest) (*http.Response, error) {
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-api-key", p.apiKey)
+	httpReq.Header.Set("anthropic-version", anthropicAPIVersion)
+	setHeaders(httpReq, p.headers)
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("Anthropic request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Anthropic API error: %d - %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return resp, nil
+}
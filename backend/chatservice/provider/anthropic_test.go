@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// replayServer serves a recorded SSE stream from testdata and hands the decoded request to check
+func replayServer(t *testing.T, recording string, check func(r *http.Request, body map[string]interface{})) *httptest.Server {
+	t.Helper()
+
+	data, err := os.ReadFile(recording)
+	if err != nil {
+		t.Fatalf("Failed to read recording %s: %v", recording, err)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		if check != nil {
+			check(r, body)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write(data)
+	}))
+}
+
+func TestAnthropicProvider_StreamCompletion(t *testing.T) {
+	server := replayServer(t, "testdata/anthropic_stream.txt", func(r *http.Request, body map[string]interface{}) {
+		if got := r.Header.Get("x-api-key"); got != "test-key" {
+			t.Errorf("Expected x-api-key header, got '%s'", got)
+		}
+		if r.Header.Get("anthropic-version") == "" {
+			t.Error("anthropic-version header should be set")
+		}
+		if body["system"] != "You are terse." {
+			t.Errorf("Expected system prompt to be moved to top level, got %v", body["system"])
+		}
+		messages := body["messages"].([]interface{})
+		if len(messages) != 1 {
+			t.Errorf("Expected system message to be removed from messages, got %v", messages)
+		}
+		if body["max_tokens"] == nil {
+			t.Error("max_tokens is required by the Messages API")
+		}
+	})
+	defer server.Close()
+
+	p := NewAnthropicProvider(Config{URL: server.URL, APIKey: "test-key"})
+
+	var text strings.Builder
+	usage, err := p.StreamCompletion(context.Background(), CompletionRequest{
+		Model: "claude-3-5-haiku-20241022",
+		Messages: []Message{
+			{Role: "system", Content: "You are terse."},
+			{Role: "user", Content: "Hi"},
+		},
+	}, func(delta string) error {
+		text.WriteString(delta)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamCompletion failed: %v", err)
+	}
+
+	if text.String() != "Hello!" {
+		t.Errorf("Expected 'Hello!', got '%s'", text.String())
+	}
+	if usage.InputTokens != 25 || usage.OutputTokens != 15 {
+		t.Errorf("Expected usage 25/15, got %d/%d", usage.InputTokens, usage.OutputTokens)
+	}
+}
+
+func TestAnthropicProvider_StreamError(t *testing.T) {
+	server := replayServer(t, "testdata/anthropic_stream_error.txt", nil)
+	defer server.Close()
+
+	p := NewAnthropicProvider(Config{URL: server.URL, APIKey: "test-key"})
+
+	var text strings.Builder
+	_, err := p.StreamCompletion(context.Background(), CompletionRequest{
+		Model:    "claude-3-5-haiku-20241022",
+		Messages: []Message{{Role: "user", Content: "Hi"}},
+	}, func(delta string) error {
+		text.WriteString(delta)
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "overloaded_error") {
+		t.Errorf("Expected overloaded_error, got %v", err)
+	}
+	if text.String() != "Partial" {
+		t.Errorf("Expected deltas before the error to be delivered, got '%s'", text.String())
+	}
+}
+
+func TestAnthropicProvider_Completion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"content":[{"type":"text","text":"Trip Planning"}],"usage":{"input_tokens":20,"output_tokens":3}}`)
+	}))
+	defer server.Close()
+
+	p := NewAnthropicProvider(Config{URL: server.URL, APIKey: "test-key"})
+
+	resp, err := p.Completion(context.Background(), CompletionRequest{
+		Model:    "claude-3-5-haiku-20241022",
+		Messages: []Message{{Role: "user", Content: "Name this chat"}},
+	})
+	if err != nil {
+		t.Fatalf("Completion failed: %v", err)
+	}
+
+	if resp.Content != "Trip Planning" {
+		t.Errorf("Expected 'Trip Planning', got '%s'", resp.Content)
+	}
+	if resp.Usage.InputTokens != 20 || resp.Usage.OutputTokens != 3 {
+		t.Errorf("Expected usage 20/3, got %d/%d", resp.Usage.InputTokens, resp.Usage.OutputTokens)
+	}
+}
+
+func TestAnthropicProvider_ListModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" {
+			t.Errorf("Expected /v1/models, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("after_id") == "" {
+			fmt.Fprint(w, `{"data":[{"id":"claude-sonnet-4-20250514","display_name":"Claude Sonnet 4"}],"has_more":true,"last_id":"claude-sonnet-4-20250514"}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":[{"id":"claude-3-5-haiku-20241022","display_name":"Claude Haiku 3.5"}],"has_more":false,"last_id":"claude-3-5-haiku-20241022"}`)
+	}))
+	defer server.Close()
+
+	p := NewAnthropicProvider(Config{URL: server.URL, APIKey: "test-key"})
+
+	models, err := p.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels failed: %v", err)
+	}
+
+	if len(models) != 2 || models[0].ID != "claude-sonnet-4-20250514" || models[1].Name != "Claude Haiku 3.5" {
+		t.Errorf("Unexpected models %+v", models)
+	}
+}
+
+func TestAnthropicGenerationOptions(t *testing.T) {
+	temperature := 0.2
+	body := toAnthropicRequest(CompletionRequest{
+		Model:   "claude-4-sonnet",
+		Options: GenerationOptions{Temperature: &temperature, Stop: []string{"END"}},
+	}, false)
+	if body.MaxTokens != anthropicDefaultMaxTokens || *body.Temperature != 0.2 || body.StopSequences[0] != "END" || body.Thinking != nil {
+		t.Errorf("Unexpected request %+v", body)
+	}
+
+	// the thinking budget counts towards max_tokens
+	body = toAnthropicRequest(CompletionRequest{Options: GenerationOptions{ReasoningEffort: ReasoningMedium}}, false)
+	if body.Thinking == nil || body.Thinking.BudgetTokens != 4096 || body.MaxTokens != 4096+anthropicDefaultMaxTokens {
+		t.Errorf("Expected thinking on top of the default max_tokens, got %+v and %+v", body, body.Thinking)
+	}
+	body = toAnthropicRequest(CompletionRequest{Options: GenerationOptions{MaxTokens: 2048, ReasoningEffort: ReasoningHigh}}, false)
+	if body.MaxTokens != 2048 || body.Thinking.BudgetTokens != 2047 {
+		t.Errorf("Expected the thinking budget within max_tokens, got %d and %d", body.MaxTokens, body.Thinking.BudgetTokens)
+	}
+}
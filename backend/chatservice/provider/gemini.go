@@ -0,0 +1,298 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+const (
+	GeminiDefaultBaseURL = "https://generativelanguage.googleapis.com/v1beta"
+
+	geminiGenerateMethod = ":generateContent"
+	geminiStreamMethod   = ":streamGenerateContent?alt=sse"
+)
+
+// GeminiProvider talks to the Google Gemini generateContent API
+type GeminiProvider struct {
+	url     string
+	apiKey  string
+	headers map[string]string
+	client  *http.Client
+}
+
+// NewGeminiProvider accepts the model's generateContent endpoint, e.g.
+// .../v1beta/models/gemini-2.5-flash:generateContent, the streaming endpoint is derived from it.
+// A base URL like .../v1beta or an empty URL is completed with the model name at request time.
+func NewGeminiProvider(cfg Config) *GeminiProvider {
+	return &GeminiProvider{
+		url:     cfg.URL,
+		apiKey:  cfg.APIKey,
+		headers: cfg.Headers,
+		client:  httpClient(cfg),
+	}
+}
+
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiRequest struct {
+	Contents          []geminiContent         `json:"contents"`
+	SystemInstruction *geminiContent          `json:"systemInstruction,omitempty"`
+	GenerationConfig  *geminiGenerationConfig `json:"generationConfig,omitempty"`
+}
+
+type geminiGenerationConfig struct {
+	Temperature     *float64              `json:"temperature,omitempty"`
+	TopP            *float64              `json:"topP,omitempty"`
+	MaxOutputTokens int                   `json:"maxOutputTokens,omitempty"`
+	StopSequences   []string              `json:"stopSequences,omitempty"`
+	Seed            *int64                `json:"seed,omitempty"`
+	ThinkingConfig  *geminiThinkingConfig `json:"thinkingConfig,omitempty"`
+}
+
+type geminiThinkingConfig struct {
+	ThinkingBudget int `json:"thinkingBudget"`
+}
+
+// toGeminiGenerationConfig maps the generation options to generationConfig, nil when none is set. A reasoning
+// effort becomes a thinking budget.
+func toGeminiGenerationConfig(opts GenerationOptions) *geminiGenerationConfig {
+	config := geminiGenerationConfig{
+		Temperature:     opts.Temperature,
+		TopP:            opts.TopP,
+		MaxOutputTokens: opts.MaxTokens,
+		StopSequences:   opts.Stop,
+		Seed:            opts.Seed,
+	}
+	if budget, ok := reasoningBudgets[opts.ReasoningEffort]; ok {
+		config.ThinkingConfig = &geminiThinkingConfig{ThinkingBudget: budget}
+	}
+	if config.Temperature == nil && config.TopP == nil && config.MaxOutputTokens == 0 && len(config.StopSequences) == 0 &&
+		config.Seed == nil && config.ThinkingConfig == nil {
+		return nil
+	}
+	return &config
+}
+
+type geminiUsageMetadata struct {
+	PromptTokenCount     int `json:"promptTokenCount"`
+	CandidatesTokenCount int `json:"candidatesTokenCount"`
+	// ThoughtsTokenCount is what thinking models spent on thoughts, billed as output but not in CandidatesTokenCount
+	ThoughtsTokenCount int `json:"thoughtsTokenCount"`
+}
+
+func (m *geminiUsageMetadata) usage() Usage {
+	return Usage{InputTokens: m.PromptTokenCount, OutputTokens: m.CandidatesTokenCount + m.ThoughtsTokenCount}
+}
+
+type geminiResponse struct {
+	Candidates []struct {
+		Content geminiContent `json:"content"`
+	} `json:"candidates"`
+	UsageMetadata *geminiUsageMetadata `json:"usageMetadata"`
+}
+
+// text concatenates the parts of the first candidate
+func (r *geminiResponse) text() string {
+	if len(r.Candidates) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	for _, part := range r.Candidates[0].Content.Parts {
+		sb.WriteString(part.Text)
+	}
+	return sb.String()
+}
+
+// toGeminiRequest maps the chat history to contents/parts.
+// Gemini calls the assistant role "model", system messages go into systemInstruction
+// and consecutive turns of the same role are merged since Gemini expects them to alternate.
+func toGeminiRequest(req CompletionRequest) geminiRequest {
+	body := geminiRequest{GenerationConfig: toGeminiGenerationConfig(req.Options)}
+
+	for _, m := range req.Messages {
+		if m.Role == "system" {
+			if body.SystemInstruction == nil {
+				body.SystemInstruction = &geminiContent{}
+			}
+			body.SystemInstruction.Parts = append(body.SystemInstruction.Parts, geminiPart{Text: m.Content})
+			continue
+		}
+
+		role := "user"
+		if m.Role == "assistant" {
+			role = "model"
+		}
+
+		if n := len(body.Contents); n > 0 && body.Contents[n-1].Role == role {
+			body.Contents[n-1].Parts = append(body.Contents[n-1].Parts, geminiPart{Text: m.Content})
+			continue
+		}
+		body.Contents = append(body.Contents, geminiContent{Role: role, Parts: []geminiPart{{Text: m.Content}}})
+	}
+
+	return body
+}
+
+// endpoint returns the URL for the given method (":generateContent" or ":streamGenerateContent?alt=sse")
+func (p *GeminiProvider) endpoint(model string, method string) string {
+	url := strings.TrimRight(p.url, "/")
+	if url == "" {
+		url = GeminiDefaultBaseURL
+	}
+	if !strings.HasSuffix(url, geminiGenerateMethod) {
+		url += "/models/" + model + geminiGenerateMethod
+	}
+	return strings.TrimSuffix(url, geminiGenerateMethod) + method
+}
+
+func (p *GeminiProvider) StreamCompletion(ctx context.Context, req CompletionRequest, onDelta func(delta string) error) (Usage, error) {
+	var usage Usage
+
+	resp, err := p.post(ctx, p.endpoint(req.Model, geminiStreamMethod), toGeminiRequest(req))
+	if err != nil {
+		return usage, err
+	}
+	defer resp.Body.Close()
+
+	err = readSSE(resp.Body, func(ev sseEvent) error {
+		var chunk geminiResponse
+		if err := json.Unmarshal([]byte(ev.Data), &chunk); err != nil {
+			log.Printf("Failed to parse Gemini chunk: %v", err)
+			return nil
+		}
+
+		// every chunk carries the running totals, the last one wins
+		if chunk.UsageMetadata != nil {
+			usage = chunk.UsageMetadata.usage()
+		}
+
+		if text := chunk.text(); text != "" {
+			return onDelta(text)
+		}
+		return nil
+	})
+	if err != nil {
+		return usage, fmt.Errorf("error reading stream: %w", err)
+	}
+
+	return usage, nil
+}
+
+func (p *GeminiProvider) Completion(ctx context.Context, req CompletionRequest) (*CompletionResponse, error) {
+	resp, err := p.post(ctx, p.endpoint(req.Model, geminiGenerateMethod), toGeminiRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var geminiResp geminiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&geminiResp); err != nil {
+		return nil, fmt.Errorf("failed to parse Gemini response: %w", err)
+	}
+
+	text := geminiResp.text()
+	if text == "" {
+		return nil, fmt.Errorf("no candidates returned from Gemini")
+	}
+
+	result := &CompletionResponse{Content: text}
+	if geminiResp.UsageMetadata != nil {
+		result.Usage = geminiResp.UsageMetadata.usage()
+	}
+	return result, nil
+}
+
+type geminiModelList struct {
+	Models []struct {
+		Name                       string   `json:"name"`
+		DisplayName                string   `json:"displayName"`
+		InputTokenLimit            int      `json:"inputTokenLimit"`
+		SupportedGenerationMethods []string `json:"supportedGenerationMethods"`
+	} `json:"models"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// ListModels pages through GET /models and keeps the models that support generateContent
+func (p *GeminiProvider) ListModels(ctx context.Context) ([]ModelInfo, error) {
+	base := strings.TrimRight(p.url, "/")
+	if base == "" {
+		base = GeminiDefaultBaseURL
+	}
+	// the configured URL may be a model endpoint, .../v1beta/models/gemini-2.5-flash:generateContent
+	if i := strings.Index(base, "/models/"); i >= 0 {
+		base = base[:i]
+	}
+	base += "/models?pageSize=1000"
+
+	var models []ModelInfo
+	pageURL := base
+	for {
+		var list geminiModelList
+		err := getJSON(ctx, p.client, pageURL, "Gemini", func(req *http.Request) {
+			req.Header.Set("x-goog-api-key", p.apiKey)
+			setHeaders(req, p.headers)
+		}, &list)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range list.Models {
+			if !slices.Contains(m.SupportedGenerationMethods, "generateContent") {
+				continue
+			}
+			models = append(models, ModelInfo{
+				ID:            strings.TrimPrefix(m.Name, "models/"),
+				Name:          m.DisplayName,
+				ContextWindow: m.InputTokenLimit,
+			})
+		}
+
+		if list.NextPageToken == "" {
+			return models, nil
+		}
+		pageURL = base + "&pageToken=" + url.QueryEscape(list.NextPageToken)
+	}
+}
+
+func (p *GeminiProvider) post(ctx context.Context, url string, body geminiRequest) (*http.Response, error) {
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-goog-api-key", p.apiKey)
+	setHeaders(httpReq, p.headers)
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("Gemini request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Gemini API error: %d - %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return resp, nil
+}
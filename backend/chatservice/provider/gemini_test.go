@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGeminiProvider_StreamCompletion(t *testing.T) {
+	var gotPath, gotQuery string
+	server := replayServer(t, "testdata/gemini_stream.txt", func(r *http.Request, body map[string]interface{}) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		if got := r.Header.Get("x-goog-api-key"); got != "test-key" {
+			t.Errorf("Expected x-goog-api-key header, got '%s'", got)
+		}
+
+		contents := body["contents"].([]interface{})
+		if len(contents) != 2 {
+			t.Fatalf("Expected consecutive user turns to be merged into 2 contents, got %d", len(contents))
+		}
+		if role := contents[1].(map[string]interface{})["role"]; role != "model" {
+			t.Errorf("Expected assistant role to be mapped to 'model', got %v", role)
+		}
+		if body["systemInstruction"] == nil {
+			t.Error("Expected system message in systemInstruction")
+		}
+	})
+	defer server.Close()
+
+	p := NewGeminiProvider(Config{URL: server.URL + "/v1beta/models/gemini-2.5-flash:generateContent", APIKey: "test-key"})
+
+	var text strings.Builder
+	usage, err := p.StreamCompletion(context.Background(), CompletionRequest{
+		Model: "gemini-2.5-flash",
+		Messages: []Message{
+			{Role: "system", Content: "Answer briefly."},
+			{Role: "user", Content: "Hi"},
+			{Role: "user", Content: "What is the capital of France?"},
+			{Role: "assistant", Content: "Let me think."},
+		},
+	}, func(delta string) error {
+		text.WriteString(delta)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamCompletion failed: %v", err)
+	}
+
+	if gotPath != "/v1beta/models/gemini-2.5-flash:streamGenerateContent" || gotQuery != "alt=sse" {
+		t.Errorf("Expected streamGenerateContent with alt=sse, got %s?%s", gotPath, gotQuery)
+	}
+	if text.String() != "The capital of France is Paris." {
+		t.Errorf("Unexpected text '%s'", text.String())
+	}
+	if usage.InputTokens != 9 || usage.OutputTokens != 29 {
+		t.Errorf("Expected usage 9/29 with the thoughts counted as output, got %d/%d", usage.InputTokens, usage.OutputTokens)
+	}
+}
+
+func TestGeminiProvider_Completion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, ":generateContent") {
+			t.Errorf("Expected generateContent endpoint, got %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"candidates":[{"content":{"parts":[{"text":"Trip Planning"}],"role":"model"}}],"usageMetadata":{"promptTokenCount":20,"candidatesTokenCount":2,"thoughtsTokenCount":30}}`)
+	}))
+	defer server.Close()
+
+	p := NewGeminiProvider(Config{URL: server.URL + "/v1beta/models/gemini-2.0-flash:generateContent", APIKey: "test-key"})
+
+	resp, err := p.Completion(context.Background(), CompletionRequest{
+		Model:    "gemini-2.0-flash",
+		Messages: []Message{{Role: "user", Content: "Name this chat"}},
+	})
+	if err != nil {
+		t.Fatalf("Completion failed: %v", err)
+	}
+
+	if resp.Content != "Trip Planning" {
+		t.Errorf("Expected 'Trip Planning', got '%s'", resp.Content)
+	}
+	if resp.Usage.InputTokens != 20 || resp.Usage.OutputTokens != 32 {
+		t.Errorf("Expected usage 20/32 with the thoughts counted as output, got %d/%d", resp.Usage.InputTokens, resp.Usage.OutputTokens)
+	}
+}
+
+func TestGeminiProvider_ListModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1beta/models" {
+			t.Errorf("Expected /v1beta/models, got %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"models":[
+			{"name":"models/gemini-2.5-flash","displayName":"Gemini 2.5 Flash","inputTokenLimit":1048576,"supportedGenerationMethods":["generateContent","countTokens"]},
+			{"name":"models/text-embedding-004","displayName":"Text Embedding 004","inputTokenLimit":2048,"supportedGenerationMethods":["embedContent"]}
+		]}`)
+	}))
+	defer server.Close()
+
+	// a model endpoint from the catalog is reduced to the API base
+	p := NewGeminiProvider(Config{URL: server.URL + "/v1beta/models/gemini-2.5-flash:generateContent", APIKey: "test-key"})
+
+	models, err := p.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels failed: %v", err)
+	}
+
+	if len(models) != 1 {
+		t.Fatalf("Expected embedding model to be skipped, got %+v", models)
+	}
+	if models[0].ID != "gemini-2.5-flash" || models[0].ContextWindow != 1048576 {
+		t.Errorf("Unexpected model %+v", models[0])
+	}
+}
+
+func TestGeminiGenerationOptions(t *testing.T) {
+	if body := toGeminiRequest(CompletionRequest{}); body.GenerationConfig != nil {
+		t.Errorf("Expected no generationConfig without options, got %+v", body.GenerationConfig)
+	}
+
+	temperature, seed := 1.5, int64(7)
+	config := toGeminiRequest(CompletionRequest{
+		Options: GenerationOptions{Temperature: &temperature, MaxTokens: 100, Seed: &seed, ReasoningEffort: ReasoningHigh},
+	}).GenerationConfig
+	if config == nil || *config.Temperature != 1.5 || config.MaxOutputTokens != 100 || *config.Seed != 7 || config.ThinkingConfig.ThinkingBudget != 16384 {
+		t.Errorf("Unexpected generationConfig %+v", config)
+	}
+}
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// OllamaModelPrefix marks model IDs that are served by the local Ollama instead of the model catalog
+const OllamaModelPrefix = "ollama/"
+
+// OllamaProvider talks to the native Ollama API, /api/chat streams newline delimited JSON instead of SSE
+type OllamaProvider struct {
+	baseURL string
+	headers map[string]string
+	client  *http.Client
+}
+
+// NewOllamaProvider accepts the Ollama base URL, the OLLAMA_URL setting holds the embeddings
+// endpoint (e.g. http://localhost:11434/v1/embeddings) so only scheme and host are kept
+func NewOllamaProvider(cfg Config) *OllamaProvider {
+	return &OllamaProvider{
+		baseURL: OllamaBaseURL(cfg.URL),
+		headers: cfg.Headers,
+		client:  httpClient(cfg),
+	}
+}
+
+// OllamaBaseURL reduces an Ollama endpoint URL to the base URL of the API by cutting off the trailing /api/... (or
+// OpenAI compatible /v1/...) endpoint. A path prefix of a reverse proxy in front of Ollama is kept.
+func OllamaBaseURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return strings.TrimRight(raw, "/")
+	}
+	path := strings.TrimRight(u.Path, "/") + "/"
+	if i := max(strings.LastIndex(path, "/api/"), strings.LastIndex(path, "/v1/")); i >= 0 {
+		path = path[:i]
+	}
+	return u.Scheme + "://" + u.Host + strings.TrimRight(path, "/")
+}
+
+type ollamaChatRequest struct {
+	Model    string         `json:"model"`
+	Messages []Message      `json:"messages"`
+	Stream   bool           `json:"stream"`
+	Options  *ollamaOptions `json:"options,omitempty"`
+	Think    bool           `json:"think,omitempty"`
+}
+
+type ollamaOptions struct {
+	Temperature *float64 `json:"temperature,omitempty"`
+	TopP        *float64 `json:"top_p,omitempty"`
+	NumPredict  int      `json:"num_predict,omitempty"`
+	Stop        []string `json:"stop,omitempty"`
+	Seed        *int64   `json:"seed,omitempty"`
+}
+
+// toOllamaRequest maps the generation options to the model options of Ollama, max_tokens is num_predict. Ollama
+// thinks or doesn't, any reasoning effort turns thinking on.
+func toOllamaRequest(req CompletionRequest, stream bool) ollamaChatRequest {
+	body := ollamaChatRequest{
+		Model:    strings.TrimPrefix(req.Model, OllamaModelPrefix),
+		Messages: req.Messages,
+		Stream:   stream,
+		Think:    req.Options.ReasoningEffort != "",
+	}
+	opts := ollamaOptions{
+		Temperature: req.Options.Temperature,
+		TopP:        req.Options.TopP,
+		NumPredict:  req.Options.MaxTokens,
+		Stop:        req.Options.Stop,
+		Seed:        req.Options.Seed,
+	}
+	if opts.Temperature != nil || opts.TopP != nil || opts.NumPredict != 0 || len(opts.Stop) > 0 || opts.Seed != nil {
+		body.Options = &opts
+	}
+	return body
+}
+
+type ollamaChatResponse struct {
+	Message struct {
+		Content string `json:"content"`
+	} `json:"message"`
+	Done            bool   `json:"done"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+	Error           string `json:"error"`
+}
+
+type ollamaTagsResponse struct {
+	Models []struct {
+		Name string `json:"name"`
+	} `json:"models"`
+}
+
+func (p *OllamaProvider) StreamCompletion(ctx context.Context, req CompletionRequest, onDelta func(delta string) error) (Usage, error) {
+	var usage Usage
+
+	resp, err := p.post(ctx, toOllamaRequest(req, true))
+	if err != nil {
+		return usage, err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSSELineSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var chunk ollamaChatResponse
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			return usage, fmt.Errorf("failed to parse Ollama chunk: %w", err)
+		}
+
+		if chunk.Error != "" {
+			return usage, fmt.Errorf("Ollama stream error: %s", chunk.Error)
+		}
+
+		if chunk.Message.Content != "" {
+			if err := onDelta(chunk.Message.Content); err != nil {
+				return usage, err
+			}
+		}
+
+		if chunk.Done {
+			usage.InputTokens = chunk.PromptEvalCount
+			usage.OutputTokens = chunk.EvalCount
+			break
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return usage, fmt.Errorf("error reading stream: %w", err)
+	}
+
+	return usage, nil
+}
+
+func (p *OllamaProvider) Completion(ctx context.Context, req CompletionRequest) (*CompletionResponse, error) {
+	resp, err := p.post(ctx, toOllamaRequest(req, false))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var ollamaResp ollamaChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ollamaResp); err != nil {
+		return nil, fmt.Errorf("failed to parse Ollama response: %w", err)
+	}
+
+	if ollamaResp.Error != "" {
+		return nil, fmt.Errorf("Ollama error: %s", ollamaResp.Error)
+	}
+
+	return &CompletionResponse{
+		Content: ollamaResp.Message.Content,
+		Usage: Usage{
+			InputTokens:  ollamaResp.PromptEvalCount,
+			OutputTokens: ollamaResp.EvalCount,
+		},
+	}, nil
+}
+
+// ListModels returns the models pulled into this Ollama instance
+func (p *OllamaProvider) ListModels(ctx context.Context) ([]ModelInfo, error) {
+	var tags ollamaTagsResponse
+	err := getJSON(ctx, p.client, p.baseURL+"/api/tags", "Ollama", func(req *http.Request) {
+		setHeaders(req, p.headers)
+	}, &tags)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]ModelInfo, 0, len(tags.Models))
+	for _, m := range tags.Models {
+		models = append(models, ModelInfo{ID: m.Name, Name: m.Name})
+	}
+	return models, nil
+}
+
+func (p *OllamaProvider) post(ctx context.Context, body ollamaChatRequest) (*http.Response, error) {
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/api/chat", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	setHeaders(httpReq, p.headers)
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("Ollama request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Ollama API error: %d - %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return resp, nil
+}
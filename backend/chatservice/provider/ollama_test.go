@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOllamaProvider_StreamCompletion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/chat" {
+			t.Errorf("Expected /api/chat, got %s", r.URL.Path)
+		}
+
+		var body ollamaChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if body.Model != "llama3.2:latest" {
+			t.Errorf("Expected ollama/ prefix to be stripped, got '%s'", body.Model)
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		fmt.Fprintln(w, `{"model":"llama3.2:latest","message":{"role":"assistant","content":"Hello"},"done":false}`)
+		fmt.Fprintln(w, `{"model":"llama3.2:latest","message":{"role":"assistant","content":" there"},"done":false}`)
+		fmt.Fprintln(w, `{"model":"llama3.2:latest","message":{"role":"assistant","content":""},"done":true,"prompt_eval_count":26,"eval_count":4}`)
+	}))
+	defer server.Close()
+
+	// the embeddings endpoint from the OLLAMA_URL setting is reduced to the base URL
+	p := NewOllamaProvider(Config{URL: server.URL + "/v1/embeddings"})
+
+	var text strings.Builder
+	usage, err := p.StreamCompletion(context.Background(), CompletionRequest{
+		Model:    "ollama/llama3.2:latest",
+		Messages: []Message{{Role: "user", Content: "Hi"}},
+	}, func(delta string) error {
+		text.WriteString(delta)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamCompletion failed: %v", err)
+	}
+
+	if text.String() != "Hello there" {
+		t.Errorf("Expected 'Hello there', got '%s'", text.String())
+	}
+	if usage.InputTokens != 26 || usage.OutputTokens != 4 {
+		t.Errorf("Expected usage 26/4, got %d/%d", usage.InputTokens, usage.OutputTokens)
+	}
+}
+
+func TestOllamaBaseURL(t *testing.T) {
+	tests := map[string]string{
+		"http://localhost:11434":                       "http://localhost:11434",
+		"http://localhost:11434/":                      "http://localhost:11434",
+		"http://localhost:11434/api/embeddings":        "http://localhost:11434",
+		"http://localhost:11434/v1/embeddings":         "http://localhost:11434",
+		"https://host/ollama":                          "https://host/ollama",
+		"https://host/ollama/api/embed":                "https://host/ollama",
+		"https://host/api/ollama/api/embeddings?x=1":   "https://host/api/ollama",
+		"https://host/tenants/a/ollama/v1/embeddings/": "https://host/tenants/a/ollama",
+	}
+	for raw, want := range tests {
+		if got := OllamaBaseURL(raw); got != want {
+			t.Errorf("Expected %s for %s, got %s", want, raw, got)
+		}
+	}
+}
+
+func TestOllamaProvider_StreamError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"error":"model 'llama9' not found"}`)
+	}))
+	defer server.Close()
+
+	p := NewOllamaProvider(Config{URL: server.URL})
+
+	_, err := p.StreamCompletion(context.Background(), CompletionRequest{Model: "llama9"}, func(string) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestOllamaProvider_ListModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/tags" {
+			t.Errorf("Expected /api/tags, got %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"models":[{"name":"llama3.2:latest","size":2019393189},{"name":"nomic-embed-text:latest","size":274302450}]}`)
+	}))
+	defer server.Close()
+
+	p := NewOllamaProvider(Config{URL: server.URL})
+
+	models, err := p.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels failed: %v", err)
+	}
+
+	if len(models) != 2 || models[0].ID != "llama3.2:latest" || models[1].ID != "nomic-embed-text:latest" {
+		t.Errorf("Unexpected models %v", models)
+	}
+}
+
+func TestOllamaGenerationOptions(t *testing.T) {
+	if body := toOllamaRequest(CompletionRequest{Model: "ollama/llama3"}, true); body.Options != nil || body.Think || body.Model != "llama3" {
+		t.Errorf("Expected no options, got %+v", body)
+	}
+
+	seed := int64(3)
+	body := toOllamaRequest(CompletionRequest{Options: GenerationOptions{MaxTokens: 64, Seed: &seed, ReasoningEffort: ReasoningLow}}, false)
+	if body.Options == nil || body.Options.NumPredict != 64 || *body.Options.Seed != 3 || !body.Think {
+		t.Errorf("Unexpected request %+v", body)
+	}
+}
@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+const openAIChatCompletionsPath = "/chat/completions"
+
+// OpenAIProvider talks to the OpenAI chat completions API, or anything
+// compatible with it (LiteLLM, OpenRouter, vLLM ...)
+type OpenAIProvider struct {
+	url     string
+	apiKey  string
+	headers map[string]string
+	client  *http.Client
+}
+
+func NewOpenAIProvider(cfg Config) *OpenAIProvider {
+	url := strings.TrimRight(cfg.URL, "/")
+	if !strings.HasSuffix(url, openAIChatCompletionsPath) {
+		url += openAIChatCompletionsPath
+	}
+
+	return &OpenAIProvider{
+		url:     url,
+		apiKey:  cfg.APIKey,
+		headers: cfg.Headers,
+		client:  httpClient(cfg),
+	}
+}
+
+type openAIRequest struct {
+	Model               string               `json:"model"`
+	Messages            []Message            `json:"messages"`
+	Stream              bool                 `json:"stream"`
+	StreamOptions       *openAIStreamOptions `json:"stream_options,omitempty"`
+	Temperature         *float64             `json:"temperature,omitempty"`
+	TopP                *float64             `json:"top_p,omitempty"`
+	MaxCompletionTokens int                  `json:"max_completion_tokens,omitempty"`
+	Stop                []string             `json:"stop,omitempty"`
+	Seed                *int64               `json:"seed,omitempty"`
+	ReasoningEffort     string               `json:"reasoning_effort,omitempty"`
+}
+
+// toOpenAIRequest maps the generation options to the chat completions fields of the same name, max_tokens goes
+// into max_completion_tokens which reasoning models require
+func toOpenAIRequest(req CompletionRequest, stream bool) openAIRequest {
+	body := openAIRequest{
+		Model:               req.Model,
+		Messages:            req.Messages,
+		Stream:              stream,
+		Temperature:         req.Options.Temperature,
+		TopP:                req.Options.TopP,
+		MaxCompletionTokens: req.Options.MaxTokens,
+		Stop:                req.Options.Stop,
+		Seed:                req.Options.Seed,
+		ReasoningEffort:     req.Options.ReasoningEffort,
+	}
+	if stream {
+		body.StreamOptions = &openAIStreamOptions{IncludeUsage: true}
+	}
+	return body
+}
+
+type openAIStreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type openAIUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
+type openAIStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *openAIUsage `json:"usage"`
+}
+
+type openAIResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+	Usage *openAIUsage `json:"usage"`
+}
+
+func (p *OpenAIProvider) StreamCompletion(ctx context.Context, req CompletionRequest, onDelta func(delta string) error) (Usage, error) {
+	var usage Usage
+
+	resp, err := p.post(ctx, toOpenAIRequest(req, true))
+	if err != nil {
+		return usage, err
+	}
+	defer resp.Body.Close()
+
+	err = readSSE(resp.Body, func(ev sseEvent) error {
+		var chunk openAIStreamChunk
+		if err := json.Unmarshal([]byte(ev.Data), &chunk); err != nil {
+			log.Printf("Failed to parse chunk: %v", err)
+			return nil
+		}
+
+		if chunk.Usage != nil {
+			usage.InputTokens = chunk.Usage.PromptTokens
+			usage.OutputTokens = chunk.Usage.CompletionTokens
+		}
+
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			return nil
+		}
+		return onDelta(chunk.Choices[0].Delta.Content)
+	})
+	if err != nil {
+		return usage, fmt.Errorf("error reading stream: %w", err)
+	}
+
+	return usage, nil
+}
+
+func (p *OpenAIProvider) Completion(ctx context.Context, req CompletionRequest) (*CompletionResponse, error) {
+	resp, err := p.post(ctx, toOpenAIRequest(req, false))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var openAIResp openAIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&openAIResp); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAI response: %w", err)
+	}
+
+	if len(openAIResp.Choices) == 0 {
+		return nil, fmt.Errorf("no choices returned from OpenAI")
+	}
+
+	result := &CompletionResponse{Content: openAIResp.Choices[0].Message.Content}
+	if openAIResp.Usage != nil {
+		result.Usage = Usage{
+			InputTokens:  openAIResp.Usage.PromptTokens,
+			OutputTokens: openAIResp.Usage.CompletionTokens,
+		}
+	}
+	return result, nil
+}
+
+type openAIModelList struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+// ListModels calls GET /models next to /chat/completions
+func (p *OpenAIProvider) ListModels(ctx context.Context) ([]ModelInfo, error) {
+	var list openAIModelList
+	url := strings.TrimSuffix(p.url, openAIChatCompletionsPath) + "/models"
+	err := getJSON(ctx, p.client, url, "OpenAI", func(req *http.Request) {
+		if p.apiKey != "" {
+			req.Header.Set("Authorization", "Bearer "+p.apiKey)
+		}
+		setHeaders(req, p.headers)
+	}, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]ModelInfo, 0, len(list.Data))
+	for _, m := range list.Data {
+		models = append(models, ModelInfo{ID: m.ID, Name: m.ID})
+	}
+	return models, nil
+}
+
+// post sends the request and returns the response only if the status is 200 OK
+func (p *OpenAIProvider) post(ctx context.Context, body openAIRequest) (*http.Response, error) {
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+	setHeaders(httpReq, p.headers)
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("OpenAI request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("OpenAI API error: %d - %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpenAIProvider_StreamCompletion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("Expected path /v1/chat/completions, got %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("Expected bearer token, got '%s'", got)
+		}
+
+		var body openAIRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if !body.Stream || body.StreamOptions == nil || !body.StreamOptions.IncludeUsage {
+			t.Errorf("Expected streaming request with usage, got %+v", body)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"role\":\"assistant\"}}]}\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"Hello\"}}]}\n\n")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\" world\"}}]}\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[],\"usage\":{\"prompt_tokens\":12,\"completion_tokens\":3}}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	p := NewOpenAIProvider(Config{URL: server.URL + "/v1", APIKey: "test-key"})
+
+	var text strings.Builder
+	usage, err := p.StreamCompletion(context.Background(), CompletionRequest{
+		Model:    "gpt-4o",
+		Messages: []Message{{Role: "user", Content: "Hi"}},
+	}, func(delta string) error {
+		text.WriteString(delta)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamCompletion failed: %v", err)
+	}
+
+	if text.String() != "Hello world" {
+		t.Errorf("Expected 'Hello world', got '%s'", text.String())
+	}
+	if usage.InputTokens != 12 || usage.OutputTokens != 3 {
+		t.Errorf("Expected usage 12/3, got %d/%d", usage.InputTokens, usage.OutputTokens)
+	}
+}
+
+func TestOpenAIProvider_Completion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"choices":[{"message":{"content":"Trip Planning"}}],"usage":{"prompt_tokens":20,"completion_tokens":2}}`)
+	}))
+	defer server.Close()
+
+	// a full endpoint URL is used as is
+	p := NewOpenAIProvider(Config{URL: server.URL + "/chat/completions", APIKey: "test-key"})
+
+	resp, err := p.Completion(context.Background(), CompletionRequest{
+		Model:    "gpt-4o",
+		Messages: []Message{{Role: "user", Content: "Name this chat"}},
+	})
+	if err != nil {
+		t.Fatalf("Completion failed: %v", err)
+	}
+
+	if resp.Content != "Trip Planning" {
+		t.Errorf("Expected 'Trip Planning', got '%s'", resp.Content)
+	}
+	if resp.Usage.InputTokens != 20 || resp.Usage.OutputTokens != 2 {
+		t.Errorf("Expected usage 20/2, got %d/%d", resp.Usage.InputTokens, resp.Usage.OutputTokens)
+	}
+}
+
+func TestOpenAIProvider_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"invalid key"}`, http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	p := NewOpenAIProvider(Config{URL: server.URL, APIKey: "bad"})
+
+	_, err := p.StreamCompletion(context.Background(), CompletionRequest{Model: "gpt-4o"}, func(string) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("Expected 401 error, got %v", err)
+	}
+}
+
+func TestOpenAIProvider_ListModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" {
+			t.Errorf("Expected /v1/models, got %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("Expected bearer token, got '%s'", got)
+		}
+		fmt.Fprint(w, `{"object":"list","data":[{"id":"gpt-4o","object":"model"},{"id":"gpt-4.1","object":"model"}]}`)
+	}))
+	defer server.Close()
+
+	p := NewOpenAIProvider(Config{URL: server.URL + "/v1", APIKey: "test-key"})
+
+	models, err := p.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels failed: %v", err)
+	}
+
+	if len(models) != 2 || models[0].ID != "gpt-4o" || models[1].ID != "gpt-4.1" {
+		t.Errorf("Unexpected models %+v", models)
+	}
+}
+
+func TestOpenAIGenerationOptions(t *testing.T) {
+	topP, seed := 0.9, int64(42)
+	body := toOpenAIRequest(CompletionRequest{
+		Model:   "o3",
+		Options: GenerationOptions{TopP: &topP, MaxTokens: 500, Stop: []string{"\n\n"}, Seed: &seed, ReasoningEffort: ReasoningLow},
+	}, false)
+	if *body.TopP != 0.9 || body.MaxCompletionTokens != 500 || body.Stop[0] != "\n\n" || *body.Seed != 42 || body.ReasoningEffort != "low" {
+		t.Errorf("Unexpected request %+v", body)
+	}
+	if body.StreamOptions != nil || body.Temperature != nil {
+		t.Errorf("Expected no stream options and no temperature, got %+v", body)
+	}
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Provider types, a model's `provider` column in model_metadata resolves to one of these
+const (
+	TypeOpenAI    = "openai"
+	TypeAnthropic = "anthropic"
+	TypeGemini    = "gemini"
+	TypeOllama    = "ollama"
+)
+
+// Message is a single turn of the conversation sent to the model
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// CompletionRequest is the provider independent input for a completion
+type CompletionRequest struct {
+	Model    string
+	Messages []Message
+	Options  GenerationOptions
+}
+
+// Reasoning efforts, how much a reasoning model thinks before it answers
+const (
+	ReasoningLow    = "low"
+	ReasoningMedium = "medium"
+	ReasoningHigh   = "high"
+)
+
+// reasoningBudgets are the thinking token budgets of the providers that take a budget instead of an effort
+var reasoningBudgets = map[string]int{
+	ReasoningLow:    1024,
+	ReasoningMedium: 4096,
+	ReasoningHigh:   16384,
+}
+
+// GenerationOptions tune how a reply is generated, unset options leave the provider's defaults. Each provider
+// translates them into its own request fields.
+type GenerationOptions struct {
+	Temperature *float64 `json:"temperature,omitempty"`
+	TopP        *float64 `json:"top_p,omitempty"`
+	MaxTokens   int      `json:"max_tokens,omitempty"`
+	Stop        []string `json:"stop,omitempty"`
+	Seed        *int64   `json:"seed,omitempty"`
+	// ReasoningEffort is one of ReasoningLow, ReasoningMedium and ReasoningHigh
+	ReasoningEffort string `json:"reasoning_effort,omitempty"`
+}
+
+// Usage holds the token counts reported by the provider for a single completion
+type Usage struct {
+	InputTokens  int
+	OutputTokens int
+}
+
+// CompletionResponse is the result of a non-streaming completion
+type CompletionResponse struct {
+	Content string
+	Usage   Usage
+}
+
+// LLMProvider abstracts an LLM API, each implementation translates the
+// provider independent request into the wire format of one vendor
+type LLMProvider interface {
+	// StreamCompletion streams the reply, onDelta is called for every text fragment as it arrives.
+	// Returning an error from onDelta aborts the stream.
+	StreamCompletion(ctx context.Context, req CompletionRequest, onDelta func(delta string) error) (Usage, error)
+
+	// Completion waits for the full reply
+	Completion(ctx context.Context, req CompletionRequest) (*CompletionResponse, error)
+}
+
+// ModelInfo describes a model as reported by the provider's model list endpoint
+type ModelInfo struct {
+	ID            string
+	Name          string
+	ContextWindow int // zero when the provider does not report it
+}
+
+// ModelLister is implemented by providers that can list the models they serve
+type ModelLister interface {
+	ListModels(ctx context.Context) ([]ModelInfo, error)
+}
+
+// OptionsChecker is implemented by providers that take only some of the generation options, or narrower ranges
+type OptionsChecker interface {
+	CheckOptions(opts GenerationOptions) error
+}
+
+// Config describes how to reach a provider
+type Config struct {
+	Type    string
+	URL     string
+	APIKey  string
+	Headers map[string]string // sent with every request, after the provider's own headers
+	Timeout time.Duration     // whole request including the streamed body, zero means no timeout
+	Client  *http.Client
+}
+
+// New creates the LLMProvider implementation for the given config
+func New(cfg Config) (LLMProvider, error) {
+	switch cfg.Type {
+	case TypeOpenAI:
+		return NewOpenAIProvider(cfg), nil
+	case TypeAnthropic:
+		return NewAnthropicProvider(cfg), nil
+	case TypeGemini:
+		return NewGeminiProvider(cfg), nil
+	case TypeOllama:
+		return NewOllamaProvider(cfg), nil
+	default:
+		return nil, fmt.Errorf("unsupported provider type: %s", cfg.Type)
+	}
+}
+
+func httpClient(cfg Config) *http.Client {
+	if cfg.Client != nil {
+		return cfg.Client
+	}
+	if cfg.Timeout > 0 {
+		return &http.Client{Timeout: cfg.Timeout}
+	}
+	return http.DefaultClient
+}
+
+func setHeaders(req *http.Request, headers map[string]string) {
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+}
+
+// getJSON sends a GET request and decodes the JSON response into out.
+// prepare sets the auth headers, name is the provider name used in error messages.
+func getJSON(ctx context.Context, client *http.Client, url string, name string, prepare func(*http.Request), out any) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	prepare(httpReq)
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s API error: %d - %s", name, resp.StatusCode, string(bodyBytes))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", name, err)
+	}
+	return nil
+}
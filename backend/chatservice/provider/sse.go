@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// maxSSELineSize is the largest single SSE line we accept, some providers send big JSON payloads in one line
+const maxSSELineSize = 1024 * 1024
+
+// sseEvent is a single server-sent event, multiple data lines are joined by a newline
+type sseEvent struct {
+	Event string
+	Data  string
+}
+
+// readSSE parses a text/event-stream body and calls fn for each event.
+// Reading stops at EOF, at an OpenAI style "[DONE]" sentinel, or when fn returns an error.
+func readSSE(r io.Reader, fn func(sseEvent) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSSELineSize)
+
+	var event string
+	var data []string
+
+	dispatch := func() (bool, error) {
+		if len(data) == 0 {
+			event = ""
+			return false, nil
+		}
+		ev := sseEvent{Event: event, Data: strings.Join(data, "\n")}
+		event, data = "", nil
+		if ev.Data == "[DONE]" {
+			return true, nil
+		}
+		return false, fn(ev)
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if line == "" {
+			done, err := dispatch()
+			if err != nil || done {
+				return err
+			}
+			continue
+		}
+
+		// comment line, used as keep-alive by some providers
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	// flush the last event if the stream did not end with a blank line
+	_, err := dispatch()
+	return err
+}
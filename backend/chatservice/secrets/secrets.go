@@ -0,0 +1,321 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+/*
+Envelope encryption for secrets stored in the database (API keys in the settings blob).
+
+Every value gets its own random data key, the value is sealed with the data key and the
+data key is sealed with the master key. Only the master key lives outside the database.
+
+Stored format:
+
+	enc:v1:<master key id>:<base64 sealed data key>:<base64 sealed value>
+
+The master key id lets a keyring hold older master keys so values written before a
+rotation can still be read until RotateKeys re-encrypts them.
+*/
+
+const (
+	// MasterKeyEnv holds the base64 encoded 32 byte master key
+	MasterKeyEnv = "SECRETS_MASTER_KEY"
+	// MasterKeyFileEnv points to a file holding the base64 encoded master key
+	MasterKeyFileEnv = "SECRETS_MASTER_KEY_FILE"
+	// PreviousMasterKeysEnv holds comma separated base64 master keys that are only used for decryption, set during rotation
+	PreviousMasterKeysEnv = "SECRETS_PREVIOUS_MASTER_KEYS"
+
+	// DefaultMasterKeyFile is created in the working directory (next to the default db.sqlite) when no master key is configured
+	DefaultMasterKeyFile = "master.key"
+
+	encryptedPrefix = "enc:v1:"
+	masterKeySize   = 32
+)
+
+var ErrUnknownMasterKey = errors.New("value was encrypted with an unknown master key")
+
+type masterKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// Keyring encrypts with the current master key and decrypts with the current or any previous one
+type Keyring struct {
+	current  masterKey
+	previous []masterKey
+}
+
+// NewKeyring builds a keyring from raw 32 byte master keys
+func NewKeyring(current []byte, previous ...[]byte) (*Keyring, error) {
+	cur, err := newMasterKey(current)
+	if err != nil {
+		return nil, err
+	}
+
+	kr := &Keyring{current: cur}
+	for _, raw := range previous {
+		prev, err := newMasterKey(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid previous master key: %w", err)
+		}
+		kr.previous = append(kr.previous, prev)
+	}
+	return kr, nil
+}
+
+// LoadKeyring reads the master key from SECRETS_MASTER_KEY, or from the file named by
+// SECRETS_MASTER_KEY_FILE. When neither is set the key is read from DefaultMasterKeyFile,
+// which is generated on first use so the desktop app works without any configuration.
+func LoadKeyring() (*Keyring, error) {
+	current, err := loadMasterKey()
+	if err != nil {
+		return nil, err
+	}
+
+	var previous [][]byte
+	for _, encoded := range strings.Split(os.Getenv(PreviousMasterKeysEnv), ",") {
+		encoded = strings.TrimSpace(encoded)
+		if encoded == "" {
+			continue
+		}
+		raw, err := decodeMasterKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", PreviousMasterKeysEnv, err)
+		}
+		previous = append(previous, raw)
+	}
+
+	return NewKeyring(current, previous...)
+}
+
+func loadMasterKey() ([]byte, error) {
+	if encoded := os.Getenv(MasterKeyEnv); encoded != "" {
+		raw, err := decodeMasterKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", MasterKeyEnv, err)
+		}
+		return raw, nil
+	}
+
+	path := os.Getenv(MasterKeyFileEnv)
+	if path != "" {
+		return readMasterKeyFile(path)
+	}
+
+	path = DefaultMasterKeyFile
+	raw, err := readMasterKeyFile(path)
+	if err == nil {
+		return raw, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	raw, err = GenerateMasterKey()
+	if err != nil {
+		return nil, err
+	}
+	// O_EXCL so two processes starting together don't overwrite each other's key
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create master key file %s: %w", path, err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(base64.StdEncoding.EncodeToString(raw) + "\n"); err != nil {
+		return nil, fmt.Errorf("failed to write master key file %s: %w", path, err)
+	}
+	slog.Warn("No master key configured, generated a new one, back it up together with the database", "path", path)
+	return raw, nil
+}
+
+func readMasterKeyFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read master key file %s: %w", path, err)
+	}
+	raw, err := decodeMasterKey(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid master key in %s: %w", path, err)
+	}
+	return raw, nil
+}
+
+func decodeMasterKey(encoded string) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("master key is not valid base64: %w", err)
+	}
+	return raw, nil
+}
+
+// GenerateMasterKey returns a new random master key
+func GenerateMasterKey() ([]byte, error) {
+	raw := make([]byte, masterKeySize)
+	if _, err := io.ReadFull(rand.Reader, raw); err != nil {
+		return nil, fmt.Errorf("failed to generate master key: %w", err)
+	}
+	return raw, nil
+}
+
+func newMasterKey(raw []byte) (masterKey, error) {
+	if len(raw) != masterKeySize {
+		return masterKey{}, fmt.Errorf("master key must be %d bytes, got %d", masterKeySize, len(raw))
+	}
+	aead, err := newAEAD(raw)
+	if err != nil {
+		return masterKey{}, err
+	}
+	sum := sha256.Sum256(raw)
+	return masterKey{id: hex.EncodeToString(sum[:4]), aead: aead}, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal returns nonce|ciphertext
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, sealed []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
+
+// IsEncrypted reports whether the value was produced by Encrypt
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// Encrypt seals the value with a fresh data key. Empty values stay empty.
+func (k *Keyring) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	dataKey := make([]byte, masterKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	sealedValue, err := seal(dataAEAD, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	sealedKey, err := seal(k.current.aead, dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	return encryptedPrefix + k.current.id + ":" +
+		base64.StdEncoding.EncodeToString(sealedKey) + ":" +
+		base64.StdEncoding.EncodeToString(sealedValue), nil
+}
+
+// Decrypt opens a value produced by Encrypt. Values without the encrypted prefix were
+// stored before encryption was introduced and are returned unchanged.
+func (k *Keyring) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, encryptedPrefix), ":")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+
+	master, ok := k.find(parts[0])
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownMasterKey, parts[0])
+	}
+
+	sealedKey, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("malformed data key: %w", err)
+	}
+	sealedValue, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("malformed ciphertext: %w", err)
+	}
+
+	dataKey, err := open(master.aead, sealedKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(dataAEAD, sealedValue)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation reports whether the value is plaintext or sealed with a previous master key
+func (k *Keyring) NeedsRotation(value string) bool {
+	if value == "" {
+		return false
+	}
+	return !strings.HasPrefix(value, encryptedPrefix+k.current.id+":")
+}
+
+func (k *Keyring) find(id string) (masterKey, bool) {
+	if k.current.id == id {
+		return k.current, true
+	}
+	for _, prev := range k.previous {
+		if prev.id == id {
+			return prev, true
+		}
+	}
+	return masterKey{}, false
+}
+
+// MaskPrefix starts every masked secret, a value sent back with this prefix means "unchanged"
+const MaskPrefix = "********"
+
+// Mask hides a secret for display, only the last 4 characters of long secrets are kept
+func Mask(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	if len(secret) <= 12 {
+		return MaskPrefix
+	}
+	return MaskPrefix + secret[len(secret)-4:]
+}
+
+// IsMasked reports whether the value is the output of Mask
+func IsMasked(value string) bool {
+	return strings.HasPrefix(value, MaskPrefix)
+}
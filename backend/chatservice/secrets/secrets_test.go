@@ -0,0 +1,105 @@
+package secrets
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newTestKeyring(t *testing.T, previous ...[]byte) (*Keyring, []byte) {
+	t.Helper()
+	raw, err := GenerateMasterKey()
+	if err != nil {
+		t.Fatalf("GenerateMasterKey failed: %v", err)
+	}
+	kr, err := NewKeyring(raw, previous...)
+	if err != nil {
+		t.Fatalf("NewKeyring failed: %v", err)
+	}
+	return kr, raw
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	kr, _ := newTestKeyring(t)
+
+	encrypted, err := kr.Encrypt("sk-secret-value")
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	if !IsEncrypted(encrypted) || strings.Contains(encrypted, "sk-secret-value") {
+		t.Fatalf("Expected an encrypted value, got '%s'", encrypted)
+	}
+
+	again, _ := kr.Encrypt("sk-secret-value")
+	if again == encrypted {
+		t.Errorf("Expected a fresh data key and nonce per value")
+	}
+
+	decrypted, err := kr.Decrypt(encrypted)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if decrypted != "sk-secret-value" {
+		t.Errorf("Expected 'sk-secret-value', got '%s'", decrypted)
+	}
+
+	// values stored before encryption was introduced are passed through
+	plain, err := kr.Decrypt("sk-plaintext")
+	if err != nil || plain != "sk-plaintext" {
+		t.Errorf("Expected plaintext passthrough, got '%s' (%v)", plain, err)
+	}
+}
+
+func TestDecryptTampered(t *testing.T) {
+	kr, _ := newTestKeyring(t)
+
+	encrypted, _ := kr.Encrypt("sk-secret-value")
+	tampered := encrypted[:len(encrypted)-4] + "AAAA"
+
+	if _, err := kr.Decrypt(tampered); err == nil {
+		t.Errorf("Expected tampered value to fail authentication")
+	}
+}
+
+func TestRotation(t *testing.T) {
+	oldKeyring, oldRaw := newTestKeyring(t)
+	encrypted, _ := oldKeyring.Encrypt("sk-secret-value")
+
+	newKeyring, _ := newTestKeyring(t)
+	if _, err := newKeyring.Decrypt(encrypted); !errors.Is(err, ErrUnknownMasterKey) {
+		t.Errorf("Expected ErrUnknownMasterKey without the previous key, got %v", err)
+	}
+
+	rotating, _ := newTestKeyring(t, oldRaw)
+	if !rotating.NeedsRotation(encrypted) {
+		t.Errorf("Expected value sealed with the previous key to need rotation")
+	}
+
+	decrypted, err := rotating.Decrypt(encrypted)
+	if err != nil || decrypted != "sk-secret-value" {
+		t.Fatalf("Expected previous key to decrypt, got '%s' (%v)", decrypted, err)
+	}
+
+	reencrypted, _ := rotating.Encrypt(decrypted)
+	if rotating.NeedsRotation(reencrypted) {
+		t.Errorf("Expected re-encrypted value to use the current key")
+	}
+	if !rotating.NeedsRotation("sk-plaintext") {
+		t.Errorf("Expected plaintext value to need rotation")
+	}
+}
+
+func TestMask(t *testing.T) {
+	if got := Mask("sk-proj-1234567890abcd"); got != MaskPrefix+"abcd" {
+		t.Errorf("Expected last 4 characters to be kept, got '%s'", got)
+	}
+	if got := Mask("short"); got != MaskPrefix {
+		t.Errorf("Expected short secrets to be fully masked, got '%s'", got)
+	}
+	if got := Mask(""); got != "" {
+		t.Errorf("Expected empty secret to stay empty, got '%s'", got)
+	}
+	if !IsMasked(Mask("sk-proj-1234567890abcd")) {
+		t.Errorf("Expected masked value to be recognised")
+	}
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrChatNotFound    = errors.New("chat not found")
+	ErrMessageNotFound = errors.New("message not found")
+	// ErrInvalidAlternative is returned when regenerating a user message or editing an assistant message
+	ErrInvalidAlternative = errors.New("invalid message alternative")
+)
+
+/*
+Regenerated and edited messages don't replace the old ones, the new version is an alternative: a
+chat_list row branched from the chat being shown (parent_chat_id) at the message before the replaced
+one (parent_message_id). The chat in the chat list points to the alternative currently shown with
+active_chat_id, and all versions of a turn share the id of the message before it.
+*/
+
+// resolveChat returns the chat row (nil for chats not in chat_list), the listed chat the client's chat
+// belongs to and the chat new messages go to: the listed chat's active alternative, or the alternative
+// the client asked for. Chats of other users the user can't read are not found.
+func (s *ChatService) resolveChat(userID string, chatId string) (*dao.ChatRow, string, string, error) {
+	chat, err := s.dao.GetChat(userID, chatId)
+	if errors.Is(err, sql.ErrNoRows) {
+		exists, err := s.dao.ChatExists(chatId)
+		if err != nil {
+			return nil, "", "", fmt.Errorf("failed to fetch chat: %w", err)
+		}
+		if exists {
+			return nil, "", "", fmt.Errorf("%w: %s", ErrChatNotFound, chatId)
+		}
+		return nil, chatId, chatId, nil
+	} else if err != nil {
+		return nil, "", "", fmt.Errorf("failed to fetch chat: %w", err)
+	}
+	if chat.Deleted {
+		return nil, "", "", fmt.Errorf("%w: %s is in the trash", ErrChatNotFound, chatId)
+	}
+
+	if chat.AlternativeOf != "" {
+		return chat, chat.AlternativeOf, chat.ChatID, nil
+	}
+	if chat.ActiveChatID != "" {
+		return chat, chat.ChatID, chat.ActiveChatID, nil
+	}
+	return chat, chat.ChatID, chat.ChatID, nil
+}
+
+// replacement is a message of a chat's active path that gets a new version
+type replacement struct {
+	chat     *dao.ChatRow
+	listedID string
+	activeID string
+	path     []dao.ChatMessageRow // active path of the chat
+	index    int                  // of the replaced message in path
+}
+
+// parentMessageID is the id all versions of the replaced message share
+func (r *replacement) parentMessageID() string {
+	if r.index == 0 {
+		return dao.NoParentMessage
+	}
+	return r.path[r.index-1].Id
+}
+
+// history returns copies of the messages before the replaced one
+func (r *replacement) history() []dao.ChatMessageRow {
+	return append([]dao.ChatMessageRow(nil), r.path[:r.index]...)
+}
+
+// findReplacement finds the message to replace in the chat's active path, it must have the given role
+func (s *ChatService) findReplacement(userID string, chatId string, messageId string, role string) (*replacement, error) {
+	if chatId == "" || messageId == "" {
+		return nil, fmt.Errorf("%w: chat and message ids are required", ErrInvalidAlternative)
+	}
+	if err := s.requireChatWrite(userID, chatId); err != nil {
+		return nil, err
+	}
+
+	chat, listedID, activeID, err := s.resolveChat(userID, chatId)
+	if err != nil {
+		return nil, err
+	}
+	if chat == nil {
+		return nil, fmt.Errorf("%w: %s", ErrChatNotFound, chatId)
+	}
+
+	path, err := s.dao.GetChatMessages(userID, activeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch message history: %w", err)
+	}
+	for i := range path {
+		if path[i].Id != messageId {
+			continue
+		}
+		if path[i].Role != role {
+			return nil, fmt.Errorf("%w: message %s is not a %s message", ErrInvalidAlternative, messageId, role)
+		}
+		return &replacement{chat: chat, listedID: listedID, activeID: activeID, path: path, index: i}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrMessageNotFound, messageId)
+}
+
+// createAlternative branches the new version off the active path and shows it
+func (s *ChatService) createAlternative(userID string, r *replacement) (string, error) {
+	alternative := dao.ChatRow{
+		ChatID:          uuid.New().String(),
+		Name:            r.chat.Name,
+		ProjectID:       r.chat.ProjectID,
+		ParentChatID:    r.activeID,
+		ParentMessageID: r.parentMessageID(),
+		AlternativeOf:   r.listedID,
+	}
+	if err := s.dao.CreateAlternative(userID, alternative); err != nil {
+		return "", fmt.Errorf("failed to create alternative: %w", err)
+	}
+	return alternative.ChatID, nil
+}
+
+// RegenerateMessage generates a new version of an assistant message, model and unset options default to those of
+// the replaced message
+func (s *ChatService) RegenerateMessage(ctx context.Context, userID string, chatId string, messageId string, model string, options *pb.GenerationOptions, stream func(*pb.ChatResponse) error) error {
+	r, err := s.findReplacement(userID, chatId, messageId, "assistant")
+	if err != nil {
+		return err
+	}
+	if r.index == 0 {
+		return fmt.Errorf("%w: message %s does not reply to anything", ErrInvalidAlternative, messageId)
+	}
+
+	if model == "" {
+		model = r.path[r.index].Model
+	}
+	if model == "" {
+		return fmt.Errorf("model is required")
+	}
+	model, err = s.checkBudgets(ctx, userID, r.chat.ProjectID, model)
+	if err != nil {
+		return err
+	}
+	llm, modelInfo, err := s.providerForModel(model)
+	if err != nil {
+		return err
+	}
+	opts, err := s.generationOptions(r.listedID, options, r.path[r.index].GenerationOptions, llm, modelInfo)
+	if err != nil {
+		return err
+	}
+
+	history := r.history()
+	if last := &history[len(history)-1]; last.Role == "user" {
+		last.Content = s.withDocuments(ctx, userID, r.chat.ProjectID, last.Content)
+	}
+	sent, err := s.prepareReply(r.listedID, history, modelInfo)
+	if err != nil {
+		return err
+	}
+
+	gen, err := s.generations.start(userID, r.listedID)
+	if err != nil {
+		return err
+	}
+
+	alternativeID, err := s.createAlternative(userID, r)
+	if err != nil {
+		s.generations.finish(r.listedID, gen, nil)
+		return err
+	}
+
+	// an alternative without a reply would hide the replaced one, so the previous version is shown again
+	previousActiveID := r.activeID
+	if previousActiveID == r.listedID {
+		previousActiveID = ""
+	}
+	return s.streamReply(ctx, gen, r.listedID, userID, alternativeID, llm, modelInfo, opts, sent, stream, func(messageId int64) {
+		if messageId != 0 {
+			return
+		}
+		if err := s.dao.SetActiveChat(r.listedID, previousActiveID); err != nil {
+			slog.Warn("failed to restore the active alternative", "chat_id", r.listedID, "error", err)
+		}
+	})
+}
+
+// EditMessage replaces a user message with newText in a new alternative and generates the reply to it,
+// model and unset options default to those of the reply to the replaced message
+func (s *ChatService) EditMessage(ctx context.Context, userID string, chatId string, messageId string, newText string, model string, options *pb.GenerationOptions, stream func(*pb.ChatResponse) error) error {
+	if newText == "" {
+		return fmt.Errorf("%w: new text is required", ErrInvalidAlternative)
+	}
+	r, err := s.findReplacement(userID, chatId, messageId, "user")
+	if err != nil {
+		return err
+	}
+
+	var previousOptions string
+	if r.index+1 < len(r.path) {
+		previousOptions = r.path[r.index+1].GenerationOptions
+		if model == "" {
+			model = r.path[r.index+1].Model
+		}
+	}
+	if model == "" {
+		return fmt.Errorf("model is required")
+	}
+	model, err = s.checkBudgets(ctx, userID, r.chat.ProjectID, model)
+	if err != nil {
+		return err
+	}
+	llm, modelInfo, err := s.providerForModel(model)
+	if err != nil {
+		return err
+	}
+	opts, err := s.generationOptions(r.listedID, options, previousOptions, llm, modelInfo)
+	if err != nil {
+		return err
+	}
+
+	history := append(r.history(), dao.ChatMessageRow{Role: "user", Content: s.withDocuments(ctx, userID, r.chat.ProjectID, newText)})
+	sent, err := s.prepareReply(r.listedID, history, modelInfo)
+	if err != nil {
+		return err
+	}
+
+	gen, err := s.generations.start(userID, r.listedID)
+	if err != nil {
+		return err
+	}
+
+	alternativeID, err := s.createAlternative(userID, r)
+	if err == nil {
+		err = s.dao.AddChatMessage(userID, alternativeID, "user", newText)
+	}
+	if err != nil {
+		s.generations.finish(r.listedID, gen, nil)
+		return fmt.Errorf("failed to store edited message: %w", err)
+	}
+
+	return s.streamReply(ctx, gen, r.listedID, userID, alternativeID, llm, modelInfo, opts, sent, stream, nil)
+}
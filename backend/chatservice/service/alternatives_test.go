@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+)
+
+func TestGetHistoryActivePath(t *testing.T) {
+	s := &ChatService{dao: newMemoryDAO()}
+
+	resp, err := s.GetHistory(context.Background(), "alice", &pb.GetHistoryRequest{ChatId: "chat"})
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	history := resp.GetHistory()
+
+	wantIDs := []string{"1", "2", "3", "5"}
+	wantAlternatives := []int32{3, 1, 1, 2}
+	if len(history) != len(wantIDs) {
+		t.Fatalf("Expected %d messages, got %d", len(wantIDs), len(history))
+	}
+	for i, m := range history {
+		if m.MessageId != wantIDs[i] || m.Alternatives != wantAlternatives[i] {
+			t.Errorf("Expected message %s with %d alternatives, got %s with %d", wantIDs[i], wantAlternatives[i], m.MessageId, m.Alternatives)
+		}
+	}
+}
+
+func TestFindReplacement(t *testing.T) {
+	s := &ChatService{dao: newMemoryDAO()}
+
+	r, err := s.findReplacement("alice", "chat", "5", "assistant")
+	if err != nil {
+		t.Fatalf("findReplacement failed: %v", err)
+	}
+	if r.listedID != "chat" || r.activeID != "alternative" || r.parentMessageID() != "3" || len(r.history()) != 3 {
+		t.Errorf("Unexpected replacement %+v", r)
+	}
+
+	if _, err := s.findReplacement("alice", "chat", "4", "assistant"); !errors.Is(err, ErrMessageNotFound) {
+		t.Errorf("Expected a message off the active path to be not found, got %v", err)
+	}
+	if _, err := s.findReplacement("alice", "chat", "3", "assistant"); !errors.Is(err, ErrInvalidAlternative) {
+		t.Errorf("Expected ErrInvalidAlternative for regenerating a user message, got %v", err)
+	}
+	if r, err := s.findReplacement("alice", "alternative", "1", "user"); err != nil || r.parentMessageID() != dao.NoParentMessage {
+		t.Errorf("Expected the first message to have no parent, got %v", err)
+	}
+	if _, err := s.findReplacement("alice", "unknown", "1", "user"); !errors.Is(err, ErrChatNotFound) {
+		t.Errorf("Expected ErrChatNotFound, got %v", err)
+	}
+}
+
+func TestResolveChat(t *testing.T) {
+	s := &ChatService{dao: newMemoryDAO()}
+
+	if chat, listedID, activeID, err := s.resolveChat("alice", "chat"); err != nil || chat == nil || listedID != "chat" || activeID != "alternative" {
+		t.Errorf("Expected chat showing its alternative, got %v %s %s (%v)", chat, listedID, activeID, err)
+	}
+	if chat, listedID, activeID, err := s.resolveChat("mallory", "new"); err != nil || chat != nil || listedID != "new" || activeID != "new" {
+		t.Errorf("Expected a chat not in chat_list to be used as is, got %v %s %s (%v)", chat, listedID, activeID, err)
+	}
+	// a chat of another user isn't taken for a new chat
+	if _, _, _, err := s.resolveChat("mallory", "chat"); !errors.Is(err, ErrChatNotFound) {
+		t.Errorf("Expected ErrChatNotFound for a chat the user can't read, got %v", err)
+	}
+	if _, err := s.GetHistory(context.Background(), "mallory", &pb.GetHistoryRequest{ChatId: "chat"}); !errors.Is(err, ErrChatNotFound) {
+		t.Errorf("Expected ErrChatNotFound for the history of a chat the user can't read, got %v", err)
+	}
+}
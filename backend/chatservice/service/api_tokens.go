@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"sortedstartup/chatservice/auth"
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrApiTokenNotFound     = errors.New("API token not found")
+	ErrInvalidApiTokenInput = errors.New("invalid API token request")
+	ErrAdminTokenDenied     = errors.New("only instance admins can create admin tokens")
+)
+
+const maxApiTokenNameLength = 100
+
+var apiTokenScopes = map[pb.ApiTokenScope]auth.Scope{
+	pb.ApiTokenScope_API_TOKEN_SCOPE_READ_ONLY: auth.ScopeReadOnly,
+	pb.ApiTokenScope_API_TOKEN_SCOPE_CHAT:      auth.ScopeChat,
+	pb.ApiTokenScope_API_TOKEN_SCOPE_ADMIN:     auth.ScopeAdmin,
+}
+
+func toPBApiTokenScope(scope string) pb.ApiTokenScope {
+	for pbScope, s := range apiTokenScopes {
+		if string(s) == scope {
+			return pbScope
+		}
+	}
+	return pb.ApiTokenScope_API_TOKEN_SCOPE_UNSPECIFIED
+}
+
+func formatNullTime(t sql.NullTime) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.UTC().Format(time.RFC3339)
+}
+
+func toPBApiToken(t dao.ApiTokenRow) *pb.ApiToken {
+	return &pb.ApiToken{
+		Id:         t.ID,
+		Name:       t.Name,
+		Scope:      toPBApiTokenScope(t.Scope),
+		Prefix:     t.TokenPrefix,
+		CreatedAt:  t.CreatedAt.UTC().Format(time.RFC3339),
+		LastUsedAt: formatNullTime(t.LastUsedAt),
+		ExpiresAt:  formatNullTime(t.ExpiresAt),
+	}
+}
+
+// CreateApiToken returns the new token, it is only stored hashed and can't be shown again
+func (s *AuthService) CreateApiToken(ctx context.Context, userID string, req *pb.CreateApiTokenRequest) (string, *pb.ApiToken, error) {
+	name := strings.TrimSpace(req.GetName())
+	if name == "" || len(name) > maxApiTokenNameLength {
+		return "", nil, fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidApiTokenInput, maxApiTokenNameLength)
+	}
+	scope, ok := apiTokenScopes[req.GetScope()]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: scope is required", ErrInvalidApiTokenInput)
+	}
+	if scope == auth.ScopeAdmin {
+		isAdmin, err := s.IsAdmin(ctx, userID)
+		if err != nil {
+			return "", nil, err
+		}
+		if !isAdmin {
+			return "", nil, ErrAdminTokenDenied
+		}
+	}
+	if req.GetExpiresInDays() < 0 {
+		return "", nil, fmt.Errorf("%w: expires_in_days can't be negative", ErrInvalidApiTokenInput)
+	}
+
+	token, hash, prefix, err := auth.GenerateAPIToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := time.Now().UTC()
+	row := dao.ApiTokenRow{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Name:        name,
+		TokenHash:   hash,
+		TokenPrefix: prefix,
+		Scope:       string(scope),
+		CreatedAt:   now,
+	}
+	if days := req.GetExpiresInDays(); days > 0 {
+		row.ExpiresAt = sql.NullTime{Time: now.AddDate(0, 0, int(days)), Valid: true}
+	}
+
+	if err := s.dao.CreateApiToken(row); err != nil {
+		return "", nil, fmt.Errorf("failed to create API token: %w", err)
+	}
+	return token, toPBApiToken(row), nil
+}
+
+func (s *AuthService) ListApiTokens(ctx context.Context, userID string) ([]*pb.ApiToken, error) {
+	rows, err := s.dao.ListApiTokens(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API tokens: %w", err)
+	}
+
+	tokens := make([]*pb.ApiToken, 0, len(rows))
+	for i := range rows {
+		tokens = append(tokens, toPBApiToken(rows[i]))
+	}
+	return tokens, nil
+}
+
+func (s *AuthService) RevokeApiToken(ctx context.Context, userID string, tokenID string) error {
+	err := s.dao.DeleteApiToken(userID, tokenID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s", ErrApiTokenNotFound, tokenID)
+	} else if err != nil {
+		return fmt.Errorf("failed to revoke API token: %w", err)
+	}
+	return nil
+}
+
+// VerifyAPIToken implements auth.APITokenVerifier
+func (s *AuthService) VerifyAPIToken(ctx context.Context, token string) (string, auth.Scope, error) {
+	row, err := s.dao.GetApiTokenByHash(auth.HashAPIToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", "", auth.ErrInvalidToken
+	} else if err != nil {
+		return "", "", fmt.Errorf("failed to fetch API token: %w", err)
+	}
+
+	now := time.Now()
+	if row.ExpiresAt.Valid && !now.Before(row.ExpiresAt.Time) {
+		return "", "", auth.ErrTokenExpired
+	}
+
+	if err := s.dao.TouchApiToken(row.ID, now.UTC()); err != nil {
+		slog.Warn("failed to update API token last use", "token_id", row.ID, "error", err)
+	}
+	return row.UserID, auth.Scope(row.Scope), nil
+}
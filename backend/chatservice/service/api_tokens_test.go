@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"sortedstartup/chatservice/auth"
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+)
+
+// usersDAO keeps accounts and API tokens in memory
+type usersDAO struct {
+	dao.DAO
+	users  map[string]dao.UserRow
+	tokens []dao.ApiTokenRow
+}
+
+func (d *usersDAO) GetUserByID(userID string) (*dao.UserRow, error) {
+	user, ok := d.users[userID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &user, nil
+}
+
+func (d *usersDAO) CreateApiToken(token dao.ApiTokenRow) error {
+	d.tokens = append(d.tokens, token)
+	return nil
+}
+
+func TestCreateAdminApiToken(t *testing.T) {
+	a, err := auth.NewAuthenticator(auth.Config{Enabled: true, TokenTTL: time.Hour})
+	if err != nil {
+		t.Fatalf("NewAuthenticator failed: %v", err)
+	}
+	d := &usersDAO{users: map[string]dao.UserRow{
+		auth.LocalUserID: {ID: auth.LocalUserID, Username: "admin", IsAdmin: true},
+		"bob":            {ID: "bob", Username: "bob"},
+	}}
+	s := &AuthService{dao: d, authenticator: a}
+	ctx := context.Background()
+
+	admin := &pb.CreateApiTokenRequest{Name: "ci", Scope: pb.ApiTokenScope_API_TOKEN_SCOPE_ADMIN}
+	if _, _, err := s.CreateApiToken(ctx, "bob", admin); !errors.Is(err, ErrAdminTokenDenied) {
+		t.Errorf("Expected ErrAdminTokenDenied for a user who isn't an admin, got %v", err)
+	}
+	if _, _, err := s.CreateApiToken(ctx, "bob", &pb.CreateApiTokenRequest{Name: "ci", Scope: pb.ApiTokenScope_API_TOKEN_SCOPE_CHAT}); err != nil {
+		t.Errorf("Expected a chat token for a user who isn't an admin, got %v", err)
+	}
+	if _, _, err := s.CreateApiToken(ctx, auth.LocalUserID, admin); err != nil {
+		t.Errorf("Expected an admin token for the admin, got %v", err)
+	}
+	if len(d.tokens) != 2 || d.tokens[0].UserID != "bob" || d.tokens[1].Scope != string(auth.ScopeAdmin) {
+		t.Errorf("Expected the chat token of bob and the admin token of the admin, got %+v", d.tokens)
+	}
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"sortedstartup/chatservice/auth"
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrUserExists         = errors.New("username already taken")
+	ErrSignupDisabled     = errors.New("registration is disabled")
+	ErrInvalidUser        = errors.New("invalid user")
+)
+
+const (
+	minUsernameLength = 3
+	maxUsernameLength = 64
+
+	// localUsername is shown for the implicit user of single user mode
+	localUsername = "local"
+)
+
+type AuthService struct {
+	dao           dao.DAO
+	authenticator *auth.Authenticator
+
+	// registerMu serializes registrations so only one account can claim the local user's data
+	registerMu sync.Mutex
+	// dummyHash is checked for unknown usernames so a login takes as long as for an existing user
+	dummyHash string
+}
+
+func NewAuthService(daoFactory dao.DAOFactory, authenticator *auth.Authenticator) (*AuthService, error) {
+	daoInstance, err := daoFactory.CreateDAO()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize DAO: %v", err)
+	}
+
+	dummyHash, err := auth.HashPassword(uuid.New().String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthService{dao: daoInstance, authenticator: authenticator, dummyHash: dummyHash}, nil
+}
+
+// AuthEnabled reports whether requests need a token, otherwise they all run as the local user
+func (s *AuthService) AuthEnabled() bool {
+	return s.authenticator.Enabled()
+}
+
+func toPBUser(u *dao.UserRow) *pb.User {
+	return &pb.User{Id: u.ID, Username: u.Username, CreatedAt: u.CreatedAt, IsAdmin: u.IsAdmin}
+}
+
+// Register creates an account. The first account takes over the local user id so the chats and
+// projects created in single user mode stay with it and becomes the instance admin, further accounts
+// need AUTH_ALLOW_SIGNUP.
+func (s *AuthService) Register(ctx context.Context, username string, password string) (*pb.User, error) {
+	username = strings.TrimSpace(username)
+	if len(username) < minUsernameLength || len(username) > maxUsernameLength {
+		return nil, fmt.Errorf("%w: username must be %d to %d characters", ErrInvalidUser, minUsernameLength, maxUsernameLength)
+	}
+
+	passwordHash, err := auth.HashPassword(password)
+	if errors.Is(err, auth.ErrPasswordTooShort) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUser, err)
+	} else if err != nil {
+		return nil, err
+	}
+
+	s.registerMu.Lock()
+	defer s.registerMu.Unlock()
+
+	count, err := s.dao.CountUsers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+	if count > 0 && !s.authenticator.AllowSignup() {
+		return nil, ErrSignupDisabled
+	}
+
+	if _, err := s.dao.GetUserByUsername(username); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUserExists, username)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	user := dao.UserRow{ID: uuid.New().String(), Username: username, PasswordHash: passwordHash}
+	if count == 0 {
+		user.ID = auth.LocalUserID
+		user.IsAdmin = true
+	}
+	if err := s.dao.CreateUser(user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	created, err := s.dao.GetUserByID(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+	return toPBUser(created), nil
+}
+
+// Login checks the password and issues a session token
+func (s *AuthService) Login(ctx context.Context, username string, password string) (string, time.Time, *pb.User, error) {
+	user, err := s.dao.GetUserByUsername(strings.TrimSpace(username))
+	if errors.Is(err, sql.ErrNoRows) {
+		auth.CheckPassword(s.dummyHash, password)
+		return "", time.Time{}, nil, ErrInvalidCredentials
+	} else if err != nil {
+		return "", time.Time{}, nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	ok, err := auth.CheckPassword(user.PasswordHash, password)
+	if err != nil {
+		return "", time.Time{}, nil, fmt.Errorf("failed to check password: %w", err)
+	}
+	if !ok {
+		return "", time.Time{}, nil, ErrInvalidCredentials
+	}
+
+	token, expiresAt, err := s.authenticator.Tokens().Issue(user.ID)
+	if err != nil {
+		return "", time.Time{}, nil, err
+	}
+	return token, expiresAt, toPBUser(user), nil
+}
+
+func (s *AuthService) GetCurrentUser(ctx context.Context, userID string) (*pb.User, error) {
+	user, err := s.dao.GetUserByID(userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		if userID == auth.LocalUserID {
+			return &pb.User{Id: auth.LocalUserID, Username: localUsername, IsAdmin: true}, nil
+		}
+		return nil, fmt.Errorf("%w: %s", ErrInvalidUser, userID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+	return toPBUser(user), nil
+}
+
+// IsAdmin reports whether the user is an instance admin, the local user of single user mode always is
+func (s *AuthService) IsAdmin(ctx context.Context, userID string) (bool, error) {
+	if !s.authenticator.Enabled() {
+		return userID == auth.LocalUserID, nil
+	}
+	user, err := s.dao.GetUserByID(userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to fetch user: %w", err)
+	}
+	return user.IsAdmin, nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+	"sortedstartup/chatservice/provider"
+)
+
+// ErrInvalidBranch is returned when summarizing a chat that isn't a branch or has nothing after its branch point
+var ErrInvalidBranch = errors.New("invalid branch")
+
+const summarizeBranchPrompt = "The conversation below was continued in a separate branch of a chat. " +
+	"Summarize what it explored and concluded in a few sentences, written so it can be added to the original chat:\n\n"
+
+// shownMessages returns the messages the client sees for chatId, with its active alternatives
+func (s *ChatService) shownMessages(userID string, chatId string) ([]dao.ChatMessageRow, error) {
+	chat, _, activeID, err := s.resolveChat(userID, chatId)
+	if err != nil {
+		return nil, err
+	}
+	if chat == nil {
+		return nil, fmt.Errorf("%w: %s", ErrChatNotFound, chatId)
+	}
+	messages, err := s.dao.GetChatMessages(userID, activeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch messages of %s: %w", chatId, err)
+	}
+	return messages, nil
+}
+
+// divergence returns how many messages a and b have in common. Branches share their ancestors' messages, so the
+// common prefix has the same ids.
+func divergence(a []dao.ChatMessageRow, b []dao.ChatMessageRow) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n].Id == b[n].Id {
+		n++
+	}
+	return n
+}
+
+func toPBMessages(messages []dao.ChatMessageRow) []*pb.ChatMessage {
+	pbMessages := make([]*pb.ChatMessage, 0, len(messages))
+	for _, m := range messages {
+		pbMessages = append(pbMessages, &pb.ChatMessage{
+			Role:        m.Role,
+			Content:     m.Content,
+			MessageId:   m.Id,
+			Interrupted: m.Interrupted,
+			Options:     StoredGenerationOptions(m.GenerationOptions),
+		})
+	}
+	return pbMessages
+}
+
+// DiffBranches compares the messages two chats show: the prefix they share and what each added after it
+func (s *ChatService) DiffBranches(ctx context.Context, userID string, chatA string, chatB string) (*pb.DiffBranchesResponse, error) {
+	if chatA == "" || chatB == "" {
+		return nil, fmt.Errorf("%w: two chat ids are required", ErrInvalidBranch)
+	}
+
+	a, err := s.shownMessages(userID, chatA)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.shownMessages(userID, chatB)
+	if err != nil {
+		return nil, err
+	}
+
+	n := divergence(a, b)
+	return &pb.DiffBranchesResponse{
+		Common: toPBMessages(a[:n]),
+		OnlyA:  toPBMessages(a[n:]),
+		OnlyB:  toPBMessages(b[n:]),
+	}, nil
+}
+
+// branchMessages returns the messages of a branch's path after its branch point
+func branchMessages(path []dao.ChatMessageRow, parentMessageID string) []dao.ChatMessageRow {
+	for i := range path {
+		if path[i].Id == parentMessageID {
+			return path[i+1:]
+		}
+	}
+	return nil
+}
+
+// SummarizeBranch has the LLM sum up what a branch added after its branch point and adds the summary to the chat
+// it was branched from as one assistant message. model defaults to the last one that replied in the branch.
+func (s *ChatService) SummarizeBranch(ctx context.Context, userID string, chatId string, model string) (*pb.SummarizeBranchResponse, error) {
+	if chatId == "" {
+		return nil, fmt.Errorf("%w: chat id is required", ErrInvalidBranch)
+	}
+
+	branch, listedID, activeID, err := s.resolveChat(userID, chatId)
+	if err != nil {
+		return nil, err
+	}
+	if branch == nil {
+		return nil, fmt.Errorf("%w: %s", ErrChatNotFound, chatId)
+	}
+	if listedID != branch.ChatID {
+		if branch, err = s.dao.GetChat(userID, listedID); err != nil {
+			return nil, fmt.Errorf("failed to fetch chat: %w", err)
+		}
+	}
+	if branch.ParentChatID == "" {
+		return nil, fmt.Errorf("%w: %s is not a branch", ErrInvalidBranch, listedID)
+	}
+
+	path, err := s.dao.GetChatMessages(userID, activeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch message history: %w", err)
+	}
+	added := branchMessages(path, branch.ParentMessageID)
+	if len(added) == 0 {
+		return nil, fmt.Errorf("%w: %s has no messages after its branch point", ErrInvalidBranch, listedID)
+	}
+
+	// the parent may be one of the alternatives of a chat, the summary goes to the version that chat shows
+	_, parentID, _, err := s.resolveChat(userID, branch.ParentChatID)
+	if err != nil {
+		return nil, err
+	}
+	_, _, parentActiveID, err := s.resolveChat(userID, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireChatWrite(userID, parentID); err != nil {
+		return nil, err
+	}
+
+	var transcript strings.Builder
+	for _, m := range added {
+		fmt.Fprintf(&transcript, "%s: %s\n\n", m.Role, m.Content)
+	}
+	for i := len(added) - 1; i >= 0 && model == ""; i-- {
+		model = added[i].Model
+	}
+	if model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+	model, err = s.checkBudgets(ctx, userID, branch.ProjectID, model)
+	if err != nil {
+		return nil, err
+	}
+	llm, modelInfo, err := s.providerForModel(model)
+	if err != nil {
+		return nil, err
+	}
+
+	// keeps a reply from being generated in the parent while the summary is added to it
+	gen, err := s.generations.start(userID, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer s.generations.finish(parentID, gen, nil)
+
+	resp, err := llm.Completion(ctx, provider.CompletionRequest{
+		Model:    modelInfo.APIModelID(),
+		Messages: []provider.Message{{Role: "user", Content: summarizeBranchPrompt + transcript.String()}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize branch: %w", err)
+	}
+
+	summary := fmt.Sprintf("Summary of branch %q:\n\n%s", branch.Name, resp.Content)
+	messageId, err := s.dao.AddChatMessageWithTokens(userID, parentActiveID, "assistant", summary, model,
+		resp.Usage.InputTokens, resp.Usage.OutputTokens, messageCost(modelInfo, resp.Usage), "", false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store summary: %w", err)
+	}
+
+	return &pb.SummarizeBranchResponse{
+		MessageId:    strconv.FormatInt(messageId, 10),
+		ParentChatId: parentID,
+		Summary:      summary,
+	}, nil
+}
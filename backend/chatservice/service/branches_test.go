@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+)
+
+// branchesDAO adds branching to memoryDAO
+type branchesDAO struct {
+	*memoryDAO
+	branchedFrom string // source of the last BranchChat
+	tree         []dao.BranchRow
+}
+
+func (d *branchesDAO) BranchChat(userID string, source_chat_id string, parent_message_id string, new_chat_id string, branch_name string) error {
+	d.branchedFrom = source_chat_id
+	return nil
+}
+
+func (d *branchesDAO) GetBranchTree(userID string, chatId string) ([]dao.BranchRow, error) {
+	return d.tree, nil
+}
+
+func TestBranchAChat(t *testing.T) {
+	memory := newMemoryDAO()
+	memory.chats["branch"] = dao.ChatRow{ChatID: "branch", UserID: "alice", ParentChatID: "chat", ParentMessageID: "2"}
+	memory.messages["branch"] = []dao.ChatMessageRow{{Id: "6", Role: "user"}, {Id: "7", Role: "assistant"}}
+	d := &branchesDAO{memoryDAO: memory}
+	s := &ChatService{dao: d}
+
+	// the shown message comes from the active alternative, the branch copies it from there
+	if _, err := s.BranchAChat(context.Background(), "alice", "chat", "5", ""); err != nil {
+		t.Fatalf("BranchAChat failed: %v", err)
+	}
+	if d.branchedFrom != "alternative" {
+		t.Errorf("Expected to branch from the active alternative, got %q", d.branchedFrom)
+	}
+
+	if _, err := s.BranchAChat(context.Background(), "alice", "chat", "4", ""); !errors.Is(err, ErrMessageNotFound) {
+		t.Errorf("Expected ErrMessageNotFound for a message that isn't shown, got %v", err)
+	}
+
+	if _, err := s.BranchAChat(context.Background(), "alice", "branch", "7", ""); err != nil {
+		t.Errorf("Expected branches to be branched again, got %v", err)
+	}
+	if _, err := s.BranchAChat(context.Background(), "alice", "missing", "1", ""); !errors.Is(err, ErrChatNotFound) {
+		t.Errorf("Expected ErrChatNotFound, got %v", err)
+	}
+}
+
+func TestGetBranchTree(t *testing.T) {
+	branch := func(id, parentID, parentMessageID string, count int) dao.BranchRow {
+		return dao.BranchRow{
+			ChatInfoRow:     dao.ChatInfoRow{Id: id, Name: id, ParentChatID: parentID, IsMainBranch: parentID == ""},
+			ParentMessageID: parentMessageID,
+			MessageCount:    count,
+		}
+	}
+	d := &branchesDAO{tree: []dao.BranchRow{
+		branch("main", "", "", 4),
+		branch("b1", "main", "2", 3),
+		branch("b1.1", "b1", "6", 5),
+		branch("b2", "main", "4", 5),
+		// branched from a chat the user can't read
+		branch("hidden.1", "hidden", "9", 2),
+	}}
+	s := &ChatService{dao: d}
+
+	root, err := s.GetBranchTree(context.Background(), "alice", "b1.1")
+	if err != nil {
+		t.Fatalf("GetBranchTree failed: %v", err)
+	}
+	if root.GetChat().GetChatId() != "main" || !root.GetChat().GetIsMainBranch() || root.GetMessageCount() != 4 {
+		t.Errorf("Expected the main branch as root, got %v", root)
+	}
+	if len(root.GetChildren()) != 2 || root.GetChildren()[0].GetChat().GetChatId() != "b1" || root.GetChildren()[1].GetChat().GetChatId() != "b2" {
+		t.Fatalf("Expected b1 and b2 under main, got %v", root.GetChildren())
+	}
+	nested := root.GetChildren()[0].GetChildren()
+	if len(nested) != 1 || nested[0].GetChat().GetChatId() != "b1.1" || nested[0].GetParentMessageId() != "6" || nested[0].GetChat().GetParentChatId() != "b1" {
+		t.Errorf("Expected b1.1 branched from b1 at message 6, got %v", nested)
+	}
+
+	d.tree = nil
+	if _, err := s.GetBranchTree(context.Background(), "alice", "b1.1"); !errors.Is(err, ErrChatNotFound) {
+		t.Errorf("Expected ErrChatNotFound for an empty tree, got %v", err)
+	}
+}
+
+func TestDiffBranches(t *testing.T) {
+	memory := newMemoryDAO()
+	memory.chats["branch"] = dao.ChatRow{ChatID: "branch", UserID: "alice", ParentChatID: "chat", ParentMessageID: "2"}
+	memory.messages["branch"] = []dao.ChatMessageRow{{Id: "1", Role: "user"}, {Id: "2", Role: "assistant"}, {Id: "6", Role: "user"}, {Id: "7", Role: "assistant"}}
+	s := &ChatService{dao: memory}
+
+	// chat shows its active alternative: 1 2 3 5
+	diff, err := s.DiffBranches(context.Background(), "alice", "chat", "branch")
+	if err != nil {
+		t.Fatalf("DiffBranches failed: %v", err)
+	}
+	ids := func(messages []*pb.ChatMessage) string {
+		var s []string
+		for _, m := range messages {
+			s = append(s, m.GetMessageId())
+		}
+		return strings.Join(s, " ")
+	}
+	if got := ids(diff.GetCommon()); got != "1 2" {
+		t.Errorf("Expected common messages 1 2, got %q", got)
+	}
+	if got := ids(diff.GetOnlyA()); got != "3 5" {
+		t.Errorf("Expected 3 5 only in chat, got %q", got)
+	}
+	if got := ids(diff.GetOnlyB()); got != "6 7" {
+		t.Errorf("Expected 6 7 only in branch, got %q", got)
+	}
+
+	if _, err := s.DiffBranches(context.Background(), "alice", "chat", "missing"); !errors.Is(err, ErrChatNotFound) {
+		t.Errorf("Expected ErrChatNotFound, got %v", err)
+	}
+}
+
+func TestBranchMessages(t *testing.T) {
+	path := []dao.ChatMessageRow{{Id: "1"}, {Id: "2"}, {Id: "6"}, {Id: "7"}}
+
+	if added := branchMessages(path, "2"); len(added) != 2 || added[0].Id != "6" {
+		t.Errorf("Expected the messages after 2, got %v", added)
+	}
+	if added := branchMessages(path, "7"); len(added) != 0 {
+		t.Errorf("Expected nothing after the last message, got %v", added)
+	}
+	if added := branchMessages(path, "3"); added != nil {
+		t.Errorf("Expected nothing for a branch point not in the path, got %v", added)
+	}
+}
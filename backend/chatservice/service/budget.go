@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"sync"
+	"time"
+
+	"sortedstartup/chatservice/events"
+	"sortedstartup/chatservice/settings"
+)
+
+var ErrBudgetExceeded = errors.New("budget exceeded")
+
+// BudgetWarningMessage is published on BUDGET_WARNING_EVENT when spend crosses the soft limit of a budget
+type BudgetWarningMessage struct {
+	Scope       string  `json:"scope"`
+	ScopeID     string  `json:"scope_id"`
+	Period      string  `json:"period"`
+	PeriodStart string  `json:"period_start"`
+	Limit       float64 `json:"limit"`
+	Spent       float64 `json:"spent"`
+}
+
+// budgetPeriodStart returns the start of the current day or calendar month in UTC
+func budgetPeriodStart(period string, now time.Time) time.Time {
+	now = now.UTC()
+	if period == settings.BudgetPeriodMonthly {
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// budgetFilters reports whether the budget covers a request of the user in the project
+// and returns the user and project filters for the spend query
+func budgetFilters(b settings.BudgetConfig, userID string, projectID string) (string, string, bool) {
+	switch b.Scope {
+	case settings.BudgetScopeGlobal:
+		return "", "", true
+	case settings.BudgetScopeUser:
+		if b.ScopeID != "" && b.ScopeID != userID {
+			return "", "", false
+		}
+		return userID, "", true
+	case settings.BudgetScopeProject:
+		if projectID == "" || (b.ScopeID != "" && b.ScopeID != projectID) {
+			return "", "", false
+		}
+		return "", projectID, true
+	default:
+		return "", "", false
+	}
+}
+
+// checkBudgets runs before the upstream call and returns the model to use, which is the requested
+// model unless an exceeded budget downgrades it. An exceeded budget only allows its downgrade model, so
+// the request is refused with ErrBudgetExceeded when one has no downgrade model or two exceeded budgets
+// downgrade to different models.
+func (s *ChatService) checkBudgets(ctx context.Context, userID string, projectID string, model string) (string, error) {
+	now := time.Now()
+	downgradeTo, downgradedBy := "", ""
+
+	for _, b := range s.settingsManager.GetSettings().Budgets {
+		userFilter, projectFilter, ok := budgetFilters(b, userID, projectID)
+		if !ok {
+			continue
+		}
+
+		periodStart := budgetPeriodStart(b.Period, now)
+		spent, err := s.dao.GetSpend(periodStart, userFilter, projectFilter)
+		if err != nil {
+			return "", fmt.Errorf("failed to check budget: %w", err)
+		}
+
+		if spent >= b.Limit {
+			if b.DowngradeModel == "" {
+				return "", fmt.Errorf("%w: %s %s budget of $%.2f reached ($%.2f spent)", ErrBudgetExceeded, b.Period, budgetName(userFilter, projectFilter), b.Limit, spent)
+			}
+			name := fmt.Sprintf("%s %s budget", b.Period, budgetName(userFilter, projectFilter))
+			if downgradeTo == "" {
+				downgradeTo, downgradedBy = b.DowngradeModel, name
+			} else if b.DowngradeModel != downgradeTo {
+				return "", fmt.Errorf("%w: the %s only allows %s, the %s only allows %s", ErrBudgetExceeded,
+					downgradedBy, downgradeTo, name, b.DowngradeModel)
+			}
+			continue
+		}
+
+		if b.SoftLimitPercent > 0 && spent >= b.Limit*b.SoftLimitPercent/100 {
+			s.publishBudgetWarning(ctx, b, userFilter+projectFilter, periodStart, spent, now)
+		}
+	}
+
+	if downgradeTo != "" && downgradeTo != model {
+		slog.Info("Budget exceeded, downgrading model", "from", model, "to", downgradeTo, "user", userID, "project", projectID)
+		return downgradeTo, nil
+	}
+	return model, nil
+}
+
+func budgetName(userID string, projectID string) string {
+	switch {
+	case userID != "":
+		return "user " + userID
+	case projectID != "":
+		return "project " + projectID
+	default:
+		return "global"
+	}
+}
+
+// budgetPeriod is a day or calendar month of budgets
+type budgetPeriod struct {
+	period string
+	start  time.Time
+}
+
+// budgetWarnings remembers the soft limit warnings published per budget period, periods that ended are
+// dropped. The zero value is ready to use.
+type budgetWarnings struct {
+	mu   sync.Mutex
+	sent map[budgetPeriod]map[string]bool // budget scope and scope id -> sent
+}
+
+// claim records the warning for budget in period and reports whether it was not sent yet
+func (w *budgetWarnings) claim(period budgetPeriod, budget string, now time.Time) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for p := range w.sent {
+		if p.start.Before(budgetPeriodStart(p.period, now)) {
+			delete(w.sent, p)
+		}
+	}
+	if w.sent == nil {
+		w.sent = make(map[budgetPeriod]map[string]bool)
+	}
+	if w.sent[period] == nil {
+		w.sent[period] = make(map[string]bool)
+	}
+	if w.sent[period][budget] {
+		return false
+	}
+	w.sent[period][budget] = true
+	return true
+}
+
+// release forgets a warning that failed to publish so it is sent again
+func (w *budgetWarnings) release(period budgetPeriod, budget string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.sent[period], budget)
+}
+
+// publishBudgetWarning publishes the warning once per budget, scope and period
+func (s *ChatService) publishBudgetWarning(ctx context.Context, b settings.BudgetConfig, scopeID string, periodStart time.Time, spent float64, now time.Time) {
+	period := budgetPeriod{period: b.Period, start: periodStart}
+	key := b.Scope + "|" + scopeID
+	if !s.budgetWarnings.claim(period, key, now) {
+		return
+	}
+
+	msg := BudgetWarningMessage{
+		Scope:       b.Scope,
+		ScopeID:     scopeID,
+		Period:      b.Period,
+		PeriodStart: periodStart.Format(time.RFC3339),
+		Limit:       b.Limit,
+		Spent:       spent,
+	}
+	msgBytes, _ := json.Marshal(msg)
+	if err := s.queue.Publish(ctx, events.BUDGET_WARNING_EVENT, msgBytes); err != nil {
+		log.Printf("Failed to publish budget warning event: %v", err)
+		s.budgetWarnings.release(period, key)
+	}
+}
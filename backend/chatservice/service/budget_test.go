@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"sortedstartup/chatservice/dao"
+	"sortedstartup/chatservice/queue"
+	"sortedstartup/chatservice/settings"
+)
+
+// spendDAO reports the same spend for every budget
+type spendDAO struct {
+	dao.DAO
+	spent float64
+}
+
+func (d *spendDAO) GetSpend(since time.Time, userID string, projectID string) (float64, error) {
+	return d.spent, nil
+}
+
+func TestBudgetPeriodStart(t *testing.T) {
+	now := time.Date(2025, 3, 15, 18, 30, 0, 0, time.UTC)
+
+	if got := budgetPeriodStart(settings.BudgetPeriodDaily, now); !got.Equal(time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected start of day, got %s", got)
+	}
+	if got := budgetPeriodStart(settings.BudgetPeriodMonthly, now); !got.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected start of month, got %s", got)
+	}
+}
+
+func TestBudgetFilters(t *testing.T) {
+	tests := []struct {
+		budget      settings.BudgetConfig
+		projectID   string
+		wantUser    string
+		wantProject string
+		wantOK      bool
+	}{
+		{settings.BudgetConfig{Scope: settings.BudgetScopeGlobal}, "p1", "", "", true},
+		{settings.BudgetConfig{Scope: settings.BudgetScopeUser}, "p1", "u1", "", true},
+		{settings.BudgetConfig{Scope: settings.BudgetScopeUser, ScopeID: "u2"}, "p1", "", "", false},
+		{settings.BudgetConfig{Scope: settings.BudgetScopeProject}, "p1", "", "p1", true},
+		{settings.BudgetConfig{Scope: settings.BudgetScopeProject}, "", "", "", false},
+		{settings.BudgetConfig{Scope: settings.BudgetScopeProject, ScopeID: "p2"}, "p1", "", "", false},
+	}
+
+	for _, tt := range tests {
+		user, project, ok := budgetFilters(tt.budget, "u1", tt.projectID)
+		if user != tt.wantUser || project != tt.wantProject || ok != tt.wantOK {
+			t.Errorf("budgetFilters(%+v, %q) = (%q, %q, %v), expected (%q, %q, %v)",
+				tt.budget, tt.projectID, user, project, ok, tt.wantUser, tt.wantProject, tt.wantOK)
+		}
+	}
+}
+
+func TestCheckBudgetsDowngrade(t *testing.T) {
+	manager := settings.NewSettingsManager(queue.NewInMemoryQueue(), settingsFactory{}, nil)
+	s := &ChatService{dao: &spendDAO{spent: 20}, settingsManager: manager, queue: queue.NewInMemoryQueue()}
+	ctx := context.Background()
+	load := func(budgets ...settings.BudgetConfig) {
+		if err := manager.LoadSettings(&settings.Settings{Budgets: budgets}); err != nil {
+			t.Fatalf("LoadSettings failed: %v", err)
+		}
+	}
+
+	load(settings.BudgetConfig{Scope: settings.BudgetScopeUser, Period: settings.BudgetPeriodDaily, Limit: 10, DowngradeModel: "gpt-4o-mini"},
+		settings.BudgetConfig{Scope: settings.BudgetScopeGlobal, Period: settings.BudgetPeriodMonthly, Limit: 10, DowngradeModel: "gpt-4o-mini"})
+	if model, err := s.checkBudgets(ctx, "u1", "", "gpt-4o"); err != nil || model != "gpt-4o-mini" {
+		t.Errorf("Expected a downgrade to gpt-4o-mini, got %q and %v", model, err)
+	}
+
+	// the downgrade model of one exceeded budget is refused by another
+	load(settings.BudgetConfig{Scope: settings.BudgetScopeUser, Period: settings.BudgetPeriodDaily, Limit: 10, DowngradeModel: "gpt-4o-mini"},
+		settings.BudgetConfig{Scope: settings.BudgetScopeGlobal, Period: settings.BudgetPeriodMonthly, Limit: 10, DowngradeModel: "gpt-4.1-nano"})
+	if _, err := s.checkBudgets(ctx, "u1", "", "gpt-4o"); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("Expected ErrBudgetExceeded for conflicting downgrades, got %v", err)
+	}
+
+	load(settings.BudgetConfig{Scope: settings.BudgetScopeUser, Period: settings.BudgetPeriodDaily, Limit: 10, DowngradeModel: "gpt-4o-mini"},
+		settings.BudgetConfig{Scope: settings.BudgetScopeProject, Period: settings.BudgetPeriodDaily, Limit: 10})
+	if _, err := s.checkBudgets(ctx, "u1", "p1", "gpt-4o"); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("Expected ErrBudgetExceeded from the project budget, got %v", err)
+	}
+}
+
+func TestBudgetWarnings(t *testing.T) {
+	var w budgetWarnings
+	day := time.Date(2025, 3, 15, 18, 0, 0, 0, time.UTC)
+	daily := budgetPeriod{period: settings.BudgetPeriodDaily, start: budgetPeriodStart(settings.BudgetPeriodDaily, day)}
+	monthly := budgetPeriod{period: settings.BudgetPeriodMonthly, start: budgetPeriodStart(settings.BudgetPeriodMonthly, day)}
+
+	if !w.claim(daily, "user|u1", day) || w.claim(daily, "user|u1", day) {
+		t.Errorf("Expected the warning to be claimed once per period")
+	}
+	w.release(daily, "user|u1")
+	if !w.claim(daily, "user|u1", day) || !w.claim(monthly, "user|u1", day) {
+		t.Errorf("Expected a released warning and another period to be claimable")
+	}
+
+	next := day.Add(24 * time.Hour)
+	nextDaily := budgetPeriod{period: settings.BudgetPeriodDaily, start: budgetPeriodStart(settings.BudgetPeriodDaily, next)}
+	if !w.claim(nextDaily, "user|u1", next) {
+		t.Errorf("Expected the warning to be sent again the next day")
+	}
+	if _, ok := w.sent[daily]; ok {
+		t.Errorf("Expected the ended day to be dropped")
+	}
+	if _, ok := w.sent[monthly]; !ok {
+		t.Errorf("Expected the current month to be kept")
+	}
+}
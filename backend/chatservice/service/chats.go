@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"sortedstartup/chatservice/dao"
+)
+
+var (
+	// ErrInvalidChatUpdate is returned for empty names and for moving a branch without the rest of its tree
+	ErrInvalidChatUpdate = errors.New("invalid chat update")
+	ErrChatNotInTrash    = errors.New("chat is not in the trash")
+	// ErrParentChatInTrash is returned when restoring a branch of a chat that is still in the trash
+	ErrParentChatInTrash = errors.New("the chat this branch was branched from is in the trash")
+)
+
+// writableChat returns a chat of the chat list the user can change, including chats in the trash
+func (s *ChatService) writableChat(userID string, chatId string) (*dao.ChatRow, error) {
+	if chatId == "" {
+		return nil, fmt.Errorf("%w: chat id is required", ErrInvalidChatUpdate)
+	}
+
+	chat, err := s.dao.GetChat(userID, chatId)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && chat.AlternativeOf != "") {
+		return nil, fmt.Errorf("%w: %s", ErrChatNotFound, chatId)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to fetch chat: %w", err)
+	}
+
+	if err := s.requireChatWrite(userID, chatId); err != nil {
+		return nil, err
+	}
+	return chat, nil
+}
+
+// activeChat is writableChat for chats that are not in the trash
+func (s *ChatService) activeChat(userID string, chatId string) (*dao.ChatRow, error) {
+	chat, err := s.writableChat(userID, chatId)
+	if err != nil {
+		return nil, err
+	}
+	if chat.Deleted {
+		return nil, fmt.Errorf("%w: %s is in the trash", ErrChatNotFound, chatId)
+	}
+	return chat, nil
+}
+
+func (s *ChatService) RenameChat(ctx context.Context, userID string, chatId string, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidChatUpdate)
+	}
+	if _, err := s.activeChat(userID, chatId); err != nil {
+		return err
+	}
+
+	if err := s.dao.RenameChat(chatId, name); err != nil {
+		return fmt.Errorf("failed to rename chat: %w", err)
+	}
+	return nil
+}
+
+// DeleteChat moves the chat and its branches to the trash, or deletes them and their messages for good. Every
+// writer can move a chat to the trash, only its creator and the owners of its workspace can delete it for good.
+func (s *ChatService) DeleteChat(ctx context.Context, userID string, chatId string, permanent bool) error {
+	chat, err := s.writableChat(userID, chatId)
+	if err != nil {
+		return err
+	}
+
+	if permanent {
+		if chat.UserID != userID {
+			role, err := s.dao.GetChatRole(userID, chatId)
+			if err != nil {
+				return fmt.Errorf("failed to check chat access: %w", err)
+			}
+			if role != dao.WorkspaceRoleOwner {
+				return fmt.Errorf("%w: only the user who created the chat or a workspace owner can delete it for good", ErrPermissionDenied)
+			}
+		}
+		if err := s.dao.PurgeChat(chatId); err != nil {
+			return fmt.Errorf("failed to delete chat: %w", err)
+		}
+		return nil
+	}
+
+	if chat.Deleted {
+		return nil
+	}
+	if err := s.dao.TrashChat(chatId); err != nil {
+		return fmt.Errorf("failed to move chat to the trash: %w", err)
+	}
+	return nil
+}
+
+// RestoreChat takes a chat out of the trash with the branches that were deleted along with it
+func (s *ChatService) RestoreChat(ctx context.Context, userID string, chatId string) error {
+	chat, err := s.writableChat(userID, chatId)
+	if err != nil {
+		return err
+	}
+	if !chat.Deleted {
+		return fmt.Errorf("%w: %s", ErrChatNotInTrash, chatId)
+	}
+
+	if chat.ParentChatID != "" {
+		parent, err := s.dao.GetChat(userID, chat.ParentChatID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to fetch parent chat: %w", err)
+		}
+		if err == nil && parent.Deleted {
+			return ErrParentChatInTrash
+		}
+	}
+
+	if err := s.dao.RestoreChat(chatId); err != nil {
+		return fmt.Errorf("failed to restore chat: %w", err)
+	}
+	return nil
+}
+
+// ListTrash returns the deleted chats of a project, or the user's deleted chats without project. Branches deleted
+// along with their parent chat are not listed, restoring the parent brings them back.
+func (s *ChatService) ListTrash(ctx context.Context, userID string, projectID string) ([]dao.ChatInfoRow, error) {
+	if projectID == "null" {
+		projectID = ""
+	}
+
+	chats, err := s.dao.GetTrash(userID, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch trash: %w", err)
+	}
+	return chats, nil
+}
+
+// ArchiveChat hides the chat from the chat list unless archived chats are asked for
+func (s *ChatService) ArchiveChat(ctx context.Context, userID string, chatId string, archived bool) error {
+	if _, err := s.activeChat(userID, chatId); err != nil {
+		return err
+	}
+
+	if err := s.dao.SetChatArchived(chatId, archived); err != nil {
+		return fmt.Errorf("failed to archive chat: %w", err)
+	}
+	return nil
+}
+
+// PinChat lists the chat before the others
+func (s *ChatService) PinChat(ctx context.Context, userID string, chatId string, pinned bool) error {
+	if _, err := s.activeChat(userID, chatId); err != nil {
+		return err
+	}
+
+	if err := s.dao.SetChatPinned(chatId, pinned); err != nil {
+		return fmt.Errorf("failed to pin chat: %w", err)
+	}
+	return nil
+}
+
+// MoveChat moves a main branch and everything branched from it to another project, an empty projectID moves them
+// out of their project, which only the user who created the chat can do
+func (s *ChatService) MoveChat(ctx context.Context, userID string, chatId string, projectID string) error {
+	chat, err := s.activeChat(userID, chatId)
+	if err != nil {
+		return err
+	}
+	if chat.ParentChatID != "" {
+		return fmt.Errorf("%w: %s is a branch, move the chat it was branched from", ErrInvalidChatUpdate, chatId)
+	}
+
+	if projectID == "null" {
+		projectID = ""
+	}
+	if projectID != "" {
+		if err := s.requireProjectWrite(userID, projectID); err != nil {
+			return err
+		}
+	} else if chat.UserID != userID {
+		return fmt.Errorf("%w: only the user who created the chat can move it out of its project", ErrPermissionDenied)
+	}
+
+	if err := s.dao.MoveChat(chatId, projectID); err != nil {
+		return fmt.Errorf("failed to move chat: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"sortedstartup/chatservice/dao"
+)
+
+func TestRestoreChat(t *testing.T) {
+	d := newMemoryDAO()
+	s := &ChatService{dao: d}
+	ctx := context.Background()
+
+	if err := s.RestoreChat(ctx, "alice", "main"); !errors.Is(err, ErrChatNotInTrash) {
+		t.Errorf("Expected ErrChatNotInTrash, got %v", err)
+	}
+	if err := s.RestoreChat(ctx, "alice", "orphan"); !errors.Is(err, ErrParentChatInTrash) {
+		t.Errorf("Expected ErrParentChatInTrash, got %v", err)
+	}
+	if err := s.RestoreChat(ctx, "alice", "alternative"); !errors.Is(err, ErrChatNotFound) {
+		t.Errorf("Expected alternatives not to be restored on their own, got %v", err)
+	}
+
+	if err := s.RestoreChat(ctx, "alice", "branch"); err != nil {
+		t.Fatalf("RestoreChat failed: %v", err)
+	}
+	if len(d.restored) != 1 || d.restored[0] != "branch" {
+		t.Errorf("Expected branch to be restored, got %v", d.restored)
+	}
+}
+
+func TestDeleteChat(t *testing.T) {
+	d := newMemoryDAO()
+	s := &ChatService{dao: d}
+	ctx := context.Background()
+	d.chats["bobs"] = dao.ChatRow{ChatID: "bobs", UserID: "bob", ProjectID: "p1"}
+
+	if err := s.DeleteChat(ctx, "bob", "main", true); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected editors not to delete the chats of others for good, got %v", err)
+	}
+	if err := s.DeleteChat(ctx, "carol", "main", false); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected viewers not to delete chats, got %v", err)
+	}
+	if err := s.DeleteChat(ctx, "bob", "main", false); err != nil {
+		t.Fatalf("DeleteChat failed: %v", err)
+	}
+	if len(d.trashed) != 1 || d.trashed[0] != "main" {
+		t.Errorf("Expected main to be moved to the trash, got %v", d.trashed)
+	}
+
+	if err := s.DeleteChat(ctx, "bob", "bobs", true); err != nil {
+		t.Fatalf("DeleteChat failed for the creator: %v", err)
+	}
+	if err := s.DeleteChat(ctx, "alice", "main", true); err != nil {
+		t.Fatalf("DeleteChat failed for the workspace owner: %v", err)
+	}
+	if len(d.purged) != 2 || d.purged[0] != "bobs" || d.purged[1] != "main" {
+		t.Errorf("Expected bobs and main to be deleted for good, got %v", d.purged)
+	}
+}
+
+func TestMoveChat(t *testing.T) {
+	d := newMemoryDAO()
+	s := &ChatService{dao: d}
+	ctx := context.Background()
+
+	if err := s.MoveChat(ctx, "alice", "main", "p2"); err != nil {
+		t.Fatalf("MoveChat failed: %v", err)
+	}
+	if d.moved["main"] != "p2" {
+		t.Errorf("Expected main to move to p2, got %v", d.moved)
+	}
+
+	if err := s.MoveChat(ctx, "alice", "main", "p3"); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected ErrPermissionDenied for a project the user can only read, got %v", err)
+	}
+	if err := s.MoveChat(ctx, "bob", "main", ""); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected only the creator to move the chat out of its project, got %v", err)
+	}
+	if err := s.MoveChat(ctx, "alice", "trashed", "p2"); !errors.Is(err, ErrChatNotFound) {
+		t.Errorf("Expected ErrChatNotFound for a chat in the trash, got %v", err)
+	}
+
+	d.chats["branch"] = dao.ChatRow{ChatID: "branch", UserID: "alice", ParentChatID: "main"}
+	if err := s.MoveChat(ctx, "alice", "branch", "p2"); !errors.Is(err, ErrInvalidChatUpdate) {
+		t.Errorf("Expected branches not to move without their tree, got %v", err)
+	}
+}
+
+func TestGenerateChatNameAccess(t *testing.T) {
+	s := &ChatService{dao: newMemoryDAO()}
+	ctx := context.Background()
+
+	// both are refused before anything is sent to a model
+	if _, err := s.GenerateChatName(ctx, "carol", "main", "hello", "gpt-4o"); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected viewers not to name chats, got %v", err)
+	}
+	if _, err := s.GenerateChatName(ctx, "alice", "new", "hello", "gpt-4o"); !errors.Is(err, ErrChatNotFound) {
+		t.Errorf("Expected ErrChatNotFound for a chat that isn't stored, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+	"sortedstartup/chatservice/tokenizer"
+)
+
+const (
+	// replyTokenReserve is kept free for the reply, at most a quarter of the context window
+	replyTokenReserve = 4096
+	// messageTokenOverhead is what chat formats add around each message, its role and separators
+	messageTokenOverhead = 4
+	truncationMarker     = "\n\n[... shortened to fit the context window ...]\n\n"
+)
+
+var ErrContextTooLarge = errors.New("context too large for the model")
+
+// promptBudget is the number of tokens a prompt can take in a context window, the rest is kept free for the reply
+func promptBudget(contextWindow int) int {
+	return contextWindow - min(replyTokenReserve, contextWindow/4)
+}
+
+// fitContext makes history fit into a context window of contextWindow tokens with room left for the reply. The
+// oldest messages are dropped first, system messages and the newest message are always sent, and the newest
+// message is shortened if that is not enough. The truncation is nil when everything fits or the context window
+// of the model is unknown (0). ErrContextTooLarge is returned when the system messages (prompt and summary) leave
+// no room for the newest message.
+func fitContext(history []dao.ChatMessageRow, contextWindow int) ([]dao.ChatMessageRow, *pb.ContextTruncation, error) {
+	if contextWindow <= 0 || len(history) == 0 {
+		return history, nil, nil
+	}
+	budget := promptBudget(contextWindow)
+
+	tokens := make([]int, len(history))
+	total := 0
+	for i, m := range history {
+		tokens[i] = tokenizer.Count(m.Content) + messageTokenOverhead
+		total += tokens[i]
+	}
+	if total <= budget {
+		return history, nil, nil
+	}
+
+	truncation := &pb.ContextTruncation{ContextWindow: int32(contextWindow)}
+	last := len(history) - 1
+	dropped := make([]bool, len(history))
+	for i := 0; i < last; i++ {
+		if history[i].Role == "system" {
+			continue
+		}
+		// the conversation sent has to start with the user, replies to a dropped message go with it
+		if total <= budget && history[i].Role != "assistant" {
+			break
+		}
+		dropped[i] = true
+		total -= tokens[i]
+		truncation.DroppedMessages++
+	}
+
+	fitted := make([]dao.ChatMessageRow, 0, len(history)-int(truncation.DroppedMessages))
+	for i := range history {
+		if !dropped[i] {
+			fitted = append(fitted, history[i])
+		}
+	}
+
+	if total > budget {
+		room := budget - (total - tokens[last]) - messageTokenOverhead
+		if room <= tokenizer.Count(truncationMarker) {
+			return nil, nil, fmt.Errorf("%w: %d of the %d tokens the model takes are needed for the system prompt and summary",
+				ErrContextTooLarge, total-tokens[last], budget)
+		}
+		newest := &fitted[len(fitted)-1]
+		newest.Content = tokenizer.TrimMiddle(newest.Content, room, truncationMarker)
+		total += tokenizer.Count(newest.Content) + messageTokenOverhead - tokens[last]
+		truncation.TrimmedMessages = 1
+	}
+
+	for _, m := range fitted {
+		if m.Role != "system" {
+			truncation.FirstMessageId = m.Id
+			break
+		}
+	}
+	truncation.PromptTokens = int32(total)
+	return fitted, truncation, nil
+}
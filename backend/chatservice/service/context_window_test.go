@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+	"sortedstartup/chatservice/queue"
+	"sortedstartup/chatservice/settings"
+	"sortedstartup/chatservice/tokenizer"
+)
+
+func TestFitContext(t *testing.T) {
+	long := strings.Repeat("word ", 1000) // 1000 tokens
+	history := []dao.ChatMessageRow{
+		{Id: "1", Role: "system", Content: "Be brief."},
+		{Id: "2", Role: "user", Content: long},
+		{Id: "3", Role: "assistant", Content: long},
+		{Id: "4", Role: "user", Content: "short question"},
+		{Id: "5", Role: "assistant", Content: long},
+		{Role: "user", Content: "next question"},
+	}
+
+	if fitted, truncation, _ := fitContext(history, 0); truncation != nil || len(fitted) != len(history) {
+		t.Errorf("Expected no truncation for an unknown context window, got %v", truncation)
+	}
+	if _, truncation, _ := fitContext(history, 100000); truncation != nil {
+		t.Errorf("Expected everything to fit into 100000 tokens, got %v", truncation)
+	}
+
+	// 2000 tokens leave 1500 for the prompt: the system message, 4 and 5 and the new question
+	fitted, truncation, err := fitContext(history, 2000)
+	if err != nil || truncation == nil {
+		t.Fatalf("Expected the history to be truncated")
+	}
+	var ids []string
+	for _, m := range fitted {
+		ids = append(ids, m.Id)
+	}
+	if got := strings.Join(ids, " "); got != "1 4 5 " {
+		t.Errorf("Expected the system message and the last turns, got %q", got)
+	}
+	if truncation.DroppedMessages != 2 || truncation.TrimmedMessages != 0 || truncation.FirstMessageId != "4" || truncation.PromptTokens > 1500 {
+		t.Errorf("Expected 2 dropped messages starting at 4, got %v", truncation)
+	}
+
+	// replies go with the message they answer, the newest message is shortened when it alone is too long
+	history = []dao.ChatMessageRow{
+		{Id: "1", Role: "user", Content: "hi"},
+		{Id: "2", Role: "assistant", Content: "hello"},
+		{Role: "user", Content: long + long},
+	}
+	fitted, truncation, err = fitContext(history, 1000)
+	if err != nil || len(fitted) != 1 || truncation.DroppedMessages != 2 || truncation.TrimmedMessages != 1 || truncation.FirstMessageId != "" {
+		t.Fatalf("Expected only the shortened new message, got %v and %v", fitted, truncation)
+	}
+	if tokens := tokenizer.Count(fitted[0].Content); tokens > 750 || !strings.Contains(fitted[0].Content, truncationMarker) {
+		t.Errorf("Expected the new message shortened to 750 tokens, got %d", tokens)
+	}
+	if history[2].Content != long+long {
+		t.Errorf("Expected the history itself to stay unchanged")
+	}
+
+	// a system prompt and summary that take up the whole context window leave nothing of the new message
+	history = []dao.ChatMessageRow{
+		{Role: "system", Content: long},
+		{Role: "user", Content: "next question"},
+	}
+	if fitted, _, err := fitContext(history, 1000); !errors.Is(err, ErrContextTooLarge) {
+		t.Errorf("Expected ErrContextTooLarge, got %v and %v", fitted, err)
+	}
+}
+
+// oversizedDAO gives every chat a system prompt that doesn't fit into the context window of its models
+type oversizedDAO struct {
+	*memoryDAO
+	stored []string // messages and alternatives stored
+}
+
+func (d *oversizedDAO) GetModel(modelID string) (*dao.ModelRow, error) {
+	return &dao.ModelRow{ID: modelID, Enabled: true, ContextWindow: 100}, nil
+}
+
+func (d *oversizedDAO) GetGenerationDefaults(chatId string) (string, string, error) {
+	return "", "", nil
+}
+
+func (d *oversizedDAO) GetChatSummary(chatId string) (*dao.ChatSummaryRow, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (d *oversizedDAO) GetSystemPrompt(chatId string) (string, error) {
+	return strings.Repeat("word ", 200), nil
+}
+
+func (d *oversizedDAO) AddChatMessage(userID string, chatId string, role string, content string) error {
+	d.stored = append(d.stored, content)
+	return nil
+}
+
+func (d *oversizedDAO) CreateAlternative(userID string, chat dao.ChatRow) error {
+	d.stored = append(d.stored, chat.ChatID)
+	return nil
+}
+
+func TestContextTooLargeStoresNothing(t *testing.T) {
+	d := &oversizedDAO{memoryDAO: newMemoryDAO()}
+	manager := settings.NewSettingsManager(queue.NewInMemoryQueue(), settingsFactory{}, nil)
+	if err := manager.LoadSettings(&settings.Settings{OllamaURL: "http://localhost:11434"}); err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	s := &ChatService{dao: d, settingsManager: manager}
+	ctx := context.Background()
+	stream := func(*pb.ChatResponse) error { return nil }
+
+	err := s.Chat(ctx, "alice", &pb.ChatRequest{ChatId: "chat", Model: "ollama/llama3", Text: "question"}, stream)
+	if !errors.Is(err, ErrContextTooLarge) {
+		t.Errorf("Expected ErrContextTooLarge from Chat, got %v", err)
+	}
+	if err := s.EditMessage(ctx, "alice", "chat", "3", "edited", "ollama/llama3", nil, stream); !errors.Is(err, ErrContextTooLarge) {
+		t.Errorf("Expected ErrContextTooLarge from EditMessage, got %v", err)
+	}
+	if err := s.RegenerateMessage(ctx, "alice", "chat", "5", "ollama/llama3", nil, stream); !errors.Is(err, ErrContextTooLarge) {
+		t.Errorf("Expected ErrContextTooLarge from RegenerateMessage, got %v", err)
+	}
+	if len(d.stored) != 0 {
+		t.Errorf("Expected nothing to be stored for a context that doesn't fit, got %v", d.stored)
+	}
+}
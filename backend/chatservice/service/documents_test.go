@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sortedstartup/chatservice/dao"
+	"sortedstartup/chatservice/store"
+)
+
+func newDocumentsService(t *testing.T, docs map[string]dao.DocumentListRow) (*ChatService, string) {
+	t.Helper()
+	base := t.TempDir()
+	objects, err := store.NewDiskObjectStore(filepath.Join(base, "filestore"))
+	if err != nil {
+		t.Fatalf("NewDiskObjectStore failed: %v", err)
+	}
+	d := newMemoryDAO()
+	d.docs = docs
+	return &ChatService{dao: d, store: objects}, base
+}
+
+func TestOpenDocument(t *testing.T) {
+	s, _ := newDocumentsService(t, map[string]dao.DocumentListRow{
+		"doc-1": {DocsID: "doc-1", FileName: "notes.txt", User: "alice"},
+	})
+	if err := s.store.StoreObject(context.Background(), "doc-1", strings.NewReader("alice's notes")); err != nil {
+		t.Fatalf("StoreObject failed: %v", err)
+	}
+
+	doc, content, err := s.OpenDocument(context.Background(), "alice", "doc-1")
+	if err != nil {
+		t.Fatalf("OpenDocument failed: %v", err)
+	}
+	defer content.Close()
+	data, _ := io.ReadAll(content)
+	if doc.FileName != "notes.txt" || string(data) != "alice's notes" {
+		t.Errorf("Unexpected document '%s' with content '%s'", doc.FileName, data)
+	}
+
+	if _, _, err := s.OpenDocument(context.Background(), "bob", "doc-1"); !errors.Is(err, ErrDocumentNotFound) {
+		t.Errorf("Expected another user's document to be not found, got %v", err)
+	}
+}
+
+func TestOpenDocumentPathTraversal(t *testing.T) {
+	// a row whose docs_id points outside the object store must not be served
+	s, base := newDocumentsService(t, map[string]dao.DocumentListRow{
+		"../../secret.txt": {DocsID: "../../secret.txt", FileName: "secret.txt", User: "alice"},
+	})
+	if err := os.WriteFile(filepath.Join(base, "secret.txt"), []byte("secret"), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	for _, id := range []string{"../../secret.txt", "../filestore/objects/doc-1"} {
+		if _, _, err := s.OpenDocument(context.Background(), "alice", id); !errors.Is(err, ErrDocumentNotFound) {
+			t.Errorf("OpenDocument(%q): expected ErrDocumentNotFound, got %v", id, err)
+		}
+	}
+}
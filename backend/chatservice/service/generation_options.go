@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+	"sortedstartup/chatservice/provider"
+)
+
+var ErrInvalidGenerationOptions = errors.New("invalid generation options")
+
+// maxStopSequences is the lowest limit of the providers, OpenAI takes 4 stop sequences
+const maxStopSequences = 4
+
+var reasoningEfforts = map[pb.ReasoningEffort]string{
+	pb.ReasoningEffort_REASONING_EFFORT_LOW:    provider.ReasoningLow,
+	pb.ReasoningEffort_REASONING_EFFORT_MEDIUM: provider.ReasoningMedium,
+	pb.ReasoningEffort_REASONING_EFFORT_HIGH:   provider.ReasoningHigh,
+}
+
+func fromPBGenerationOptions(o *pb.GenerationOptions) (provider.GenerationOptions, error) {
+	if o == nil {
+		return provider.GenerationOptions{}, nil
+	}
+	opts := provider.GenerationOptions{
+		Temperature: o.Temperature,
+		TopP:        o.TopP,
+		MaxTokens:   int(o.GetMaxTokens()),
+		Stop:        o.GetStop(),
+		Seed:        o.Seed,
+	}
+	if o.GetReasoningEffort() != pb.ReasoningEffort_REASONING_EFFORT_UNSPECIFIED {
+		effort, ok := reasoningEfforts[o.GetReasoningEffort()]
+		if !ok {
+			return opts, fmt.Errorf("%w: unknown reasoning effort %v", ErrInvalidGenerationOptions, o.GetReasoningEffort())
+		}
+		opts.ReasoningEffort = effort
+	}
+	return opts, nil
+}
+
+// toPBGenerationOptions returns nil for options that leave everything to the defaults
+func toPBGenerationOptions(opts provider.GenerationOptions) *pb.GenerationOptions {
+	if isDefaultGenerationOptions(opts) {
+		return nil
+	}
+	o := &pb.GenerationOptions{
+		Temperature: opts.Temperature,
+		TopP:        opts.TopP,
+		MaxTokens:   int32(opts.MaxTokens),
+		Stop:        opts.Stop,
+		Seed:        opts.Seed,
+	}
+	for effort, name := range reasoningEfforts {
+		if name == opts.ReasoningEffort {
+			o.ReasoningEffort = effort
+		}
+	}
+	return o
+}
+
+// StoredGenerationOptions converts options stored as JSON, nil if none were stored
+func StoredGenerationOptions(data string) *pb.GenerationOptions {
+	return toPBGenerationOptions(storedGenerationOptions(data, "stored"))
+}
+
+func isDefaultGenerationOptions(opts provider.GenerationOptions) bool {
+	return opts.Temperature == nil && opts.TopP == nil && opts.MaxTokens == 0 && len(opts.Stop) == 0 &&
+		opts.Seed == nil && opts.ReasoningEffort == ""
+}
+
+// encodeGenerationOptions stores options as JSON, empty when they leave everything to the defaults
+func encodeGenerationOptions(opts provider.GenerationOptions) string {
+	if isDefaultGenerationOptions(opts) {
+		return ""
+	}
+	data, _ := json.Marshal(opts)
+	return string(data)
+}
+
+func decodeGenerationOptions(data string) (provider.GenerationOptions, error) {
+	var opts provider.GenerationOptions
+	if data == "" {
+		return opts, nil
+	}
+	err := json.Unmarshal([]byte(data), &opts)
+	return opts, err
+}
+
+// storedGenerationOptions is decodeGenerationOptions for stored options, which are left out if they can't be read
+func storedGenerationOptions(data string, source string) provider.GenerationOptions {
+	opts, err := decodeGenerationOptions(data)
+	if err != nil {
+		slog.Warn("ignoring unreadable generation options", "source", source, "error", err)
+	}
+	return opts
+}
+
+// mergeGenerationOptions fills the options opts leaves unset from defaults
+func mergeGenerationOptions(opts provider.GenerationOptions, defaults provider.GenerationOptions) provider.GenerationOptions {
+	if opts.Temperature == nil {
+		opts.Temperature = defaults.Temperature
+	}
+	if opts.TopP == nil {
+		opts.TopP = defaults.TopP
+	}
+	if opts.MaxTokens == 0 {
+		opts.MaxTokens = defaults.MaxTokens
+	}
+	if len(opts.Stop) == 0 {
+		opts.Stop = defaults.Stop
+	}
+	if opts.Seed == nil {
+		opts.Seed = defaults.Seed
+	}
+	if opts.ReasoningEffort == "" {
+		opts.ReasoningEffort = defaults.ReasoningEffort
+	}
+	return opts
+}
+
+// checkOptionRanges checks the options every provider takes
+func checkOptionRanges(opts provider.GenerationOptions) error {
+	switch {
+	case opts.Temperature != nil && (*opts.Temperature < 0 || *opts.Temperature > 2):
+		return fmt.Errorf("%w: temperature must be between 0 and 2", ErrInvalidGenerationOptions)
+	case opts.TopP != nil && (*opts.TopP <= 0 || *opts.TopP > 1):
+		return fmt.Errorf("%w: top_p must be above 0 and at most 1", ErrInvalidGenerationOptions)
+	case opts.MaxTokens < 0:
+		return fmt.Errorf("%w: max_tokens can't be negative", ErrInvalidGenerationOptions)
+	case len(opts.Stop) > maxStopSequences:
+		return fmt.Errorf("%w: at most %d stop sequences", ErrInvalidGenerationOptions, maxStopSequences)
+	}
+	for _, stop := range opts.Stop {
+		if stop == "" {
+			return fmt.Errorf("%w: stop sequences can't be empty", ErrInvalidGenerationOptions)
+		}
+	}
+	return nil
+}
+
+// checkGenerationOptions checks the options against the capabilities of the model and what its provider takes
+func checkGenerationOptions(opts provider.GenerationOptions, llm provider.LLMProvider, model *dao.ModelRow) error {
+	if err := checkOptionRanges(opts); err != nil {
+		return err
+	}
+	if model.MaxOutputTokens > 0 && opts.MaxTokens > model.MaxOutputTokens {
+		return fmt.Errorf("%w: %s generates at most %d tokens", ErrInvalidGenerationOptions, model.ID, model.MaxOutputTokens)
+	}
+	if opts.ReasoningEffort != "" && !model.SupportsReasoning {
+		return fmt.Errorf("%w: %s takes no reasoning effort", ErrInvalidGenerationOptions, model.ID)
+	}
+	if checker, ok := llm.(provider.OptionsChecker); ok {
+		if err := checker.CheckOptions(opts); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidGenerationOptions, model.ID, err)
+		}
+	}
+	return nil
+}
+
+// withDefaults fills the options opts leaves unset from defaults, leaving out the defaults the model doesn't take
+// together with opts. Unlike options of the request they don't fail the reply, the defaults of a chat or project
+// apply to every model used in it.
+func withDefaults(opts provider.GenerationOptions, defaults provider.GenerationOptions, llm provider.LLMProvider, model *dao.ModelRow) provider.GenerationOptions {
+	for _, option := range []provider.GenerationOptions{
+		{ReasoningEffort: defaults.ReasoningEffort},
+		{MaxTokens: defaults.MaxTokens},
+		{Temperature: defaults.Temperature},
+		{TopP: defaults.TopP},
+		{Stop: defaults.Stop},
+		{Seed: defaults.Seed},
+	} {
+		if merged := mergeGenerationOptions(opts, option); checkGenerationOptions(merged, llm, model) == nil {
+			opts = merged
+		}
+	}
+	return opts
+}
+
+// generationOptions resolves the options of a reply. Options set in the request come first, then those of
+// previous (the options of the reply being replaced, JSON), the chat's defaults and its project's.
+func (s *ChatService) generationOptions(listedID string, requested *pb.GenerationOptions, previous string, llm provider.LLMProvider, model *dao.ModelRow) (provider.GenerationOptions, error) {
+	opts, err := fromPBGenerationOptions(requested)
+	if err != nil {
+		return opts, err
+	}
+	if err := checkGenerationOptions(opts, llm, model); err != nil {
+		return opts, err
+	}
+
+	chatDefaults, projectDefaults, err := s.dao.GetGenerationDefaults(listedID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return opts, fmt.Errorf("failed to fetch generation defaults: %w", err)
+	}
+	opts = withDefaults(opts, storedGenerationOptions(previous, "message"), llm, model)
+	opts = withDefaults(opts, storedGenerationOptions(chatDefaults, "chat"), llm, model)
+	return withDefaults(opts, storedGenerationOptions(projectDefaults, "project"), llm, model), nil
+}
+
+// SetGenerationOptions replaces the default generation options of a chat or project and returns them, options
+// of a request take their place one by one
+func (s *ChatService) SetGenerationOptions(ctx context.Context, userID string, req *pb.SetGenerationOptionsRequest) (*pb.GenerationOptions, error) {
+	chatId, projectID := req.GetChatId(), req.GetProjectId()
+	if (chatId == "") == (projectID == "") {
+		return nil, fmt.Errorf("%w: set one of chat_id and project_id", ErrInvalidGenerationOptions)
+	}
+	opts, err := fromPBGenerationOptions(req.GetOptions())
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOptionRanges(opts); err != nil {
+		return nil, err
+	}
+
+	if chatId != "" {
+		if _, err := s.activeChat(userID, chatId); err != nil {
+			return nil, err
+		}
+		if err := s.dao.SetChatGenerationOptions(chatId, encodeGenerationOptions(opts)); err != nil {
+			return nil, fmt.Errorf("failed to set generation options: %w", err)
+		}
+		return toPBGenerationOptions(opts), nil
+	}
+
+	if err := s.requireProjectWrite(userID, projectID); err != nil {
+		return nil, err
+	}
+	if err := s.dao.SetProjectGenerationOptions(projectID, encodeGenerationOptions(opts)); err != nil {
+		return nil, fmt.Errorf("failed to set generation options: %w", err)
+	}
+	return toPBGenerationOptions(opts), nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+	"sortedstartup/chatservice/provider"
+)
+
+// optionsDAO adds generation defaults to the in-memory chats of memoryDAO
+type optionsDAO struct {
+	*memoryDAO
+	options map[string]string // chat or project id -> default options
+}
+
+func (d *optionsDAO) GetGenerationDefaults(chatId string) (string, string, error) {
+	return d.options[chatId], d.options["p1"], nil
+}
+
+func (d *optionsDAO) SetChatGenerationOptions(chatId string, options string) error {
+	d.options[chatId] = options
+	return nil
+}
+
+func (d *optionsDAO) SetProjectGenerationOptions(projectID string, options string) error {
+	d.options[projectID] = options
+	return nil
+}
+
+func TestGenerationOptions(t *testing.T) {
+	d := &optionsDAO{memoryDAO: newMemoryDAO(), options: map[string]string{
+		"main": `{"temperature":0.9,"max_tokens":2000}`,
+		"p1":   `{"stop":["END"],"seed":7,"reasoning_effort":"high"}`,
+	}}
+	s := &ChatService{dao: d}
+	openai := provider.NewOpenAIProvider(provider.Config{})
+	anthropic := provider.NewAnthropicProvider(provider.Config{})
+	gpt := &dao.ModelRow{ID: "gpt-4o", MaxOutputTokens: 16384}
+	sonnet := &dao.ModelRow{ID: "claude-sonnet-4", SupportsReasoning: true, MaxOutputTokens: 64000}
+
+	temperature := 0.2
+	opts, err := s.generationOptions("main", &pb.GenerationOptions{Temperature: &temperature}, `{"top_p":0.5}`, openai, gpt)
+	if err != nil {
+		t.Fatalf("generationOptions failed: %v", err)
+	}
+	if *opts.Temperature != 0.2 || *opts.TopP != 0.5 || opts.MaxTokens != 2000 || !slices.Equal(opts.Stop, []string{"END"}) || *opts.Seed != 7 {
+		t.Errorf("Expected the request's temperature over the defaults, got %+v", opts)
+	}
+	if opts.ReasoningEffort != "" {
+		t.Errorf("Expected the reasoning effort left out for %s, got %q", gpt.ID, opts.ReasoningEffort)
+	}
+
+	// extended thinking takes no temperature and Anthropic no seed, defaults that clash with the request are left out
+	opts, err = s.generationOptions("main", &pb.GenerationOptions{ReasoningEffort: pb.ReasoningEffort_REASONING_EFFORT_HIGH}, "", anthropic, sonnet)
+	if err != nil {
+		t.Fatalf("generationOptions failed: %v", err)
+	}
+	if opts.ReasoningEffort != provider.ReasoningHigh || opts.Temperature != nil || opts.Seed != nil || opts.MaxTokens != 2000 {
+		t.Errorf("Expected the reasoning effort without temperature and seed, got %+v", opts)
+	}
+
+	seed := int64(1)
+	tooHot := 1.5
+	for _, tt := range []struct {
+		options *pb.GenerationOptions
+		llm     provider.LLMProvider
+		model   *dao.ModelRow
+	}{
+		{&pb.GenerationOptions{ReasoningEffort: pb.ReasoningEffort_REASONING_EFFORT_LOW}, openai, gpt},
+		{&pb.GenerationOptions{MaxTokens: 20000}, openai, gpt},
+		{&pb.GenerationOptions{Stop: []string{"a", "b", "c", "d", "e"}}, openai, gpt},
+		{&pb.GenerationOptions{Seed: &seed}, anthropic, sonnet},
+		{&pb.GenerationOptions{Temperature: &tooHot}, anthropic, sonnet},
+		{&pb.GenerationOptions{ReasoningEffort: pb.ReasoningEffort_REASONING_EFFORT_LOW, MaxTokens: 1000}, anthropic, sonnet},
+	} {
+		if _, err := s.generationOptions("main", tt.options, "", tt.llm, tt.model); !errors.Is(err, ErrInvalidGenerationOptions) {
+			t.Errorf("Expected ErrInvalidGenerationOptions for %v on %s, got %v", tt.options, tt.model.ID, err)
+		}
+	}
+}
+
+func TestSetGenerationOptions(t *testing.T) {
+	d := &optionsDAO{memoryDAO: newMemoryDAO(), options: map[string]string{}}
+	s := &ChatService{dao: d}
+	ctx := context.Background()
+
+	temperature := 0.7
+	options, err := s.SetGenerationOptions(ctx, "alice", &pb.SetGenerationOptionsRequest{ChatId: "main", Options: &pb.GenerationOptions{
+		Temperature:     &temperature,
+		ReasoningEffort: pb.ReasoningEffort_REASONING_EFFORT_MEDIUM,
+	}})
+	if err != nil {
+		t.Fatalf("SetGenerationOptions failed: %v", err)
+	}
+	if want := `{"temperature":0.7,"reasoning_effort":"medium"}`; d.options["main"] != want {
+		t.Errorf("Expected %s stored for main, got %s", want, d.options["main"])
+	}
+	if options.GetTemperature() != 0.7 || options.GetReasoningEffort() != pb.ReasoningEffort_REASONING_EFFORT_MEDIUM {
+		t.Errorf("Expected the stored options back, got %v", options)
+	}
+
+	// no options clears the defaults
+	if options, err := s.SetGenerationOptions(ctx, "alice", &pb.SetGenerationOptionsRequest{ProjectId: "p2"}); err != nil || options != nil {
+		t.Fatalf("Expected no options for p2, got %v and %v", options, err)
+	}
+	if v, ok := d.options["p2"]; !ok || v != "" {
+		t.Errorf("Expected the defaults of p2 cleared, got %v", d.options)
+	}
+
+	topP := 0.0
+	tests := []struct {
+		req  *pb.SetGenerationOptionsRequest
+		want error
+	}{
+		{&pb.SetGenerationOptionsRequest{}, ErrInvalidGenerationOptions},
+		{&pb.SetGenerationOptionsRequest{ChatId: "main", ProjectId: "p1"}, ErrInvalidGenerationOptions},
+		{&pb.SetGenerationOptionsRequest{ChatId: "main", Options: &pb.GenerationOptions{TopP: &topP}}, ErrInvalidGenerationOptions},
+		{&pb.SetGenerationOptionsRequest{ChatId: "main", Options: &pb.GenerationOptions{Stop: []string{""}}}, ErrInvalidGenerationOptions},
+		{&pb.SetGenerationOptionsRequest{ChatId: "alternative"}, ErrChatNotFound},
+		{&pb.SetGenerationOptionsRequest{ProjectId: "p3"}, ErrPermissionDenied},
+	}
+	for _, tt := range tests {
+		if _, err := s.SetGenerationOptions(ctx, "alice", tt.req); !errors.Is(err, tt.want) {
+			t.Errorf("Expected %v for %v, got %v", tt.want, tt.req, err)
+		}
+	}
+}
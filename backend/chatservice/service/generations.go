@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	pb "sortedstartup/chatservice/proto"
+)
+
+var (
+	ErrGenerationInProgress = errors.New("a reply is already being generated for this chat")
+	ErrNoGeneration         = errors.New("no reply is being generated for this chat")
+	ErrInvalidResumeOffset  = errors.New("offset is past the end of the reply")
+)
+
+var (
+	// errChatCancelled is the cancel cause of generations stopped with CancelChat
+	errChatCancelled = errors.New("generation cancelled by the user")
+	// errGenerationAbandoned is the cancel cause of generations nobody resumed within the grace period
+	errGenerationAbandoned = errors.New("generation abandoned by the client")
+)
+
+// DefaultResumeGracePeriod is how long a reply keeps being generated without a client streaming it
+const DefaultResumeGracePeriod = time.Minute
+
+/*
+generations tracks the replies being generated by this process. A reply runs independently of
+the Chat call that started it and buffers every ChatResponse it produces, so a client that lost
+its connection can replay them with ResumeChat and CancelChat can stop it from another connection.
+The zero value is ready to use.
+*/
+type generations struct {
+	// gracePeriod overrides DefaultResumeGracePeriod
+	gracePeriod time.Duration
+	// afterFunc starts the grace period timers, time.AfterFunc when nil
+	afterFunc func(d time.Duration, f func()) idleTimer
+
+	mu     sync.Mutex
+	active map[string]*generation // chat id -> generation
+}
+
+// idleTimer is the part of *time.Timer the grace period uses
+type idleTimer interface {
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+type generation struct {
+	userID string
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+
+	mu       sync.Mutex
+	events   []*pb.ChatResponse
+	changed  chan struct{} // closed and replaced whenever events are added or the generation finishes
+	finished bool
+	err      error
+	// followers counts the streams relaying the generation, idle cancels it once there were none for grace
+	followers int
+	grace     time.Duration
+	idle      idleTimer
+}
+
+// start registers a generation for the chat. It is not tied to the caller's context, it ends with
+// finish, CancelChat or when nobody follows it for the grace period (counted from now until the first follow).
+func (g *generations) start(userID string, chatID string) (*generation, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.active[chatID]; ok {
+		return nil, ErrGenerationInProgress
+	}
+	if g.active == nil {
+		g.active = make(map[string]*generation)
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	gen := &generation{
+		userID:  userID,
+		ctx:     ctx,
+		cancel:  cancel,
+		changed: make(chan struct{}),
+		grace:   g.gracePeriod,
+	}
+	if gen.grace == 0 {
+		gen.grace = DefaultResumeGracePeriod
+	}
+	afterFunc := g.afterFunc
+	if afterFunc == nil {
+		afterFunc = func(d time.Duration, f func()) idleTimer { return time.AfterFunc(d, f) }
+	}
+	gen.idle = afterFunc(gen.grace, func() { cancel(errGenerationAbandoned) })
+
+	g.active[chatID] = gen
+	return gen, nil
+}
+
+// get returns the chat's generation, only the user who started it can see it
+func (g *generations) get(userID string, chatID string) (*generation, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	gen, ok := g.active[chatID]
+	if !ok || gen.userID != userID {
+		return nil, ErrNoGeneration
+	}
+	return gen, nil
+}
+
+func (g *generations) cancel(userID string, chatID string) error {
+	gen, err := g.get(userID, chatID)
+	if err != nil {
+		return err
+	}
+	gen.cancel(errChatCancelled)
+	return nil
+}
+
+// finish ends the generation with err, followers get the events buffered so far and then err
+func (g *generations) finish(chatID string, gen *generation, err error) {
+	g.mu.Lock()
+	if g.active[chatID] == gen {
+		delete(g.active, chatID)
+	}
+	g.mu.Unlock()
+
+	gen.mu.Lock()
+	gen.finished = true
+	gen.err = err
+	gen.idle.Stop()
+	close(gen.changed)
+	gen.mu.Unlock()
+
+	gen.cancel(nil)
+}
+
+// publish buffers an event and wakes up the followers
+func (gen *generation) publish(event *pb.ChatResponse) {
+	gen.mu.Lock()
+	defer gen.mu.Unlock()
+
+	gen.events = append(gen.events, event)
+	close(gen.changed)
+	gen.changed = make(chan struct{})
+}
+
+// follow sends the events from offset on and keeps sending new ones until the generation finishes.
+// Returning early (ctx ends or send fails) does not stop the generation.
+func (gen *generation) follow(ctx context.Context, offset int, send func(*pb.ChatResponse) error) error {
+	gen.mu.Lock()
+	if offset < 0 || offset > len(gen.events) {
+		gen.mu.Unlock()
+		return ErrInvalidResumeOffset
+	}
+	gen.followers++
+	gen.idle.Stop()
+	gen.mu.Unlock()
+
+	defer func() {
+		gen.mu.Lock()
+		gen.followers--
+		if gen.followers == 0 && !gen.finished {
+			gen.idle.Reset(gen.grace)
+		}
+		gen.mu.Unlock()
+	}()
+
+	for {
+		gen.mu.Lock()
+		events := gen.events[offset:]
+		changed, finished, err := gen.changed, gen.finished, gen.err
+		gen.mu.Unlock()
+
+		for _, event := range events {
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+		offset += len(events)
+
+		if finished {
+			return err
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
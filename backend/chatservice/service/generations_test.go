@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	pb "sortedstartup/chatservice/proto"
+)
+
+func textEvent(text string) *pb.ChatResponse {
+	return &pb.ChatResponse{Response: &pb.ChatResponse_Text{Text: text}}
+}
+
+func TestGenerationsCancel(t *testing.T) {
+	var g generations
+
+	gen, err := g.start("alice", "chat-1")
+	if err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	if _, err := g.start("alice", "chat-1"); !errors.Is(err, ErrGenerationInProgress) {
+		t.Errorf("Expected ErrGenerationInProgress, got %v", err)
+	}
+
+	if err := g.cancel("bob", "chat-1"); !errors.Is(err, ErrNoGeneration) {
+		t.Errorf("Expected another user not to cancel the generation, got %v", err)
+	}
+	if gen.ctx.Err() != nil {
+		t.Fatalf("Expected the generation to keep running, got %v", gen.ctx.Err())
+	}
+
+	if err := g.cancel("alice", "chat-1"); err != nil {
+		t.Fatalf("cancel failed: %v", err)
+	}
+	if !errors.Is(context.Cause(gen.ctx), errChatCancelled) {
+		t.Errorf("Expected errChatCancelled as the cause, got %v", context.Cause(gen.ctx))
+	}
+
+	g.finish("chat-1", gen, nil)
+	if err := g.cancel("alice", "chat-1"); !errors.Is(err, ErrNoGeneration) {
+		t.Errorf("Expected ErrNoGeneration after finish, got %v", err)
+	}
+	if gen, err := g.start("alice", "chat-1"); err != nil {
+		t.Errorf("Expected a new generation after finish, got %v", err)
+	} else {
+		g.finish("chat-1", gen, nil)
+	}
+}
+
+func TestGenerationFollow(t *testing.T) {
+	var g generations
+	gen, _ := g.start("alice", "chat-1")
+	gen.publish(textEvent("Hello"))
+	gen.publish(textEvent(", "))
+
+	// a client that lost its connection after the first event gets the rest replayed and then the live events
+	var received []string
+	followed := make(chan error)
+	go func() {
+		followed <- gen.follow(context.Background(), 1, func(event *pb.ChatResponse) error {
+			received = append(received, event.GetText())
+			return nil
+		})
+	}()
+
+	gen.publish(textEvent("world"))
+	g.finish("chat-1", gen, errors.New("provider failed"))
+
+	if err := <-followed; err == nil || err.Error() != "provider failed" {
+		t.Errorf("Expected the generation's error, got %v", err)
+	}
+	if len(received) != 2 || received[0] != ", " || received[1] != "world" {
+		t.Errorf("Expected [', ' 'world'], got %q", received)
+	}
+
+	if err := gen.follow(context.Background(), 4, func(*pb.ChatResponse) error { return nil }); !errors.Is(err, ErrInvalidResumeOffset) {
+		t.Errorf("Expected ErrInvalidResumeOffset, got %v", err)
+	}
+}
+
+// manualTimer is an idleTimer that only fires when the test calls fire, stopped gets a value when it is stopped
+type manualTimer struct {
+	mu      sync.Mutex
+	f       func()
+	armed   bool
+	stopped chan struct{}
+}
+
+func (t *manualTimer) Stop() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	armed := t.armed
+	t.armed = false
+	select {
+	case t.stopped <- struct{}{}:
+	default:
+	}
+	return armed
+}
+
+func (t *manualTimer) Reset(d time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	armed := t.armed
+	t.armed = true
+	return armed
+}
+
+// fire runs the timer's function if it is armed, like the timer expiring
+func (t *manualTimer) fire() {
+	t.mu.Lock()
+	armed := t.armed
+	t.armed = false
+	t.mu.Unlock()
+	if armed {
+		t.f()
+	}
+}
+
+func TestGenerationAbandoned(t *testing.T) {
+	timer := &manualTimer{stopped: make(chan struct{}, 1)}
+	g := generations{afterFunc: func(d time.Duration, f func()) idleTimer {
+		timer.f, timer.armed = f, true
+		return timer
+	}}
+	gen, _ := g.start("alice", "chat-1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	followed := make(chan error)
+	go func() {
+		followed <- gen.follow(ctx, 0, func(*pb.ChatResponse) error { return nil })
+	}()
+
+	// the grace period ends while the generation is followed
+	<-timer.stopped
+	timer.fire()
+	if gen.ctx.Err() != nil {
+		t.Fatalf("Expected the generation to run while it is followed, got %v", context.Cause(gen.ctx))
+	}
+
+	cancel()
+	if err := <-followed; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the follower to stop with its context, got %v", err)
+	}
+
+	timer.fire()
+	if !errors.Is(context.Cause(gen.ctx), errGenerationAbandoned) {
+		t.Errorf("Expected errGenerationAbandoned as the cause once nobody followed it, got %v", context.Cause(gen.ctx))
+	}
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"database/sql"
+	"slices"
+	"strconv"
+
+	"sortedstartup/chatservice/dao"
+)
+
+/*
+memoryDAO keeps the chats, messages, projects, workspace w1 and documents the service tests need in memory and
+records the lifecycle calls. Access checks are reduced to lookups here, the queries behind them are tested
+against SQLite in the dao package. The embedded DAO panics on any other call.
+*/
+type memoryDAO struct {
+	dao.DAO
+	chats    map[string]dao.ChatRow
+	messages map[string][]dao.ChatMessageRow // chat id -> path
+	counts   map[string]int                  // alternatives by parent message id
+	projects map[string]map[string]string    // project id -> user id -> role
+	members  map[string]string               // user id -> role in w1
+	docs     map[string]dao.DocumentListRow  // docs_id -> row, User owns the project
+	restored []string
+	trashed  []string
+	purged   []string
+	moved    map[string]string // chat id -> project id
+}
+
+/*
+newMemoryDAO holds two chats:
+
+  - main, in project p1, with a branch in the trash, an alternative and a trashed chat whose branch is trashed too
+  - chat, with messages 1 2 3 4 and its active alternative showing 1 2 3 5
+
+alice owns p1 and w1 and edits p2 and reads p3, bob edits and carol reads p1 and w1.
+*/
+func newMemoryDAO() *memoryDAO {
+	return &memoryDAO{
+		chats: map[string]dao.ChatRow{
+			"main":        {ChatID: "main", UserID: "alice", ProjectID: "p1"},
+			"branch":      {ChatID: "branch", UserID: "alice", ProjectID: "p1", ParentChatID: "main", Deleted: true},
+			"trashed":     {ChatID: "trashed", UserID: "alice", Deleted: true},
+			"orphan":      {ChatID: "orphan", UserID: "alice", ParentChatID: "trashed", Deleted: true},
+			"chat":        {ChatID: "chat", UserID: "alice", ActiveChatID: "alternative"},
+			"alternative": {ChatID: "alternative", UserID: "alice", ParentChatID: "chat", ParentMessageID: "3", AlternativeOf: "chat"},
+		},
+		messages: map[string][]dao.ChatMessageRow{
+			"chat":        {{Id: "1", Role: "user"}, {Id: "2", Role: "assistant"}, {Id: "3", Role: "user"}, {Id: "4", Role: "assistant"}},
+			"alternative": {{Id: "1", Role: "user"}, {Id: "2", Role: "assistant"}, {Id: "3", Role: "user"}, {Id: "5", Role: "assistant"}},
+		},
+		counts: map[string]int{"3": 1, dao.NoParentMessage: 2},
+		projects: map[string]map[string]string{
+			"p1": {"alice": dao.WorkspaceRoleOwner, "bob": dao.WorkspaceRoleEditor, "carol": dao.WorkspaceRoleViewer},
+			"p2": {"alice": dao.WorkspaceRoleEditor},
+			"p3": {"alice": dao.WorkspaceRoleViewer},
+		},
+		members: map[string]string{"alice": dao.WorkspaceRoleOwner, "bob": dao.WorkspaceRoleEditor, "carol": dao.WorkspaceRoleViewer},
+		docs:    map[string]dao.DocumentListRow{},
+		moved:   map[string]string{},
+	}
+}
+
+func (d *memoryDAO) GetChat(userID string, chatId string) (*dao.ChatRow, error) {
+	if _, err := d.GetChatRole(userID, chatId); err != nil {
+		return nil, err
+	}
+	chat := d.chats[chatId]
+	return &chat, nil
+}
+
+func (d *memoryDAO) GetChatRole(userID string, chatId string) (string, error) {
+	chat, ok := d.chats[chatId]
+	if !ok {
+		return "", sql.ErrNoRows
+	}
+	if chat.ProjectID == "" && chat.UserID == userID {
+		return dao.WorkspaceRoleOwner, nil
+	}
+	return d.GetProjectRole(userID, chat.ProjectID)
+}
+
+func (d *memoryDAO) ChatExists(chatId string) (bool, error) {
+	_, ok := d.chats[chatId]
+	return ok, nil
+}
+
+func (d *memoryDAO) GetChatMessages(userID string, chatId string) ([]dao.ChatMessageRow, error) {
+	return d.messages[chatId], nil
+}
+
+func (d *memoryDAO) GetChatMessagePage(userID string, chatId string, page dao.MessagePage) ([]dao.ChatMessageRow, error) {
+	var messages []dao.ChatMessageRow
+	for _, m := range d.messages[chatId] {
+		id, _ := strconv.ParseInt(m.Id, 10, 64)
+		if page.After == 0 || (!page.Descending && id > page.After) || (page.Descending && id < page.After) {
+			messages = append(messages, m)
+		}
+	}
+	if page.Descending {
+		slices.Reverse(messages)
+	}
+	if page.Limit > 0 && len(messages) > page.Limit {
+		messages = messages[:page.Limit]
+	}
+	return messages, nil
+}
+
+func (d *memoryDAO) GetAlternativeCounts(chatId string) (map[string]int, error) {
+	return d.counts, nil
+}
+
+func (d *memoryDAO) TrashChat(chatId string) error {
+	d.trashed = append(d.trashed, chatId)
+	return nil
+}
+
+func (d *memoryDAO) PurgeChat(chatId string) error {
+	d.purged = append(d.purged, chatId)
+	return nil
+}
+
+func (d *memoryDAO) RestoreChat(chatId string) error {
+	d.restored = append(d.restored, chatId)
+	return nil
+}
+
+func (d *memoryDAO) MoveChat(chatId string, projectID string) error {
+	d.moved[chatId] = projectID
+	return nil
+}
+
+func (d *memoryDAO) GetProjectRole(userID string, projectID string) (string, error) {
+	role, ok := d.projects[projectID][userID]
+	if !ok {
+		return "", sql.ErrNoRows
+	}
+	return role, nil
+}
+
+func (d *memoryDAO) GetWorkspaceRole(userID string, workspaceID string) (string, error) {
+	role, ok := d.members[userID]
+	if !ok {
+		return "", sql.ErrNoRows
+	}
+	return role, nil
+}
+
+func (d *memoryDAO) GetWorkspaceMembers(workspaceID string) ([]dao.WorkspaceMemberRow, error) {
+	var rows []dao.WorkspaceMemberRow
+	for userID, role := range d.members {
+		rows = append(rows, dao.WorkspaceMemberRow{WorkspaceID: workspaceID, UserID: userID, Role: role})
+	}
+	return rows, nil
+}
+
+func (d *memoryDAO) UpdateWorkspaceMember(workspaceID string, userID string, role string) error {
+	if _, ok := d.members[userID]; !ok {
+		return sql.ErrNoRows
+	}
+	d.members[userID] = role
+	return nil
+}
+
+func (d *memoryDAO) RemoveWorkspaceMember(workspaceID string, userID string) error {
+	if _, ok := d.members[userID]; !ok {
+		return sql.ErrNoRows
+	}
+	delete(d.members, userID)
+	return nil
+}
+
+func (d *memoryDAO) GetDocument(userID string, docsID string) (*dao.DocumentListRow, error) {
+	doc, ok := d.docs[docsID]
+	if !ok || doc.User != userID {
+		return nil, sql.ErrNoRows
+	}
+	return &doc, nil
+}
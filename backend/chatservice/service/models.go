@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+	"sortedstartup/chatservice/provider"
+)
+
+var (
+	ErrModelNotFound = errors.New("model not found")
+	ErrModelExists   = errors.New("model already exists")
+	ErrInvalidModel  = errors.New("invalid model")
+)
+
+func toPBModel(m dao.ModelRow) *pb.ModelListInfo {
+	return &pb.ModelListInfo{
+		Id:                m.ID,
+		Label:             m.Name,
+		Provider:          m.Provider,
+		Url:               m.URL,
+		InputTokenCost:    float32(m.InputTokenCost),
+		OutputTokenCost:   float32(m.OutputTokenCost),
+		SupportsVision:    m.SupportsVision,
+		SupportsTools:     m.SupportsTools,
+		ContextWindow:     int32(m.ContextWindow),
+		Enabled:           &m.Enabled,
+		SupportsReasoning: m.SupportsReasoning,
+		MaxOutputTokens:   int32(m.MaxOutputTokens),
+		ProviderModelId:   m.ProviderModelID,
+	}
+}
+
+func fromPBModel(m *pb.ModelListInfo) (dao.ModelRow, error) {
+	if m == nil || strings.TrimSpace(m.Id) == "" {
+		return dao.ModelRow{}, fmt.Errorf("%w: id is required", ErrInvalidModel)
+	}
+	if m.ContextWindow < 0 || m.MaxOutputTokens < 0 || m.InputTokenCost < 0 || m.OutputTokenCost < 0 {
+		return dao.ModelRow{}, fmt.Errorf("%w: context window, max output tokens and token costs can't be negative", ErrInvalidModel)
+	}
+
+	name := m.Label
+	if name == "" {
+		name = m.Id
+	}
+	return dao.ModelRow{
+		ID:                strings.TrimSpace(m.Id),
+		Name:              name,
+		URL:               m.Url,
+		Provider:          m.Provider,
+		InputTokenCost:    float64(m.InputTokenCost),
+		OutputTokenCost:   float64(m.OutputTokenCost),
+		SupportsVision:    m.SupportsVision,
+		SupportsTools:     m.SupportsTools,
+		ContextWindow:     int(m.ContextWindow),
+		Enabled:           m.Enabled == nil || *m.Enabled,
+		SupportsReasoning: m.SupportsReasoning,
+		MaxOutputTokens:   int(m.MaxOutputTokens),
+		ProviderModelID:   strings.TrimSpace(m.ProviderModelId),
+	}, nil
+}
+
+// ListModel returns the model catalog followed by the models pulled into the local Ollama
+func (s *ChatService) ListModel(ctx context.Context, includeDisabled bool) ([]*pb.ModelListInfo, error) {
+	// disabled models are fetched too, synced Ollama models that are disabled in the catalog stay hidden
+	models, err := s.dao.GetModels(true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch models: %v", err)
+	}
+
+	pbModels := make([]*pb.ModelListInfo, 0, len(models))
+	inCatalog := make(map[string]bool, len(models))
+	for i := range models {
+		inCatalog[models[i].ID] = true
+		if models[i].Enabled || includeDisabled {
+			pbModels = append(pbModels, toPBModel(models[i]))
+		}
+	}
+
+	for _, m := range s.listOllamaModels(ctx) {
+		if !inCatalog[m.Id] {
+			pbModels = append(pbModels, m)
+		}
+	}
+
+	return pbModels, nil
+}
+
+const ollamaListTimeout = 2 * time.Second
+
+// listOllamaModels returns the models pulled into the local Ollama.
+// Errors are only logged, an Ollama that is not running should not hide the catalog models.
+func (s *ChatService) listOllamaModels(ctx context.Context) []*pb.ModelListInfo {
+	settings_ := s.settingsManager.GetSettings()
+	cfg, _ := settings_.GetProvider(provider.TypeOllama)
+	ollamaURL := cfg.BaseURL
+	if ollamaURL == "" {
+		ollamaURL = settings_.OllamaURL
+	}
+	if ollamaURL == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ollamaListTimeout)
+	defer cancel()
+
+	ollama := provider.NewOllamaProvider(provider.Config{URL: ollamaURL, Headers: cfg.Headers})
+	models, err := ollama.ListModels(ctx)
+	if err != nil {
+		slog.Warn("failed to list Ollama models", "error", err)
+		return nil
+	}
+
+	var pbModels []*pb.ModelListInfo
+	enabled := true
+	for _, m := range models {
+		// embedding models (e.g. nomic-embed-text used by RAG) can't chat
+		if strings.Contains(m.ID, "embed") {
+			continue
+		}
+		pbModels = append(pbModels, &pb.ModelListInfo{
+			Id:       provider.OllamaModelPrefix + m.ID,
+			Label:    m.Name,
+			Provider: provider.TypeOllama,
+			Url:      provider.OllamaBaseURL(ollamaURL),
+			Enabled:  &enabled,
+		})
+	}
+	return pbModels
+}
+
+// CreateModel adds a model to the catalog, it is enabled unless enabled is set to false
+func (s *ChatService) CreateModel(ctx context.Context, model *pb.ModelListInfo) (*pb.ModelListInfo, error) {
+	row, err := fromPBModel(model)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.dao.GetModel(row.ID); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrModelExists, row.ID)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to fetch model metadata: %w", err)
+	}
+
+	if err := s.dao.CreateModel(row); err != nil {
+		return nil, fmt.Errorf("failed to create model: %w", err)
+	}
+	return toPBModel(row), nil
+}
+
+// UpdateModel replaces the catalog entry, a model sent without enabled keeps whether it is enabled
+func (s *ChatService) UpdateModel(ctx context.Context, model *pb.ModelListInfo) (*pb.ModelListInfo, error) {
+	row, err := fromPBModel(model)
+	if err != nil {
+		return nil, err
+	}
+	if model.Enabled == nil {
+		stored, err := s.dao.GetModel(row.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", ErrModelNotFound, row.ID)
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to fetch model metadata: %w", err)
+		}
+		row.Enabled = stored.Enabled
+	}
+
+	err = s.dao.UpdateModel(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrModelNotFound, row.ID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to update model: %w", err)
+	}
+	return toPBModel(row), nil
+}
+
+func (s *ChatService) DeleteModel(ctx context.Context, modelID string) error {
+	err := s.dao.DeleteModel(modelID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s", ErrModelNotFound, modelID)
+	} else if err != nil {
+		return fmt.Errorf("failed to delete model: %w", err)
+	}
+	return nil
+}
+
+const syncModelsTimeout = 30 * time.Second
+
+// SyncModelsFromProvider adds the models listed by the provider's API to the catalog. New models are
+// added disabled so a provider listing hundreds of models doesn't flood the model picker, existing rows
+// are not touched. Returns the added models and the number of models the provider reported.
+func (s *ChatService) SyncModelsFromProvider(ctx context.Context, providerName string) ([]*pb.ModelListInfo, int, error) {
+	cfg, ok := s.settingsManager.GetSettings().GetProvider(providerName)
+	if !ok {
+		return nil, 0, fmt.Errorf("provider %s is not configured", providerName)
+	}
+
+	llm, err := s.newProvider(cfg, "")
+	if err != nil {
+		return nil, 0, err
+	}
+	lister, ok := llm.(provider.ModelLister)
+	if !ok {
+		return nil, 0, fmt.Errorf("provider type %s can't list models", cfg.Type)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, syncModelsTimeout)
+	defer cancel()
+
+	models, err := lister.ListModels(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list models of provider %s: %w", providerName, err)
+	}
+
+	var added []*pb.ModelListInfo
+	for _, m := range models {
+		id := m.ID
+		if cfg.Type == provider.TypeOllama {
+			id = provider.OllamaModelPrefix + id
+		}
+
+		if _, err := s.dao.GetModel(id); err == nil {
+			continue
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return added, len(models), fmt.Errorf("failed to fetch model metadata: %w", err)
+		}
+
+		name := m.Name
+		if name == "" {
+			name = m.ID
+		}
+		row := dao.ModelRow{
+			ID:            id,
+			Name:          name,
+			URL:           cfg.BaseURL,
+			Provider:      providerName,
+			ContextWindow: m.ContextWindow,
+		}
+		if err := s.dao.CreateModel(row); err != nil {
+			return added, len(models), fmt.Errorf("failed to create model %s: %w", id, err)
+		}
+		added = append(added, toPBModel(row))
+	}
+
+	slog.Info("Synced models from provider", "provider", providerName, "reported", len(models), "added", len(added))
+	return added, len(models), nil
+}
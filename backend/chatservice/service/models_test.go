@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+	"sortedstartup/chatservice/queue"
+	"sortedstartup/chatservice/settings"
+)
+
+// modelsDAO keeps the model catalog in memory
+type modelsDAO struct {
+	dao.DAO
+	models map[string]dao.ModelRow
+}
+
+func (d *modelsDAO) GetModels(includeDisabled bool) ([]dao.ModelRow, error) {
+	var models []dao.ModelRow
+	for _, m := range d.models {
+		if m.Enabled || includeDisabled {
+			models = append(models, m)
+		}
+	}
+	return models, nil
+}
+
+func (d *modelsDAO) GetModel(modelID string) (*dao.ModelRow, error) {
+	m, ok := d.models[modelID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &m, nil
+}
+
+func (d *modelsDAO) CreateModel(model dao.ModelRow) error {
+	d.models[model.ID] = model
+	return nil
+}
+
+func (d *modelsDAO) UpdateModel(model dao.ModelRow) error {
+	if _, ok := d.models[model.ID]; !ok {
+		return sql.ErrNoRows
+	}
+	d.models[model.ID] = model
+	return nil
+}
+
+type settingsFactory struct {
+	dao.DAOFactory
+}
+
+func (settingsFactory) CreateSettingsDAO() (dao.SettingsDAO, error) {
+	return settingsDAO{}, nil
+}
+
+func TestModelCatalogEnabled(t *testing.T) {
+	d := &modelsDAO{models: map[string]dao.ModelRow{}}
+	manager := settings.NewSettingsManager(queue.NewInMemoryQueue(), settingsFactory{}, nil)
+	if err := manager.LoadSettings(&settings.Settings{}); err != nil {
+		t.Fatalf("LoadSettings failed: %v", err)
+	}
+	s := &ChatService{dao: d, settingsManager: manager}
+	ctx := context.Background()
+
+	if _, err := s.CreateModel(ctx, &pb.ModelListInfo{Id: "gpt-4o"}); err != nil {
+		t.Fatalf("CreateModel failed: %v", err)
+	}
+	if !d.models["gpt-4o"].Enabled {
+		t.Errorf("Expected a model created without enabled to be enabled")
+	}
+
+	disabled := false
+	if _, err := s.UpdateModel(ctx, &pb.ModelListInfo{Id: "gpt-4o", Enabled: &disabled}); err != nil {
+		t.Fatalf("UpdateModel failed: %v", err)
+	}
+	if _, err := s.UpdateModel(ctx, &pb.ModelListInfo{Id: "gpt-4o", Label: "GPT-4o"}); err != nil {
+		t.Fatalf("UpdateModel failed: %v", err)
+	}
+	if m := d.models["gpt-4o"]; m.Enabled || m.Name != "GPT-4o" {
+		t.Errorf("Expected the model to stay disabled, got %+v", m)
+	}
+
+	if models, _ := s.ListModel(ctx, false); len(models) != 0 {
+		t.Errorf("Expected disabled models to be hidden, got %v", models)
+	}
+	if models, _ := s.ListModel(ctx, true); len(models) != 1 || models[0].GetEnabled() {
+		t.Errorf("Expected the disabled model with include_disabled, got %v", models)
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+)
+
+// ErrInvalidPage is returned for negative page sizes and page tokens that don't belong to the request
+var ErrInvalidPage = errors.New("invalid page request")
+
+// maxPageSize caps the page size of paginated lists
+const maxPageSize = 200
+
+var chatListSorts = map[pb.ChatSort]dao.ChatListSort{
+	pb.ChatSort_CHAT_SORT_LAST_MESSAGE: dao.ChatSortLastMessage,
+	pb.ChatSort_CHAT_SORT_CREATED:      dao.ChatSortCreated,
+	pb.ChatSort_CHAT_SORT_UPDATED:      dao.ChatSortUpdated,
+	pb.ChatSort_CHAT_SORT_NAME:         dao.ChatSortName,
+}
+
+// chatListPageToken is where the next page of a chat list starts, with the sort it is only valid for
+type chatListPageToken struct {
+	Sort       dao.ChatListSort `json:"sort"`
+	Descending bool             `json:"desc"`
+	dao.ChatListCursor
+}
+
+// historyPageToken is where the next page of a chat's history starts
+type historyPageToken struct {
+	Descending bool  `json:"desc"`
+	After      int64 `json:"after"`
+}
+
+// pageLimit checks a requested page size, 0 stands for everything
+func pageLimit(pageSize int32) (int, error) {
+	if pageSize < 0 {
+		return 0, fmt.Errorf("%w: page size must not be negative", ErrInvalidPage)
+	}
+	return min(int(pageSize), maxPageSize), nil
+}
+
+// descending resolves a requested sort order, defaultDescending applies to SORT_ORDER_DEFAULT
+func descending(order pb.SortOrder, defaultDescending bool) (bool, error) {
+	switch order {
+	case pb.SortOrder_SORT_ORDER_DEFAULT:
+		return defaultDescending, nil
+	case pb.SortOrder_SORT_ORDER_ASCENDING:
+		return false, nil
+	case pb.SortOrder_SORT_ORDER_DESCENDING:
+		return true, nil
+	default:
+		return false, fmt.Errorf("%w: unsupported sort order %v", ErrInvalidPage, order)
+	}
+}
+
+// encodePageToken turns a position into an opaque page token
+func encodePageToken(position any) string {
+	data, _ := json.Marshal(position)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(token string, position any) error {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(data, position)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: malformed page token", ErrInvalidPage)
+	}
+	return nil
+}
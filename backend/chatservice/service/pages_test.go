@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+)
+
+// chatListDAO returns a fixed chat list and records the query it was asked for
+type chatListDAO struct {
+	dao.DAO
+	chats []dao.ChatListRow
+	query dao.ChatListQuery
+}
+
+func (d *chatListDAO) GetChatList(userID string, q dao.ChatListQuery) ([]dao.ChatListRow, error) {
+	d.query = q
+	if q.Limit > 0 && len(d.chats) > q.Limit {
+		return d.chats[:q.Limit], nil
+	}
+	return d.chats, nil
+}
+
+func TestGetHistoryPages(t *testing.T) {
+	s := &ChatService{dao: newMemoryDAO()}
+	ctx := context.Background()
+
+	// the active path is 1 2 3 5, going back from the newest message two at a time
+	req := &pb.GetHistoryRequest{ChatId: "chat", PageSize: 2, Order: pb.SortOrder_SORT_ORDER_DESCENDING}
+	first, err := s.GetHistory(ctx, "alice", req)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(first.GetHistory()) != 2 || first.GetHistory()[0].GetMessageId() != "5" || first.GetHistory()[1].GetMessageId() != "3" {
+		t.Fatalf("Expected messages 5 3, got %v", first.GetHistory())
+	}
+	// 3 is the first message after 2, which has a second version
+	if first.GetHistory()[0].GetAlternatives() != 2 || first.GetHistory()[1].GetAlternatives() != 1 {
+		t.Errorf("Expected 2 and 1 alternatives, got %v", first.GetHistory())
+	}
+	if first.GetNextPageToken() == "" {
+		t.Fatalf("Expected a next page token")
+	}
+
+	req.PageToken = first.GetNextPageToken()
+	second, err := s.GetHistory(ctx, "alice", req)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(second.GetHistory()) != 2 || second.GetHistory()[0].GetMessageId() != "2" || second.GetHistory()[1].GetAlternatives() != 3 {
+		t.Errorf("Expected messages 2 1 with 3 versions of 1, got %v", second.GetHistory())
+	}
+	if second.GetNextPageToken() != "" {
+		t.Errorf("Expected no page after the first message, got %q", second.GetNextPageToken())
+	}
+
+	// a token going back can't continue forwards
+	req.Order = pb.SortOrder_SORT_ORDER_ASCENDING
+	req.PageToken = first.GetNextPageToken()
+	if _, err := s.GetHistory(ctx, "alice", req); !errors.Is(err, ErrInvalidPage) {
+		t.Errorf("Expected ErrInvalidPage for a token of the other order, got %v", err)
+	}
+	req.PageToken = "not a token"
+	if _, err := s.GetHistory(ctx, "alice", req); !errors.Is(err, ErrInvalidPage) {
+		t.Errorf("Expected ErrInvalidPage for a malformed token, got %v", err)
+	}
+}
+
+func TestGetChatListPages(t *testing.T) {
+	row := func(id string, rowID int64, value string) dao.ChatListRow {
+		return dao.ChatListRow{ChatInfoRow: dao.ChatInfoRow{Id: id, Name: id}, RowID: rowID, SortValue: value}
+	}
+	d := &chatListDAO{chats: []dao.ChatListRow{row("c", 3, "2026-01-03"), row("b", 2, "2026-01-02"), row("a", 1, "2026-01-01")}}
+	s := &ChatService{dao: d}
+	ctx := context.Background()
+
+	chats, next, err := s.GetChatList(ctx, "alice", &pb.GetChatListRequest{ProjectId: "null", PageSize: 2})
+	if err != nil {
+		t.Fatalf("GetChatList failed: %v", err)
+	}
+	if len(chats) != 2 || next == "" {
+		t.Fatalf("Expected a page of 2 chats and a next page, got %d and %q", len(chats), next)
+	}
+	if d.query.ProjectID != "" || d.query.Sort != dao.ChatSortLastMessage || !d.query.Descending || d.query.Limit != 3 {
+		t.Errorf("Expected the recent chats without project first, got %+v", d.query)
+	}
+
+	if _, _, err := s.GetChatList(ctx, "alice", &pb.GetChatListRequest{PageSize: 2, PageToken: next}); err != nil {
+		t.Fatalf("GetChatList failed: %v", err)
+	}
+	if d.query.After == nil || d.query.After.RowID != 2 || d.query.After.SortValue != "2026-01-02" {
+		t.Errorf("Expected the next page to start after b, got %+v", d.query.After)
+	}
+
+	if _, _, err := s.GetChatList(ctx, "alice", &pb.GetChatListRequest{Sort: pb.ChatSort_CHAT_SORT_NAME}); err != nil {
+		t.Fatalf("GetChatList failed: %v", err)
+	}
+	if d.query.Descending || d.query.Limit != 0 {
+		t.Errorf("Expected every chat by name from A to Z, got %+v", d.query)
+	}
+
+	if _, _, err := s.GetChatList(ctx, "alice", &pb.GetChatListRequest{Sort: pb.ChatSort_CHAT_SORT_NAME, PageToken: next}); !errors.Is(err, ErrInvalidPage) {
+		t.Errorf("Expected ErrInvalidPage for a token of another sort, got %v", err)
+	}
+	if _, _, err := s.GetChatList(ctx, "alice", &pb.GetChatListRequest{PageSize: -1}); !errors.Is(err, ErrInvalidPage) {
+		t.Errorf("Expected ErrInvalidPage for a negative page size, got %v", err)
+	}
+}
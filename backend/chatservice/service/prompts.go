@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidSystemPrompt    = errors.New("invalid system prompt request")
+	ErrInvalidPromptTemplate  = errors.New("invalid prompt template")
+	ErrPromptTemplateNotFound = errors.New("prompt template not found")
+)
+
+// templateVariable matches {{ name }}, names are letters, digits and underscores
+var templateVariable = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// templateVariables returns the variables of a template in the order they first appear
+func templateVariables(content string) []string {
+	var names []string
+	for _, m := range templateVariable.FindAllStringSubmatch(content, -1) {
+		if !slices.Contains(names, m[1]) {
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// checkTemplate rejects templates with {{ that don't form a variable
+func checkTemplate(content string) error {
+	if rest := templateVariable.ReplaceAllString(content, ""); strings.Contains(rest, "{{") || strings.Contains(rest, "}}") {
+		return fmt.Errorf("%w: variables are written {{ name }}, names are letters, digits and underscores", ErrInvalidPromptTemplate)
+	}
+	return nil
+}
+
+// renderTemplate substitutes the variables of a template, every variable needs a value. Values are inserted as
+// they are, braces in them are not substituted again.
+func renderTemplate(content string, variables map[string]string) (string, error) {
+	var missing []string
+	for _, name := range templateVariables(content) {
+		if _, ok := variables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("%w: no value for %s", ErrInvalidPromptTemplate, strings.Join(missing, ", "))
+	}
+
+	return templateVariable.ReplaceAllStringFunc(content, func(m string) string {
+		return variables[templateVariable.FindStringSubmatch(m)[1]]
+	}), nil
+}
+
+func toPBPromptTemplate(t dao.PromptTemplateRow) *pb.PromptTemplate {
+	return &pb.PromptTemplate{
+		Id:          t.ID,
+		ProjectId:   t.ProjectID,
+		Name:        t.Name,
+		Description: t.Description,
+		Content:     t.Content,
+		Variables:   templateVariables(t.Content),
+		CreatedAt:   t.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// withSystemPrompt sends the system prompt ahead of everything else, the chat's summary included
+func withSystemPrompt(history []dao.ChatMessageRow, prompt string) []dao.ChatMessageRow {
+	if prompt == "" {
+		return history
+	}
+	return append([]dao.ChatMessageRow{{Role: "system", Content: prompt}}, history...)
+}
+
+// systemPrompt returns the system prompt of a listed chat, empty if neither the chat nor its project has one
+func (s *ChatService) systemPrompt(chatId string) string {
+	prompt, err := s.dao.GetSystemPrompt(chatId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.Warn("sending the chat without its system prompt", "chat_id", chatId, "error", err)
+	}
+	return prompt
+}
+
+// SetSystemPrompt sets or removes the system prompt of a chat or project and returns it. With a template the
+// system prompt is the template rendered with the request's variables.
+func (s *ChatService) SetSystemPrompt(ctx context.Context, userID string, req *pb.SetSystemPromptRequest) (string, error) {
+	chatId, projectID := req.GetChatId(), req.GetProjectId()
+	if (chatId == "") == (projectID == "") {
+		return "", fmt.Errorf("%w: set one of chat_id and project_id", ErrInvalidSystemPrompt)
+	}
+
+	prompt := req.GetSystemPrompt()
+	if req.GetTemplateId() != "" {
+		if prompt != "" {
+			return "", fmt.Errorf("%w: set system_prompt or template_id, not both", ErrInvalidSystemPrompt)
+		}
+		template, err := s.dao.GetPromptTemplate(userID, req.GetTemplateId())
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("%w: %s", ErrPromptTemplateNotFound, req.GetTemplateId())
+		} else if err != nil {
+			return "", fmt.Errorf("failed to fetch prompt template: %w", err)
+		}
+		if prompt, err = renderTemplate(template.Content, req.GetVariables()); err != nil {
+			return "", err
+		}
+	} else if len(req.GetVariables()) > 0 {
+		return "", fmt.Errorf("%w: variables need a template_id", ErrInvalidSystemPrompt)
+	}
+	prompt = strings.TrimSpace(prompt)
+
+	if chatId != "" {
+		if _, err := s.activeChat(userID, chatId); err != nil {
+			return "", err
+		}
+		if err := s.dao.SetChatSystemPrompt(chatId, prompt); err != nil {
+			return "", fmt.Errorf("failed to set system prompt: %w", err)
+		}
+		return prompt, nil
+	}
+
+	if err := s.requireProjectWrite(userID, projectID); err != nil {
+		return "", err
+	}
+	if err := s.dao.SetProjectSystemPrompt(projectID, prompt); err != nil {
+		return "", fmt.Errorf("failed to set system prompt: %w", err)
+	}
+	return prompt, nil
+}
+
+// CreatePromptTemplate adds a template to the user's own templates, or to a project the user can edit
+func (s *ChatService) CreatePromptTemplate(ctx context.Context, userID string, projectID string, name string, description string, content string) (*pb.PromptTemplate, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.TrimSpace(content) == "" {
+		return nil, fmt.Errorf("%w: name and content are required", ErrInvalidPromptTemplate)
+	}
+	if err := checkTemplate(content); err != nil {
+		return nil, err
+	}
+	if projectID == "null" {
+		projectID = ""
+	}
+	if projectID != "" {
+		if err := s.requireProjectWrite(userID, projectID); err != nil {
+			return nil, err
+		}
+	}
+
+	template := dao.PromptTemplateRow{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		ProjectID:   projectID,
+		Name:        name,
+		Description: strings.TrimSpace(description),
+		Content:     content,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := s.dao.CreatePromptTemplate(template); err != nil {
+		return nil, fmt.Errorf("failed to create prompt template: %w", err)
+	}
+	return toPBPromptTemplate(template), nil
+}
+
+// ListPromptTemplates returns the user's own templates and, for a project, the project's
+func (s *ChatService) ListPromptTemplates(ctx context.Context, userID string, projectID string) ([]*pb.PromptTemplate, error) {
+	if projectID == "null" {
+		projectID = ""
+	}
+	if projectID != "" {
+		if _, err := s.dao.GetProjectRole(userID, projectID); errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", ErrProjectNotFound, projectID)
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to check project access: %w", err)
+		}
+	}
+
+	rows, err := s.dao.GetPromptTemplates(userID, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch prompt templates: %w", err)
+	}
+	templates := make([]*pb.PromptTemplate, 0, len(rows))
+	for _, t := range rows {
+		templates = append(templates, toPBPromptTemplate(t))
+	}
+	return templates, nil
+}
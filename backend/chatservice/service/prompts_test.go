@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+)
+
+// promptsDAO adds system prompts and templates to the in-memory chats of memoryDAO
+type promptsDAO struct {
+	*memoryDAO
+	templates map[string]dao.PromptTemplateRow
+	prompts   map[string]string // chat or project id -> system prompt
+}
+
+func (d *promptsDAO) GetPromptTemplate(userID string, templateID string) (*dao.PromptTemplateRow, error) {
+	template, ok := d.templates[templateID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &template, nil
+}
+
+func (d *promptsDAO) SetChatSystemPrompt(chatId string, prompt string) error {
+	d.prompts[chatId] = prompt
+	return nil
+}
+
+func (d *promptsDAO) SetProjectSystemPrompt(projectID string, prompt string) error {
+	d.prompts[projectID] = prompt
+	return nil
+}
+
+func TestRenderTemplate(t *testing.T) {
+	content := "You review {{ language }} code for {{team}}. Answer in {{ language }} terms."
+
+	if got := templateVariables(content); !slices.Equal(got, []string{"language", "team"}) {
+		t.Errorf("Expected the variables in the order they appear, got %v", got)
+	}
+
+	got, err := renderTemplate(content, map[string]string{"language": "Go", "team": "{{ team }}"})
+	if err != nil {
+		t.Fatalf("renderTemplate failed: %v", err)
+	}
+	if want := "You review Go code for {{ team }}. Answer in Go terms."; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	if _, err := renderTemplate(content, map[string]string{"language": "Go"}); !errors.Is(err, ErrInvalidPromptTemplate) {
+		t.Errorf("Expected ErrInvalidPromptTemplate for a missing variable, got %v", err)
+	}
+	if err := checkTemplate("Hello {{ first name }}"); !errors.Is(err, ErrInvalidPromptTemplate) {
+		t.Errorf("Expected ErrInvalidPromptTemplate for an invalid variable, got %v", err)
+	}
+	if err := checkTemplate(content); err != nil {
+		t.Errorf("Expected a valid template, got %v", err)
+	}
+}
+
+func TestWithSystemPrompt(t *testing.T) {
+	history := withSummary([]dao.ChatMessageRow{
+		{Id: "1", Role: "user", Content: "hi"},
+		{Id: "2", Role: "user", Content: "question"},
+	}, &dao.ChatSummaryRow{Summary: "greetings", UpToMessageID: "1"})
+
+	got := withSystemPrompt(history, "Be brief.")
+	if len(got) != 3 || got[0].Content != "Be brief." || got[1].Role != "system" || got[2].Id != "2" {
+		t.Errorf("Expected the system prompt ahead of the summary, got %v", got)
+	}
+	if got := withSystemPrompt(history, ""); len(got) != 2 {
+		t.Errorf("Expected no system message without a system prompt, got %v", got)
+	}
+}
+
+func TestSetSystemPrompt(t *testing.T) {
+	d := &promptsDAO{
+		memoryDAO: newMemoryDAO(),
+		templates: map[string]dao.PromptTemplateRow{"t1": {ID: "t1", Content: "Answer as a {{ role }}."}},
+		prompts:   map[string]string{},
+	}
+	s := &ChatService{dao: d}
+	ctx := context.Background()
+
+	prompt, err := s.SetSystemPrompt(ctx, "alice", &pb.SetSystemPromptRequest{ChatId: "main", TemplateId: "t1", Variables: map[string]string{"role": "lawyer"}})
+	if err != nil {
+		t.Fatalf("SetSystemPrompt failed: %v", err)
+	}
+	if prompt != "Answer as a lawyer." || d.prompts["main"] != prompt {
+		t.Errorf("Expected the rendered template on main, got %q and %v", prompt, d.prompts)
+	}
+
+	if _, err := s.SetSystemPrompt(ctx, "alice", &pb.SetSystemPromptRequest{ProjectId: "p2", SystemPrompt: " Be brief. "}); err != nil {
+		t.Fatalf("SetSystemPrompt failed: %v", err)
+	}
+	if d.prompts["p2"] != "Be brief." {
+		t.Errorf("Expected the trimmed system prompt on p2, got %v", d.prompts)
+	}
+
+	tests := []struct {
+		req  *pb.SetSystemPromptRequest
+		want error
+	}{
+		{&pb.SetSystemPromptRequest{SystemPrompt: "Be brief."}, ErrInvalidSystemPrompt},
+		{&pb.SetSystemPromptRequest{ChatId: "main", ProjectId: "p1"}, ErrInvalidSystemPrompt},
+		{&pb.SetSystemPromptRequest{ChatId: "main", SystemPrompt: "Be brief.", TemplateId: "t1"}, ErrInvalidSystemPrompt},
+		{&pb.SetSystemPromptRequest{ChatId: "main", Variables: map[string]string{"role": "lawyer"}}, ErrInvalidSystemPrompt},
+		{&pb.SetSystemPromptRequest{ChatId: "main", TemplateId: "t1"}, ErrInvalidPromptTemplate},
+		{&pb.SetSystemPromptRequest{ChatId: "main", TemplateId: "t2"}, ErrPromptTemplateNotFound},
+		{&pb.SetSystemPromptRequest{ChatId: "alternative", SystemPrompt: "Be brief."}, ErrChatNotFound},
+		{&pb.SetSystemPromptRequest{ProjectId: "p3", SystemPrompt: "Be brief."}, ErrPermissionDenied},
+	}
+	for _, tt := range tests {
+		if _, err := s.SetSystemPrompt(ctx, "alice", tt.req); !errors.Is(err, tt.want) {
+			t.Errorf("Expected %v for %v, got %v", tt.want, tt.req, err)
+		}
+	}
+}
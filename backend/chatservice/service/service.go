@@ -1,22 +1,26 @@
 package service
 
 import (
-	"bufio"
-	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"log/slog"
 	"mime/multipart"
-	"net/http"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"sortedstartup/chatservice/dao"
 	"sortedstartup/chatservice/events"
 	pb "sortedstartup/chatservice/proto"
+	"sortedstartup/chatservice/provider"
 	"sortedstartup/chatservice/queue"
 	"sortedstartup/chatservice/rag"
 	settings "sortedstartup/chatservice/settings"
@@ -28,11 +32,16 @@ import (
 type ChatService struct {
 	dao                dao.DAO
 	settingsDAO        dao.SettingsDAO
-	store              *store.DiskObjectStore
+	store              store.ObjectStore
 	queue              queue.Queue
 	pipeline           rag.RAGIndexingPipeline
 	embeddingsProvider rag.Embedder
 	settingsManager    *settings.SettingsManager
+
+	budgetWarnings budgetWarnings
+	generations    generations
+	// summarizing holds the listed chats being summarized
+	summarizing sync.Map
 }
 
 type GenerateEmbeddingMessage struct {
@@ -77,14 +86,91 @@ func NewChatService(queue queue.Queue, settingsManager *settings.SettingsManager
 	}, nil
 }
 
-func (s *ChatService) Chat(ctx context.Context, userID string, req *pb.ChatRequest, stream func(*pb.ChatResponse) error) error {
-	projectID := req.GetProjectId()
+// providerForModel resolves the LLMProvider that serves the given model together with the model's
+// catalog row. The model's provider column is looked up in the settings provider registry, models
+// whose provider has no entry go through the default provider (usually an OpenAI compatible endpoint like LiteLLM)
+func (s *ChatService) providerForModel(modelID string) (provider.LLMProvider, *dao.ModelRow, error) {
+	settings_ := s.settingsManager.GetSettings()
+
+	model, err := s.dao.GetModel(modelID)
+	if errors.Is(err, sql.ErrNoRows) {
+		model = &dao.ModelRow{ID: modelID, Enabled: true}
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch model metadata: %w", err)
+	}
+	if !model.Enabled {
+		return nil, nil, fmt.Errorf("model %s is disabled", modelID)
+	}
+
+	// locally pulled Ollama models are listed by ListModel without being part of the catalog
+	if strings.HasPrefix(modelID, provider.OllamaModelPrefix) {
+		llm, err := s.ollamaProvider("")
+		return llm, model, err
+	}
+
+	cfg, ok := settings_.GetProvider(model.Provider)
+	if !ok {
+		cfg, ok = settings_.GetProvider(settings.DefaultProviderName)
+	}
+	if !ok {
+		return nil, nil, fmt.Errorf("no provider configured for model %s", modelID)
+	}
+
+	llm, err := s.newProvider(cfg, model.URL)
+	return llm, model, err
+}
+
+// newProvider builds the provider for a registry entry, modelURL is the url column of the catalog
+// and is used by native providers whose entry has no base URL
+func (s *ChatService) newProvider(cfg settings.ProviderConfig, modelURL string) (provider.LLMProvider, error) {
+	if cfg.Type == provider.TypeOllama && cfg.BaseURL == "" {
+		return s.ollamaProvider(modelURL)
+	}
+
+	url := cfg.BaseURL
+	if url == "" && cfg.Type != provider.TypeOpenAI {
+		url = modelURL
+	}
+
+	return provider.New(provider.Config{
+		Type:    cfg.Type,
+		URL:     url,
+		APIKey:  cfg.APIKey,
+		Headers: cfg.Headers,
+		Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second,
+	})
+}
+
+// ollamaProvider uses the given URL, the "ollama" registry entry or the OLLAMA_URL setting, in that order
+func (s *ChatService) ollamaProvider(url string) (provider.LLMProvider, error) {
+	cfg, _ := s.settingsManager.GetSettings().GetProvider(provider.TypeOllama)
+	if url == "" {
+		url = cfg.BaseURL
+	}
+	if url == "" {
+		url = s.settingsManager.GetSettings().OllamaURL
+	}
+	if url == "" {
+		return nil, fmt.Errorf("Ollama URL not set")
+	}
 
-	apiKey := s.settingsManager.GetSettings().OpenAIAPIKey
-	if apiKey == "" {
-		return fmt.Errorf("OpenAI API key not set")
+	return provider.New(provider.Config{
+		Type:    provider.TypeOllama,
+		URL:     url,
+		Headers: cfg.Headers,
+		Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second,
+	})
+}
+
+func toProviderMessages(rows []dao.ChatMessageRow) []provider.Message {
+	messages := make([]provider.Message, 0, len(rows))
+	for _, row := range rows {
+		messages = append(messages, provider.Message{Role: row.Role, Content: row.Content})
 	}
+	return messages
+}
 
+func (s *ChatService) Chat(ctx context.Context, userID string, req *pb.ChatRequest, stream func(*pb.ChatResponse) error) error {
 	chatId := req.ChatId
 	if chatId == "" {
 		return fmt.Errorf("Chat ID is required to maintain context")
@@ -95,146 +181,224 @@ func (s *ChatService) Chat(ctx context.Context, userID string, req *pb.ChatReque
 		return fmt.Errorf("model is required")
 	}
 
-	// Get chat history using DAO
-	history, err := s.dao.GetChatMessages(userID, chatId)
+	if err := s.requireChatWrite(userID, chatId); err != nil {
+		return err
+	}
+	// new messages go to the alternative shown for the chat
+	chat, listedID, activeID, err := s.resolveChat(userID, chatId)
 	if err != nil {
-		slog.Error("failed to fetch message history", "error", err)
-		return fmt.Errorf("failed to fetch message history: %v", err)
+		return err
 	}
 
-	err = s.dao.AddChatMessage(userID, chatId, "user", req.Text)
+	// budgets and documents go by the project the chat is stored in, the request's only counts for chats
+	// that are not in chat_list
+	projectID := req.GetProjectId()
+	if projectID == "null" {
+		projectID = ""
+	}
+	if chat != nil {
+		projectID = chat.ProjectID
+	} else if projectID != "" {
+		if err := s.requireProjectWrite(userID, projectID); err != nil {
+			return err
+		}
+	}
+	model, err = s.checkBudgets(ctx, userID, projectID, model)
 	if err != nil {
-		return fmt.Errorf("failed to insert user message: %v", err)
+		return err
 	}
 
-	userMessage := req.Text
+	llm, modelInfo, err := s.providerForModel(model)
+	if err != nil {
+		return err
+	}
+	options, err := s.generationOptions(listedID, req.GetOptions(), "", llm, modelInfo)
+	if err != nil {
+		return err
+	}
 
-	if projectID != "" && projectID != "null" { // if this chat is in context of a project
-		chunks, err := s.retrieveSimilarChunks(ctx, userID, projectID, req.Text)
-		if err != nil {
-			slog.Error("failed to retrieve similar chunks", "error", err)
-		} else if len(chunks.Results) > 0 {
-			userMessage = chunks.Prompt
-		}
+	// Get chat history using DAO
+	history, err := s.dao.GetChatMessages(userID, activeID)
+	if err != nil {
+		slog.Error("failed to fetch message history", "error", err)
+		return fmt.Errorf("failed to fetch message history: %v", err)
+	}
+	history = append(history, dao.ChatMessageRow{Role: "user", Content: s.withDocuments(ctx, userID, projectID, req.Text)})
+	sent, err := s.prepareReply(listedID, history, modelInfo)
+	if err != nil {
+		return err
 	}
 
-	history = append(history, dao.ChatMessageRow{Role: "user", Content: userMessage})
+	gen, err := s.generations.start(userID, listedID)
+	if err != nil {
+		return err
+	}
 
-	requestBody := map[string]interface{}{
-		"model":    model,
-		"messages": history,
-		"stream":   true,
-		"stream_options": map[string]interface{}{
-			"include_usage": true,
-		},
+	if chatId != listedID { // continuing an alternative shows it from now on
+		if err := s.dao.SetActiveChat(listedID, chatId); err != nil {
+			slog.Warn("failed to switch the active alternative", "chat_id", listedID, "error", err)
+		}
 	}
 
-	jsonData, err := json.Marshal(requestBody)
+	err = s.dao.AddChatMessage(userID, activeID, "user", req.Text)
 	if err != nil {
-		return fmt.Errorf("failed to marshal request: %v", err)
+		s.generations.finish(listedID, gen, nil)
+		return fmt.Errorf("failed to insert user message: %v", err)
 	}
 
-	httpReq, err := http.NewRequest("POST", s.settingsManager.GetSettings().OpenAIAPIURL, bytes.NewBuffer(jsonData))
+	return s.streamReply(ctx, gen, listedID, userID, activeID, llm, modelInfo, options, sent, stream, nil)
+}
+
+// replyContext is what a reply is generated from
+type replyContext struct {
+	// messages is the history fitted into the model's context window
+	messages   []dao.ChatMessageRow
+	truncation *pb.ContextTruncation
+	// summarize requests a new summary of the chat once the reply is stored
+	summarize bool
+}
+
+// prepareReply prepares history for a reply in the listed chat: the chat's system prompt goes first, its summary is
+// sent instead of the messages it covers, and history that doesn't fit into the model's context window is truncated.
+// It runs before the new message or alternative is stored, so ErrContextTooLarge leaves nothing behind.
+func (s *ChatService) prepareReply(listedID string, history []dao.ChatMessageRow, modelInfo *dao.ModelRow) (*replyContext, error) {
+	summary, err := s.chatSummary(listedID)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %v", err)
+		slog.Warn("sending the chat without its summary", "chat_id", listedID, "error", err)
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+	history = withSummary(history, summary)
+	summarize := needsSummary(history, s.settingsManager.GetSettings().Summarization)
+	history = withSystemPrompt(history, s.systemPrompt(listedID))
 
-	resp, err := http.DefaultClient.Do(httpReq)
+	messages, truncation, err := fitContext(history, modelInfo.ContextWindow)
 	if err != nil {
-		return fmt.Errorf("OpenAI request failed: %v", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
+	return &replyContext{messages: messages, truncation: truncation, summarize: summarize}, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("OpenAI API error: %d - %s", resp.StatusCode, string(bodyBytes))
+// withDocuments replaces the user's message with a prompt built from the most similar chunks of
+// the project's documents, if the chat is in a project and anything similar was found
+func (s *ChatService) withDocuments(ctx context.Context, userID string, projectID string, text string) string {
+	if projectID == "" || projectID == "null" {
+		return text
 	}
 
-	var fullResponse strings.Builder
-	var inputTokens, outputTokens int
-
-	scanner := bufio.NewScanner(resp.Body)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-
-		if line == "" {
-			continue
-		}
+	chunks, err := s.retrieveSimilarChunks(ctx, userID, projectID, text)
+	if err != nil {
+		slog.Error("failed to retrieve similar chunks", "error", err)
+		return text
+	}
+	if len(chunks.Results) == 0 {
+		return text
+	}
+	return chunks.Prompt
+}
 
-		if !strings.HasPrefix(line, "data: ") {
-			continue
+// streamReply generates the reply to sent into chatId in the background and streams it until it is done, a
+// truncation of the history is reported before the reply.
+// onDone is called with the id of the stored reply (0 when nothing was stored) before the generation ends.
+func (s *ChatService) streamReply(ctx context.Context, gen *generation, listedID string, userID string, chatId string, llm provider.LLMProvider, modelInfo *dao.ModelRow, options provider.GenerationOptions, sent *replyContext, stream func(*pb.ChatResponse) error, onDone func(messageId int64)) error {
+	go func() {
+		if truncation := sent.truncation; truncation != nil {
+			slog.Info("history truncated to fit the context window", "chat_id", chatId, "model", modelInfo.ID,
+				"dropped", truncation.DroppedMessages, "trimmed", truncation.TrimmedMessages)
+			gen.publish(&pb.ChatResponse{Response: &pb.ChatResponse_Truncation{Truncation: truncation}})
 		}
 
-		data := strings.TrimPrefix(line, "data: ")
-
-		if data == "[DONE]" {
-			break
+		messageId, err := s.generate(gen, userID, chatId, llm, modelInfo, provider.CompletionRequest{
+			Model:    modelInfo.APIModelID(),
+			Messages: toProviderMessages(sent.messages),
+			Options:  options,
+		})
+		if onDone != nil {
+			onDone(messageId)
 		}
-
-		var chunk map[string]interface{}
-		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
-			log.Printf("Failed to parse chunk: %v", err)
-			continue
+		s.generations.finish(listedID, gen, err)
+		if sent.summarize && messageId != 0 {
+			s.requestSummary(userID, listedID)
 		}
+	}()
 
-		if usage, ok := chunk["usage"].(map[string]interface{}); ok {
-			if promptTokens, ok := usage["prompt_tokens"].(float64); ok {
-				inputTokens = int(promptTokens)
-			}
-			if completionTokens, ok := usage["completion_tokens"].(float64); ok {
-				outputTokens = int(completionTokens)
-			}
-		}
+	return gen.follow(ctx, 0, stream)
+}
 
-		choices, ok := chunk["choices"].([]interface{})
-		if !ok || len(choices) == 0 {
-			continue
-		}
+// generate streams the reply into gen and stores it with the options it was generated with, returning the id of
+// the stored message. A cancelled generation (CancelChat or no client following it) still keeps what was generated
+// so far, marked as interrupted and with the usage estimated where the provider didn't report it yet.
+func (s *ChatService) generate(gen *generation, userID string, chatId string, llm provider.LLMProvider, modelInfo *dao.ModelRow, req provider.CompletionRequest) (int64, error) {
+	var fullResponse strings.Builder
 
-		choice, ok := choices[0].(map[string]interface{})
-		if !ok {
-			continue
-		}
+	usage, err := llm.StreamCompletion(gen.ctx, req, func(delta string) error {
+		fullResponse.WriteString(delta)
+		gen.publish(&pb.ChatResponse{Response: &pb.ChatResponse_Text{
+			Text: delta,
+		}})
+		return nil
+	})
+	interrupted := err != nil && gen.ctx.Err() != nil
+	if err != nil && !interrupted {
+		return 0, err
+	}
+	if interrupted {
+		slog.Info("generation interrupted", "chat_id", chatId, "cause", context.Cause(gen.ctx))
+	}
 
-		if delta, ok := choice["delta"].(map[string]interface{}); ok {
-			if content, ok := delta["content"].(string); ok && content != "" {
-				fullResponse.WriteString(content)
+	assistantText := fullResponse.String()
+	if assistantText == "" {
+		return 0, nil
+	}
 
-				if err := stream(&pb.ChatResponse{Response: &pb.ChatResponse_Text{
-					Text: content,
-				}}); err != nil {
-					return fmt.Errorf("failed to send stream response: %v", err)
-				}
-			}
-		}
+	if interrupted {
+		usage = partialUsage(usage, req.Messages, assistantText)
+	}
+	cost := messageCost(modelInfo, usage)
+	messageId, err := s.dao.AddChatMessageWithTokens(userID, chatId, "assistant", assistantText, modelInfo.ID, usage.InputTokens, usage.OutputTokens, cost,
+		encodeGenerationOptions(req.Options), interrupted)
+	if err != nil {
+		log.Printf("Failed to insert assistant message: %v", err)
+		return 0, nil
 	}
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("error reading stream: %v", err)
+	gen.publish(&pb.ChatResponse{
+		Response: &pb.ChatResponse_Summary{
+			Summary: &pb.MessageSummary{
+				MessageId:   fmt.Sprintf("%d", messageId),
+				Interrupted: interrupted,
+			},
+		},
+	})
+	return messageId, nil
+}
+
+// ResumeChat replays the reply being generated for the chat from the offset-th ChatResponse
+// (the number of responses the client already received) and then streams it live
+func (s *ChatService) ResumeChat(ctx context.Context, userID string, chatId string, offset int, stream func(*pb.ChatResponse) error) error {
+	if chatId == "" {
+		return fmt.Errorf("chat ID is required")
 	}
 
-	assistantText := fullResponse.String()
-	if assistantText != "" {
-		messageId, err := s.dao.AddChatMessageWithTokens(userID, chatId, "assistant", assistantText, model, inputTokens, outputTokens)
-		if err != nil {
-			log.Printf("Failed to insert assistant message: %v", err)
-		} else {
-			summary := &pb.MessageSummary{
-				MessageId: fmt.Sprintf("%d", messageId),
-			}
-			if err := stream(&pb.ChatResponse{
-				Response: &pb.ChatResponse_Summary{
-					Summary: summary,
-				},
-			}); err != nil {
-				return fmt.Errorf("failed to send message summary: %v", err)
-			}
-		}
+	_, listedID, _, err := s.resolveChat(userID, chatId)
+	if err != nil {
+		return err
 	}
+	gen, err := s.generations.get(userID, listedID)
+	if err != nil {
+		return err
+	}
+	return gen.follow(ctx, offset, stream)
+}
 
-	return nil
+// CancelChat stops the reply being generated for the chat, the partial reply is stored as interrupted
+func (s *ChatService) CancelChat(ctx context.Context, userID string, chatId string) error {
+	if chatId == "" {
+		return fmt.Errorf("chat ID is required")
+	}
+	_, listedID, _, err := s.resolveChat(userID, chatId)
+	if err != nil {
+		return err
+	}
+	return s.generations.cancel(userID, listedID)
 }
 
 const (
@@ -243,6 +407,8 @@ const (
 	END_MESSAGE_LENGTH   = 250
 )
 
+// GenerateChatName names a chat that has no name yet after the given message. Naming is a completion like any
+// other, it goes through the budgets of the chat's project and is recorded in the usage ledger.
 func (s *ChatService) GenerateChatName(ctx context.Context, userID string, chatId string, message string, model string) (string, error) {
 	if chatId == "" {
 		return "", fmt.Errorf("chat ID is required")
@@ -256,18 +422,28 @@ func (s *ChatService) GenerateChatName(ctx context.Context, userID string, chatI
 		return "", fmt.Errorf("model is required")
 	}
 
-	apiKey := s.settingsManager.GetSettings().OpenAIAPIKey
-	if apiKey == "" {
-		return "", fmt.Errorf("OpenAI API key not set")
+	if err := s.requireChatWrite(userID, chatId); err != nil {
+		return "", err
 	}
-
-	name, err := s.dao.GetChatName(userID, chatId)
+	chat, listedID, _, err := s.resolveChat(userID, chatId)
 	if err != nil {
-		return "", fmt.Errorf("failed to get chat name: %v", err)
+		return "", err
+	}
+	if chat == nil {
+		return "", fmt.Errorf("%w: %s", ErrChatNotFound, chatId)
 	}
 
-	if name != "" {
-		return "", fmt.Errorf("Chat name already exists: %s", name)
+	if chat.Name != "" {
+		return "", fmt.Errorf("Chat name already exists: %s", chat.Name)
+	}
+
+	model, err = s.checkBudgets(ctx, userID, chat.ProjectID, model)
+	if err != nil {
+		return "", err
+	}
+	llm, modelInfo, err := s.providerForModel(model)
+	if err != nil {
+		return "", err
 	}
 
 	words := strings.Fields(message)
@@ -279,101 +455,165 @@ func (s *ChatService) GenerateChatName(ctx context.Context, userID string, chatI
 
 	prompt := "Based on the given user message give me a most appropriate chat name of 1-5 word length: " + message
 
-	requestBody := map[string]interface{}{
-		"model": model,
-		"messages": []map[string]string{
+	resp, err := llm.Completion(ctx, provider.CompletionRequest{
+		Model: modelInfo.APIModelID(),
+		Messages: []provider.Message{
 			{
-				"role":    "user",
-				"content": prompt,
+				Role:    "user",
+				Content: prompt,
 			},
 		},
-		"stream": false,
-	}
-
-	jsonData, err := json.Marshal(requestBody)
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %v", err)
+		return "", err
 	}
 
-	httpReq, err := http.NewRequest("POST", s.settingsManager.GetSettings().OpenAIAPIURL, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %v", err)
+	if err := s.dao.AddUsage(userID, listedID, modelInfo.ID, resp.Usage.InputTokens, resp.Usage.OutputTokens, messageCost(modelInfo, resp.Usage)); err != nil {
+		return "", fmt.Errorf("failed to record usage: %w", err)
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+apiKey)
 
-	resp, err := http.DefaultClient.Do(httpReq)
-	if err != nil {
-		return "", fmt.Errorf("OpenAI request failed: %v", err)
-	}
-	defer resp.Body.Close()
+	chatName := resp.Content
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("OpenAI API error: %d - %s", resp.StatusCode, string(bodyBytes))
+	if err := s.dao.SaveChatName(listedID, chatName); err != nil {
+		return "", fmt.Errorf("error while saving name: %v", err)
 	}
 
-	respBody, err := io.ReadAll(resp.Body)
+	return chatName, nil
+}
+
+// GetHistory returns the messages a chat shows, all of them or one page. Descending pages go back from the newest
+// message, which is how a client loads a long chat while scrolling up.
+func (s *ChatService) GetHistory(ctx context.Context, userID string, req *pb.GetHistoryRequest) (*pb.GetHistoryResponse, error) {
+	if req.GetChatId() == "" {
+		return nil, fmt.Errorf("chat ID is required")
+	}
+	pageSize, err := pageLimit(req.GetPageSize())
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %v", err)
+		return nil, err
 	}
-
-	var openAIResp struct {
-		Choices []struct {
-			Message struct {
-				Content string `json:"content"`
-			} `json:"message"`
-		} `json:"choices"`
+	page := dao.MessagePage{}
+	if page.Descending, err = descending(req.GetOrder(), false); err != nil {
+		return nil, err
 	}
-
-	if err := json.Unmarshal(respBody, &openAIResp); err != nil {
-		return "", fmt.Errorf("failed to parse OpenAI response: %v", err)
+	if req.GetPageToken() != "" {
+		var token historyPageToken
+		if err := decodePageToken(req.GetPageToken(), &token); err != nil {
+			return nil, err
+		}
+		if token.Descending != page.Descending {
+			return nil, fmt.Errorf("%w: page token is for the other order", ErrInvalidPage)
+		}
+		page.After = token.After
 	}
-
-	if len(openAIResp.Choices) == 0 {
-		return "", fmt.Errorf("no choices returned from OpenAI")
+	if pageSize > 0 {
+		// one more message tells whether there is a next page, going back it is the parent of the page's last one
+		page.Limit = pageSize + 1
 	}
 
-	chatName := openAIResp.Choices[0].Message.Content
-
-	if err := s.dao.SaveChatName(userID, chatId, chatName); err != nil {
-		return "", fmt.Errorf("error while saving name: %v", err)
+	_, listedID, activeID, err := s.resolveChat(userID, req.GetChatId())
+	if err != nil {
+		return nil, err
 	}
 
-	return chatName, nil
-}
-
-func (s *ChatService) GetHistory(ctx context.Context, userID string, chatId string) ([]*pb.ChatMessage, error) {
-	if chatId == "" {
-		return nil, fmt.Errorf("chat ID is required")
+	messages, err := s.dao.GetChatMessagePage(userID, activeID, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch history: %v", err)
 	}
 
-	messages, err := s.dao.GetChatMessages(userID, chatId)
+	alternatives, err := s.dao.GetAlternativeCounts(listedID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch history: %v", err)
+		return nil, fmt.Errorf("failed to count alternatives: %w", err)
+	}
+
+	shown := messages
+	if pageSize > 0 && len(messages) > pageSize {
+		shown = messages[:pageSize]
 	}
 
 	var pbMessages []*pb.ChatMessage
-	for _, m := range messages {
+	for i, m := range shown {
+		parentMessageID := dao.NoParentMessage
+		switch {
+		case page.Descending && i+1 < len(messages):
+			parentMessageID = messages[i+1].Id
+		case !page.Descending && i > 0:
+			parentMessageID = messages[i-1].Id
+		case !page.Descending && page.After > 0:
+			parentMessageID = strconv.FormatInt(page.After, 10)
+		}
 		pbMessages = append(pbMessages, &pb.ChatMessage{
-			Role:      m.Role,
-			Content:   m.Content,
-			MessageId: m.Id,
+			Role:         m.Role,
+			Content:      m.Content,
+			MessageId:    m.Id,
+			Interrupted:  m.Interrupted,
+			Alternatives: int32(1 + alternatives[parentMessageID]),
+			Options:      StoredGenerationOptions(m.GenerationOptions),
 		})
 	}
 
-	return pbMessages, nil
+	resp := &pb.GetHistoryResponse{History: pbMessages}
+	if len(shown) < len(messages) {
+		last, err := strconv.ParseInt(shown[len(shown)-1].Id, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid message id %s: %w", shown[len(shown)-1].Id, err)
+		}
+		resp.NextPageToken = encodePageToken(historyPageToken{Descending: page.Descending, After: last})
+	}
+	return resp, nil
 }
 
-func (s *ChatService) GetChatList(ctx context.Context, userID string, projectID string) ([]*pb.ChatInfo, error) {
-	chats, err := s.dao.GetChatList(userID, projectID)
+// GetChatList returns the chats of a project, or the user's chats without project, all of them or one page, and the
+// token of the next page
+func (s *ChatService) GetChatList(ctx context.Context, userID string, req *pb.GetChatListRequest) ([]dao.ChatListRow, string, error) {
+	sort, ok := chatListSorts[req.GetSort()]
+	if !ok {
+		return nil, "", fmt.Errorf("%w: unsupported sort %v", ErrInvalidPage, req.GetSort())
+	}
+	pageSize, err := pageLimit(req.GetPageSize())
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch chat list: %v", err)
+		return nil, "", err
+	}
+	q := dao.ChatListQuery{ProjectID: req.GetProjectId(), IncludeArchived: req.GetIncludeArchived(), Sort: sort}
+	if q.ProjectID == "null" {
+		q.ProjectID = ""
+	}
+	// names read A to Z, timestamps newest first
+	if q.Descending, err = descending(req.GetOrder(), sort != dao.ChatSortName); err != nil {
+		return nil, "", err
 	}
-	return chats, nil
+	if req.GetPageToken() != "" {
+		var token chatListPageToken
+		if err := decodePageToken(req.GetPageToken(), &token); err != nil {
+			return nil, "", err
+		}
+		if token.Sort != q.Sort || token.Descending != q.Descending {
+			return nil, "", fmt.Errorf("%w: page token is for another sort order", ErrInvalidPage)
+		}
+		q.After = &token.ChatListCursor
+	}
+	if pageSize > 0 {
+		q.Limit = pageSize + 1
+	}
+
+	chats, err := s.dao.GetChatList(userID, q)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch chat list: %w", err)
+	}
+	if pageSize == 0 || len(chats) <= pageSize {
+		return chats, "", nil
+	}
+	chats = chats[:pageSize]
+	next := encodePageToken(chatListPageToken{Sort: q.Sort, Descending: q.Descending, ChatListCursor: *chats[pageSize-1].Cursor()})
+	return chats, next, nil
 }
 
 func (s *ChatService) CreateChat(ctx context.Context, userID string, name string, projectID string) (string, error) {
+	if projectID != "" && projectID != "null" {
+		if err := s.requireProjectWrite(userID, projectID); err != nil {
+			return "", err
+		}
+	}
+
 	chatId := uuid.New().String()
 
 	err := s.dao.CreateChat(userID, chatId, name, projectID)
@@ -384,23 +624,6 @@ func (s *ChatService) CreateChat(ctx context.Context, userID string, name string
 	return chatId, nil
 }
 
-func (s *ChatService) ListModel(ctx context.Context) ([]*pb.ModelListInfo, error) {
-	models, err := s.dao.GetModels()
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch models: %v", err)
-	}
-
-	pbModels := make([]*pb.ModelListInfo, 0, len(models))
-	for i := range models {
-		pbModels = append(pbModels, &pb.ModelListInfo{
-			Id:    models[i].Id,
-			Label: models[i].Label,
-		})
-	}
-
-	return pbModels, nil
-}
-
 func (s *ChatService) SearchChat(ctx context.Context, userID string, query string) ([]*pb.SearchResult, error) {
 	if query == "" {
 		return nil, fmt.Errorf("query is required")
@@ -423,14 +646,25 @@ func (s *ChatService) SearchChat(ctx context.Context, userID string, query strin
 	return pbResults, nil
 }
 
-func (s *ChatService) CreateProject(ctx context.Context, userID string, name string, description string, additionalData string) (string, error) {
+// CreateProject creates a personal project, or a shared one in a workspace the user can edit
+func (s *ChatService) CreateProject(ctx context.Context, userID string, name string, description string, additionalData string, systemPrompt string, workspaceID string) (string, error) {
 	id := uuid.New().String()
 
 	if name == "" {
 		return "", fmt.Errorf("name is required")
 	}
 
-	projectID, err := s.dao.CreateProject(userID, id, name, description, additionalData)
+	if workspaceID != "" {
+		role, err := s.workspaceRole(userID, workspaceID)
+		if err != nil {
+			return "", err
+		}
+		if !canWrite(role) {
+			return "", fmt.Errorf("%w: viewers can't create projects", ErrPermissionDenied)
+		}
+	}
+
+	projectID, err := s.dao.CreateProject(userID, id, name, description, additionalData, strings.TrimSpace(systemPrompt), workspaceID)
 	if err != nil {
 		return "", fmt.Errorf("failed to create project: %w", err)
 	}
@@ -460,6 +694,9 @@ func (s *ChatService) UploadFile(ctx context.Context, userID string, projectID s
 	if projectID == "" {
 		return "", fmt.Errorf("project_id is required")
 	}
+	if err := s.requireProjectWrite(userID, projectID); err != nil {
+		return "", err
+	}
 
 	fileSize := header.Size
 	if fileSize > maxFileSize {
@@ -500,6 +737,36 @@ func (s *ChatService) UploadFile(ctx context.Context, userID string, projectID s
 	return objectID, nil
 }
 
+var ErrDocumentNotFound = errors.New("document not found")
+
+// GetDocument returns the metadata of a document in one of the user's projects.
+// Documents of other users are reported as not found so their IDs can't be probed.
+func (s *ChatService) GetDocument(ctx context.Context, userID string, docsID string) (*dao.DocumentListRow, error) {
+	doc, err := s.dao.GetDocument(userID, docsID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrDocumentNotFound, docsID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to fetch document: %w", err)
+	}
+	return doc, nil
+}
+
+// OpenDocument returns the metadata and content of a document, see GetDocument
+func (s *ChatService) OpenDocument(ctx context.Context, userID string, docsID string) (*dao.DocumentListRow, io.ReadSeekCloser, error) {
+	doc, err := s.GetDocument(ctx, userID, docsID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.store.OpenObject(ctx, doc.DocsID)
+	if errors.Is(err, store.ErrObjectNotFound) || errors.Is(err, store.ErrInvalidObjectID) {
+		return nil, nil, fmt.Errorf("%w: %s", ErrDocumentNotFound, docsID)
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("failed to open document: %w", err)
+	}
+	return doc, content, nil
+}
+
 func (s *ChatService) retrieveSimilarChunks(ctx context.Context, userID string, projectID string, query string) (*rag.Response, error) {
 	if projectID == "" || query == "" {
 		return nil, fmt.Errorf("project_id and query are required")
@@ -574,6 +841,9 @@ func (s *ChatService) SubmitGenerateEmbeddingsJob(ctx context.Context, userID st
 	if projectID == "" {
 		return fmt.Errorf("project_id is required")
 	}
+	if err := s.requireProjectWrite(userID, projectID); err != nil {
+		return err
+	}
 
 	docs, error := s.dao.FetchErrorDocs(userID, projectID)
 	if error != nil {
@@ -596,6 +866,7 @@ func (s *ChatService) SubmitGenerateEmbeddingsJob(ctx context.Context, userID st
 	return nil
 }
 
+// BranchAChat creates a chat continuing sourceChatId after branchFromMessageId, branches can be branched again
 func (s *ChatService) BranchAChat(ctx context.Context, userID string, sourceChatId string, branchFromMessageId string, branchName string) (string, error) {
 	if sourceChatId == "" {
 		return "", fmt.Errorf("parent id is required")
@@ -605,14 +876,29 @@ func (s *ChatService) BranchAChat(ctx context.Context, userID string, sourceChat
 		return "", fmt.Errorf("message id is required")
 	}
 
-	isMain, err := s.dao.IsMainBranch(userID, sourceChatId)
-	if err != nil || !isMain {
-		return "", fmt.Errorf("can only branch from main branch chats")
+	if err := s.requireChatWrite(userID, sourceChatId); err != nil {
+		return "", err
+	}
+	chat, _, activeID, err := s.resolveChat(userID, sourceChatId)
+	if err != nil {
+		return "", err
+	}
+	if chat == nil {
+		return "", fmt.Errorf("%w: %s", ErrChatNotFound, sourceChatId)
+	}
+
+	// the branch point must be a message the source chat shows, it may come from one of its alternatives
+	path, err := s.dao.GetChatMessages(userID, activeID)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch message history: %w", err)
+	}
+	if !slices.ContainsFunc(path, func(m dao.ChatMessageRow) bool { return m.Id == branchFromMessageId }) {
+		return "", fmt.Errorf("%w: %s", ErrMessageNotFound, branchFromMessageId)
 	}
 
 	newChatId := uuid.New().String()
 
-	err = s.dao.BranchChat(userID, sourceChatId, branchFromMessageId, newChatId, branchName)
+	err = s.dao.BranchChat(userID, activeID, branchFromMessageId, newChatId, branchName)
 	if err != nil {
 		return "", fmt.Errorf("failed to create branch: %v", err)
 	}
@@ -620,22 +906,61 @@ func (s *ChatService) BranchAChat(ctx context.Context, userID string, sourceChat
 	return newChatId, nil
 }
 
+// ListChatBranch returns the chat a branch was branched from and the chat's own branches
 func (s *ChatService) ListChatBranch(ctx context.Context, userID string, chatId string) ([]dao.ChatInfoRow, error) {
 	if chatId == "" {
 		return nil, fmt.Errorf("Chat Id is required")
 	}
 
-	isMain, err := s.dao.IsMainBranch(userID, chatId)
+	innerChats, err := s.dao.GetChatBranches(userID, chatId)
 	if err != nil {
-		return nil, fmt.Errorf("cannot identify chat id: %w", err)
+		return nil, fmt.Errorf("failed to get inner chat list: %w", err)
 	}
 
-	innerChats, err := s.dao.GetChatBranches(userID, chatId, isMain)
+	return innerChats, nil
+}
+
+// GetBranchTree returns the branch tree chatId belongs to, rooted at its main branch. Branches the user can't
+// read are left out along with their subtrees.
+func (s *ChatService) GetBranchTree(ctx context.Context, userID string, chatId string) (*pb.BranchNode, error) {
+	if chatId == "" {
+		return nil, fmt.Errorf("chat id is required")
+	}
+
+	branches, err := s.dao.GetBranchTree(userID, chatId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get inner chat list: %w", err)
+		return nil, fmt.Errorf("failed to get branch tree: %w", err)
 	}
 
-	return innerChats, nil
+	nodes := make(map[string]*pb.BranchNode, len(branches))
+	for _, b := range branches {
+		nodes[b.Id] = &pb.BranchNode{
+			Chat: &pb.ChatInfo{
+				ChatId:       b.Id,
+				Name:         b.Name,
+				ParentChatId: b.ParentChatID,
+				IsMainBranch: b.IsMainBranch,
+			},
+			ParentMessageId: b.ParentMessageID,
+			MessageCount:    int32(b.MessageCount),
+		}
+	}
+
+	// branches come in creation order, so children are listed oldest first
+	var root *pb.BranchNode
+	for _, b := range branches {
+		if b.ParentChatID == "" {
+			if root == nil {
+				root = nodes[b.Id]
+			}
+		} else if parent, ok := nodes[b.ParentChatID]; ok {
+			parent.Children = append(parent.Children, nodes[b.Id])
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("%w: %s", ErrChatNotFound, chatId)
+	}
+	return root, nil
 }
 
 func (s *ChatService) EmbeddingSubscriber() {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
@@ -12,20 +13,22 @@ import (
 	"sortedstartup/chatservice/events"
 	pb "sortedstartup/chatservice/proto"
 	"sortedstartup/chatservice/queue"
+	"sortedstartup/chatservice/secrets"
 	settings "sortedstartup/chatservice/settings"
 )
 
 type SettingService struct {
-	dao   dao.SettingsDAO
-	queue queue.Queue
+	dao     dao.SettingsDAO
+	queue   queue.Queue
+	keyring *secrets.Keyring
 }
 
-func NewSettingService(queue queue.Queue, daoFactory dao.DAOFactory) *SettingService {
+func NewSettingService(queue queue.Queue, daoFactory dao.DAOFactory, keyring *secrets.Keyring) *SettingService {
 	settingsDAO, err := daoFactory.CreateSettingsDAO()
 	if err != nil {
 		log.Fatalf("Failed to create settings DAO: %v", err)
 	}
-	return &SettingService{dao: settingsDAO, queue: queue}
+	return &SettingService{dao: settingsDAO, queue: queue, keyring: keyring}
 }
 
 func (s *SettingService) Init() {
@@ -38,11 +41,41 @@ func (s *SettingService) Init() {
 
 	if isFirstBoot {
 		s.SetSetting(context.Background(), settings.DefaultSettings.ToProto())
+	} else if err := s.RotateKeys(); err != nil {
+		log.Printf("Failed to migrate stored settings: %v", err)
 	}
 
 	s.FirstBootComplete()
 }
 
+// RotateKeys rewrites the stored settings so every API key is sealed with the current master key.
+// It also folds the deprecated single key fields into the provider registry and encrypts keys
+// saved before encryption existed, so it runs on every boot and is a no-op when nothing changed.
+func (s *SettingService) RotateKeys() error {
+	settingsObj, err := s.loadSettings()
+	if err != nil {
+		return err
+	}
+
+	migrated := settingsObj.MigrateLegacyFields()
+	if !migrated && !settingsObj.SecretsNeedRotation(s.keyring) {
+		return nil
+	}
+
+	if err := settingsObj.DecryptSecrets(s.keyring); err != nil {
+		return err
+	}
+	if err := settingsObj.EncryptSecrets(s.keyring); err != nil {
+		return err
+	}
+	if err := s.saveSettings(settingsObj); err != nil {
+		return err
+	}
+
+	log.Printf("Re-encrypted stored settings with the current master key")
+	return nil
+}
+
 func (s *SettingService) FirstBootComplete() {
 	err := s.dao.SetSettingValue("is_first_boot", "1")
 	if err != nil {
@@ -51,6 +84,23 @@ func (s *SettingService) FirstBootComplete() {
 }
 
 func (s *SettingService) GetSetting(ctx context.Context) (*pb.Settings, error) {
+	settingsObj, err := s.loadSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	settingsObj.MigrateLegacyFields()
+
+	// API keys never leave the server, only a masked form for display
+	if err := settingsObj.DecryptSecrets(s.keyring); err != nil {
+		return nil, err
+	}
+	settingsObj.MaskSecrets()
+
+	return settingsObj.ToProto(), nil
+}
+
+func (s *SettingService) loadSettings() (*settings.Settings, error) {
 	settingsString, err := s.dao.GetSettingValue("settings")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get settings: %w", err)
@@ -63,20 +113,57 @@ func (s *SettingService) GetSetting(ctx context.Context) (*pb.Settings, error) {
 		return nil, fmt.Errorf("failed to unmarshal settings: %w", err)
 	}
 
-	return settingsObj.ToProto(), nil
+	return &settingsObj, nil
 }
 
 func (s *SettingService) SetSetting(ctx context.Context, settingsProto *pb.Settings) error {
-	settingsJSON, err := json.Marshal(settings.FromProto(settingsProto))
-	if err != nil {
-		return fmt.Errorf("failed to set settings: %w", err)
+	settingsObj := settings.FromProto(settingsProto)
+
+	stored, err := s.loadSettings()
+	if errors.Is(err, sql.ErrNoRows) {
+		// nothing stored yet on first boot
+		stored = &settings.Settings{}
+	} else if err != nil {
+		return err
 	}
 
-	err = s.dao.SetSettingValue("settings", string(settingsJSON))
-	if err != nil {
+	// older clients only send the deprecated single key fields, keep the stored registry and budgets
+	// so their keys are merged into it instead of wiping the other providers. Clients sending the
+	// registry send back the deprecated fields GetSetting echoes too, the registry is what they edited.
+	if len(settingsObj.Providers) == 0 {
+		settingsObj.Providers = stored.Providers
+		settingsObj.Budgets = stored.Budgets
+		settingsObj.MigrateLegacyFields()
+	} else {
+		settingsObj.DropLegacyFields()
+	}
+
+	// clients get masked keys from GetSetting and send them back unchanged
+	if err := settingsObj.KeepMaskedSecrets(stored); err != nil {
+		return err
+	}
+
+	for _, b := range settingsObj.Budgets {
+		if err := b.Validate(); err != nil {
+			return err
+		}
+	}
+	// clients from before summarization don't send it
+	if settingsProto.GetSummarization() == nil {
+		settingsObj.Summarization = stored.Summarization
+	}
+	if err := settingsObj.Summarization.Validate(); err != nil {
+		return err
+	}
+
+	if err := settingsObj.EncryptSecrets(s.keyring); err != nil {
 		return fmt.Errorf("failed to set settings: %w", err)
 	}
 
+	if err := s.saveSettings(settingsObj); err != nil {
+		return err
+	}
+
 	log.Printf("Publishing event [%s], data:[%s] to reload settings", events.SETTINGS_CHANGED_EVENT, "")
 	// publish an event, any subscriber now need to reload settings from the database
 	s.queue.Publish(context.Background(), events.SETTINGS_CHANGED_EVENT, []byte(""))
@@ -84,6 +171,19 @@ func (s *SettingService) SetSetting(ctx context.Context, settingsProto *pb.Setti
 	return nil
 }
 
+func (s *SettingService) saveSettings(settingsObj *settings.Settings) error {
+	settingsJSON, err := json.Marshal(settingsObj)
+	if err != nil {
+		return fmt.Errorf("failed to set settings: %w", err)
+	}
+
+	err = s.dao.SetSettingValue("settings", string(settingsJSON))
+	if err != nil {
+		return fmt.Errorf("failed to set settings: %w", err)
+	}
+	return nil
+}
+
 // IsFirstBoot checks if this is the first boot by looking for the 'is_first_boot' setting
 // Returns true if the setting doesn't exist or is 0, false otherwise
 // Returns an error if there's a database error (except for sql.ErrNoRows)
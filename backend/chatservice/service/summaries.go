@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"sortedstartup/chatservice/dao"
+	"sortedstartup/chatservice/events"
+	"sortedstartup/chatservice/provider"
+	"sortedstartup/chatservice/settings"
+	"sortedstartup/chatservice/tokenizer"
+)
+
+var (
+	// ErrNothingToSummarize is returned when a chat has no messages before the ones always sent as they are
+	ErrNothingToSummarize = errors.New("nothing to summarize")
+	ErrSummaryInProgress  = errors.New("the chat is already being summarized")
+)
+
+const chatSummaryPrompt = "The messages below are the older part of a conversation between a user and an assistant. " +
+	"Summarize them so the summary can stand in for them when the conversation continues: keep facts, decisions, " +
+	"names, numbers, code identifiers and open questions, leave out pleasantries.\n\n"
+
+// SummarizeChatMessage is published on SUMMARIZE_CHAT_EVENT when the history of a chat passed the summarization
+// threshold
+type SummarizeChatMessage struct {
+	UserID string `json:"user_id"`
+	ChatID string `json:"chat_id"`
+}
+
+// withSummary sends the summary of a chat instead of the messages it covers, as a system message. History the
+// summary isn't part of, like another alternative than the one it was written for, is returned as it is.
+func withSummary(history []dao.ChatMessageRow, summary *dao.ChatSummaryRow) []dao.ChatMessageRow {
+	if summary == nil {
+		return history
+	}
+	i := slices.IndexFunc(history, func(m dao.ChatMessageRow) bool { return m.Id == summary.UpToMessageID })
+	if i < 0 {
+		return history
+	}
+
+	messages := make([]dao.ChatMessageRow, 0, len(history)-i)
+	messages = append(messages, dao.ChatMessageRow{Role: "system", Content: "Summary of the earlier conversation:\n\n" + summary.Summary})
+	return append(messages, history[i+1:]...)
+}
+
+// needsSummary tells whether the messages sent for a chat passed the summarization threshold and some of them can
+// go into the summary
+func needsSummary(sent []dao.ChatMessageRow, cfg settings.SummarizationConfig) bool {
+	if cfg.Model == "" {
+		return false
+	}
+	tokens, messages := 0, 0
+	for _, m := range sent {
+		tokens += tokenizer.Count(m.Content) + messageTokenOverhead
+		if m.Role != "system" {
+			messages++
+		}
+	}
+	return tokens > cfg.Threshold() && messages > cfg.KeepRecent()
+}
+
+// chatSummary returns the summary of a listed chat, nil if it has none
+func (s *ChatService) chatSummary(chatId string) (*dao.ChatSummaryRow, error) {
+	summary, err := s.dao.GetChatSummary(chatId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to fetch chat summary: %w", err)
+	}
+	return summary, nil
+}
+
+// requestSummary has the summarization job fold the older messages of the chat into its summary
+func (s *ChatService) requestSummary(userID string, chatId string) {
+	msgBytes, _ := json.Marshal(SummarizeChatMessage{UserID: userID, ChatID: chatId})
+	if err := s.queue.Publish(context.Background(), events.SUMMARIZE_CHAT_EVENT, msgBytes); err != nil {
+		slog.Warn("failed to publish chat summary request", "chat_id", chatId, "error", err)
+	}
+}
+
+// summarizeChat folds the messages of a listed chat before the ones always sent as they are into its summary.
+// fromScratch summarizes them all again instead of adding the new ones to the stored summary. model defaults to
+// the configured summarization model.
+func (s *ChatService) summarizeChat(ctx context.Context, userID string, listedID string, model string, fromScratch bool) (*dao.ChatSummaryRow, error) {
+	cfg := s.settingsManager.GetSettings().Summarization
+	if model == "" {
+		model = cfg.Model
+	}
+	if model == "" {
+		return nil, fmt.Errorf("model is required, no summarization model is configured")
+	}
+
+	if _, busy := s.summarizing.LoadOrStore(listedID, struct{}{}); busy {
+		return nil, fmt.Errorf("%w: %s", ErrSummaryInProgress, listedID)
+	}
+	defer s.summarizing.Delete(listedID)
+
+	chat, _, activeID, err := s.resolveChat(userID, listedID)
+	if err != nil {
+		return nil, err
+	}
+	if chat == nil {
+		return nil, fmt.Errorf("%w: %s", ErrChatNotFound, listedID)
+	}
+	path, err := s.dao.GetChatMessages(userID, activeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch message history: %w", err)
+	}
+
+	var previous *dao.ChatSummaryRow
+	if !fromScratch {
+		if previous, err = s.chatSummary(listedID); err != nil {
+			return nil, err
+		}
+	}
+	start := 0
+	if previous != nil {
+		// a summary of another alternative is replaced
+		if i := slices.IndexFunc(path, func(m dao.ChatMessageRow) bool { return m.Id == previous.UpToMessageID }); i >= 0 {
+			start = i + 1
+		} else {
+			previous = nil
+		}
+	}
+	end := len(path) - cfg.KeepRecent()
+	if end <= start {
+		return nil, fmt.Errorf("%w: %s has no messages before the newest %d", ErrNothingToSummarize, listedID, cfg.KeepRecent())
+	}
+
+	model, err = s.checkBudgets(ctx, userID, chat.ProjectID, model)
+	if err != nil {
+		return nil, err
+	}
+	llm, modelInfo, err := s.providerForModel(model)
+	if err != nil {
+		return nil, err
+	}
+
+	// a first summary of a very long chat may not fit into a cheap model, it is then summarized in chunks that do
+	summary := dao.ChatSummaryRow{ChatID: listedID, Model: model}
+	if previous != nil {
+		summary.Summary = previous.Summary
+	}
+	for start < end {
+		prompt, n := summaryPrompt(summary.Summary, path[start:end], modelInfo.ContextWindow)
+		// a single message too long for the window is shortened
+		request, _, err := fitContext([]dao.ChatMessageRow{{Role: "user", Content: prompt}}, modelInfo.ContextWindow)
+		var resp *provider.CompletionResponse
+		if err == nil {
+			resp, err = llm.Completion(ctx, provider.CompletionRequest{Model: modelInfo.APIModelID(), Messages: toProviderMessages(request)})
+		}
+		if err != nil && summary.UpToMessageID == "" {
+			return nil, fmt.Errorf("failed to summarize chat: %w", err)
+		} else if err != nil {
+			// the chunks summarized so far are kept, the next summary goes on from there
+			slog.Warn("chat summarized in part", "chat_id", listedID, "up_to", summary.UpToMessageID, "error", err)
+			break
+		}
+		start += n
+		summary.Summary = resp.Content
+		summary.UpToMessageID = path[start-1].Id
+		summary.InputTokens += resp.Usage.InputTokens
+		summary.OutputTokens += resp.Usage.OutputTokens
+		summary.Cost += messageCost(modelInfo, resp.Usage)
+	}
+	summary.UpdatedAt = time.Now().UTC()
+	if err := s.dao.SaveChatSummary(userID, summary); err != nil {
+		return nil, fmt.Errorf("failed to store chat summary: %w", err)
+	}
+	return &summary, nil
+}
+
+// summaryPrompt asks for a summary of as many of messages, oldest first, as fit into the context window along with
+// the previous summary, at least one. It returns the prompt and the number of messages it covers.
+func summaryPrompt(previous string, messages []dao.ChatMessageRow, contextWindow int) (string, int) {
+	var prompt strings.Builder
+	prompt.WriteString(chatSummaryPrompt)
+	if previous != "" {
+		fmt.Fprintf(&prompt, "Summary of the conversation before these messages:\n%s\n\n", previous)
+	}
+
+	budget := promptBudget(contextWindow)
+	tokens := tokenizer.Count(prompt.String()) + messageTokenOverhead
+	for i, m := range messages {
+		message := fmt.Sprintf("%s: %s\n\n", m.Role, m.Content)
+		count := tokenizer.Count(message)
+		if contextWindow > 0 && i > 0 && tokens+count > budget {
+			return prompt.String(), i
+		}
+		tokens += count
+		prompt.WriteString(message)
+	}
+	return prompt.String(), len(messages)
+}
+
+// SummarizationSubscriber runs the summarization job, it summarizes the chats published on SUMMARIZE_CHAT_EVENT
+// one after the other
+func (s *ChatService) SummarizationSubscriber() {
+	go func() {
+		sub, err := s.queue.Subscribe(context.Background(), events.SUMMARIZE_CHAT_EVENT)
+		if err != nil {
+			slog.Error("failed to subscribe to chat summary requests", "error", err)
+			return
+		}
+
+		for msg := range sub {
+			var payload SummarizeChatMessage
+			if err := json.Unmarshal(msg.Data, &payload); err != nil {
+				slog.Warn("invalid chat summary request", "error", err)
+				continue
+			}
+			_, err := s.summarizeChat(context.Background(), payload.UserID, payload.ChatID, "", false)
+			if err != nil && !errors.Is(err, ErrSummaryInProgress) && !errors.Is(err, ErrNothingToSummarize) {
+				slog.Error("failed to summarize chat", "chat_id", payload.ChatID, "error", err)
+			}
+		}
+	}()
+}
+
+// GetChatSummary returns the summary sent instead of the older messages of a chat, nil if it has none
+func (s *ChatService) GetChatSummary(ctx context.Context, userID string, chatId string) (*dao.ChatSummaryRow, error) {
+	if chatId == "" {
+		return nil, fmt.Errorf("chat ID is required")
+	}
+	chat, listedID, _, err := s.resolveChat(userID, chatId)
+	if err != nil {
+		return nil, err
+	}
+	if chat == nil {
+		return nil, fmt.Errorf("%w: %s", ErrChatNotFound, chatId)
+	}
+	return s.chatSummary(listedID)
+}
+
+// RegenerateChatSummary summarizes the older messages of a chat again from scratch, model defaults to the
+// configured summarization model
+func (s *ChatService) RegenerateChatSummary(ctx context.Context, userID string, chatId string, model string) (*dao.ChatSummaryRow, error) {
+	if chatId == "" {
+		return nil, fmt.Errorf("chat ID is required")
+	}
+	chat, listedID, _, err := s.resolveChat(userID, chatId)
+	if err != nil {
+		return nil, err
+	}
+	if chat == nil {
+		return nil, fmt.Errorf("%w: %s", ErrChatNotFound, chatId)
+	}
+	if err := s.requireChatWrite(userID, listedID); err != nil {
+		return nil, err
+	}
+	return s.summarizeChat(ctx, userID, listedID, model, true)
+}
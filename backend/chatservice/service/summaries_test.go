@@ -0,0 +1,73 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"sortedstartup/chatservice/dao"
+	"sortedstartup/chatservice/settings"
+)
+
+func TestWithSummary(t *testing.T) {
+	history := []dao.ChatMessageRow{
+		{Id: "1", Role: "user", Content: "hi"},
+		{Id: "2", Role: "assistant", Content: "hello"},
+		{Id: "3", Role: "user", Content: "question"},
+	}
+
+	if got := withSummary(history, nil); len(got) != 3 {
+		t.Errorf("Expected the history as it is without a summary, got %v", got)
+	}
+	if got := withSummary(history, &dao.ChatSummaryRow{Summary: "greetings", UpToMessageID: "9"}); len(got) != 3 {
+		t.Errorf("Expected the history as it is for a summary of another alternative, got %v", got)
+	}
+
+	got := withSummary(history, &dao.ChatSummaryRow{Summary: "greetings", UpToMessageID: "2"})
+	if len(got) != 2 || got[0].Role != "system" || !strings.HasSuffix(got[0].Content, "greetings") || got[1].Id != "3" {
+		t.Errorf("Expected the summary and the newest message, got %v", got)
+	}
+}
+
+func TestNeedsSummary(t *testing.T) {
+	long := strings.Repeat("word ", 1000)
+	var sent []dao.ChatMessageRow
+	for range 4 {
+		sent = append(sent, dao.ChatMessageRow{Role: "user", Content: long})
+	}
+	cfg := settings.SummarizationConfig{Model: "cheap", ThresholdTokens: 3000, KeepRecentMessages: 2}
+
+	if !needsSummary(sent, cfg) {
+		t.Errorf("Expected 4000 tokens to pass a threshold of 3000")
+	}
+	if needsSummary(sent, settings.SummarizationConfig{ThresholdTokens: 3000, KeepRecentMessages: 2}) {
+		t.Errorf("Expected no summary without a summarization model")
+	}
+	if needsSummary(sent, settings.SummarizationConfig{Model: "cheap", ThresholdTokens: 3000, KeepRecentMessages: 4}) {
+		t.Errorf("Expected no summary when all messages are kept as they are")
+	}
+	if needsSummary(sent[:2], cfg) {
+		t.Errorf("Expected 2000 tokens to stay below the threshold")
+	}
+}
+
+func TestSummaryPrompt(t *testing.T) {
+	long := strings.Repeat("word ", 1000)
+	var messages []dao.ChatMessageRow
+	for range 4 {
+		messages = append(messages, dao.ChatMessageRow{Role: "user", Content: long})
+	}
+
+	if _, n := summaryPrompt("", messages, 0); n != 4 {
+		t.Errorf("Expected all messages without a context window, got %d", n)
+	}
+	prompt, n := summaryPrompt("greetings", messages, 4000)
+	if n != 2 {
+		t.Errorf("Expected 2 messages of 1000 tokens to fit into a window of 4000, got %d", n)
+	}
+	if !strings.Contains(prompt, "greetings") || strings.Count(prompt, "user: ") != n {
+		t.Errorf("Expected the previous summary and the covered messages in the prompt, got %q", prompt)
+	}
+	if _, n := summaryPrompt("", messages, 500); n != 1 {
+		t.Errorf("Expected a message too long for the window to be covered on its own, got %d", n)
+	}
+}
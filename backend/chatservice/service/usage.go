@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+	"sortedstartup/chatservice/provider"
+	"sortedstartup/chatservice/tokenizer"
+)
+
+const (
+	// token costs in model_metadata are USD per 1M tokens
+	tokenCostUnit = 1_000_000
+
+	usageDateLayout       = "2006-01-02"
+	defaultUsageRangeDays = 30
+)
+
+// messageCost prices a completion with the model's token costs, models missing from the catalog cost nothing
+func messageCost(model *dao.ModelRow, usage provider.Usage) float64 {
+	if model == nil {
+		return 0
+	}
+	return (float64(usage.InputTokens)*model.InputTokenCost + float64(usage.OutputTokens)*model.OutputTokenCost) / tokenCostUnit
+}
+
+// partialUsage completes the usage a provider reported for a reply interrupted before its final usage, the tokens
+// it didn't report are estimated from the request and the text streamed so far
+func partialUsage(usage provider.Usage, messages []provider.Message, reply string) provider.Usage {
+	if usage.InputTokens == 0 {
+		for _, m := range messages {
+			usage.InputTokens += tokenizer.Count(m.Content) + messageTokenOverhead
+		}
+	}
+	usage.OutputTokens = max(usage.OutputTokens, tokenizer.Count(reply))
+	return usage
+}
+
+var ErrInvalidUsageRequest = errors.New("invalid usage request")
+
+var usageGroupBy = map[pb.UsageGroupBy]dao.UsageGroupBy{
+	pb.UsageGroupBy_USAGE_BY_DAY:     dao.UsageByDay,
+	pb.UsageGroupBy_USAGE_BY_MODEL:   dao.UsageByModel,
+	pb.UsageGroupBy_USAGE_BY_PROJECT: dao.UsageByProject,
+	pb.UsageGroupBy_USAGE_BY_CHAT:    dao.UsageByChat,
+}
+
+// usageRange parses the inclusive YYYY-MM-DD range of a GetUsage request into [from, to) in UTC
+func usageRange(fromStr string, toStr string, now time.Time) (time.Time, time.Time, error) {
+	to := now.UTC().Truncate(24 * time.Hour)
+	if toStr != "" {
+		t, err := time.Parse(usageDateLayout, toStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: invalid to date %q, expected YYYY-MM-DD", ErrInvalidUsageRequest, toStr)
+		}
+		to = t
+	}
+
+	from := to.AddDate(0, 0, -(defaultUsageRangeDays - 1))
+	if fromStr != "" {
+		f, err := time.Parse(usageDateLayout, fromStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: invalid from date %q, expected YYYY-MM-DD", ErrInvalidUsageRequest, fromStr)
+		}
+		from = f
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: from %s is after to %s", ErrInvalidUsageRequest, from.Format(usageDateLayout), to.Format(usageDateLayout))
+	}
+
+	// to is inclusive, the query end is the start of the next day
+	return from, to.AddDate(0, 0, 1), nil
+}
+
+// GetUsage aggregates the tokens and cost of the user's completions over a range of days
+func (s *ChatService) GetUsage(ctx context.Context, userID string, req *pb.GetUsageRequest) (*pb.GetUsageResponse, error) {
+	groupBy, ok := usageGroupBy[req.GetGroupBy()]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported group_by %v", ErrInvalidUsageRequest, req.GetGroupBy())
+	}
+
+	from, to, err := usageRange(req.GetFrom(), req.GetTo(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.dao.GetUsage(userID, groupBy, from, to, req.GetProjectId())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch usage: %w", err)
+	}
+
+	total := &pb.UsageBucket{Key: "total", Label: "Total"}
+	buckets := make([]*pb.UsageBucket, 0, len(rows))
+	for _, row := range rows {
+		buckets = append(buckets, &pb.UsageBucket{
+			Key:          row.Key,
+			Label:        row.Label,
+			InputTokens:  row.InputTokens,
+			OutputTokens: row.OutputTokens,
+			Cost:         row.Cost,
+			MessageCount: row.MessageCount,
+		})
+		total.InputTokens += row.InputTokens
+		total.OutputTokens += row.OutputTokens
+		total.Cost += row.Cost
+		total.MessageCount += row.MessageCount
+	}
+
+	return &pb.GetUsageResponse{
+		Buckets: buckets,
+		Total:   total,
+		From:    from.Format(usageDateLayout),
+		To:      to.AddDate(0, 0, -1).Format(usageDateLayout),
+	}, nil
+}
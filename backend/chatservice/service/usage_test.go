@@ -0,0 +1,69 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"sortedstartup/chatservice/dao"
+	"sortedstartup/chatservice/provider"
+	"sortedstartup/chatservice/tokenizer"
+)
+
+func TestMessageCost(t *testing.T) {
+	model := &dao.ModelRow{InputTokenCost: 2.5, OutputTokenCost: 10}
+
+	cost := messageCost(model, provider.Usage{InputTokens: 1000, OutputTokens: 500})
+	if math.Abs(cost-0.0075) > 1e-12 {
+		t.Errorf("Expected 0.0075, got %v", cost)
+	}
+
+	if cost := messageCost(nil, provider.Usage{InputTokens: 1000}); cost != 0 {
+		t.Errorf("Expected unknown model to cost nothing, got %v", cost)
+	}
+}
+
+func TestPartialUsage(t *testing.T) {
+	messages := []provider.Message{{Role: "user", Content: "What is the capital of France?"}}
+	reply := "The capital of France is Paris, which"
+
+	// nothing reported yet, both sides are estimated
+	usage := partialUsage(provider.Usage{}, messages, reply)
+	if usage.InputTokens != tokenizer.Count(messages[0].Content)+messageTokenOverhead || usage.OutputTokens != tokenizer.Count(reply) {
+		t.Errorf("Expected estimated usage, got %+v", usage)
+	}
+
+	// Anthropic reports the input tokens when the reply starts, they are kept
+	usage = partialUsage(provider.Usage{InputTokens: 120, OutputTokens: 1}, messages, reply)
+	if usage.InputTokens != 120 || usage.OutputTokens != tokenizer.Count(reply) {
+		t.Errorf("Expected the reported input tokens and estimated output tokens, got %+v", usage)
+	}
+}
+
+func TestUsageRange(t *testing.T) {
+	now := time.Date(2025, 3, 15, 18, 30, 0, 0, time.UTC)
+
+	from, to, err := usageRange("", "", now)
+	if err != nil {
+		t.Fatalf("usageRange failed: %v", err)
+	}
+	if from.Format(usageDateLayout) != "2025-02-14" || to.Format(usageDateLayout) != "2025-03-16" {
+		t.Errorf("Expected default range [2025-02-14, 2025-03-16), got [%s, %s)", from, to)
+	}
+
+	from, to, err = usageRange("2025-03-01", "2025-03-01", now)
+	if err != nil {
+		t.Fatalf("usageRange failed: %v", err)
+	}
+	if to.Sub(from) != 24*time.Hour {
+		t.Errorf("Expected a single day, got [%s, %s)", from, to)
+	}
+
+	if _, _, err := usageRange("2025-03-02", "2025-03-01", now); !errors.Is(err, ErrInvalidUsageRequest) {
+		t.Errorf("Expected ErrInvalidUsageRequest for reversed range, got %v", err)
+	}
+	if _, _, err := usageRange("03/01/2025", "", now); !errors.Is(err, ErrInvalidUsageRequest) {
+		t.Errorf("Expected ErrInvalidUsageRequest for bad date, got %v", err)
+	}
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"sortedstartup/chatservice/dao"
+	pb "sortedstartup/chatservice/proto"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWorkspaceNotFound     = errors.New("workspace not found")
+	ErrProjectNotFound       = errors.New("project not found")
+	ErrPermissionDenied      = errors.New("permission denied")
+	ErrInvalidWorkspace      = errors.New("invalid workspace request")
+	ErrLastWorkspaceOwner    = errors.New("a workspace needs at least one owner")
+	ErrWorkspaceMemberExists = errors.New("user is already a member of the workspace")
+)
+
+const maxWorkspaceNameLength = 100
+
+var workspaceRoles = map[pb.WorkspaceRole]string{
+	pb.WorkspaceRole_WORKSPACE_ROLE_OWNER:  dao.WorkspaceRoleOwner,
+	pb.WorkspaceRole_WORKSPACE_ROLE_EDITOR: dao.WorkspaceRoleEditor,
+	pb.WorkspaceRole_WORKSPACE_ROLE_VIEWER: dao.WorkspaceRoleViewer,
+}
+
+func toPBWorkspaceRole(role string) pb.WorkspaceRole {
+	for pbRole, r := range workspaceRoles {
+		if r == role {
+			return pbRole
+		}
+	}
+	return pb.WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED
+}
+
+func canWrite(role string) bool {
+	return role == dao.WorkspaceRoleOwner || role == dao.WorkspaceRoleEditor
+}
+
+// requireProjectWrite checks that the user owns the project or is an editor of its workspace
+func (s *ChatService) requireProjectWrite(userID string, projectID string) error {
+	role, err := s.dao.GetProjectRole(userID, projectID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s", ErrProjectNotFound, projectID)
+	} else if err != nil {
+		return fmt.Errorf("failed to check project access: %w", err)
+	}
+	if !canWrite(role) {
+		return fmt.Errorf("%w: %s can only read project %s", ErrPermissionDenied, role, projectID)
+	}
+	return nil
+}
+
+// requireChatWrite keeps viewers of a shared project from adding messages to its chats. Chats without
+// a row in chat_list stay private to the user, like before workspaces, chats of other users can't be written.
+func (s *ChatService) requireChatWrite(userID string, chatID string) error {
+	role, err := s.dao.GetChatRole(userID, chatID)
+	if errors.Is(err, sql.ErrNoRows) {
+		exists, err := s.dao.ChatExists(chatID)
+		if err != nil {
+			return fmt.Errorf("failed to check chat access: %w", err)
+		}
+		if exists {
+			return fmt.Errorf("%w: chat %s", ErrPermissionDenied, chatID)
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to check chat access: %w", err)
+	}
+	if !canWrite(role) {
+		return fmt.Errorf("%w: %s can only read chat %s", ErrPermissionDenied, role, chatID)
+	}
+	return nil
+}
+
+// workspaceRole returns the user's role, non-members get ErrWorkspaceNotFound so workspace ids can't be probed
+func (s *ChatService) workspaceRole(userID string, workspaceID string) (string, error) {
+	role, err := s.dao.GetWorkspaceRole(userID, workspaceID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w: %s", ErrWorkspaceNotFound, workspaceID)
+	} else if err != nil {
+		return "", fmt.Errorf("failed to fetch workspace role: %w", err)
+	}
+	return role, nil
+}
+
+func (s *ChatService) requireWorkspaceOwner(userID string, workspaceID string) error {
+	role, err := s.workspaceRole(userID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if role != dao.WorkspaceRoleOwner {
+		return fmt.Errorf("%w: only owners can manage members", ErrPermissionDenied)
+	}
+	return nil
+}
+
+func (s *ChatService) CreateWorkspace(ctx context.Context, userID string, name string) (*pb.Workspace, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxWorkspaceNameLength {
+		return nil, fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidWorkspace, maxWorkspaceNameLength)
+	}
+
+	workspace := dao.WorkspaceRow{ID: uuid.New().String(), Name: name}
+	if err := s.dao.CreateWorkspace(workspace, userID); err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+
+	return &pb.Workspace{
+		Id:   workspace.ID,
+		Name: workspace.Name,
+		Role: pb.WorkspaceRole_WORKSPACE_ROLE_OWNER,
+	}, nil
+}
+
+func (s *ChatService) ListWorkspaces(ctx context.Context, userID string) ([]*pb.Workspace, error) {
+	rows, err := s.dao.GetWorkspaces(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workspaces: %w", err)
+	}
+
+	workspaces := make([]*pb.Workspace, 0, len(rows))
+	for _, w := range rows {
+		workspaces = append(workspaces, &pb.Workspace{
+			Id:        w.ID,
+			Name:      w.Name,
+			Role:      toPBWorkspaceRole(w.Role),
+			CreatedAt: w.CreatedAt,
+		})
+	}
+	return workspaces, nil
+}
+
+func (s *ChatService) ListWorkspaceMembers(ctx context.Context, userID string, workspaceID string) ([]*pb.WorkspaceMember, error) {
+	if _, err := s.workspaceRole(userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.dao.GetWorkspaceMembers(workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workspace members: %w", err)
+	}
+
+	members := make([]*pb.WorkspaceMember, 0, len(rows))
+	for _, m := range rows {
+		members = append(members, &pb.WorkspaceMember{
+			UserId:    m.UserID,
+			Username:  m.Username,
+			Role:      toPBWorkspaceRole(m.Role),
+			CreatedAt: m.CreatedAt,
+		})
+	}
+	return members, nil
+}
+
+func (s *ChatService) AddWorkspaceMember(ctx context.Context, userID string, workspaceID string, username string, role pb.WorkspaceRole) (*pb.WorkspaceMember, error) {
+	memberRole, ok := workspaceRoles[role]
+	if !ok {
+		return nil, fmt.Errorf("%w: role is required", ErrInvalidWorkspace)
+	}
+	if err := s.requireWorkspaceOwner(userID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.dao.GetUserByUsername(strings.TrimSpace(username))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: unknown user %q", ErrInvalidWorkspace, username)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	if _, err := s.dao.GetWorkspaceRole(user.ID, workspaceID); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrWorkspaceMemberExists, user.Username)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to fetch workspace role: %w", err)
+	}
+
+	if err := s.dao.AddWorkspaceMember(workspaceID, user.ID, memberRole); err != nil {
+		return nil, fmt.Errorf("failed to add workspace member: %w", err)
+	}
+
+	return &pb.WorkspaceMember{
+		UserId:   user.ID,
+		Username: user.Username,
+		Role:     role,
+	}, nil
+}
+
+func (s *ChatService) UpdateWorkspaceMember(ctx context.Context, userID string, workspaceID string, memberID string, role pb.WorkspaceRole) error {
+	memberRole, ok := workspaceRoles[role]
+	if !ok {
+		return fmt.Errorf("%w: role is required", ErrInvalidWorkspace)
+	}
+	if err := s.requireWorkspaceOwner(userID, workspaceID); err != nil {
+		return err
+	}
+	if memberRole != dao.WorkspaceRoleOwner {
+		if err := s.keepAnOwner(workspaceID, memberID); err != nil {
+			return err
+		}
+	}
+
+	err := s.dao.UpdateWorkspaceMember(workspaceID, memberID, memberRole)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s is not a member", ErrInvalidWorkspace, memberID)
+	} else if err != nil {
+		return fmt.Errorf("failed to update workspace member: %w", err)
+	}
+	return nil
+}
+
+// RemoveWorkspaceMember lets owners remove anyone and members leave the workspace themselves
+func (s *ChatService) RemoveWorkspaceMember(ctx context.Context, userID string, workspaceID string, memberID string) error {
+	if memberID == userID {
+		if _, err := s.workspaceRole(userID, workspaceID); err != nil {
+			return err
+		}
+	} else if err := s.requireWorkspaceOwner(userID, workspaceID); err != nil {
+		return err
+	}
+	if err := s.keepAnOwner(workspaceID, memberID); err != nil {
+		return err
+	}
+
+	err := s.dao.RemoveWorkspaceMember(workspaceID, memberID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s is not a member", ErrInvalidWorkspace, memberID)
+	} else if err != nil {
+		return fmt.Errorf("failed to remove workspace member: %w", err)
+	}
+	return nil
+}
+
+// keepAnOwner fails if memberID is the only owner of the workspace
+func (s *ChatService) keepAnOwner(workspaceID string, memberID string) error {
+	members, err := s.dao.GetWorkspaceMembers(workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch workspace members: %w", err)
+	}
+
+	owners, isOwner := 0, false
+	for _, m := range members {
+		if m.Role == dao.WorkspaceRoleOwner {
+			owners++
+			isOwner = isOwner || m.UserID == memberID
+		}
+	}
+	if isOwner && owners == 1 {
+		return ErrLastWorkspaceOwner
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pb "sortedstartup/chatservice/proto"
+)
+
+func TestRequireProjectWrite(t *testing.T) {
+	s := &ChatService{dao: newMemoryDAO()}
+
+	tests := []struct {
+		userID string
+		want   error
+	}{
+		{"alice", nil},
+		{"bob", nil},
+		{"carol", ErrPermissionDenied},
+		{"mallory", ErrProjectNotFound},
+	}
+	for _, tt := range tests {
+		err := s.requireProjectWrite(tt.userID, "p1")
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Expected %v for %s, got %v", tt.want, tt.userID, err)
+		}
+	}
+}
+
+func TestRequireChatWrite(t *testing.T) {
+	s := &ChatService{dao: newMemoryDAO()}
+
+	tests := []struct {
+		userID string
+		chatID string
+		want   error
+	}{
+		{"alice", "main", nil},
+		{"bob", "main", nil},
+		{"carol", "main", ErrPermissionDenied},
+		// a chat of another user isn't taken for a new chat
+		{"mallory", "main", ErrPermissionDenied},
+		{"mallory", "new", nil},
+	}
+	for _, tt := range tests {
+		err := s.requireChatWrite(tt.userID, tt.chatID)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Expected %v for %s in %s, got %v", tt.want, tt.userID, tt.chatID, err)
+		}
+	}
+}
+
+func TestWorkspaceMemberManagement(t *testing.T) {
+	s := &ChatService{dao: newMemoryDAO()}
+	ctx := context.Background()
+
+	if err := s.UpdateWorkspaceMember(ctx, "bob", "w1", "carol", pb.WorkspaceRole_WORKSPACE_ROLE_EDITOR); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Expected editors not to manage members, got %v", err)
+	}
+	if _, err := s.ListWorkspaceMembers(ctx, "mallory", "w1"); !errors.Is(err, ErrWorkspaceNotFound) {
+		t.Errorf("Expected ErrWorkspaceNotFound for a non-member, got %v", err)
+	}
+
+	if err := s.UpdateWorkspaceMember(ctx, "alice", "w1", "alice", pb.WorkspaceRole_WORKSPACE_ROLE_VIEWER); !errors.Is(err, ErrLastWorkspaceOwner) {
+		t.Errorf("Expected the last owner not to be demoted, got %v", err)
+	}
+	if err := s.RemoveWorkspaceMember(ctx, "alice", "w1", "alice"); !errors.Is(err, ErrLastWorkspaceOwner) {
+		t.Errorf("Expected the last owner not to leave, got %v", err)
+	}
+
+	if err := s.RemoveWorkspaceMember(ctx, "carol", "w1", "carol"); err != nil {
+		t.Errorf("Expected a viewer to leave the workspace, got %v", err)
+	}
+	if err := s.UpdateWorkspaceMember(ctx, "alice", "w1", "bob", pb.WorkspaceRole_WORKSPACE_ROLE_OWNER); err != nil {
+		t.Fatalf("UpdateWorkspaceMember failed: %v", err)
+	}
+	if err := s.RemoveWorkspaceMember(ctx, "bob", "w1", "alice"); err != nil {
+		t.Errorf("Expected an owner to be removed when another owner is left, got %v", err)
+	}
+}
@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"maps"
 	"sortedstartup/chatservice/dao"
 	"sortedstartup/chatservice/events"
 	"sortedstartup/chatservice/proto"
 	"sortedstartup/chatservice/queue"
+	"sortedstartup/chatservice/secrets"
+	"strings"
 	"sync"
 )
 
@@ -18,31 +21,419 @@ var SQLITE_DB_URL = "db.sqlite"
 - TODO: To think : settings have to be app level and then broken down to the service level
 */
 type Settings struct {
-	OpenAIAPIKey string `koanf:"openai_api_key" json:"openai_api_key"`
-	OpenAIAPIURL string `koanf:"openai_api_url" json:"openai_api_url"`
-	OllamaURL    string `koanf:"ollama_url" json:"ollama_url"`
+	// OllamaURL is the embeddings endpoint used by RAG
+	OllamaURL string `koanf:"ollama_url" json:"ollama_url"`
+
+	// Providers is the provider registry, a model is served by the provider
+	// whose name matches the model's `provider` column in model_metadata
+	Providers []ProviderConfig `koanf:"providers" json:"providers"`
+
+	// Budgets are checked before every completion
+	Budgets []BudgetConfig `koanf:"budgets" json:"budgets,omitempty"`
+
+	// Summarization sends the older messages of long chats as a summary
+	Summarization SummarizationConfig `koanf:"summarization" json:"summarization"`
+
+	// Deprecated: single key settings from before the provider registry,
+	// they are converted into Providers by MigrateLegacyFields
+	OpenAIAPIKey    string `koanf:"openai_api_key" json:"openai_api_key,omitempty"`
+	OpenAIAPIURL    string `koanf:"openai_api_url" json:"openai_api_url,omitempty"`
+	AnthropicAPIKey string `koanf:"anthropic_api_key" json:"anthropic_api_key,omitempty"`
+	GeminiAPIKey    string `koanf:"gemini_api_key" json:"gemini_api_key,omitempty"`
+}
+
+// ProviderConfig holds the credentials and endpoint of one named provider
+type ProviderConfig struct {
+	Name string `koanf:"name" json:"name"`
+	// Type selects the implementation: openai (also OpenRouter, vLLM, LiteLLM ...), anthropic, gemini or ollama
+	Type string `koanf:"type" json:"type"`
+	// BaseURL overrides the model's url column when set
+	BaseURL        string            `koanf:"base_url" json:"base_url"`
+	APIKey         string            `koanf:"api_key" json:"api_key"`
+	Headers        map[string]string `koanf:"headers" json:"headers,omitempty"`
+	TimeoutSeconds int               `koanf:"timeout_seconds" json:"timeout_seconds,omitempty"`
+}
+
+// Budget scopes and periods
+const (
+	BudgetScopeGlobal  = "global"
+	BudgetScopeProject = "project"
+	BudgetScopeUser    = "user"
+
+	BudgetPeriodDaily   = "daily"
+	BudgetPeriodMonthly = "monthly"
+)
+
+// BudgetConfig caps the cost of completions (USD) over a day or a calendar month in UTC
+type BudgetConfig struct {
+	Scope string `koanf:"scope" json:"scope"`
+	// ScopeID is the project or user id, empty applies the budget to every project or user separately
+	ScopeID          string  `koanf:"scope_id" json:"scope_id,omitempty"`
+	Period           string  `koanf:"period" json:"period"`
+	Limit            float64 `koanf:"limit" json:"limit"`
+	SoftLimitPercent float64 `koanf:"soft_limit_percent" json:"soft_limit_percent,omitempty"`
+	// DowngradeModel is used instead of refusing the request once the budget is exceeded, exceeded
+	// budgets with different downgrade models refuse it
+	DowngradeModel string `koanf:"downgrade_model" json:"downgrade_model,omitempty"`
+}
+
+// Validate checks the scope, period and limits of the budget
+func (b BudgetConfig) Validate() error {
+	switch b.Scope {
+	case BudgetScopeGlobal, BudgetScopeProject, BudgetScopeUser:
+	default:
+		return fmt.Errorf("invalid budget scope %q, expected global, project or user", b.Scope)
+	}
+	if b.Period != BudgetPeriodDaily && b.Period != BudgetPeriodMonthly {
+		return fmt.Errorf("invalid budget period %q, expected daily or monthly", b.Period)
+	}
+	if b.Limit <= 0 {
+		return fmt.Errorf("budget limit must be positive")
+	}
+	if b.SoftLimitPercent < 0 || b.SoftLimitPercent > 100 {
+		return fmt.Errorf("budget soft limit must be between 0 and 100 percent")
+	}
+	return nil
+}
+
+// SummarizationConfig sets when the older messages of a chat are summarized and by which model. A background job
+// folds them into the chat's summary once its history passes ThresholdTokens, the newest KeepRecentMessages are
+// always sent as they are.
+type SummarizationConfig struct {
+	// Model should be a cheap one, empty disables summarization
+	Model              string `koanf:"model" json:"model,omitempty"`
+	ThresholdTokens    int    `koanf:"threshold_tokens" json:"threshold_tokens,omitempty"`
+	KeepRecentMessages int    `koanf:"keep_recent_messages" json:"keep_recent_messages,omitempty"`
+}
+
+// Summarization defaults for zero values
+const (
+	DefaultSummarizationThresholdTokens    = 8000
+	DefaultSummarizationKeepRecentMessages = 10
+)
+
+// Validate checks the summarization thresholds
+func (c SummarizationConfig) Validate() error {
+	if c.ThresholdTokens < 0 || c.KeepRecentMessages < 0 {
+		return fmt.Errorf("summarization thresholds must not be negative")
+	}
+	return nil
+}
+
+// Threshold returns ThresholdTokens or its default
+func (c SummarizationConfig) Threshold() int {
+	if c.ThresholdTokens == 0 {
+		return DefaultSummarizationThresholdTokens
+	}
+	return c.ThresholdTokens
+}
+
+// KeepRecent returns KeepRecentMessages or its default
+func (c SummarizationConfig) KeepRecent() int {
+	if c.KeepRecentMessages == 0 {
+		return DefaultSummarizationKeepRecentMessages
+	}
+	return c.KeepRecentMessages
 }
 
+// DefaultProviderName is used for models whose provider has no entry in the registry
+const DefaultProviderName = "openai"
+
+// legacy provider names, these match the provider column of the seeded models
+const (
+	legacyAnthropicProviderName = "claude"
+	legacyGeminiProviderName    = "gemini"
+)
+
 var DefaultSettings = &Settings{
-	OpenAIAPIKey: "",
-	OpenAIAPIURL: "https://api.openai.com/v1/chat/completions",
-	OllamaURL:    "",
+	OllamaURL: "",
+	Providers: []ProviderConfig{
+		{
+			Name:    DefaultProviderName,
+			Type:    "openai",
+			BaseURL: "https://api.openai.com/v1",
+		},
+	},
+}
+
+// GetProvider returns the registry entry with the given name
+func (s *Settings) GetProvider(name string) (ProviderConfig, bool) {
+	for _, p := range s.Providers {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return ProviderConfig{}, false
+}
+
+// upsertProvider replaces the entry with the same name or appends a new one
+func (s *Settings) upsertProvider(provider ProviderConfig) {
+	for i := range s.Providers {
+		if s.Providers[i].Name == provider.Name {
+			s.Providers[i] = provider
+			return
+		}
+	}
+	s.Providers = append(s.Providers, provider)
+}
+
+// MigrateLegacyFields converts the deprecated single key fields into provider registry
+// entries and clears them. Existing entries with the same name are updated in place so
+// headers and timeouts survive. Returns true if anything changed.
+func (s *Settings) MigrateLegacyFields() bool {
+	changed := false
+
+	if s.OpenAIAPIKey != "" || s.OpenAIAPIURL != "" {
+		openai, ok := s.GetProvider(DefaultProviderName)
+		if !ok {
+			openai = ProviderConfig{Name: DefaultProviderName, Type: "openai"}
+		}
+		if s.OpenAIAPIKey != "" {
+			openai.APIKey = s.OpenAIAPIKey
+		}
+		if s.OpenAIAPIURL != "" {
+			openai.BaseURL = strings.TrimSuffix(s.OpenAIAPIURL, "/chat/completions")
+		}
+		s.upsertProvider(openai)
+		changed = true
+	}
+
+	if s.AnthropicAPIKey != "" {
+		anthropic, ok := s.GetProvider(legacyAnthropicProviderName)
+		if !ok {
+			anthropic = ProviderConfig{Name: legacyAnthropicProviderName, Type: "anthropic"}
+		}
+		anthropic.APIKey = s.AnthropicAPIKey
+		s.upsertProvider(anthropic)
+		changed = true
+	}
+
+	if s.GeminiAPIKey != "" {
+		gemini, ok := s.GetProvider(legacyGeminiProviderName)
+		if !ok {
+			gemini = ProviderConfig{Name: legacyGeminiProviderName, Type: "gemini"}
+		}
+		gemini.APIKey = s.GeminiAPIKey
+		s.upsertProvider(gemini)
+		changed = true
+	}
+
+	s.DropLegacyFields()
+	return changed
+}
+
+// DropLegacyFields clears the deprecated single key fields without applying them
+func (s *Settings) DropLegacyFields() {
+	s.OpenAIAPIKey, s.OpenAIAPIURL, s.AnthropicAPIKey, s.GeminiAPIKey = "", "", "", ""
 }
 
+// EncryptSecrets seals every plaintext API key and header value with the keyring, values that are already
+// encrypted are kept
+func (s *Settings) EncryptSecrets(kr *secrets.Keyring) error {
+	return s.mapSecrets(func(value string) (string, error) {
+		if secrets.IsEncrypted(value) {
+			return value, nil
+		}
+		return kr.Encrypt(value)
+	}, "encrypt")
+}
+
+// DecryptSecrets opens the API keys and header values in place, plaintext values from before encryption are kept
+// as they are
+func (s *Settings) DecryptSecrets(kr *secrets.Keyring) error {
+	return s.mapSecrets(kr.Decrypt, "decrypt")
+}
+
+// SecretsNeedRotation reports whether any API key or header value is plaintext or sealed with a previous master key
+func (s *Settings) SecretsNeedRotation(kr *secrets.Keyring) bool {
+	for _, p := range s.Providers {
+		if kr.NeedsRotation(p.APIKey) {
+			return true
+		}
+		for _, value := range p.Headers {
+			if kr.NeedsRotation(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MaskSecrets replaces the decrypted API keys and header values with their masked form for display
+func (s *Settings) MaskSecrets() {
+	s.mapSecrets(func(value string) (string, error) {
+		return secrets.Mask(value), nil
+	}, "mask")
+}
+
+// mapSecrets replaces the API key and the header values of every provider with f's result, headers carry
+// credentials (Authorization, x-api-key ...) as often as not. The header maps are replaced, not written to.
+func (s *Settings) mapSecrets(f func(string) (string, error), action string) error {
+	for i := range s.Providers {
+		p := &s.Providers[i]
+		key, err := f(p.APIKey)
+		if err != nil {
+			return fmt.Errorf("failed to %s API key of provider %s: %w", action, p.Name, err)
+		}
+		p.APIKey = key
+
+		if p.Headers == nil {
+			continue
+		}
+		headers := make(map[string]string, len(p.Headers))
+		for name, value := range p.Headers {
+			if headers[name], err = f(value); err != nil {
+				return fmt.Errorf("failed to %s header %s of provider %s: %w", action, name, p.Name, err)
+			}
+		}
+		p.Headers = headers
+	}
+	return nil
+}
+
+// KeepMaskedSecrets puts back the stored API key and header values for every provider whose secrets were sent back
+// masked, i.e. the client did not change them. Providers are matched by name, a masked secret of a provider that
+// isn't stored under that name (a renamed one) is refused as it can't be told apart from a lost secret.
+func (s *Settings) KeepMaskedSecrets(stored *Settings) error {
+	for i := range s.Providers {
+		p := &s.Providers[i]
+		previous, found := stored.GetProvider(p.Name)
+
+		if secrets.IsMasked(p.APIKey) {
+			if !found {
+				return fmt.Errorf("provider %s has a masked API key but no stored key, send the key again when renaming a provider", p.Name)
+			}
+			p.APIKey = previous.APIKey
+		}
+
+		var headers map[string]string
+		for name, value := range p.Headers {
+			if !secrets.IsMasked(value) {
+				continue
+			}
+			kept, ok := previous.Headers[name]
+			if !ok {
+				return fmt.Errorf("header %s of provider %s is masked but not stored, send its value again when renaming a provider or header", name, p.Name)
+			}
+			if headers == nil {
+				headers = maps.Clone(p.Headers)
+			}
+			headers[name] = kept
+		}
+		if headers != nil {
+			p.Headers = headers
+		}
+	}
+	return nil
+}
+
+// clone returns a copy that does not share the providers slice or header maps
+func (s *Settings) clone() *Settings {
+	c := *s
+	c.Budgets = append([]BudgetConfig(nil), s.Budgets...)
+	c.Providers = make([]ProviderConfig, len(s.Providers))
+	for i, p := range s.Providers {
+		if p.Headers != nil {
+			headers := make(map[string]string, len(p.Headers))
+			for k, v := range p.Headers {
+				headers[k] = v
+			}
+			p.Headers = headers
+		}
+		c.Providers[i] = p
+	}
+	return &c
+}
+
+// ToProto also fills the deprecated fields from the registry so older clients keep showing them
 func (s *Settings) ToProto() *proto.Settings {
-	return &proto.Settings{
-		OPENAI_API_KEY: s.OpenAIAPIKey,
-		OPENAI_API_URL: s.OpenAIAPIURL,
-		OLLAMA_URL:     s.OllamaURL,
+	protoSettings := &proto.Settings{
+		OLLAMA_URL: s.OllamaURL,
+	}
+
+	for _, p := range s.Providers {
+		protoSettings.Providers = append(protoSettings.Providers, &proto.ProviderConfig{
+			Name:           p.Name,
+			Type:           p.Type,
+			BaseUrl:        p.BaseURL,
+			ApiKey:         p.APIKey,
+			Headers:        p.Headers,
+			TimeoutSeconds: int32(p.TimeoutSeconds),
+		})
+	}
+
+	for _, b := range s.Budgets {
+		protoSettings.Budgets = append(protoSettings.Budgets, &proto.Budget{
+			Scope:            b.Scope,
+			ScopeId:          b.ScopeID,
+			Period:           b.Period,
+			Limit:            b.Limit,
+			SoftLimitPercent: b.SoftLimitPercent,
+			DowngradeModel:   b.DowngradeModel,
+		})
 	}
+
+	protoSettings.Summarization = &proto.SummarizationSettings{
+		Model:              s.Summarization.Model,
+		ThresholdTokens:    int32(s.Summarization.ThresholdTokens),
+		KeepRecentMessages: int32(s.Summarization.KeepRecentMessages),
+	}
+
+	if openai, ok := s.GetProvider(DefaultProviderName); ok {
+		protoSettings.OPENAI_API_KEY = openai.APIKey
+		protoSettings.OPENAI_API_URL = openai.BaseURL
+	}
+	if anthropic, ok := s.GetProvider(legacyAnthropicProviderName); ok {
+		protoSettings.ANTHROPIC_API_KEY = anthropic.APIKey
+	}
+	if gemini, ok := s.GetProvider(legacyGeminiProviderName); ok {
+		protoSettings.GEMINI_API_KEY = gemini.APIKey
+	}
+
+	return protoSettings
 }
 
+// FromProto keeps the deprecated fields as they are, call MigrateLegacyFields to fold them into the registry
 func FromProto(protoSettings *proto.Settings) *Settings {
-	return &Settings{
-		OpenAIAPIKey: protoSettings.OPENAI_API_KEY,
-		OpenAIAPIURL: protoSettings.OPENAI_API_URL,
-		OllamaURL:    protoSettings.OLLAMA_URL,
+	s := &Settings{
+		OllamaURL:       protoSettings.OLLAMA_URL,
+		OpenAIAPIKey:    protoSettings.OPENAI_API_KEY,
+		OpenAIAPIURL:    protoSettings.OPENAI_API_URL,
+		AnthropicAPIKey: protoSettings.ANTHROPIC_API_KEY,
+		GeminiAPIKey:    protoSettings.GEMINI_API_KEY,
 	}
+
+	for _, p := range protoSettings.Providers {
+		s.Providers = append(s.Providers, ProviderConfig{
+			Name:           p.Name,
+			Type:           p.Type,
+			BaseURL:        p.BaseUrl,
+			APIKey:         p.ApiKey,
+			Headers:        p.Headers,
+			TimeoutSeconds: int(p.TimeoutSeconds),
+		})
+	}
+
+	for _, b := range protoSettings.Budgets {
+		s.Budgets = append(s.Budgets, BudgetConfig{
+			Scope:            b.Scope,
+			ScopeID:          b.ScopeId,
+			Period:           b.Period,
+			Limit:            b.Limit,
+			SoftLimitPercent: b.SoftLimitPercent,
+			DowngradeModel:   b.DowngradeModel,
+		})
+	}
+
+	if summarization := protoSettings.GetSummarization(); summarization != nil {
+		s.Summarization = SummarizationConfig{
+			Model:              summarization.GetModel(),
+			ThresholdTokens:    int(summarization.GetThresholdTokens()),
+			KeepRecentMessages: int(summarization.GetKeepRecentMessages()),
+		}
+	}
+
+	return s
 }
 
 // Application should use settings from here, not directly from the database
@@ -52,9 +443,10 @@ type SettingsManager struct {
 	mu       sync.RWMutex
 	queue    queue.Queue
 	dao      dao.SettingsDAO
+	keyring  *secrets.Keyring
 }
 
-func NewSettingsManager(queue queue.Queue, daoFactory dao.DAOFactory) *SettingsManager {
+func NewSettingsManager(queue queue.Queue, daoFactory dao.DAOFactory, keyring *secrets.Keyring) *SettingsManager {
 	settingsDAO, err := daoFactory.CreateSettingsDAO()
 	if err != nil {
 		log.Fatalf("Failed to create settings DAO: %v", err)
@@ -64,6 +456,7 @@ func NewSettingsManager(queue queue.Queue, daoFactory dao.DAOFactory) *SettingsM
 		settings: &Settings{},
 		queue:    queue,
 		dao:      settingsDAO,
+		keyring:  keyring,
 	}
 
 	cm.StartSettingsChangedSubscriber()
@@ -71,7 +464,7 @@ func NewSettingsManager(queue queue.Queue, daoFactory dao.DAOFactory) *SettingsM
 }
 
 // Legacy constructor for backward compatibility
-func NewSettingsManagerWithSQLite(queue queue.Queue) *SettingsManager {
+func NewSettingsManagerWithSQLite(queue queue.Queue, keyring *secrets.Keyring) *SettingsManager {
 	// Create a simple SQLite settings DAO directly
 	settingsDAO := dao.NewSQLiteSettingsDAO(SQLITE_DB_URL)
 
@@ -79,6 +472,7 @@ func NewSettingsManagerWithSQLite(queue queue.Queue) *SettingsManager {
 		settings: &Settings{},
 		queue:    queue,
 		dao:      settingsDAO,
+		keyring:  keyring,
 	}
 
 	cm.StartSettingsChangedSubscriber()
@@ -86,15 +480,10 @@ func NewSettingsManagerWithSQLite(queue queue.Queue) *SettingsManager {
 }
 
 func (cm *SettingsManager) LoadSettingsFromProto(protoSettings *proto.Settings) error {
+	settings := FromProto(protoSettings)
+	settings.MigrateLegacyFields()
 
-	cm.settings = &Settings{
-		OpenAIAPIKey: protoSettings.OPENAI_API_KEY,
-		OpenAIAPIURL: protoSettings.OPENAI_API_URL,
-		OllamaURL:    protoSettings.OLLAMA_URL,
-	}
-
-	cm.LoadSettings(cm.settings)
-	return nil
+	return cm.LoadSettings(settings)
 }
 
 func (cm *SettingsManager) LoadSettings(settings_ *Settings) error {
@@ -105,10 +494,10 @@ func (cm *SettingsManager) LoadSettings(settings_ *Settings) error {
 
 	// Create new config struct
 	// clone the settings_, Later this will be replaced by koanf
-	newSettings := *settings_
+	newSettings := settings_.clone()
 
 	// Replace the config atomically
-	cm.settings = &newSettings
+	cm.settings = newSettings
 	return nil
 }
 
@@ -149,5 +538,12 @@ func (s *SettingsManager) LoadSettingsFromDB() error {
 		return err
 	}
 
+	// a blob written before the provider registry existed may not have been migrated yet
+	settings.MigrateLegacyFields()
+
+	if err := settings.DecryptSecrets(s.keyring); err != nil {
+		return err
+	}
+
 	return s.LoadSettings(&settings)
 }
@@ -0,0 +1,46 @@
+package settings
+
+import "testing"
+
+func TestMigrateLegacyFields(t *testing.T) {
+	s := &Settings{
+		OpenAIAPIKey:    "sk-openai",
+		OpenAIAPIURL:    "http://localhost:4000/v1/chat/completions",
+		AnthropicAPIKey: "sk-ant",
+		Providers: []ProviderConfig{
+			{Name: DefaultProviderName, Type: "openai", Headers: map[string]string{"X-Team": "core"}},
+		},
+	}
+
+	if !s.MigrateLegacyFields() {
+		t.Fatalf("Expected migration to report a change")
+	}
+
+	openai, ok := s.GetProvider(DefaultProviderName)
+	if !ok {
+		t.Fatalf("Expected %s provider", DefaultProviderName)
+	}
+	if openai.APIKey != "sk-openai" || openai.BaseURL != "http://localhost:4000/v1" {
+		t.Errorf("Unexpected openai provider %+v", openai)
+	}
+	if openai.Headers["X-Team"] != "core" {
+		t.Errorf("Expected headers of the existing entry to be kept, got %v", openai.Headers)
+	}
+
+	anthropic, ok := s.GetProvider("claude")
+	if !ok || anthropic.Type != "anthropic" || anthropic.APIKey != "sk-ant" {
+		t.Errorf("Unexpected claude provider %+v (found: %v)", anthropic, ok)
+	}
+
+	if _, ok := s.GetProvider("gemini"); ok {
+		t.Errorf("Expected no gemini provider without a key")
+	}
+
+	if s.OpenAIAPIKey != "" || s.OpenAIAPIURL != "" || s.AnthropicAPIKey != "" {
+		t.Errorf("Expected legacy fields to be cleared")
+	}
+
+	if s.MigrateLegacyFields() {
+		t.Errorf("Expected second migration to be a no-op")
+	}
+}
@@ -2,17 +2,37 @@ package store
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrObjectNotFound  = errors.New("object not found")
+	ErrInvalidObjectID = errors.New("invalid object ID")
 )
 
 type ObjectStore interface {
 	GetObject(ctx context.Context, objectID string) (name string, object io.Reader, err error)
+	// OpenObject opens the object for random access, e.g. to serve HTTP range requests. The caller closes it.
+	OpenObject(ctx context.Context, objectID string) (io.ReadSeekCloser, error)
 	StoreObject(ctx context.Context, objectID string, object io.Reader) error
 }
 
+// validateObjectID rejects IDs that would resolve outside the objects directory
+func validateObjectID(objectID string) error {
+	if objectID == "" {
+		return fmt.Errorf("%w: objectID cannot be empty", ErrInvalidObjectID)
+	}
+	if objectID == "." || objectID == ".." || strings.ContainsAny(objectID, `/\`) || !filepath.IsLocal(objectID) {
+		return fmt.Errorf("%w: %q", ErrInvalidObjectID, objectID)
+	}
+	return nil
+}
+
 // DiskObjectStore implements ObjectStore interface by storing objects on disk
 type DiskObjectStore struct {
 	basePath string
@@ -38,8 +58,8 @@ func NewDiskObjectStore(basePath string) (*DiskObjectStore, error) {
 // StoreObject stores an object on disk with the given object ID
 func (d *DiskObjectStore) StoreObject(ctx context.Context, objectID string, object io.Reader) error {
 	// Validate objectID
-	if objectID == "" {
-		return fmt.Errorf("objectID cannot be empty")
+	if err := validateObjectID(objectID); err != nil {
+		return err
 	}
 
 	// Create object path
@@ -64,9 +84,20 @@ func (d *DiskObjectStore) StoreObject(ctx context.Context, objectID string, obje
 
 // GetObject retrieves an object from disk by object ID
 func (d *DiskObjectStore) GetObject(ctx context.Context, objectID string) (string, io.Reader, error) {
+	file, err := d.OpenObject(ctx, objectID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Return objectID as name since we don't store original names
+	return objectID, file, nil
+}
+
+// OpenObject opens the object file, *os.File supports seeking
+func (d *DiskObjectStore) OpenObject(ctx context.Context, objectID string) (io.ReadSeekCloser, error) {
 	// Validate objectID
-	if objectID == "" {
-		return "", nil, fmt.Errorf("objectID cannot be empty")
+	if err := validateObjectID(objectID); err != nil {
+		return nil, err
 	}
 
 	// Open the object file
@@ -74,11 +105,9 @@ func (d *DiskObjectStore) GetObject(ctx context.Context, objectID string) (strin
 	file, err := os.Open(objectPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return "", nil, fmt.Errorf("object not found: %s", objectID)
+			return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, objectID)
 		}
-		return "", nil, fmt.Errorf("failed to open object file: %w", err)
+		return nil, fmt.Errorf("failed to open object file: %w", err)
 	}
-
-	// Return objectID as name since we don't store original names
-	return objectID, file, nil
+	return file, nil
 }
@@ -0,0 +1,64 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiskObjectStore(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewDiskObjectStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskObjectStore failed: %v", err)
+	}
+
+	if err := s.StoreObject(ctx, "doc-1", strings.NewReader("hello world")); err != nil {
+		t.Fatalf("StoreObject failed: %v", err)
+	}
+
+	object, err := s.OpenObject(ctx, "doc-1")
+	if err != nil {
+		t.Fatalf("OpenObject failed: %v", err)
+	}
+	defer object.Close()
+
+	if _, err := object.Seek(6, io.SeekStart); err != nil {
+		t.Fatalf("Seek failed: %v", err)
+	}
+	rest, _ := io.ReadAll(object)
+	if string(rest) != "world" {
+		t.Errorf("Expected 'world' after seeking, got '%s'", rest)
+	}
+
+	if _, err := s.OpenObject(ctx, "missing"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Expected ErrObjectNotFound, got %v", err)
+	}
+}
+
+func TestObjectIDPathTraversal(t *testing.T) {
+	ctx := context.Background()
+	base := t.TempDir()
+	s, err := NewDiskObjectStore(filepath.Join(base, "filestore"))
+	if err != nil {
+		t.Fatalf("NewDiskObjectStore failed: %v", err)
+	}
+
+	// a file next to the store that must not be reachable through an object ID
+	if err := os.WriteFile(filepath.Join(base, "secret.txt"), []byte("secret"), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	for _, id := range []string{"../../secret.txt", "..", ".", "objects/../../../secret.txt", `..\secret.txt`, "/etc/passwd", ""} {
+		if _, err := s.OpenObject(ctx, id); !errors.Is(err, ErrInvalidObjectID) {
+			t.Errorf("OpenObject(%q): expected ErrInvalidObjectID, got %v", id, err)
+		}
+		if err := s.StoreObject(ctx, id, strings.NewReader("x")); !errors.Is(err, ErrInvalidObjectID) {
+			t.Errorf("StoreObject(%q): expected ErrInvalidObjectID, got %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Package tokenizer counts the tokens of a text locally, without asking the provider. It pre-tokenizes text the
+// way byte pair encoders do (words with their leading space, short runs of digits, punctuation, whitespace) and
+// charges long words and scripts without spaces by length. The counts land close to, and mostly above, the ones
+// of the OpenAI, Anthropic and Gemini tokenizers, which is what deciding whether a prompt fits needs.
+package tokenizer
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// pieces splits text into pre-tokens, every pre-token is at least one token
+var pieces = regexp.MustCompile(`(?i:'s|'t|'re|'ve|'m|'ll|'d)| ?\pL+| ?\pN{1,3}| ?[^\s\pL\pN]+|\s+`)
+
+// Count returns the number of tokens of text
+func Count(text string) int {
+	n := 0
+	for _, piece := range pieces.FindAllString(text, -1) {
+		n += pieceTokens(piece)
+	}
+	return n
+}
+
+func pieceTokens(piece string) int {
+	trimmed := strings.TrimPrefix(piece, " ")
+	if trimmed == "" {
+		return 1
+	}
+	r, _ := utf8.DecodeRuneInString(trimmed)
+	runes := utf8.RuneCountInString(trimmed)
+
+	switch {
+	case isWide(r):
+		// Chinese, Japanese, Korean and Thai are written without spaces, about a token per character
+		return runes
+	case unicode.IsLetter(r) && r < utf8.RuneSelf:
+		// common English words are one token, longer ones split into a few
+		return (runes + 5) / 6
+	case unicode.IsLetter(r):
+		return (runes + 2) / 3
+	case unicode.IsSpace(r):
+		return (runes + 3) / 4
+	case unicode.IsNumber(r):
+		return 1
+	default:
+		return (runes + 1) / 2
+	}
+}
+
+func isWide(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul, unicode.Thai)
+}
+
+// TrimMiddle shortens text to at most maxTokens tokens by cutting out its middle, where marker is put instead.
+// The start of a message usually says what it is about and the end asks the question, both are kept.
+func TrimMiddle(text string, maxTokens int, marker string) string {
+	if Count(text) <= maxTokens {
+		return text
+	}
+
+	budget := maxTokens - Count(marker)
+	if budget <= 0 {
+		return ""
+	}
+	locs := pieces.FindAllStringIndex(text, -1)
+
+	head, headTokens := 0, 0
+	for _, loc := range locs {
+		n := pieceTokens(text[loc[0]:loc[1]])
+		if headTokens+n > budget/2 {
+			break
+		}
+		head, headTokens = loc[1], headTokens+n
+	}
+
+	tail, tailTokens := len(text), 0
+	for i := len(locs) - 1; i >= 0 && locs[i][0] >= head; i-- {
+		n := pieceTokens(text[locs[i][0]:locs[i][1]])
+		if headTokens+tailTokens+n > budget {
+			break
+		}
+		tail, tailTokens = locs[i][0], tailTokens+n
+	}
+
+	return text[:head] + marker + text[tail:]
+}
@@ -0,0 +1,52 @@
+package tokenizer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"Hello world", 2},
+		{"Hello, world!", 4},
+		{"It's 2025", 4},
+		{"internationalization", 4},
+		{"你好世界", 4},
+	}
+	for _, tt := range tests {
+		if got := Count(tt.text); got != tt.want {
+			t.Errorf("Expected %d tokens for %q, got %d", tt.want, tt.text, got)
+		}
+	}
+}
+
+func TestCountCloseToBPE(t *testing.T) {
+	// byte pair encoders read each sentence as 10 tokens: every word with its leading space and the period
+	text := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 10)
+	if got := Count(text); got < 100 || got > 120 {
+		t.Errorf("Expected a count a little above 100, got %d", got)
+	}
+}
+
+func TestTrimMiddle(t *testing.T) {
+	text := "Summarize this: " + strings.Repeat("lorem ipsum ", 500) + "What is the conclusion?"
+
+	trimmed := TrimMiddle(text, 50, " [...] ")
+	if got := Count(trimmed); got > 50 {
+		t.Errorf("Expected at most 50 tokens, got %d", got)
+	}
+	if !strings.HasPrefix(trimmed, "Summarize this:") || !strings.HasSuffix(trimmed, "What is the conclusion?") || !strings.Contains(trimmed, " [...] ") {
+		t.Errorf("Expected the start and end around the marker, got %q", trimmed)
+	}
+
+	if got := TrimMiddle("short", 50, " [...] "); got != "short" {
+		t.Errorf("Expected a short text to stay as it is, got %q", got)
+	}
+	if got := TrimMiddle(text, 1, " [...] "); got != "" {
+		t.Errorf("Expected nothing to fit next to the marker, got %q", got)
+	}
+}
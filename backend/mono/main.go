@@ -14,9 +14,11 @@ import (
 
 	"sortedstartup/chat/mono/util"
 	"sortedstartup/chatservice/api"
+	"sortedstartup/chatservice/auth"
 	"sortedstartup/chatservice/dao"
 	"sortedstartup/chatservice/proto"
 	"sortedstartup/chatservice/queue"
+	"sortedstartup/chatservice/secrets"
 	"sortedstartup/chatservice/settings"
 
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
@@ -40,6 +42,7 @@ func main() {
 	host := flag.String("host", defaultHost, "Host to bind the server to (default: all interfaces)")
 	grpcPort := flag.String("grpc-port", defaultGrpcPort, "Port for gRPC server")
 	httpPort := flag.String("http-port", defaultHttpPort, "Port for HTTP server")
+	rotateKeys := flag.Bool("rotate-keys", false, "Re-encrypt stored secrets with the current master key and exit")
 	flag.Parse()
 
 	// Build addresses
@@ -56,7 +59,24 @@ func main() {
 		log.Fatalf("Failed to listen on %s: %v", grpcAddr, err)
 	}
 
-	grpcServer := grpc.NewServer()
+	authConfig, err := auth.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load auth configuration: %v", err)
+	}
+	if !*serverOnly && authConfig.Enabled {
+		// the desktop app has a single implicit local user
+		log.Println("Ignoring AUTH_ENABLED, authentication is only available in server mode (--server)")
+		authConfig.Enabled = false
+	}
+	authenticator, err := auth.NewAuthenticator(authConfig)
+	if err != nil {
+		log.Fatalf("Failed to initialize authentication: %v", err)
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(authenticator.UnaryInterceptor),
+		grpc.ChainStreamInterceptor(authenticator.StreamInterceptor),
+	)
 	mux := http.NewServeMux()
 
 	// Load configuration
@@ -82,17 +102,35 @@ func main() {
 		}
 	}()
 
+	keyring, err := secrets.LoadKeyring()
+	if err != nil {
+		log.Fatalf("Failed to load master key: %v", err)
+	}
+
 	queue := queue.NewInMemoryQueue()
-	settingsManager := settings.NewSettingsManager(queue, daoFactory)
 
-	chatServiceApi := api.NewChatService(mux, queue, settingsManager, daoFactory)
+	if *rotateKeys {
+		// the previous master key must be in SECRETS_PREVIOUS_MASTER_KEYS to read the stored values
+		if err := api.NewSettingService(queue, daoFactory, keyring).RotateKeys(); err != nil {
+			log.Fatalf("Failed to rotate keys: %v", err)
+		}
+		log.Println("Stored secrets re-encrypted with the current master key")
+		return
+	}
+
+	settingsManager := settings.NewSettingsManager(queue, daoFactory, keyring)
+
+	chatServiceApi := api.NewChatService(mux, queue, settingsManager, daoFactory, authenticator)
 	chatServiceApi.Init(config)
 	proto.RegisterSortedChatServer(grpcServer, chatServiceApi)
 
-	settingServiceApi := api.NewSettingService(queue, daoFactory)
+	settingServiceApi := api.NewSettingService(queue, daoFactory, keyring)
 	settingServiceApi.Init()
 	proto.RegisterSettingServiceServer(grpcServer, settingServiceApi)
 
+	authServiceApi := api.NewAuthService(daoFactory, authenticator)
+	proto.RegisterAuthServiceServer(grpcServer, authServiceApi)
+
 	// Enable reflection, TODO: may be remove in production ?
 	reflection.Register(grpcServer)
 